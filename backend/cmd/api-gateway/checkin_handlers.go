@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/attendwise/backend/internal/module/checkin/usecase"
 	event_domain "github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
 	"github.com/gin-gonic/gin"
 )
 
@@ -41,8 +43,12 @@ func (h *CheckinHandler) GenerateTicketAndQR(c *gin.Context) {
 	// We use BindJSON, but don't fail if it's empty, for backward compatibility.
 	_ = c.ShouldBindJSON(&req)
 
-	signedToken, fallbackCode, err := h.service.GenerateTicket(c.Request.Context(), sessionID, userID.(string), req.DeviceFingerprint)
+	signedToken, fallbackCode, seat, err := h.service.GenerateTicket(c.Request.Context(), sessionID, userID.(string), req.DeviceFingerprint)
 	if err != nil {
+		if errors.Is(err, event_domain.ErrNotRegisteredForSession) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error_details": err.Error(), "error": "Failed to generate ticket"})
 		return
 	}
@@ -50,10 +56,19 @@ func (h *CheckinHandler) GenerateTicketAndQR(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"qr_payload":    signedToken,
 		"fallback_code": fallbackCode,
+		"seat":          seat,
 	})
 }
 
+// VerifyCheckin checks an attendee in from a scanned QR payload or a fallback code. The scanning user
+// must be allowed to check attendees of the event in.
 func (h *CheckinHandler) VerifyCheckin(c *gin.Context) {
+	scannerID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
 	var req struct {
 		QRPayload                string `json:"qr_payload"`
 		FallbackCode             string `json:"fallback_code"`
@@ -117,9 +132,9 @@ func (h *CheckinHandler) VerifyCheckin(c *gin.Context) {
 	var err error
 
 	if req.QRPayload != "" {
-		attendee, success, message, err = h.service.VerifyCheckinFromQR(c.Request.Context(), req.QRPayload, decodedImageData, decodedLivenessStream, req.ChallengeType, req.ScannerDeviceFingerprint)
+		attendee, success, message, err = h.service.VerifyCheckinFromQR(c.Request.Context(), scannerID.(string), req.QRPayload, decodedImageData, decodedLivenessStream, req.ChallengeType, req.ScannerDeviceFingerprint)
 	} else if req.FallbackCode != "" {
-		attendee, success, message, err = h.service.VerifyCheckinFromFallback(c.Request.Context(), req.FallbackCode, decodedImageData)
+		attendee, success, message, err = h.service.VerifyCheckinFromFallback(c.Request.Context(), scannerID.(string), req.FallbackCode, decodedImageData)
 	} else {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Either qr_payload or fallback_code must be provided"})
 		return
@@ -127,6 +142,10 @@ func (h *CheckinHandler) VerifyCheckin(c *gin.Context) {
 
 	if err != nil {
 		log.Printf("Check-in verification error: %v", err)
+		if errors.Is(err, permission_domain.ErrPermissionDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"status": false, "message": message, "error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusConflict, gin.H{"status": false, "message": message, "error_details": err.Error()})
 		return
 	}
@@ -173,7 +192,13 @@ func (h *CheckinHandler) SyncOfflineCheckins(c *gin.Context) {
 		return
 	}
 
-	results, err := h.service.ProcessOfflineBatch(c.Request.Context(), req.DeviceID, req.Attempts)
+	scannerID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	results, err := h.service.ProcessOfflineBatch(c.Request.Context(), scannerID.(string), req.DeviceID, req.Attempts)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process offline batch"})
 		return
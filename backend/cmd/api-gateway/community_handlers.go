@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/attendwise/backend/internal/module/community/domain"
+	event_domain "github.com/attendwise/backend/internal/module/event/domain"
 	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
 	"github.com/gin-gonic/gin"
 )
@@ -521,6 +522,14 @@ func (h *CommunityHandler) CreatePost(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "You must be an active member to post."})
 			return
 		}
+		if errors.Is(err, event_domain.ErrNotEventParticipant) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, event_domain.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+			return
+		}
 		log.Printf("[ERROR] CreatePost handler failed: %v", err) // More detailed server log
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create post"})
 		return
@@ -585,6 +594,130 @@ func (h *CommunityHandler) ListPosts(c *gin.Context) {
 	})
 }
 
+// @Summary List an event's discussion
+// @Description List the posts of an event's discussion, pinned posts first, then newest first. Limited to the event's registrants and staff.
+// @ID list-event-discussion
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success 200 {object} ListPostsResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/discussion [get]
+// @Security ApiKeyAuth
+func (h *CommunityHandler) ListEventDiscussion(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	eventID := c.Param("id")
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	posts, total, err := h.service.GetEventDiscussion(c.Request.Context(), eventID, userID.(string), limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, event_domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, event_domain.ErrNotEventParticipant):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			log.Printf("[ERROR] Handler ListEventDiscussion: Failed to get the discussion of event %s: %v", eventID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch the event discussion"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"posts": posts,
+		"pagination": gin.H{
+			"total":    total,
+			"page":     page,
+			"limit":    limit,
+			"has_more": (page*limit < total),
+		},
+	})
+}
+
+// @Summary Post to an event's discussion
+// @Description Add a post to an event's discussion. Limited to the event's registrants and staff; the post is published right away and only shown in the event's discussion.
+// @ID create-event-discussion-post
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param post_data body main.EventDiscussionPostRequest true "Post data"
+// @Success 201 {object} PostResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/discussion [post]
+// @Security ApiKeyAuth
+func (h *CommunityHandler) CreateEventDiscussionPost(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	eventID := c.Param("id")
+
+	var req EventDiscussionPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Post must have content."})
+		return
+	}
+
+	post := &domain.Post{
+		AuthorID: userID.(string),
+		Title:    sql.NullString{String: req.Title, Valid: req.Title != ""},
+		Content:  req.Content,
+		Hashtags: req.Hashtags,
+	}
+	for _, att := range req.FileAttachments {
+		if url := strings.TrimSpace(att.Url); url != "" {
+			post.FileAttachments = append(post.FileAttachments, domain.Attachment{Name: att.Name, Url: url, Type: att.Type})
+		}
+	}
+	for _, mediaURL := range req.MediaURLs {
+		if mediaURL = strings.TrimSpace(mediaURL); mediaURL != "" {
+			post.MediaURLs = append(post.MediaURLs, mediaURL)
+		}
+	}
+
+	createdPost, err := h.service.CreateEventDiscussionPost(c.Request.Context(), eventID, post)
+	if err != nil {
+		switch {
+		case errors.Is(err, event_domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, event_domain.ErrNotEventParticipant):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			log.Printf("[ERROR] Handler CreateEventDiscussionPost: Failed to post to the discussion of event %s: %v", eventID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create post"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"post": createdPost})
+}
+
 // @Summary Create a comment
 // @Description Create a new comment on a post
 // @ID create-comment
@@ -630,6 +763,10 @@ func (h *CommunityHandler) CreateComment(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "You must be a member of the community to comment."})
 			return
 		}
+		if errors.Is(err, event_domain.ErrNotEventParticipant) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create comment"})
 		return
 	}
@@ -1158,7 +1295,7 @@ func (h *CommunityHandler) GetPost(c *gin.Context) {
 	if err != nil {
 		if errors.Is(err, domain.ErrPostNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Post not found"})
-		} else if errors.Is(err, domain.ErrPermissionDenied) {
+		} else if errors.Is(err, domain.ErrPermissionDenied) || errors.Is(err, event_domain.ErrNotEventParticipant) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this post."})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve post data"})
@@ -2,13 +2,18 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/attendwise/backend/internal/module/event/domain"
@@ -17,14 +22,27 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// maxICalImportSize limits the size of uploaded .ics files.
+const maxICalImportSize = 5 << 20
+
+// maxWhitelistImportSize limits the size of uploaded whitelist CSV files.
+const maxWhitelistImportSize = 2 << 20
+
+// Number of occurrences returned by a recurrence preview, by default and at most.
+const (
+	defaultOccurrencePreviewCount = 10
+	maxOccurrencePreviewCount     = 100
+)
+
 // EventHander holds the dependencies for event handlers
 type EventHandler struct {
-	service usecase.EventService
+	service     usecase.EventService
+	frontendURL string
 }
 
-// NewEventHandler creates a new EventHandler
-func NewEventHandler(service usecase.EventService) *EventHandler {
-	return &EventHandler{service: service}
+// NewEventHandler creates a new EventHandler. frontendURL is used for the links printed on certificates.
+func NewEventHandler(service usecase.EventService, frontendURL string) *EventHandler {
+	return &EventHandler{service: service, frontendURL: frontendURL}
 }
 
 // @Summary Create an event
@@ -64,6 +82,14 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 
 	createdEvent, err := h.service.CreateEvent(c.Request.Context(), req.Event, hostID.(string), req.Whitelist)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRecurrence) || errors.Is(err, domain.ErrInvalidGenerationHorizon) || errors.Is(err, domain.ErrInvalidReminderSchedule) || errors.Is(err, domain.ErrInvalidStatusTransition) || errors.Is(err, domain.ErrInvalidVenue) || errors.Is(err, domain.ErrVenueNotFound) || errors.Is(err, domain.ErrInvalidMeetingLinkReveal) || errors.Is(err, domain.ErrInvalidTransferPolicy) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, domain.ErrVenueDoubleBooked) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create event", "details": err.Error()})
 		return
 	}
@@ -194,8 +220,8 @@ func (h *EventHandler) ListMyAccessibleEvents(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Event ID"
-// @Param registration_data body main.RegisterForEventRequest false "Registration form data (optional)"
-// @Success 200 {object} MessageResponse
+// @Param registration_data body main.RegisterForEventRequest false "Registration form data (optional). With strict set, registrations overlapping another of the user's registrations fail instead of returning conflicts as warnings."
+// @Success 200 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 409 {object} map[string]interface{}
@@ -212,15 +238,18 @@ func (h *EventHandler) RegisterForEvent(c *gin.Context) {
 
 	var req struct {
 		RegistrationFormData json.RawMessage `json:"registration_form_data"`
+		Strict               bool            `json:"strict"`
 	}
 
 	_ = c.ShouldBindJSON(&req)
 
-	err := h.service.RegisterForEvent(c.Request.Context(), eventID, userID.(string), req.RegistrationFormData)
+	conflicts, err := h.service.RegisterForEvent(c.Request.Context(), eventID, userID.(string), req.RegistrationFormData, req.Strict)
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrEventNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrScheduleConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflicts": conflicts})
 		case errors.Is(err, domain.ErrRegistrationClosed), errors.Is(err, domain.ErrWhitelistOnly), errors.Is(err, domain.ErrEventFull), errors.Is(err, domain.ErrAlreadyRegistered):
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		default:
@@ -229,21 +258,36 @@ func (h *EventHandler) RegisterForEvent(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Successfully submitted registration request"})
+	response := gin.H{"message": "Successfully submitted registration request"}
+	if len(conflicts) > 0 {
+		response["conflicts"] = conflicts
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary Get event attendance summary
-// @Description Get a summary of attendance for a specific event
+// @Description Get a summary of attendance for a specific event. Requires permission to view the event's attendees.
 // @Param id path string true "Event ID"
 // @Success 200 {object} AttendanceSummaryResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/events/{id}/attendance/summary [get]
 // @Security ApiKeyAuth
 func (h *EventHandler) GetEventAttendanceSummary(c *gin.Context) {
 	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
 
-	summary, err := h.service.GetEventAttendanceSummary(c.Request.Context(), eventID)
+	summary, err := h.service.GetEventAttendanceSummary(c.Request.Context(), eventID, userID.(string))
 	if err != nil {
+		if errors.Is(err, permission_domain.ErrPermissionDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view attendance for this event."})
+			return
+		}
 		log.Printf("Error getting event attendance summary: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get attendance summary"})
 		return
@@ -278,7 +322,7 @@ func (h *EventHandler) GetEventAttendees(c *gin.Context) {
 
 	attendees, err := h.service.GetEventAttendees(c.Request.Context(), eventID, sessionID, status, userID.(string))
 	if err != nil {
-		if errors.Is(err, domain.ErrPermissionDenied) {
+		if errors.Is(err, permission_domain.ErrPermissionDenied) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view attendees for this event."})
 			return
 		}
@@ -290,16 +334,17 @@ func (h *EventHandler) GetEventAttendees(c *gin.Context) {
 }
 
 // @Summary Add users to event whitelist
-// @Description Add a list of user IDs to an event's whitelist
+// @Description Add users to an event's whitelist by user ID, or people by email and/or phone whether or not they have an account yet. Entries by email are matched to the account that verified that email, and linked to it once it exists; entries by phone, or by an email the account has not verified, are linked once a host confirms the account. Entries already on the whitelist are skipped; the report lists what happened to each entry.
 // @ID add-users-to-whitelist
 // @Accept json
 // @Produce json
 // @Param id path string true "Event ID"
-// @Param whitelist_data body main.AddUsersToWhitelistRequest true "User IDs to add to whitelist"
-// @Success 200 {object} MessageResponse
+// @Param whitelist_data body main.AddUsersToWhitelistRequest true "User IDs and contacts to add to whitelist"
+// @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
 // @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/events/{id}/whitelist [post]
 // @Security ApiKeyAuth
@@ -307,37 +352,262 @@ func (h *EventHandler) AddUsersToWhitelist(c *gin.Context) {
 	eventID := c.Param("id")
 	userID, _ := c.Get("userID")
 
-	var req struct {
-		UserIDs []string `json:"user_ids" binding:"required"`
+	var req AddUsersToWhitelistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		return
+	}
+	if len(req.UserIDs) == 0 && len(req.Entries) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user_ids or entries is required"})
+		return
+	}
+	if len(req.Entries) > domain.MaxWhitelistImportRows {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d entries can be added at once", domain.MaxWhitelistImportRows)})
+		return
+	}
+
+	if len(req.UserIDs) > 0 {
+		if err := h.service.AddUsersToWhitelist(c.Request.Context(), eventID, req.UserIDs, userID.(string)); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	response := gin.H{"message": "Users added to whitelist successfully"}
+	if len(req.Entries) > 0 {
+		rows := make([]*domain.WhitelistImportRow, len(req.Entries))
+		for i, entry := range req.Entries {
+			rows[i] = &domain.WhitelistImportRow{Email: entry.Email, Phone: entry.Phone, Notes: entry.Notes}
+		}
+		result, err := h.service.AddWhitelistContacts(c.Request.Context(), eventID, userID.(string), rows, true)
+		if err != nil {
+			switch {
+			case errors.Is(err, permission_domain.ErrPermissionDenied):
+				c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the whitelist of this event."})
+			case errors.Is(err, domain.ErrEventNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add whitelist entries"})
+			}
+			return
+		}
+		response["whitelist"] = result
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary List event whitelist
+// @Description List the whitelist of an event, newest first. Entries added by email or phone show the linked user once linked, and until then the account with their unverified email or phone as the candidate a host may link. Requires permission to edit the event.
+// @ID list-event-whitelist
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/whitelist [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListWhitelistEntries(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	entries, err := h.service.ListWhitelistEntries(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the whitelist of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list whitelist entries"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+// @Summary Import event whitelist from CSV
+// @Description Upload a CSV file whose header names "email", "phone" and "notes" columns. Without confirm=true the rows are only validated; with confirm=true the valid rows are added to the whitelist. The report lists, per row, whether it is added, skipped (already on the whitelist or repeated in the file) or invalid, and why.
+// @ID import-event-whitelist
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param file formData file true "CSV file"
+// @Param confirm formData bool false "Add the entries instead of only validating them"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/whitelist/import [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) ImportWhitelistCSV(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File not provided or invalid"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxWhitelistImportSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Could not read file"})
+		return
+	}
+	if len(data) > maxWhitelistImportSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is too large"})
+		return
+	}
+	confirm := c.PostForm("confirm") == "true"
+
+	result, err := h.service.ImportWhitelistCSV(c.Request.Context(), eventID, userID.(string), data, confirm)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the whitelist of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidWhitelistCSV):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid CSV file", "details": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import whitelist"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"import": result})
+}
+
+// @Summary Remove whitelist entry
+// @Description Remove an entry from the whitelist of an event. Registrations already made are kept. Requires permission to edit the event.
+// @ID remove-whitelist-entry
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param entryId path string true "Whitelist entry ID"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/whitelist/{entryId} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) RemoveWhitelistEntry(c *gin.Context) {
+	eventID := c.Param("id")
+	entryID := c.Param("entryId")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.RemoveWhitelistEntry(c.Request.Context(), eventID, entryID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the whitelist of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrWhitelistEntryNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Whitelist entry not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove whitelist entry"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Link whitelist entry to an account
+// @Description Link a whitelist entry to the account of the person it was added for. Entries are only linked on their own to an account that verified the entry's email; an account that has the entry's email without verifying it, or its phone, is shown as the entry's candidate and is linked once a host confirms it here. Requires permission to edit the event.
+// @ID confirm-whitelist-link
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param entryId path string true "Whitelist entry ID"
+// @Param link body main.ConfirmWhitelistLinkRequest true "Account to link"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/whitelist/{entryId}/link [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) ConfirmWhitelistLink(c *gin.Context) {
+	eventID := c.Param("id")
+	entryID := c.Param("entryId")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
 	}
 
+	var req ConfirmWhitelistLinkRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
 		return
 	}
 
-	if err := h.service.AddUsersToWhitelist(c.Request.Context(), eventID, req.UserIDs, userID.(string)); err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	if err := h.service.ConfirmWhitelistLink(c.Request.Context(), eventID, entryID, req.UserID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the whitelist of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrWhitelistEntryNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Whitelist entry not found"})
+		case errors.Is(err, domain.ErrWhitelistLinkMismatch):
+			c.JSON(http.StatusConflict, gin.H{"error": "The entry is already linked, the account does not have its email or phone, or the account is already on the whitelist"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to link whitelist entry"})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Users added to whitelist successfully"})
+	c.Status(http.StatusNoContent)
 }
 
 // @Summary Get event sessions
-// @Description Get all sessions for a specific event
+// @Description Get all sessions for a specific event. Meeting links are only included for the event's staff, and for approved registrants once revealed.
 // @ID get-event-sessions
 // @Produce json
 // @Param id path string true "Event ID"
 // @Success 200 {array} EventSessionResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/events/{id}/sessions [get]
 // @Security ApiKeyAuth
 func (h *EventHandler) GetEventSessions(c *gin.Context) {
 	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
 
-	sessions, err := h.service.GetEventSessions(c.Request.Context(), eventID)
+	sessions, err := h.service.GetEventSessions(c.Request.Context(), eventID, userID.(string))
 	if err != nil {
+		if errors.Is(err, domain.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+			return
+		}
 		log.Printf("Error getting event sessions: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve event sessions"})
 		return
@@ -347,19 +617,25 @@ func (h *EventHandler) GetEventSessions(c *gin.Context) {
 }
 
 // @Summary Get event session by ID
-// @Description Get details of a specific event session by its ID
+// @Description Get details of a specific event session by its ID. The meeting link is only included for the event's staff, and for approved registrants once revealed.
 // @ID get-event-session-by-id
 // @Produce json
 // @Param id path string true "Session ID"
 // @Success 200 {object} EventSessionResponse
+// @Failure 401 {object} map[string]interface{}
 // @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/events/sessions/{id} [get]
 // @Security ApiKeyAuth
 func (h *EventHandler) GetEventSessionByID(c *gin.Context) {
 	sessionId := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
 
-	session, err := h.service.GetEventSessionByID(c.Request.Context(), sessionId)
+	session, err := h.service.GetEventSessionByID(c.Request.Context(), sessionId, userID.(string))
 	if err != nil {
 		log.Printf("Error getting event session: %v", err)
 		c.JSON(http.StatusNotFound, gin.H{"error": "Event session not found"})
@@ -394,7 +670,7 @@ func (h *EventHandler) ListPendingRegistrations(c *gin.Context) {
 }
 
 // @Summary Approve registration
-// @Description Approve a pending registration for an event
+// @Description Approve a pending registration for an event and notify the registrant
 // @ID approve-registration
 // @Produce json
 // @Param id path string true "Event ID"
@@ -402,23 +678,137 @@ func (h *EventHandler) ListPendingRegistrations(c *gin.Context) {
 // @Success 200 {object} MessageResponse
 // @Failure 401 {object} map[string]interface{}
 // @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/events/{id}/registrations/{registrationID}/approve [post]
 // @Security ApiKeyAuth
 func (h *EventHandler) ApproveRegistration(c *gin.Context) {
 	eventID := c.Param("id")
 	registrationID := c.Param("registrationID")
-	userID, _ := c.Get("userID")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
 
 	err := h.service.ApproveRegistration(c.Request.Context(), eventID, registrationID, userID.(string))
 	if err != nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to approve registrations for this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrRegistrationNotPending):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to approve registration"})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Registration approved successfully"})
 }
 
+// @Summary Reject registration
+// @Description Reject a pending registration for an event and notify the registrant, with the reason if one is given
+// @ID reject-registration
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param registrationID path string true "Registration ID"
+// @Param rejection_data body main.RejectRegistrationRequest false "Reason"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/registrations/{registrationID}/reject [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) RejectRegistration(c *gin.Context) {
+	eventID := c.Param("id")
+	registrationID := c.Param("registrationID")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req RejectRegistrationRequest
+	_ = c.ShouldBindJSON(&req)
+
+	err := h.service.RejectRegistration(c.Request.Context(), eventID, registrationID, userID.(string), req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to approve registrations for this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrRegistrationNotPending):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidRegistrationDecision):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reject registration"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Registration rejected successfully"})
+}
+
+// @Summary Approve or reject registrations in bulk
+// @Description Approve or reject pending registrations of an event, the given ones or all of them, and notify the registrants with the optional reason. Registrations that are not pending registrations of the event are returned as skipped.
+// @ID decide-registrations
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param decision_data body main.RegistrationDecisionRequest true "Decision"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/registrations/decisions [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) DecideRegistrations(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req RegistrationDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	result, err := h.service.DecideRegistrations(c.Request.Context(), eventID, userID.(string), &domain.RegistrationDecisionInput{
+		Decision:        req.Decision,
+		RegistrationIDs: req.RegistrationIDs,
+		All:             req.All,
+		Reason:          req.Reason,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to approve registrations for this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidRegistrationDecision):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decide registrations"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // @Summary Cancel registration
 // @Description Cancel a registration for an event
 // @Success 200 {object} MessageResponse
@@ -471,7 +861,7 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 
 	for key, value := range reqBody {
 		switch key {
-		case "name", "location_type", "timezone", "currency", "status":
+		case "name", "location_type", "timezone", "currency", "status", "transfer_policy":
 			if v, ok := value.(string); ok {
 				switch key {
 				case "name":
@@ -484,10 +874,12 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 					eventToUpdate.Currency = v
 				case "status":
 					eventToUpdate.Status = v
+				case "transfer_policy":
+					eventToUpdate.TransferPolicy = v
 				}
 				fieldMaskPaths = append(fieldMaskPaths, key)
 			}
-		case "description", "cover_image_url", "online_meeting_url":
+		case "description", "cover_image_url", "online_meeting_url", "location_address", "venue_id":
 			if vMap, ok := value.(map[string]interface{}); ok {
 				strVal, _ := vMap["String"].(string)
 				validVal, _ := vMap["Valid"].(bool)
@@ -499,16 +891,22 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 					eventToUpdate.CoverImageURL = nullString
 				case "online_meeting_url":
 					eventToUpdate.OnlineMeetingURL = nullString
+				case "location_address":
+					eventToUpdate.LocationAddress = nullString
+				case "venue_id":
+					eventToUpdate.VenueID = nullString
 				}
 				fieldMaskPaths = append(fieldMaskPaths, key)
 			}
-		case "is_recurring", "waitlist_enabled", "registration_required", "whitelist_only", "require_approval", "face_verification_required", "liveness_check_required", "qr_code_enabled", "fallback_code_enabled", "manual_checkin_allowed", "is_paid":
+		case "is_recurring", "waitlist_enabled", "per_session_registration", "registration_required", "whitelist_only", "require_approval", "face_verification_required", "liveness_check_required", "qr_code_enabled", "fallback_code_enabled", "manual_checkin_allowed", "is_paid":
 			if v, ok := value.(bool); ok {
 				switch key {
 				case "is_recurring":
 					eventToUpdate.IsRecurring = v
 				case "waitlist_enabled":
 					eventToUpdate.WaitlistEnabled = v
+				case "per_session_registration":
+					eventToUpdate.PerSessionRegistration = v
 				case "registration_required":
 					eventToUpdate.RegistrationRequired = v
 				case "whitelist_only":
@@ -530,30 +928,42 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 				}
 				fieldMaskPaths = append(fieldMaskPaths, key)
 			}
-		case "start_time", "end_time":
+		case "start_time", "end_time", "recurrence_end_date":
 			if vMap, ok := value.(map[string]interface{}); ok {
 				timeStr, _ := vMap["Time"].(string)
 				validVal, _ := vMap["Valid"].(bool)
 				parsedTime, err := time.Parse(time.RFC3339, timeStr)
 				if err == nil {
 					nullTime := sql.NullTime{Time: parsedTime, Valid: validVal}
-					if key == "start_time" {
+					switch key {
+					case "start_time":
 						eventToUpdate.StartTime = nullTime
-					} else {
+					case "end_time":
 						eventToUpdate.EndTime = nullTime
+					case "recurrence_end_date":
+						eventToUpdate.RecurrenceEndDate = nullTime
 					}
 					fieldMaskPaths = append(fieldMaskPaths, key)
 				}
 			}
-		case "max_attendees", "max_waitlist":
+		case "max_attendees", "max_waitlist", "max_occurrences", "generation_horizon_days", "meeting_link_reveal_minutes", "transfer_deadline_minutes":
 			if v, ok := value.(map[string]interface{}); ok {
 				intVal, _ := v["Int32"].(float64)
 				validVal, _ := v["Valid"].(bool)
 				nullInt := sql.NullInt32{Int32: int32(intVal), Valid: validVal}
-				if key == "max_attendees" {
+				switch key {
+				case "max_attendees":
 					eventToUpdate.MaxAttendees = nullInt
-				} else {
+				case "max_waitlist":
 					eventToUpdate.MaxWaitlist = nullInt
+				case "max_occurrences":
+					eventToUpdate.MaxOccurrences = nullInt
+				case "generation_horizon_days":
+					eventToUpdate.GenerationHorizonDays = nullInt
+				case "meeting_link_reveal_minutes":
+					eventToUpdate.MeetingLinkRevealMinutes = nullInt
+				case "transfer_deadline_minutes":
+					eventToUpdate.TransferDeadlineMinutes = nullInt
 				}
 				fieldMaskPaths = append(fieldMaskPaths, key)
 			}
@@ -596,6 +1006,14 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, domain.ErrInvalidRecurrence) || errors.Is(err, domain.ErrInvalidGenerationHorizon) || errors.Is(err, domain.ErrInvalidReminderSchedule) || errors.Is(err, domain.ErrInvalidVenue) || errors.Is(err, domain.ErrVenueNotFound) || errors.Is(err, domain.ErrInvalidMeetingLinkReveal) || errors.Is(err, domain.ErrInvalidTransferPolicy) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidStatusTransition) || errors.Is(err, domain.ErrVenueDoubleBooked) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update event", "details": err.Error()})
 		return
 	}
@@ -657,15 +1075,65 @@ func (h *EventHandler) ListMyRegistrations(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"registrations": registrations})
 }
 
-// @Summary Delete an event
-// @Description Soft delete an event (mark as cancelled)
-// @ID delete-event
-// @Param id path string true "Event ID"
-// @Success 200 {object} MessageResponse
+// @Summary Get my agenda
+// @Description List the sessions of all events the authenticated user is registered for, in order, marking those that overlap another session on the agenda. Defaults to the next 90 days; the range is at most a year.
+// @ID get-my-agenda
+// @Produce json
+// @Param from query string false "Start of the range (RFC 3339), defaults to now"
+// @Param to query string false "End of the range (RFC 3339), defaults to 90 days after from"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
 // @Failure 401 {object} map[string]interface{}
-// @Failure 403 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
-// @Router /api/v1/events/{id} [delete]
+// @Router /api/v1/users/me/agenda [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetMyAgenda(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var from, to time.Time
+	for param, value := range map[string]*time.Time{"from": &from, "to": &to} {
+		if raw := c.Query(param); raw != "" {
+			parsed, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid %s, expected RFC 3339", param)})
+				return
+			}
+			*value = parsed
+		}
+	}
+
+	items, err := h.service.GetMyAgenda(c.Request.Context(), userID.(string), from, to)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAgendaRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get agenda"})
+		return
+	}
+
+	conflicts := 0
+	for _, item := range items {
+		if item.HasConflict {
+			conflicts++
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"agenda": items, "conflicting_sessions": conflicts})
+}
+
+// @Summary Delete an event
+// @Description Soft delete an event (mark as cancelled)
+// @ID delete-event
+// @Param id path string true "Event ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id} [delete]
 // @Security ApiKeyAuth
 func (h *EventHandler) DeleteEvent(c *gin.Context) {
 	eventID := c.Param("id")
@@ -726,3 +1194,4634 @@ func (h *EventHandler) CancelEventSession(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Event session cancelled successfully"})
 }
+
+// @Summary Edit an event occurrence
+// @Description Edit one occurrence of an event. The scope applies the change to this occurrence only, to this and all following occurrences (splitting the series), or to the whole series.
+// @ID update-event-occurrence
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param occurrence body main.UpdateEventOccurrenceRequest true "Occurrence changes"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id} [patch]
+// @Security ApiKeyAuth
+func (h *EventHandler) UpdateEventOccurrence(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req UpdateEventOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	edit := &domain.OccurrenceEdit{Scope: req.Scope}
+	if req.StartTime != nil {
+		edit.StartTime = sql.NullTime{Time: *req.StartTime, Valid: true}
+	}
+	if req.EndTime != nil {
+		edit.EndTime = sql.NullTime{Time: *req.EndTime, Valid: true}
+	}
+	if req.Name != nil {
+		edit.Name = sql.NullString{String: *req.Name, Valid: *req.Name != ""}
+	}
+	if req.LocationOverride != nil {
+		edit.LocationOverride = sql.NullString{String: *req.LocationOverride, Valid: *req.LocationOverride != ""}
+	}
+	if req.OnlineMeetingURLOverride != nil {
+		edit.OnlineMeetingURLOverride = sql.NullString{String: *req.OnlineMeetingURLOverride, Valid: *req.OnlineMeetingURLOverride != ""}
+	}
+	if req.MaxAttendeesOverride != nil {
+		edit.MaxAttendeesOverride = sql.NullInt32{Int32: *req.MaxAttendeesOverride, Valid: *req.MaxAttendeesOverride > 0}
+	}
+
+	event, err := h.service.UpdateEventOccurrence(c.Request.Context(), sessionID, userID.(string), edit)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to edit this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		case errors.Is(err, domain.ErrInvalidOccurrence), errors.Is(err, domain.ErrEventNotRecurring), errors.Is(err, domain.ErrInvalidRecurrence):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVenueDoubleBooked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update event session"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"event": event})
+}
+
+// @Summary Add an event occurrence
+// @Description Add an extra occurrence (RDATE) to a recurring event and create its session
+// @ID add-event-occurrence
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param occurrence body main.AddEventOccurrenceRequest true "Occurrence timing"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/occurrences [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) AddEventOccurrence(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req AddEventOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	session, err := h.service.AddEventOccurrence(c.Request.Context(), eventID, userID.(string), req.StartTime, req.EndTime)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to add sessions to this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidOccurrence), errors.Is(err, domain.ErrEventNotRecurring), errors.Is(err, domain.ErrInvalidRecurrence):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVenueDoubleBooked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add event occurrence"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"session": session})
+}
+
+// @Summary Preview event occurrences
+// @Description Dry run of a recurrence rule: returns the next occurrences it produces in the event's timezone, honoring the recurrence end date and max occurrences. Nothing is saved.
+// @ID preview-event-occurrences
+// @Accept json
+// @Produce json
+// @Param preview body main.PreviewEventOccurrencesRequest true "Recurrence to preview"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/occurrences/preview [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) PreviewEventOccurrences(c *gin.Context) {
+	var req PreviewEventOccurrencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	count := req.Count
+	if count <= 0 {
+		count = defaultOccurrencePreviewCount
+	}
+	if count > maxOccurrencePreviewCount {
+		count = maxOccurrencePreviewCount
+	}
+
+	event := &domain.Event{
+		Timezone:       req.Timezone,
+		StartTime:      sql.NullTime{Time: req.StartTime, Valid: true},
+		EndTime:        sql.NullTime{Time: req.EndTime, Valid: true},
+		IsRecurring:    true,
+		RecurrenceRule: req.RecurrenceRule,
+	}
+	if req.RecurrenceEndDate != nil {
+		event.RecurrenceEndDate = sql.NullTime{Time: *req.RecurrenceEndDate, Valid: true}
+	}
+	if req.MaxOccurrences != nil {
+		event.MaxOccurrences = sql.NullInt32{Int32: *req.MaxOccurrences, Valid: true}
+	}
+	if req.GenerationHorizonDays != nil {
+		event.GenerationHorizonDays = sql.NullInt32{Int32: *req.GenerationHorizonDays, Valid: true}
+	}
+
+	occurrences, err := h.service.PreviewEventOccurrences(c.Request.Context(), event, count)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRecurrence) || errors.Is(err, domain.ErrInvalidGenerationHorizon) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to preview occurrences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"timezone": event.Location().String(), "occurrences": occurrences})
+}
+
+// @Summary Export event as iCalendar
+// @Description Download an .ics file containing the event and all of its sessions
+// @ID export-event-ical
+// @Produce text/calendar
+// @Param id path string true "Event ID"
+// @Success 200 {file} string "iCalendar file"
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/calendar.ics [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ExportEventICal(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	data, err := h.service.ExportEventICal(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		if errors.Is(err, permission_domain.ErrPermissionDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this event."})
+			return
+		}
+		if errors.Is(err, domain.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export event", "details": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment;filename=event-%s.ics", eventID))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
+
+// @Summary Get my calendar feed
+// @Description Get the private subscription URL of the authenticated user's calendar feed
+// @ID get-my-calendar-feed
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/users/me/calendar-feed [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetMyCalendarFeed(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	token, err := h.service.GetCalendarFeedToken(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get calendar feed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token, "feed_path": calendarFeedPath(token)})
+}
+
+// @Summary Rotate my calendar feed
+// @Description Issue a new calendar feed token. The previous feed URL stops working.
+// @ID rotate-my-calendar-feed
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/users/me/calendar-feed/rotate [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) RotateMyCalendarFeed(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	token, err := h.service.RotateCalendarFeedToken(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate calendar feed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token, "feed_path": calendarFeedPath(token)})
+}
+
+// @Summary Get user calendar feed
+// @Description Public iCalendar feed of a user's registrations, authenticated by the feed token
+// @ID get-user-calendar-feed
+// @Produce text/calendar
+// @Param token path string true "Calendar feed token"
+// @Success 200 {file} string "iCalendar feed"
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/calendar/users/{token}/events.ics [get]
+func (h *EventHandler) GetUserCalendarFeed(c *gin.Context) {
+	token := c.Param("token")
+
+	data, err := h.service.GetUserCalendarFeed(c.Request.Context(), token)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidFeedToken) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Calendar feed not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build calendar feed", "details": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
+
+// @Summary Get community calendar feed
+// @Description Public iCalendar feed of a public community's events
+// @ID get-community-calendar-feed
+// @Produce text/calendar
+// @Param id path string true "Community ID"
+// @Success 200 {file} string "iCalendar feed"
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/calendar/communities/{id}/events.ics [get]
+func (h *EventHandler) GetCommunityCalendarFeed(c *gin.Context) {
+	communityID := c.Param("id")
+
+	data, err := h.service.GetCommunityCalendarFeed(c.Request.Context(), communityID)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "This community does not have a public calendar."})
+		case errors.Is(err, permission_domain.ErrCommunityNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Community not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build calendar feed", "details": err.Error()})
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
+
+func calendarFeedPath(token string) string {
+	return fmt.Sprintf("/api/v1/calendar/users/%s/events.ics", token)
+}
+
+// @Summary Import events from iCalendar
+// @Description Upload an .ics file for a community. Without confirm=true the parsed events, sessions and RRULEs are only previewed; with confirm=true new events are created. Events already imported (by UID) are skipped.
+// @ID import-events-ical
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Community ID"
+// @Param file formData file true "iCalendar (.ics) file"
+// @Param confirm formData bool false "Create the events instead of previewing them"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/communities/{id}/events/import [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) ImportEventsFromICal(c *gin.Context) {
+	communityID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File not provided or invalid"})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxICalImportSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Could not read file"})
+		return
+	}
+	if len(data) > maxICalImportSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is too large"})
+		return
+	}
+	confirm := c.PostForm("confirm") == "true"
+
+	result, err := h.service.ImportEventsFromICal(c.Request.Context(), communityID, userID.(string), data, confirm)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to import events into this community."})
+		case errors.Is(err, domain.ErrInvalidICalendar):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid iCalendar file", "details": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import events", "details": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"import": result})
+}
+
+// @Summary List event staff
+// @Description List the co-hosts, check-in staff, speakers and volunteers of an event
+// @ID list-event-staff
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param role query string false "Only staff with this role (co_host, checkin_staff, speaker, volunteer)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/staff [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListEventStaff(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	staff, err := h.service.ListEventStaff(c.Request.Context(), eventID, userID.(string), c.Query("role"))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list event staff"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"staff": staff})
+}
+
+// @Summary Assign an event role
+// @Description Give a user a role within an event (co-host, check-in staff, speaker or volunteer), replacing any role the user had. Only the event host and community admins can assign roles.
+// @ID assign-event-staff
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param userId path string true "User ID"
+// @Param staff body main.AssignEventStaffRequest true "Role to assign"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/staff/{userId} [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) AssignEventStaff(c *gin.Context) {
+	eventID := c.Param("id")
+	staffUserID := c.Param("userId")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req AssignEventStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	member, err := h.service.AssignEventStaff(c.Request.Context(), eventID, staffUserID, req.Role, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the staff of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidEventRole):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign event role"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"staff": member})
+}
+
+// @Summary Remove an event role
+// @Description Remove a user's role within an event. Only the event host and community admins can remove roles.
+// @ID remove-event-staff
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param userId path string true "User ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/staff/{userId} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) RemoveEventStaff(c *gin.Context) {
+	eventID := c.Param("id")
+	staffUserID := c.Param("userId")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.RemoveEventStaff(c.Request.Context(), eventID, staffUserID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the staff of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrStaffNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "User has no role in this event"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove event role"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Event role removed successfully"})
+}
+
+// @Summary Register for a session
+// @Description Sign the authenticated user up for one session of an event that uses per-session registration. The user must be registered for the event. Once the session is full the user is put on its waitlist.
+// @ID register-for-session
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/registrations [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) RegisterForSession(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	registration, err := h.service.RegisterForSession(c.Request.Context(), sessionID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		case errors.Is(err, domain.ErrSessionRegistrationDisabled), errors.Is(err, domain.ErrSessionUnavailable):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotRegisteredForSession):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionFull), errors.Is(err, domain.ErrSessionOverlap), errors.Is(err, domain.ErrAlreadyRegistered):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register for session"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"registration": registration})
+}
+
+// @Summary Drop a session
+// @Description Cancel the authenticated user's registration or waitlist spot for a session. The freed seat goes to the next user on the waitlist.
+// @ID drop-session-registration
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} MessageResponse
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/registrations [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) DropSessionRegistration(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DropSessionRegistration(c.Request.Context(), sessionID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		case errors.Is(err, domain.ErrSessionRegistrationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to drop session"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session registration cancelled successfully"})
+}
+
+// @Summary List session registrations
+// @Description List the attendees registered for a session followed by its waitlist. Requires permission to view the event's attendees.
+// @ID list-session-registrations
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param status query string false "Only registrations with this status (registered, waitlisted, cancelled)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/registrations [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListSessionRegistrations(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	registrations, err := h.service.ListSessionRegistrations(c.Request.Context(), sessionID, c.Query("status"), userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the attendees of this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list session registrations"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"registrations": registrations})
+}
+
+// @Summary List my sessions
+// @Description List the sessions of an event the authenticated user is registered or waitlisted for
+// @ID list-my-session-registrations
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/my-sessions [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListMySessionRegistrations(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	registrations, err := h.service.ListMySessionRegistrations(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list session registrations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"registrations": registrations})
+}
+
+// @Summary Change event status
+// @Description Move an event to another status of its lifecycle, e.g. publish it, close registration early, cancel or archive it. Only transitions allowed by the lifecycle are accepted; publishing an event immediately brings it to the status its schedule calls for. Requires permission to edit the event.
+// @ID change-event-status
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param request body main.ChangeEventStatusRequest true "New status"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/status [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) ChangeEventStatus(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req ChangeEventStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+
+	event, err := h.service.ChangeEventStatus(c.Request.Context(), eventID, req.Status, req.Reason, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to change the status of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change event status"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"event": event})
+}
+
+// @Summary Get event status history
+// @Description List the status transitions of an event, oldest first. Transitions made by the lifecycle worker have no triggered_by.
+// @ID get-event-status-history
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/status-history [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetEventStatusHistory(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	transitions, err := h.service.GetEventStatusHistory(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get event status history"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transitions": transitions})
+}
+
+// @Summary Create a feedback survey
+// @Description Attach a feedback survey with rating, NPS and free-text questions to an event, or to one of its sessions if session_id is set. Attendees who checked in are asked to answer it send_delay_minutes after their session ends; event surveys are answered once after the last session, or once per attended session if per_session is set. Requires permission to edit the event.
+// @ID create-feedback-survey
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param request body main.CreateFeedbackSurveyRequest true "Survey"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/feedback-surveys [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) CreateFeedbackSurvey(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req CreateFeedbackSurveyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+
+	survey := &domain.FeedbackSurvey{
+		SessionID:        sql.NullString{String: req.SessionID, Valid: req.SessionID != ""},
+		Title:            req.Title,
+		Description:      sql.NullString{String: req.Description, Valid: req.Description != ""},
+		Questions:        req.Questions,
+		PerSession:       req.PerSession,
+		SendDelayMinutes: req.SendDelayMinutes,
+		IsActive:         req.IsActive == nil || *req.IsActive,
+	}
+	survey, err := h.service.CreateFeedbackSurvey(c.Request.Context(), eventID, userID.(string), survey)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the feedback surveys of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrSessionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		case errors.Is(err, domain.ErrInvalidFeedbackSurvey):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create feedback survey"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"survey": survey})
+}
+
+// @Summary List feedback surveys
+// @Description List the feedback surveys of an event, event surveys first, with their number of responses.
+// @ID list-feedback-surveys
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/feedback-surveys [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListFeedbackSurveys(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	surveys, err := h.service.ListFeedbackSurveys(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list feedback surveys"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"surveys": surveys})
+}
+
+// @Summary Get a feedback survey
+// @Description Get a feedback survey with its questions.
+// @ID get-feedback-survey
+// @Produce json
+// @Param id path string true "Survey ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/feedback-surveys/{id} [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetFeedbackSurvey(c *gin.Context) {
+	surveyID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	survey, err := h.service.GetFeedbackSurvey(c.Request.Context(), surveyID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this event."})
+		case errors.Is(err, domain.ErrFeedbackSurveyNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Feedback survey not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get feedback survey"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"survey": survey})
+}
+
+// @Summary Update a feedback survey
+// @Description Update a feedback survey; omitted fields are left unchanged. Questions and per_session cannot be changed once responses were submitted. Set is_active to false to stop sending requests and accepting responses. Requires permission to edit the event.
+// @ID update-feedback-survey
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey ID"
+// @Param request body main.UpdateFeedbackSurveyRequest true "Changes"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/feedback-surveys/{id} [patch]
+// @Security ApiKeyAuth
+func (h *EventHandler) UpdateFeedbackSurvey(c *gin.Context) {
+	surveyID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req UpdateFeedbackSurveyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+
+	survey, err := h.service.GetFeedbackSurvey(c.Request.Context(), surveyID, userID.(string))
+	if err == nil {
+		if req.Title != nil {
+			survey.Title = *req.Title
+		}
+		if req.Description != nil {
+			survey.Description = sql.NullString{String: *req.Description, Valid: *req.Description != ""}
+		}
+		if req.Questions != nil {
+			survey.Questions = req.Questions
+		}
+		if req.PerSession != nil {
+			survey.PerSession = *req.PerSession
+		}
+		if req.SendDelayMinutes != nil {
+			survey.SendDelayMinutes = *req.SendDelayMinutes
+		}
+		if req.IsActive != nil {
+			survey.IsActive = *req.IsActive
+		}
+		survey, err = h.service.UpdateFeedbackSurvey(c.Request.Context(), survey, userID.(string))
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the feedback surveys of this event."})
+		case errors.Is(err, domain.ErrFeedbackSurveyNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Feedback survey not found"})
+		case errors.Is(err, domain.ErrInvalidFeedbackSurvey):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update feedback survey"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"survey": survey})
+}
+
+// @Summary Delete a feedback survey
+// @Description Delete a feedback survey together with its responses. Requires permission to edit the event.
+// @ID delete-feedback-survey
+// @Produce json
+// @Param id path string true "Survey ID"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/feedback-surveys/{id} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) DeleteFeedbackSurvey(c *gin.Context) {
+	surveyID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DeleteFeedbackSurvey(c.Request.Context(), surveyID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the feedback surveys of this event."})
+		case errors.Is(err, domain.ErrFeedbackSurveyNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Feedback survey not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete feedback survey"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Submit feedback
+// @Description Answer a feedback survey. Only attendees who checked in can respond: to the survey's session, to the given session_id for per-session surveys, or to any session of the event. Each attendee answers once per survey and session.
+// @ID submit-feedback
+// @Accept json
+// @Produce json
+// @Param id path string true "Survey ID"
+// @Param request body main.SubmitFeedbackRequest true "Answers"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/feedback-surveys/{id}/responses [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) SubmitFeedback(c *gin.Context) {
+	surveyID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SubmitFeedbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+
+	response, err := h.service.SubmitFeedback(c.Request.Context(), surveyID, req.SessionID, userID.(string), req.Answers)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrFeedbackNotAllowed):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrFeedbackSurveyNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Feedback survey not found"})
+		case errors.Is(err, domain.ErrSessionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		case errors.Is(err, domain.ErrInvalidFeedbackResponse):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrFeedbackSurveyClosed), errors.Is(err, domain.ErrFeedbackAlreadySubmitted):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit feedback"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"response": response})
+}
+
+// @Summary List pending feedback
+// @Description List the feedback requests sent to the current user that are still unanswered, newest first.
+// @ID list-pending-feedback
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/feedback/pending [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListPendingFeedback(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	requests, err := h.service.ListPendingFeedback(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list pending feedback"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"requests": requests})
+}
+
+// @Summary Save certificate template
+// @Description Create or replace the certificate template of an event. When the event completes, attendees whose share of attended sessions reaches min_attendance_percent are issued a certificate, unless is_active is false. Requires permission to edit the event.
+// @ID save-certificate-template
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param request body main.SaveCertificateTemplateRequest true "Template"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/certificate-template [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) SaveCertificateTemplate(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SaveCertificateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+
+	template := &domain.CertificateTemplate{
+		Title:                req.Title,
+		Body:                 req.Body,
+		SignerName:           sql.NullString{String: req.SignerName, Valid: req.SignerName != ""},
+		SignerTitle:          sql.NullString{String: req.SignerTitle, Valid: req.SignerTitle != ""},
+		MinAttendancePercent: req.MinAttendancePercent,
+		IsActive:             req.IsActive == nil || *req.IsActive,
+	}
+	template, err := h.service.SaveCertificateTemplate(c.Request.Context(), eventID, userID.(string), template)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the certificates of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidCertificateTemplate):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save certificate template"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+// @Summary Get certificate template
+// @Description Get the certificate template of an event. Requires permission to edit the event.
+// @ID get-certificate-template
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/certificate-template [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetCertificateTemplate(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	template, err := h.service.GetCertificateTemplate(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the certificates of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrCertificateTemplateNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Certificate template not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get certificate template"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+// @Summary Delete certificate template
+// @Description Delete the certificate template of an event. Certificates already issued stay valid. Requires permission to edit the event.
+// @ID delete-certificate-template
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/certificate-template [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) DeleteCertificateTemplate(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DeleteCertificateTemplate(c.Request.Context(), eventID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the certificates of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrCertificateTemplateNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Certificate template not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete certificate template"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Issue certificates
+// @Description Issue certificates to the attendees of a completed event who meet the template's attendance rule and do not have one yet, e.g. after the template was created or changed. Returns the certificates issued. Requires permission to edit the event.
+// @ID issue-event-certificates
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/certificates/issue [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) IssueEventCertificates(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	certificates, err := h.service.IssueEventCertificates(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the certificates of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrCertificateTemplateNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Certificate template not found"})
+		case errors.Is(err, domain.ErrCertificatesNotAvailable):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to issue certificates"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"certificates": certificates})
+}
+
+// @Summary List event certificates
+// @Description List the certificates issued for an event. Requires permission to view the event's attendees.
+// @ID list-event-certificates
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/certificates [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListEventCertificates(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	certificates, err := h.service.ListEventCertificates(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the certificates of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list certificates"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"certificates": certificates})
+}
+
+// @Summary List my certificates
+// @Description List the certificates issued to the current user, newest first.
+// @ID list-my-certificates
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/certificates/me [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListMyCertificates(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	certificates, err := h.service.ListMyCertificates(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list certificates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"certificates": certificates})
+}
+
+// @Summary Download certificate
+// @Description Download a certificate as a PDF with a QR code linking to its verification page. Available to its recipient and to the event staff who can view attendees.
+// @ID download-certificate
+// @Produce application/pdf
+// @Param id path string true "Certificate ID"
+// @Success 200 {file} string "PDF file"
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/certificates/{id}/pdf [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) DownloadCertificate(c *gin.Context) {
+	certificateID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	pdfData, certificate, err := h.service.GetCertificatePDF(c.Request.Context(), certificateID, userID.(string), h.frontendURL+"/certificates/verify/")
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this certificate."})
+		case errors.Is(err, domain.ErrCertificateNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Certificate not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate certificate"})
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment;filename=certificate_%s.pdf", certificate.VerificationCode))
+	c.Data(http.StatusOK, "application/pdf", pdfData)
+}
+
+// @Summary Revoke certificate
+// @Description Revoke a certificate, e.g. one issued by mistake. It then verifies as revoked. Requires permission to edit the event.
+// @ID revoke-certificate
+// @Accept json
+// @Produce json
+// @Param id path string true "Certificate ID"
+// @Param request body main.RevokeCertificateRequest false "Reason"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/certificates/{id}/revoke [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) RevokeCertificate(c *gin.Context) {
+	certificateID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req RevokeCertificateRequest
+	_ = c.ShouldBindJSON(&req)
+
+	if err := h.service.RevokeCertificate(c.Request.Context(), certificateID, userID.(string), req.Reason); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the certificates of this event."})
+		case errors.Is(err, domain.ErrCertificateNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Certificate not found or already revoked"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke certificate"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Verify certificate
+// @Description Confirm that a certificate is genuine by its verification code. Public; only the recipient's name, the event, the certificate title, its issue date and whether it was revoked are returned.
+// @ID verify-certificate
+// @Produce json
+// @Param code path string true "Verification code"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/certificates/verify/{code} [get]
+func (h *EventHandler) VerifyCertificate(c *gin.Context) {
+	verification, err := h.service.VerifyCertificate(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		if errors.Is(err, domain.ErrCertificateNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"valid": false, "error": "No certificate with this verification code"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify certificate"})
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+// @Summary Invite people to an event
+// @Description Invite users by ID or people by email to an event, with an optional personal message. Invitees are notified; people already invited are skipped. Invitees of a whitelist-only event are added to its whitelist. Requires permission to edit the event.
+// @ID invite-to-event
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param invitation_data body main.InviteToEventRequest true "People to invite"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/invitations [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) InviteToEvent(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req InviteToEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload", "details": err.Error()})
+		return
+	}
+
+	invitations, err := h.service.InviteToEvent(c.Request.Context(), eventID, userID.(string), req.UserIDs, req.Emails, req.Message)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to invite people to this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidInvitation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvitationsClosed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send invitations"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"invitations": invitations, "invited": len(invitations)})
+}
+
+// @Summary Get RSVP dashboard
+// @Description List the invitations of an event with their responses and the invitees' registration status, and count them by response. Requires permission to view the event's attendees.
+// @ID get-invitation-dashboard
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param rsvp query string false "Only invitations with this response (pending, going, maybe or declined)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/invitations [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetInvitationDashboard(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	dashboard, err := h.service.GetInvitationDashboard(c.Request.Context(), eventID, userID.(string), c.Query("rsvp"))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the invitations of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidRSVP):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "rsvp must be pending, going, maybe or declined"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list invitations"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"dashboard": dashboard})
+}
+
+// @Summary Remind pending invitees
+// @Description Remind the invitees of an event who have not responded yet. Each invitation is reminded at most once a day and five times in total, including automatic reminders. Requires permission to edit the event.
+// @ID remind-pending-invitations
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/invitations/remind [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) RemindPendingInvitations(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reminded, err := h.service.RemindPendingInvitations(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the invitations of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send reminders"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reminded": reminded})
+}
+
+// @Summary Revoke invitation
+// @Description Withdraw an event invitation. A registration the invitee already made is kept. Requires permission to edit the event.
+// @ID revoke-invitation
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/invitations/{id} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) RevokeInvitation(c *gin.Context) {
+	invitationID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.RevokeInvitation(c.Request.Context(), invitationID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the invitations of this event."})
+		case errors.Is(err, domain.ErrInvitationNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke invitation"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List my invitations
+// @Description List the event invitations sent to the current user, to their account or their email, newest first.
+// @ID list-my-invitations
+// @Produce json
+// @Param rsvp query string false "Only invitations with this response (pending, going, maybe or declined)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/invitations/me [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListMyInvitations(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	invitations, err := h.service.ListMyInvitations(c.Request.Context(), userID.(string), c.Query("rsvp"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRSVP) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "rsvp must be pending, going, maybe or declined"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list invitations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+// @Summary Respond to invitation
+// @Description Answer an event invitation with going, maybe or declined. Going registers the user for the event under its approval, capacity and eligibility rules; if the registration is refused the answer is still recorded and registration_error says why. Declined cancels the user's registration.
+// @ID respond-to-invitation
+// @Accept json
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Param rsvp_data body main.RespondToInvitationRequest true "Response"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/invitations/{id}/rsvp [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) RespondToInvitation(c *gin.Context) {
+	invitationID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req RespondToInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload", "details": err.Error()})
+		return
+	}
+
+	response, err := h.service.RespondToInvitation(c.Request.Context(), invitationID, userID.(string), req.RSVP)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidRSVP):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvitationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Invitation not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to respond to invitation", "details": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary Transfer my registration
+// @Description Offer the current user's registration for an event to another user or to an email address. The recipient is notified and accepts or declines; on events whose transfer policy is approval, a host approves the transfer too. Transfers close transfer_deadline_minutes before the next session.
+// @ID request-registration-transfer
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param transfer_data body main.RequestRegistrationTransferRequest true "Recipient"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/transfers [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) RequestRegistrationTransfer(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req RequestRegistrationTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload", "details": err.Error()})
+		return
+	}
+
+	transfer, err := h.service.RequestRegistrationTransfer(c.Request.Context(), eventID, userID.(string), req.ToUserID, req.Email, req.Message)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTransfer):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotRegistered):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only attendees holding a confirmed registration can transfer it."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrTransfersDisabled), errors.Is(err, domain.ErrTransfersClosed),
+			errors.Is(err, domain.ErrTransferPending), errors.Is(err, domain.ErrTransferRecipientActive):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request transfer"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"transfer": transfer})
+}
+
+// @Summary List registration transfers of an event
+// @Description List the registration transfers of an event, newest first. Requires permission to approve the event's registrations.
+// @ID list-event-registration-transfers
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param status query string false "Only transfers with this status (pending, completed, declined, rejected or cancelled)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/transfers [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListEventRegistrationTransfers(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	transfers, err := h.service.ListEventRegistrationTransfers(c.Request.Context(), eventID, userID.(string), c.Query("status"))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the transfers of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list transfers"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transfers": transfers})
+}
+
+// @Summary List my registration transfers
+// @Description List the registration transfers the current user requested or was offered, to their account or their email, newest first.
+// @ID list-my-registration-transfers
+// @Produce json
+// @Param status query string false "Only transfers with this status (pending, completed, declined, rejected or cancelled)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/transfers/me [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListMyRegistrationTransfers(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	transfers, err := h.service.ListMyRegistrationTransfers(c.Request.Context(), userID.(string), c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list transfers"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transfers": transfers})
+}
+
+// @Summary Accept registration transfer
+// @Description Accept a registration transfer offered to the current user. The registration, with its payment and registration form data, changes hands right away, or once a host approves it on events whose transfer policy is approval. The sender's tickets and fallback codes stop working; the recipient gets new ones from the ticket endpoint. The recipient must be a member of the event's community.
+// @ID accept-registration-transfer
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/transfers/{id}/accept [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) AcceptRegistrationTransfer(c *gin.Context) {
+	transferID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	transfer, err := h.service.AcceptRegistrationTransfer(c.Request.Context(), transferID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only members of the event's community can accept the transfer."})
+		case errors.Is(err, domain.ErrTransferNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Transfer not found"})
+		case errors.Is(err, domain.ErrTransferNotPending), errors.Is(err, domain.ErrTransfersDisabled), errors.Is(err, domain.ErrTransfersClosed),
+			errors.Is(err, domain.ErrTransferRecipientActive), errors.Is(err, domain.ErrNotRegistered):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept transfer"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transfer": transfer})
+}
+
+// @Summary Decline registration transfer
+// @Description Decline a registration transfer offered to the current user. The sender keeps the registration and is notified.
+// @ID decline-registration-transfer
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/transfers/{id}/decline [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) DeclineRegistrationTransfer(c *gin.Context) {
+	transferID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DeclineRegistrationTransfer(c.Request.Context(), transferID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTransferNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Transfer not found"})
+		case errors.Is(err, domain.ErrTransferNotPending):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decline transfer"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Approve registration transfer
+// @Description Approve a registration transfer on an event whose transfer policy is approval. The registration changes hands once the recipient has accepted too. Requires permission to approve the event's registrations.
+// @ID approve-registration-transfer
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/transfers/{id}/approve [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) ApproveRegistrationTransfer(c *gin.Context) {
+	transferID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	transfer, err := h.service.ApproveRegistrationTransfer(c.Request.Context(), transferID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTransfer):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to approve transfers for this event."})
+		case errors.Is(err, domain.ErrTransferNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Transfer not found"})
+		case errors.Is(err, domain.ErrTransferNotPending), errors.Is(err, domain.ErrTransferRecipientActive), errors.Is(err, domain.ErrNotRegistered):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to approve transfer"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transfer": transfer})
+}
+
+// @Summary Reject registration transfer
+// @Description Reject a pending registration transfer. The sender keeps the registration, and both parties are notified. Requires permission to approve the event's registrations.
+// @ID reject-registration-transfer
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/transfers/{id}/reject [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) RejectRegistrationTransfer(c *gin.Context) {
+	transferID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.RejectRegistrationTransfer(c.Request.Context(), transferID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to reject transfers for this event."})
+		case errors.Is(err, domain.ErrTransferNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Transfer not found"})
+		case errors.Is(err, domain.ErrTransferNotPending):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reject transfer"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Cancel registration transfer
+// @Description Withdraw a pending registration transfer the current user requested.
+// @ID cancel-registration-transfer
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/transfers/{id} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) CancelRegistrationTransfer(c *gin.Context) {
+	transferID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.CancelRegistrationTransfer(c.Request.Context(), transferID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTransferNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Transfer not found"})
+		case errors.Is(err, domain.ErrTransferNotPending):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel transfer"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Create announcement
+// @Description Broadcast an announcement to the attendees of an event or of one of its sessions: everyone registered, only those who checked in, only no-shows, or the waitlist. It is delivered through each recipient's enabled notification channels, right away or at scheduled_at. Requires permission to edit the event.
+// @ID create-announcement
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param announcement_data body main.CreateAnnouncementRequest true "Announcement"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/announcements [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) CreateAnnouncement(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req CreateAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
+		return
+	}
+
+	announcement := &domain.EventAnnouncement{
+		SessionID: sql.NullString{String: req.SessionID, Valid: req.SessionID != ""},
+		Title:     req.Title,
+		Message:   req.Message,
+		Audience:  req.Audience,
+	}
+	if req.ScheduledAt != nil {
+		announcement.ScheduledAt = *req.ScheduledAt
+	}
+	announcement, err := h.service.CreateAnnouncement(c.Request.Context(), eventID, userID.(string), announcement)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to make announcements for this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrSessionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		case errors.Is(err, domain.ErrInvalidAnnouncement):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create announcement"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"announcement": announcement})
+}
+
+// @Summary List announcements
+// @Description List the announcements of an event, newest first, including scheduled and cancelled ones, with the number of people each was sent to. Requires permission to edit the event.
+// @ID list-announcements
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/announcements [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListAnnouncements(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	announcements, err := h.service.ListAnnouncements(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the announcements of this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list announcements"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"announcements": announcements})
+}
+
+// @Summary Cancel announcement
+// @Description Cancel a scheduled announcement before it is sent. Requires permission to edit the event.
+// @ID cancel-announcement
+// @Produce json
+// @Param id path string true "Announcement ID"
+// @Success 204
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/announcements/{id} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) CancelAnnouncement(c *gin.Context) {
+	announcementID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.CancelAnnouncement(c.Request.Context(), announcementID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the announcements of this event."})
+		case errors.Is(err, domain.ErrAnnouncementNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Announcement not found"})
+		case errors.Is(err, domain.ErrAnnouncementNotPending):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel announcement"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Reschedule an event session
+// @Description Move a session to another time or place. Registrations are kept and tickets already issued for the session are revoked, so attendees get new ones. Attendees are notified with the previous and new details; with require_reconfirmation, those holding a seat are asked to confirm they still attend.
+// @ID reschedule-event-session
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param reschedule_data body main.RescheduleEventSessionRequest true "New time or place"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/reschedule [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) RescheduleEventSession(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req RescheduleEventSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	input := &domain.SessionRescheduleInput{Reason: req.Reason, RequireReconfirmation: req.RequireReconfirmation}
+	if req.StartTime != nil {
+		input.StartTime = sql.NullTime{Time: *req.StartTime, Valid: true}
+	}
+	if req.EndTime != nil {
+		input.EndTime = sql.NullTime{Time: *req.EndTime, Valid: true}
+	}
+	if req.LocationOverride != nil {
+		input.LocationOverride = sql.NullString{String: *req.LocationOverride, Valid: *req.LocationOverride != ""}
+	}
+	if req.OnlineMeetingURLOverride != nil {
+		input.OnlineMeetingURLOverride = sql.NullString{String: *req.OnlineMeetingURLOverride, Valid: *req.OnlineMeetingURLOverride != ""}
+	}
+
+	reschedule, err := h.service.RescheduleEventSession(c.Request.Context(), sessionID, userID.(string), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to reschedule this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		case errors.Is(err, domain.ErrInvalidReschedule):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVenueDoubleBooked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reschedule event session"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reschedule": reschedule})
+}
+
+// @Summary List session reschedules
+// @Description List the changes of a session's time or place, newest first, with how many attendees confirmed, declined or have yet to answer. Requires permission to view the event's attendees.
+// @ID list-session-reschedules
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/reschedules [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListSessionReschedules(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reschedules, err := h.service.ListSessionReschedules(c.Request.Context(), sessionID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the changes of this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list session reschedules"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reschedules": reschedules})
+}
+
+// @Summary List my re-confirmation requests
+// @Description List the requests to confirm attendance of rescheduled sessions sent to the current user, newest first.
+// @ID list-my-reconfirmations
+// @Produce json
+// @Param status query string false "Only requests with this status (pending, confirmed or declined)"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/reconfirmations/me [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListMyReconfirmations(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	reconfirmations, err := h.service.ListMyReconfirmations(c.Request.Context(), userID.(string), c.Query("status"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidReconfirmation) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "status must be pending, confirmed or declined"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list re-confirmation requests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reconfirmations": reconfirmations})
+}
+
+// @Summary Answer a re-confirmation request
+// @Description Confirm or decline attending a rescheduled session. Declining releases the seat: the session registration on events with per-session registration, the event registration otherwise.
+// @ID answer-reconfirmation
+// @Accept json
+// @Produce json
+// @Param id path string true "Re-confirmation request ID"
+// @Param answer_data body main.AnswerReconfirmationRequest true "Answer"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/reconfirmations/{id} [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) AnswerReconfirmation(c *gin.Context) {
+	reconfirmationID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req AnswerReconfirmationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	reconfirmation, err := h.service.AnswerReconfirmation(c.Request.Context(), reconfirmationID, userID.(string), req.Status)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidReconfirmation):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrReconfirmationNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Re-confirmation request not found"})
+		case errors.Is(err, domain.ErrReconfirmationAlreadyAnswered):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to answer re-confirmation request"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reconfirmation": reconfirmation})
+}
+
+// @Summary Save an event as a template
+// @Description Save the settings of an event as a template of its community: check-in requirements, registration and capacity settings, pricing, reminders, staff roles, event-wide feedback surveys and the certificate template. Times are kept relative to the event start. Requires permission to edit the event.
+// @ID save-event-as-template
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param template_data body main.EventTemplateRequest true "Template name, description and sharing"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/templates [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) SaveEventAsTemplate(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req EventTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	template := &domain.EventTemplate{
+		Name:        req.Name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+		IsShared:    req.IsShared,
+	}
+	template, err := h.service.SaveEventAsTemplate(c.Request.Context(), eventID, userID.(string), template)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to save this event as a template."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidEventTemplate), errors.Is(err, domain.ErrInvalidRecurrence):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save event template"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"template": template})
+}
+
+// @Summary List event templates
+// @Description List the event templates of a community the current user created and, for the community's admins, those shared with the community.
+// @ID list-event-templates
+// @Produce json
+// @Param id path string true "Community ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/communities/{id}/event-templates [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListEventTemplates(c *gin.Context) {
+	communityID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	templates, err := h.service.ListEventTemplates(c.Request.Context(), communityID, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list event templates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+// @Summary Get an event template
+// @Description Get a template with its settings. Templates are visible to their creator and, once shared, to the admins of their community.
+// @ID get-event-template
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/templates/{id} [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetEventTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	template, err := h.service.GetEventTemplate(c.Request.Context(), templateID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this template."})
+		case errors.Is(err, domain.ErrEventTemplateNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event template not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get event template"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+// @Summary Update an event template
+// @Description Rename a template, change its description or whether it is shared with the community. Only its creator can change the sharing.
+// @ID update-event-template
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param template_data body main.EventTemplateRequest true "Template name, description and sharing"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/templates/{id} [patch]
+// @Security ApiKeyAuth
+func (h *EventHandler) UpdateEventTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req EventTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	template := &domain.EventTemplate{
+		ID:          templateID,
+		Name:        req.Name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+		IsShared:    req.IsShared,
+	}
+	template, err := h.service.UpdateEventTemplate(c.Request.Context(), template, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to change this template."})
+		case errors.Is(err, domain.ErrEventTemplateNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event template not found"})
+		case errors.Is(err, domain.ErrInvalidEventTemplate):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update event template"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+// @Summary Delete an event template
+// @Description Delete a template. Events created from it are kept.
+// @ID delete-event-template
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/templates/{id} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) DeleteEventTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DeleteEventTemplate(c.Request.Context(), templateID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to delete this template."})
+		case errors.Is(err, domain.ErrEventTemplateNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event template not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete event template"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Event template deleted successfully"})
+}
+
+// @Summary Create an event from a template
+// @Description Create a draft event in the template's community starting at start_time, with the template's settings, staff roles, feedback surveys and certificate template. Only community admins can create events.
+// @ID create-event-from-template
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param event_data body main.CreateEventFromTemplateRequest true "Name and start time of the new event"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/templates/{id}/events [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) CreateEventFromTemplate(c *gin.Context) {
+	templateID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req CreateEventFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	event, err := h.service.CreateEventFromTemplate(c.Request.Context(), templateID, userID.(string), req.Name, req.StartTime)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to create events from this template."})
+		case errors.Is(err, domain.ErrEventTemplateNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event template not found"})
+		case errors.Is(err, domain.ErrInvalidEventTemplate), errors.Is(err, domain.ErrInvalidRecurrence),
+			errors.Is(err, domain.ErrInvalidGenerationHorizon), errors.Is(err, domain.ErrInvalidReminderSchedule):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVenueDoubleBooked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create event from template"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"event": event})
+}
+
+// @Summary Duplicate an event
+// @Description Create a draft copy of an event starting at start_time. Registration windows and the end of a series move along with the start. Staff roles, event-wide feedback surveys and the certificate template are copied; attendees, check-ins and edits of single occurrences are not. Only community admins can create events.
+// @ID duplicate-event
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param event_data body main.CreateEventFromTemplateRequest true "Name and start time of the copy"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/duplicate [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) DuplicateEvent(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req CreateEventFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	event, err := h.service.DuplicateEvent(c.Request.Context(), eventID, userID.(string), req.Name, req.StartTime)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to duplicate this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidEventTemplate), errors.Is(err, domain.ErrInvalidRecurrence),
+			errors.Is(err, domain.ErrInvalidGenerationHorizon), errors.Is(err, domain.ErrInvalidReminderSchedule):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVenueDoubleBooked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to duplicate event"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"event": event})
+}
+
+// venueFromRequest converts a venue request into a venue.
+func venueFromRequest(req *VenueRequest) *domain.Venue {
+	venue := &domain.Venue{
+		Name:               req.Name,
+		Address:            req.Address,
+		AccessibilityNotes: sql.NullString{String: req.AccessibilityNotes, Valid: req.AccessibilityNotes != ""},
+	}
+	if req.Latitude != nil {
+		venue.Latitude = sql.NullFloat64{Float64: *req.Latitude, Valid: true}
+	}
+	if req.Longitude != nil {
+		venue.Longitude = sql.NullFloat64{Float64: *req.Longitude, Valid: true}
+	}
+	if req.Capacity != nil {
+		venue.Capacity = sql.NullInt32{Int32: *req.Capacity, Valid: true}
+	}
+	return venue
+}
+
+// @Summary Create a venue
+// @Description Add a reusable venue to a community, with its address, coordinates, capacity and accessibility notes. Events and sessions then reference it by venue_id. Only community admins manage venues.
+// @ID create-venue
+// @Accept json
+// @Produce json
+// @Param id path string true "Community ID"
+// @Param venue_data body main.VenueRequest true "Venue"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/communities/{id}/venues [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) CreateVenue(c *gin.Context) {
+	communityID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req VenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	venue, err := h.service.CreateVenue(c.Request.Context(), communityID, userID.(string), venueFromRequest(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only community admins can manage venues."})
+		case errors.Is(err, domain.ErrInvalidVenue):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create venue"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"venue": venue})
+}
+
+// @Summary List venues
+// @Description List the venues of a community by name.
+// @ID list-venues
+// @Produce json
+// @Param id path string true "Community ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/communities/{id}/venues [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListVenues(c *gin.Context) {
+	communityID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	venues, err := h.service.ListVenues(c.Request.Context(), communityID, userID.(string))
+	if err != nil {
+		if errors.Is(err, permission_domain.ErrPermissionDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this community's venues."})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list venues"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"venues": venues})
+}
+
+// @Summary Get a venue
+// @Description Get a venue of a community.
+// @ID get-venue
+// @Produce json
+// @Param id path string true "Venue ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/venues/{id} [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetVenue(c *gin.Context) {
+	venueID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	venue, err := h.service.GetVenue(c.Request.Context(), venueID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this venue."})
+		case errors.Is(err, domain.ErrVenueNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Venue not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get venue"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"venue": venue})
+}
+
+// @Summary Update a venue
+// @Description Replace the details of a venue. Events already held there keep the location text they were given.
+// @ID update-venue
+// @Accept json
+// @Produce json
+// @Param id path string true "Venue ID"
+// @Param venue_data body main.VenueRequest true "Venue"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/venues/{id} [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) UpdateVenue(c *gin.Context) {
+	venueID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req VenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	venue := venueFromRequest(&req)
+	venue.ID = venueID
+	venue, err := h.service.UpdateVenue(c.Request.Context(), venue, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only community admins can manage venues."})
+		case errors.Is(err, domain.ErrVenueNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Venue not found"})
+		case errors.Is(err, domain.ErrInvalidVenue):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update venue"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"venue": venue})
+}
+
+// @Summary Delete a venue
+// @Description Delete a venue. Events and sessions held there no longer reference it but keep their location text.
+// @ID delete-venue
+// @Produce json
+// @Param id path string true "Venue ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/venues/{id} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) DeleteVenue(c *gin.Context) {
+	venueID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DeleteVenue(c.Request.Context(), venueID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only community admins can manage venues."})
+		case errors.Is(err, domain.ErrVenueNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Venue not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete venue"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Venue deleted successfully"})
+}
+
+// @Summary Set the venue of a session
+// @Description Hold a session at a venue of the event's community other than the event's, or at the event's again with an empty venue_id. The session's location follows the venue. Requires permission to edit the event.
+// @ID set-session-venue
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param venue_data body main.SetSessionVenueRequest true "Venue"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/venue [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) SetSessionVenue(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SetSessionVenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	session, err := h.service.SetSessionVenue(c.Request.Context(), sessionID, userID.(string), req.VenueID)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to edit this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		case errors.Is(err, domain.ErrVenueNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Venue not found"})
+		case errors.Is(err, domain.ErrInvalidVenue):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrVenueDoubleBooked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set session venue"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"session": session})
+}
+
+// @Summary Get my join link
+// @Description Get the personal join link of the authenticated user for an online or hybrid event. Opening the link redirects to the meeting of the session in progress and checks the user in to it. Only approved registrants have a join link.
+// @ID get-my-join-link
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/join-link [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetMyJoinLink(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	token, err := h.service.GetJoinLinkToken(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotRegistered):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Only approved registrants have a join link."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrNoOnlineMeeting):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get join link"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token, "join_path": joinLinkPath(token)})
+}
+
+// @Summary Join an online session
+// @Description Open a personal join link: redirects to the meeting of the attendee's session that is in progress or starts within 30 minutes, and records a virtual check-in for it. No authentication is needed; the token identifies the attendee.
+// @ID join-online-session
+// @Param token path string true "Join token"
+// @Success 302
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/join/{token} [get]
+func (h *EventHandler) JoinOnlineSession(c *gin.Context) {
+	meetingURL, err := h.service.JoinOnlineSession(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidJoinLink), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Join link not found"})
+		case errors.Is(err, domain.ErrNotRegistered):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Your registration is not active."})
+		case errors.Is(err, domain.ErrSessionNotJoinable), errors.Is(err, domain.ErrNoOnlineMeeting):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to join the session"})
+		}
+		return
+	}
+
+	c.Redirect(http.StatusFound, meetingURL)
+}
+
+func joinLinkPath(token string) string {
+	return fmt.Sprintf("/api/v1/join/%s", token)
+}
+
+// @Summary Get public event page
+// @Description Get the public landing page of an event by its slug, without authentication. Only events of public communities that are not drafts or archived have one. The response carries the event's public fields, schema.org JSON-LD structured data, page metadata for link previews, and sign-up and login links that bring people back to the event. Responses can be cached for five minutes and revalidated with If-None-Match.
+// @ID get-public-event
+// @Produce json
+// @Param slug path string true "Event slug"
+// @Success 200 {object} map[string]interface{}
+// @Success 304
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/public/events/{slug} [get]
+func (h *EventHandler) GetPublicEvent(c *gin.Context) {
+	slug := c.Param("slug")
+
+	event, err := h.service.GetPublicEventBySlug(c.Request.Context(), slug)
+	if err != nil {
+		if errors.Is(err, domain.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get event"})
+		return
+	}
+
+	pageURL := h.frontendURL + publicEventPath(event.Slug)
+	meta := gin.H{
+		"title":         fmt.Sprintf("%s | %s", event.Name, event.CommunityName),
+		"description":   previewText(event.Description, 200),
+		"canonical_url": pageURL,
+		"og_type":       "website",
+	}
+	if event.CoverImageURL != "" {
+		meta["image"] = event.CoverImageURL
+	}
+	body, err := json.Marshal(gin.H{
+		"event":           event,
+		"structured_data": event.StructuredData(pageURL),
+		"meta":            meta,
+		"registration": gin.H{
+			"register_path":    fmt.Sprintf("/api/v1/events/%s/registrations", event.ID),
+			"signup_url":       fmt.Sprintf("%s/register?redirect_to=%s", h.frontendURL, url.QueryEscape(publicEventPath(event.Slug))),
+			"login_url":        fmt.Sprintf("%s/login?redirect_to=%s", h.frontendURL, url.QueryEscape(publicEventPath(event.Slug))),
+			"google_login_url": fmt.Sprintf("/api/v1/auth/google/login?callback_url=%s", url.QueryEscape(pageURL)),
+		},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get event"})
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:16]))
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(domain.PublicEventMaxAge.Seconds())))
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", event.UpdatedAt.UTC().Format(http.TimeFormat))
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// publicEventPath is the path of an event's landing page on the frontend.
+func publicEventPath(slug string) string {
+	return "/events/" + url.PathEscape(slug)
+}
+
+// previewText shortens text for link previews to at most limit characters, cutting at a word boundary.
+func previewText(text string, limit int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= limit {
+		return string(runes)
+	}
+	cut := strings.LastIndex(string(runes[:limit]), " ")
+	if cut <= 0 {
+		return string(runes[:limit]) + "…"
+	}
+	return string(runes[:limit])[:cut] + "…"
+}
+
+// trackFromRequest converts a track request into a track.
+func trackFromRequest(req *EventTrackRequest) *domain.EventTrack {
+	return &domain.EventTrack{
+		Name:        req.Name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+		Color:       sql.NullString{String: req.Color, Valid: req.Color != ""},
+		Position:    req.Position,
+	}
+}
+
+// agendaItemFromRequest converts an agenda item request into an agenda item.
+func agendaItemFromRequest(req *AgendaItemRequest) *domain.SessionAgendaItem {
+	return &domain.SessionAgendaItem{
+		Title:     req.Title,
+		Abstract:  sql.NullString{String: req.Abstract, Valid: req.Abstract != ""},
+		Room:      sql.NullString{String: req.Room, Valid: req.Room != ""},
+		TrackID:   sql.NullString{String: req.TrackID, Valid: req.TrackID != ""},
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+}
+
+// @Summary Create a track
+// @Description Add a track to an event's programme, such as a topic or a stage. Agenda items are grouped into tracks. Requires permission to edit the event.
+// @ID create-event-track
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param track_data body main.EventTrackRequest true "Track"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/tracks [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) CreateEventTrack(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req EventTrackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	track, err := h.service.CreateEventTrack(c.Request.Context(), eventID, userID.(string), trackFromRequest(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to edit this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidTrack):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create track"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"track": track})
+}
+
+// @Summary List tracks
+// @Description List the tracks of an event's programme by position.
+// @ID list-event-tracks
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/tracks [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListEventTracks(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	tracks, err := h.service.ListEventTracks(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tracks"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tracks": tracks})
+}
+
+// @Summary Update a track
+// @Description Replace the name, description, color and position of a track. Requires permission to edit the event.
+// @ID update-event-track
+// @Accept json
+// @Produce json
+// @Param id path string true "Track ID"
+// @Param track_data body main.EventTrackRequest true "Track"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/tracks/{id} [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) UpdateEventTrack(c *gin.Context) {
+	trackID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req EventTrackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	track := trackFromRequest(&req)
+	track.ID = trackID
+	track, err := h.service.UpdateEventTrack(c.Request.Context(), track, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to edit this event."})
+		case errors.Is(err, domain.ErrTrackNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Track not found"})
+		case errors.Is(err, domain.ErrInvalidTrack):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update track"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"track": track})
+}
+
+// @Summary Delete a track
+// @Description Delete a track. Its agenda items stay on the programme without a track. Requires permission to edit the event.
+// @ID delete-event-track
+// @Produce json
+// @Param id path string true "Track ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/tracks/{id} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) DeleteEventTrack(c *gin.Context) {
+	trackID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DeleteEventTrack(c.Request.Context(), trackID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to edit this event."})
+		case errors.Is(err, domain.ErrTrackNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Track not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete track"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Track deleted successfully"})
+}
+
+// @Summary Create an agenda item
+// @Description Add a talk, workshop or break to a session, with a title, abstract, speakers, room, track and a time slot within the session. Speakers must have the speaker role on the event. Requires permission to edit the event.
+// @ID create-agenda-item
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param item_data body main.AgendaItemRequest true "Agenda item"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/agenda-items [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) CreateAgendaItem(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req AgendaItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	item, err := h.service.CreateAgendaItem(c.Request.Context(), sessionID, userID.(string), agendaItemFromRequest(&req), req.SpeakerIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to edit this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		case errors.Is(err, domain.ErrTrackNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Track not found"})
+		case errors.Is(err, domain.ErrInvalidAgendaItem):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create agenda item"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"agenda_item": item})
+}
+
+// @Summary List the agenda items of a session
+// @Description List the agenda items of a session by start time, with their speakers, star counts and whether the authenticated user starred them.
+// @ID list-session-agenda-items
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/agenda-items [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListSessionAgendaItems(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	items, err := h.service.ListSessionAgendaItems(c.Request.Context(), sessionID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this event."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list agenda items"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"agenda_items": items})
+}
+
+// @Summary Update an agenda item
+// @Description Replace the details and speakers of an agenda item. It stays in its session. Requires permission to edit the event.
+// @ID update-agenda-item
+// @Accept json
+// @Produce json
+// @Param id path string true "Agenda item ID"
+// @Param item_data body main.AgendaItemRequest true "Agenda item"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/agenda-items/{id} [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) UpdateAgendaItem(c *gin.Context) {
+	itemID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req AgendaItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	item := agendaItemFromRequest(&req)
+	item.ID = itemID
+	item, err := h.service.UpdateAgendaItem(c.Request.Context(), item, req.SpeakerIDs, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to edit this session."})
+		case errors.Is(err, domain.ErrAgendaItemNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Agenda item not found"})
+		case errors.Is(err, domain.ErrTrackNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Track not found"})
+		case errors.Is(err, domain.ErrInvalidAgendaItem):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update agenda item"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"agenda_item": item})
+}
+
+// @Summary Delete an agenda item
+// @Description Remove an agenda item from its session, along with its stars. Requires permission to edit the event.
+// @ID delete-agenda-item
+// @Produce json
+// @Param id path string true "Agenda item ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/agenda-items/{id} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) DeleteAgendaItem(c *gin.Context) {
+	itemID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DeleteAgendaItem(c.Request.Context(), itemID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to edit this session."})
+		case errors.Is(err, domain.ErrAgendaItemNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Agenda item not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete agenda item"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Agenda item deleted successfully"})
+}
+
+// @Summary Star an agenda item
+// @Description Add an agenda item to the authenticated user's personal schedule. Starring an item twice has no effect.
+// @ID star-agenda-item
+// @Produce json
+// @Param id path string true "Agenda item ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/agenda-items/{id}/star [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) StarAgendaItem(c *gin.Context) {
+	itemID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	item, err := h.service.StarAgendaItem(c.Request.Context(), itemID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this event."})
+		case errors.Is(err, domain.ErrAgendaItemNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Agenda item not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to star agenda item"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"agenda_item": item})
+}
+
+// @Summary Unstar an agenda item
+// @Description Remove an agenda item from the authenticated user's personal schedule.
+// @ID unstar-agenda-item
+// @Produce json
+// @Param id path string true "Agenda item ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/agenda-items/{id}/star [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) UnstarAgendaItem(c *gin.Context) {
+	itemID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.UnstarAgendaItem(c.Request.Context(), itemID, userID.(string)); err != nil {
+		if errors.Is(err, domain.ErrAgendaItemNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Agenda item not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unstar agenda item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Agenda item unstarred successfully"})
+}
+
+// @Summary Get the schedule of an event
+// @Description Get the programme of an event: its tracks and its agenda items by day, ordered by start time, then track and room. Times are given in the requested IANA timezone, or the event's. Items of cancelled sessions are left out. With starred=true, only the items the authenticated user starred are returned, as their personal schedule.
+// @ID get-event-schedule
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param timezone query string false "IANA timezone, e.g. Europe/Paris"
+// @Param starred query bool false "Only the items the user starred"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/schedule [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetEventSchedule(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	schedule, err := h.service.GetEventSchedule(c.Request.Context(), eventID, userID.(string), c.Query("timezone"), c.Query("starred") == "true")
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidTimezone):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get schedule"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"schedule": schedule})
+}
+
+// @Summary Export the schedule of an event as iCalendar
+// @Description Download an .ics file with one entry per agenda item of the event, in the event's timezone. With starred=true, only the items the authenticated user starred are exported.
+// @ID export-event-schedule-ical
+// @Produce text/calendar
+// @Param id path string true "Event ID"
+// @Param starred query bool false "Only the items the user starred"
+// @Success 200 {file} string "iCalendar file"
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/schedule.ics [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ExportEventScheduleICal(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	data, err := h.service.ExportEventScheduleICal(c.Request.Context(), eventID, userID.(string), c.Query("starred") == "true")
+	if err != nil {
+		if errors.Is(err, permission_domain.ErrPermissionDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view this event."})
+			return
+		}
+		if errors.Is(err, domain.ErrEventNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export schedule", "details": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment;filename=schedule-%s.ics", eventID))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", data)
+}
+
+// @Summary Create a registration approval rule
+// @Description Add a rule approving the registrations of an event that requires approval automatically: members of a community (the event's by default), users with an email at one of the given domains, or users who attended at least the given number of the community's other events. The rule also approves the matching registrations already pending. Requires the approve_registrations permission.
+// @ID create-registration-approval-rule
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param rule_data body main.RegistrationApprovalRuleRequest true "Approval rule"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/approval-rules [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) CreateRegistrationApprovalRule(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req RegistrationApprovalRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	rule := &domain.RegistrationApprovalRule{
+		Kind:         req.Kind,
+		CommunityID:  sql.NullString{String: req.CommunityID, Valid: req.CommunityID != ""},
+		EmailDomains: req.EmailDomains,
+	}
+	if req.MinAttendedEvents != nil {
+		rule.MinAttendedEvents = sql.NullInt32{Int32: *req.MinAttendedEvents, Valid: true}
+	}
+	rule, approved, err := h.service.CreateRegistrationApprovalRule(c.Request.Context(), eventID, userID.(string), rule)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to approve registrations for this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		case errors.Is(err, domain.ErrInvalidApprovalRule):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create approval rule"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"rule": rule, "approved_registrations": approved})
+}
+
+// @Summary List registration approval rules
+// @Description List the rules approving the registrations of an event automatically. Requires the approve_registrations permission.
+// @ID list-registration-approval-rules
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/{id}/approval-rules [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListRegistrationApprovalRules(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	rules, err := h.service.ListRegistrationApprovalRules(c.Request.Context(), eventID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to approve registrations for this event."})
+		case errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Event not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list approval rules"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// @Summary Delete a registration approval rule
+// @Description Delete an approval rule. Registrations it approved stay approved. Requires the approve_registrations permission.
+// @ID delete-registration-approval-rule
+// @Produce json
+// @Param id path string true "Approval rule ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/approval-rules/{id} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) DeleteRegistrationApprovalRule(c *gin.Context) {
+	ruleID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DeleteRegistrationApprovalRule(c.Request.Context(), ruleID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to approve registrations for this event."})
+		case errors.Is(err, domain.ErrApprovalRuleNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Approval rule not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete approval rule"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Approval rule deleted successfully"})
+}
+
+// @Summary Open or close the Q&A of a session
+// @Description Turn the live Q&A of a session on or off. Questions asked stay visible once it is closed. Requires the moderate_qa permission (host, co-host or speaker) or community admin role.
+// @ID set-session-qa
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param qa_data body main.SessionQARequest true "Q&A state"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/qa [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) SetSessionQA(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SessionQARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.SetSessionQAEnabled(c.Request.Context(), sessionID, userID.(string), *req.Enabled); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to run the Q&A of this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update session Q&A"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"session_id": sessionID, "enabled": *req.Enabled})
+}
+
+// @Summary Get the Q&A of a session
+// @Description List the questions of a session, open questions first, then answered ones. Limited to the event's approved registrants and staff. Moderators also see hidden questions and the authors of anonymous ones.
+// @ID get-session-qa
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param sort query string false "top (most votes first, default) or recent"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/questions [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetSessionQA(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	sort := c.DefaultQuery("sort", domain.QuestionSortTop)
+	if sort != domain.QuestionSortTop && sort != domain.QuestionSortRecent {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be top or recent"})
+		return
+	}
+
+	qa, err := h.service.GetSessionQA(c.Request.Context(), sessionID, userID.(string), sort)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotEventParticipant):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get session Q&A"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"qa": qa})
+}
+
+// @Summary Ask a question
+// @Description Ask a question in the Q&A of a session, possibly anonymously. The Q&A must be open and the user an approved registrant or staff member of the event. The question is streamed to the session's live clients.
+// @ID ask-session-question
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param question_data body main.SessionQuestionRequest true "Question"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/questions [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) AskSessionQuestion(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SessionQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	question, err := h.service.AskSessionQuestion(c.Request.Context(), sessionID, userID.(string), &domain.SessionQuestion{
+		Content:     req.Content,
+		IsAnonymous: req.IsAnonymous,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidQuestion):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotEventParticipant):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionQAClosed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to ask question"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"question": question})
+}
+
+// @Summary Upvote a question
+// @Description Upvote an open question of a session's Q&A. Voting twice counts once. The new vote count is streamed to the session's live clients.
+// @ID vote-session-question
+// @Produce json
+// @Param id path string true "Question ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/questions/{id}/vote [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) VoteSessionQuestion(c *gin.Context) {
+	h.voteSessionQuestion(c, true)
+}
+
+// @Summary Withdraw an upvote
+// @Description Withdraw the authenticated user's upvote of an open question.
+// @ID unvote-session-question
+// @Produce json
+// @Param id path string true "Question ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/questions/{id}/vote [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) UnvoteSessionQuestion(c *gin.Context) {
+	h.voteSessionQuestion(c, false)
+}
+
+func (h *EventHandler) voteSessionQuestion(c *gin.Context, vote bool) {
+	questionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var question *domain.SessionQuestion
+	var err error
+	if vote {
+		question, err = h.service.VoteSessionQuestion(c.Request.Context(), questionID, userID.(string))
+	} else {
+		question, err = h.service.UnvoteSessionQuestion(c.Request.Context(), questionID, userID.(string))
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidQuestion):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotEventParticipant):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionQAClosed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrQuestionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record vote"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"question": question})
+}
+
+// @Summary Moderate a question
+// @Description Mark a question answered, hide it or reopen it. Requires the moderate_qa permission (host, co-host or speaker) or community admin role. The change is streamed to the session's live clients.
+// @ID moderate-session-question
+// @Accept json
+// @Produce json
+// @Param id path string true "Question ID"
+// @Param status_data body main.SessionQuestionStatusRequest true "New status"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/questions/{id}/status [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) ModerateSessionQuestion(c *gin.Context) {
+	questionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SessionQuestionStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	question, err := h.service.ModerateSessionQuestion(c.Request.Context(), questionID, userID.(string), req.Status)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidQuestion):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to moderate this Q&A."})
+		case errors.Is(err, domain.ErrQuestionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to moderate question"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"question": question})
+}
+
+// sessionPollFromRequest converts a poll request into a poll with its options.
+func sessionPollFromRequest(req *SessionPollRequest) *domain.SessionPoll {
+	poll := &domain.SessionPoll{
+		Question:        req.Question,
+		DurationSeconds: req.DurationSeconds,
+	}
+	if req.OpensAt != nil {
+		poll.OpensAt = sql.NullTime{Time: *req.OpensAt, Valid: true}
+	}
+	for _, label := range req.Options {
+		poll.Options = append(poll.Options, &domain.SessionPollOption{Label: label})
+	}
+	return poll
+}
+
+// @Summary List the polls of a session
+// @Description List the live polls of a session with their results, open polls first. Limited to the event's approved registrants and staff; drafts are only listed to staff with the run_polls permission (host, co-host or speaker) or community admins.
+// @ID list-session-polls
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/polls [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListSessionPolls(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	polls, err := h.service.ListSessionPolls(c.Request.Context(), sessionID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotEventParticipant):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list session polls"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"polls": polls})
+}
+
+// @Summary Prepare a poll
+// @Description Prepare a single-choice live poll for a session. The poll stays a draft until it is opened, by hand or automatically at opens_at; with a duration it closes automatically that many seconds after opening. Requires the run_polls permission (host, co-host or speaker) or community admin role.
+// @ID create-session-poll
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param poll_data body main.SessionPollRequest true "Poll"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/polls [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) CreateSessionPoll(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SessionPollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	poll, err := h.service.CreateSessionPoll(c.Request.Context(), sessionID, userID.(string), sessionPollFromRequest(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidPoll):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to run the polls of this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create poll"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"poll": poll})
+}
+
+// @Summary Update a poll
+// @Description Change the question, options and timing of a poll that was not opened yet. Requires the run_polls permission (host, co-host or speaker) or community admin role.
+// @ID update-session-poll
+// @Accept json
+// @Produce json
+// @Param id path string true "Poll ID"
+// @Param poll_data body main.SessionPollRequest true "Poll"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/polls/{id} [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) UpdateSessionPoll(c *gin.Context) {
+	pollID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SessionPollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	poll, err := h.service.UpdateSessionPoll(c.Request.Context(), pollID, userID.(string), sessionPollFromRequest(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidPoll):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to run the polls of this session."})
+		case errors.Is(err, domain.ErrPollNotDraft):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrPollNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Poll not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update poll"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"poll": poll})
+}
+
+// @Summary Delete a poll
+// @Description Delete a poll that was not opened yet. Polls that ran are kept for the session report. Requires the run_polls permission (host, co-host or speaker) or community admin role.
+// @ID delete-session-poll
+// @Produce json
+// @Param id path string true "Poll ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/polls/{id} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) DeleteSessionPoll(c *gin.Context) {
+	pollID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DeleteSessionPoll(c.Request.Context(), pollID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to run the polls of this session."})
+		case errors.Is(err, domain.ErrPollNotDraft):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrPollNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Poll not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete poll"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Poll deleted successfully"})
+}
+
+// @Summary Open a poll
+// @Description Open a draft poll now. Checked-in attendees can vote until it is closed, or until its duration runs out. The poll is streamed to the session's live clients and dashboards. Requires the run_polls permission (host, co-host or speaker) or community admin role.
+// @ID open-session-poll
+// @Produce json
+// @Param id path string true "Poll ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/polls/{id}/open [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) OpenSessionPoll(c *gin.Context) {
+	h.setSessionPollOpen(c, true)
+}
+
+// @Summary Close a poll
+// @Description Close an open poll now, making its results final. The final results are streamed to the session's live clients and dashboards. Requires the run_polls permission (host, co-host or speaker) or community admin role.
+// @ID close-session-poll
+// @Produce json
+// @Param id path string true "Poll ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/polls/{id}/close [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) CloseSessionPoll(c *gin.Context) {
+	h.setSessionPollOpen(c, false)
+}
+
+func (h *EventHandler) setSessionPollOpen(c *gin.Context, open bool) {
+	pollID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var poll *domain.SessionPoll
+	var err error
+	if open {
+		poll, err = h.service.OpenSessionPoll(c.Request.Context(), pollID, userID.(string))
+	} else {
+		poll, err = h.service.CloseSessionPoll(c.Request.Context(), pollID, userID.(string))
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidPoll):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to run the polls of this session."})
+		case errors.Is(err, domain.ErrPollNotDraft), errors.Is(err, domain.ErrPollNotOpen):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrPollNotFound), errors.Is(err, domain.ErrEventNotFound), errors.Is(err, domain.ErrSessionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Poll not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update poll"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"poll": poll})
+}
+
+// @Summary Vote in a poll
+// @Description Vote for an option of an open poll, replacing any earlier vote. Only attendees checked in to the session can vote. The new results are streamed to the session's live clients and dashboards.
+// @ID vote-session-poll
+// @Accept json
+// @Produce json
+// @Param id path string true "Poll ID"
+// @Param vote_data body main.SessionPollVoteRequest true "Chosen option"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/polls/{id}/vote [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) VoteSessionPoll(c *gin.Context) {
+	pollID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SessionPollVoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	poll, err := h.service.VoteSessionPoll(c.Request.Context(), pollID, req.OptionID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidPollVote):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotCheckedIn):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrPollNotOpen):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrPollNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Poll not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record vote"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"poll": poll})
+}
+
+// seatingLayoutFromRequest converts a seating layout request into a layout with its sections and units.
+func seatingLayoutFromRequest(req *SeatingLayoutRequest) *domain.SeatingLayout {
+	layout := &domain.SeatingLayout{Name: req.Name}
+	for _, sectionReq := range req.Sections {
+		section := &domain.SeatingSection{Name: sectionReq.Name}
+		for _, unitReq := range sectionReq.Units {
+			section.Units = append(section.Units, &domain.SeatingUnit{
+				Kind:     unitReq.Kind,
+				Label:    unitReq.Label,
+				Capacity: unitReq.Capacity,
+			})
+		}
+		layout.Sections = append(layout.Sections, section)
+	}
+	return layout
+}
+
+// @Summary Set the seating layout of a session
+// @Description Set the assigned seating of a session: named sections holding rows or tables, each with a capacity. Replaces the current layout as long as none of its seats is assigned. Requires the edit_event permission (host or co-host) or community admin role.
+// @ID save-seating-layout
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param layout_data body main.SeatingLayoutRequest true "Seating layout"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/seating [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) SaveSeatingLayout(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SeatingLayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	layout, err := h.service.SaveSeatingLayout(c.Request.Context(), sessionID, userID.(string), seatingLayoutFromRequest(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidSeatingLayout):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the seating of this session."})
+		case errors.Is(err, domain.ErrSeatingLayoutInUse):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save seating layout"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"layout": layout})
+}
+
+// @Summary Get the seating layout of a session
+// @Description Get the seating layout of a session with its capacity and the number of assigned seats. Limited to the event's approved registrants and staff.
+// @ID get-seating-layout
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/seating [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) GetSeatingLayout(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	layout, err := h.service.GetSeatingLayout(c.Request.Context(), sessionID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotEventParticipant):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSeatingLayoutNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get seating layout"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"layout": layout})
+}
+
+// @Summary Delete the seating layout of a session
+// @Description Remove the seating layout of a session, as long as none of its seats is assigned. Requires the edit_event permission (host or co-host) or community admin role.
+// @ID delete-seating-layout
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/seating [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) DeleteSeatingLayout(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.DeleteSeatingLayout(c.Request.Context(), sessionID, userID.(string)); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the seating of this session."})
+		case errors.Is(err, domain.ErrSeatingLayoutInUse):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSeatingLayoutNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete seating layout"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Seating layout deleted"})
+}
+
+// @Summary List the seat assignments of a session
+// @Description List the assigned seats of a session in layout order. Requires the view_attendees permission or community admin role.
+// @ID list-seat-assignments
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/seats [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListSeatAssignments(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	seats, err := h.service.ListSeatAssignments(c.Request.Context(), sessionID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the attendees of this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list seat assignments"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"seats": seats})
+}
+
+// @Summary Assign seats automatically
+// @Description Give a seat to every registrant of the session who has none, in registration order and in layout order. Seats of users who are no longer registered are freed first; registrants left over when the layout is full are returned as unseated. Requires the edit_event permission (host or co-host) or community admin role.
+// @ID auto-assign-seats
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/seats/auto [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) AutoAssignSeats(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	result, err := h.service.AutoAssignSeats(c.Request.Context(), sessionID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the seating of this session."})
+		case errors.Is(err, domain.ErrSeatTaken):
+			c.JSON(http.StatusConflict, gin.H{"error": "Seats changed while assigning; please try again."})
+		case errors.Is(err, domain.ErrSeatingLayoutNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign seats"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Seat a group together
+// @Description Seat a group of registrants together: at the same table, or on consecutive seats of a row. The first row or table of the layout, or of the given section, with enough free seats is used; members who already had a seat are moved. Requires the edit_event permission (host or co-host) or community admin role.
+// @ID assign-seat-group
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param group_data body main.SeatGroupRequest true "Group"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/seats/group [post]
+// @Security ApiKeyAuth
+func (h *EventHandler) AssignSeatGroup(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SeatGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.service.AssignSeatGroup(c.Request.Context(), sessionID, userID.(string), req.UserIDs, req.SectionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidSeatGroup), errors.Is(err, domain.ErrNotSeatable):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the seating of this session."})
+		case errors.Is(err, domain.ErrNotEnoughSeats), errors.Is(err, domain.ErrSeatTaken):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSeatingLayoutNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to seat group"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Assign a seat
+// @Description Give a registrant a specific seat of the session's layout, moving them if they already had one. Requires the edit_event permission (host or co-host) or community admin role.
+// @ID assign-seat
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param userID path string true "User ID of the registrant"
+// @Param seat_data body main.SeatAssignRequest true "Seat"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/seats/{userID} [put]
+// @Security ApiKeyAuth
+func (h *EventHandler) AssignSeat(c *gin.Context) {
+	sessionID := c.Param("id")
+	attendeeID := c.Param("userID")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req SeatAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	seat, err := h.service.AssignSeat(c.Request.Context(), sessionID, userID.(string), attendeeID, req.UnitID, req.SeatNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSeatNotFound), errors.Is(err, domain.ErrNotSeatable):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the seating of this session."})
+		case errors.Is(err, domain.ErrSeatTaken):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSeatingLayoutNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign seat"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"seat": seat})
+}
+
+// @Summary Unassign a seat
+// @Description Free the seat of a user for a session. Requires the edit_event permission (host or co-host) or community admin role.
+// @ID unassign-seat
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param userID path string true "User ID of the seat holder"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/seats/{userID} [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) UnassignSeat(c *gin.Context) {
+	sessionID := c.Param("id")
+	attendeeID := c.Param("userID")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.UnassignSeat(c.Request.Context(), sessionID, userID.(string), attendeeID); err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the seating of this session."})
+		case errors.Is(err, domain.ErrSeatAssignmentNotFound), errors.Is(err, domain.ErrSeatingLayoutNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to unassign seat"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Seat unassigned"})
+}
+
+// @Summary Clear the seat assignments of a session
+// @Description Free every seat of a session, for instance before changing its layout. Requires the edit_event permission (host or co-host) or community admin role.
+// @ID clear-seat-assignments
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/seats [delete]
+// @Security ApiKeyAuth
+func (h *EventHandler) ClearSeatAssignments(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	released, err := h.service.ClearSeatAssignments(c.Request.Context(), sessionID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to manage the seating of this session."})
+		case errors.Is(err, domain.ErrSeatingLayoutNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear seat assignments"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"released": released})
+}
+
+// @Summary List the seat changes of a session
+// @Description List who was seated, moved or unseated, when, how and by whom, newest first. Requires the view_attendees permission or community admin role.
+// @ID list-seat-assignment-audit
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Number of items per page"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/seats/audit [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListSeatAssignmentAudit(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+	offset := (page - 1) * limit
+
+	changes, total, err := h.service.ListSeatAssignmentAudit(c.Request.Context(), sessionID, userID.(string), limit, offset)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the attendees of this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list seat changes"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"changes": changes,
+		"pagination": gin.H{
+			"total":    total,
+			"page":     page,
+			"limit":    limit,
+			"has_more": (page*limit < total),
+		},
+	})
+}
+
+// @Summary List the badges of a session
+// @Description List what to print on the badges of the session's registrants: name, email, role and assigned seat, if any. Requires the view_attendees permission or community admin role.
+// @ID list-session-badges
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/events/sessions/{id}/badges [get]
+// @Security ApiKeyAuth
+func (h *EventHandler) ListSessionBadges(c *gin.Context) {
+	sessionID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	badges, err := h.service.ListSessionBadges(c.Request.Context(), sessionID, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the attendees of this session."})
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list badges"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"badges": badges})
+}
@@ -169,13 +169,13 @@ func run() error {
 	permissionService := permission_usecase.NewService(permissionRepo)
 
 	// User Module
-	userService := user_usecase.NewUserService(userRepo, userGraphRepo, neo4jRepo, aiClient, cfg.JWTSecret)
+	userService := user_usecase.NewUserService(userRepo, userGraphRepo, neo4jRepo, aiClient, eventRepo, cfg.JWTSecret)
 	userHandler := NewUserHandler(userService, googleOAuthClient, cfg.GoogleRedirectURI, redisClient, cfg)
 
 	// Event Module
 	natsPublisher := pubsub.NewNatsPublisher(nc)
 	eventService := event_usecase.NewService(eventRepo, neo4jRepo, permissionService, natsPublisher)
-	eventHandler := NewEventHandler(eventService)
+	eventHandler := NewEventHandler(eventService, cfg.FrontendURL)
 
 	// Community Module
 	var communityService domain.CommunityService = community_usecase.NewService(communityRepo, neo4jRepo, activityRepo, permissionService, eventService, nc)
@@ -225,7 +225,7 @@ func run() error {
 	notificationHandler := NewNotificationHandler(notificationService)
 
 	// Checkin Module
-	checkinService := checkin_usecase.NewService(checkinRepo, eventRepo, userRepo, cfg.JWTSecret, aiClient, nc)
+	checkinService := checkin_usecase.NewService(checkinRepo, eventRepo, userRepo, permissionService, cfg.JWTSecret, aiClient, nc)
 	checkinHandler := NewCheckinHandler(checkinService, cfg.JWTSecret)
 
 	// Report Module
@@ -246,6 +246,18 @@ func run() error {
 	recurringEventWorker := worker.NewRecurringEventWorker(eventRepo)
 	go recurringEventWorker.Start()
 
+	eventLifecycleWorker := worker.NewEventLifecycleWorker(eventService)
+	go eventLifecycleWorker.Start()
+
+	invitationReminderWorker := worker.NewInvitationReminderWorker(eventService)
+	go invitationReminderWorker.Start()
+
+	announcementWorker := worker.NewAnnouncementWorker(eventService)
+	go announcementWorker.Start()
+
+	sessionPollWorker := worker.NewSessionPollWorker(eventService)
+	go sessionPollWorker.Start()
+
 	spamWorker := worker.NewSpamDetectionWorker(userRepo, userGraphRepo)
 	go spamWorker.Start()
 
@@ -261,6 +273,9 @@ func run() error {
 	r.GET("/ws/dashboard/:sessionID", func(c *gin.Context) {
 		serveDashboardWs(hub, c, cfg.JWTSecret)
 	})
+	r.GET("/ws/sessions/:sessionID/live", func(c *gin.Context) {
+		serveSessionWs(hub, c, cfg.JWTSecret, eventService)
+	})
 
 	registerRoutes(r, userHandler, communityHandler, feedHandler, eventHandler, mediaHandler, messagingHandler, notificationHandler, checkinHandler, reportHandler, searchHandler, permissionService, cfg.JWTSecret, dbPool, cfg)
 
@@ -290,7 +305,8 @@ func run() error {
 
 	// The context is used to inform the server it has 5 seconds to finish
 	// the requests it is currently handling
-	ctx, _ = context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server forced to shutdown:", err)
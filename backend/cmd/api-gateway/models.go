@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"time"
 
+	event_domain "github.com/attendwise/backend/internal/module/event/domain"
 	"github.com/attendwise/backend/internal/module/user/domain"
 )
 
@@ -18,12 +19,14 @@ type RegisterRequest struct {
 	ProfilePictureURL string `json:"profile_picture_url"`
 	Bio               string `json:"bio"`
 	Location          string `json:"location"`
+	RedirectTo        string `json:"redirect_to"` // Frontend path to return to after signing up, echoed back when valid
 }
 
 // LoginRequest represents the request body for user login
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email      string `json:"email" binding:"required,email"`
+	Password   string `json:"password" binding:"required"`
+	RedirectTo string `json:"redirect_to"` // Frontend path to return to after logging in, echoed back when valid
 }
 
 // UpdateProfileRequest represents the request body for updating user profile
@@ -306,6 +309,8 @@ type EventSessionResponse struct {
 	CancellationReason               *string    `json:"cancellation_reason,omitempty"`
 	TotalCheckins                    int        `json:"total_checkins"`
 	TotalNoShows                     int        `json:"total_no_shows"`
+	TotalRegistrations               int        `json:"total_registrations"`
+	TotalWaitlisted                  int        `json:"total_waitlisted"`
 	CreatedAt                        time.Time  `json:"created_at"`
 	UpdatedAt                        time.Time  `json:"updated_at"`
 }
@@ -334,6 +339,7 @@ type EventResponse struct {
 	CurrentAttendees         int            `json:"current_attendees"`
 	WaitlistEnabled          bool           `json:"waitlist_enabled"`
 	MaxWaitlist              *int32         `json:"max_waitlist,omitempty"`
+	PerSessionRegistration   bool           `json:"per_session_registration"`
 	RegistrationRequired     bool           `json:"registration_required"`
 	RegistrationOpensAt      *time.Time     `json:"registration_opens_at,omitempty"`
 	RegistrationClosesAt     *time.Time     `json:"registration_closes_at,omitempty"`
@@ -663,6 +669,15 @@ type CreatePostRequest struct {
 	PostType        string       `json:"post_type"`
 }
 
+// EventDiscussionPostRequest represents the request body for posting to an event's discussion
+type EventDiscussionPostRequest struct {
+	Title           string       `json:"title"`
+	Content         string       `json:"content"`
+	MediaURLs       []string     `json:"media_urls"`
+	FileAttachments []Attachment `json:"file_attachments"`
+	Hashtags        []string     `json:"hashtags"`
+}
+
 // CreateCommentRequest represents the request body for creating a comment
 type CreateCommentRequest struct {
 	Content         string `json:"content"`
@@ -738,6 +753,7 @@ type Event struct {
 	MaxAttendees             NullInt32   `json:"max_attendees"`
 	WaitlistEnabled          bool        `json:"waitlist_enabled"`
 	MaxWaitlist              NullInt32   `json:"max_waitlist"`
+	PerSessionRegistration   bool        `json:"per_session_registration"`
 	RegistrationRequired     bool        `json:"registration_required"`
 	WhitelistOnly            bool        `json:"whitelist_only"`
 	RequireApproval          bool        `json:"require_approval"`
@@ -763,11 +779,25 @@ type RawMessage []byte
 // RegisterForEventRequest represents the request body for registering for an event
 type RegisterForEventRequest struct {
 	RegistrationFormData json.RawMessage `json:"registration_form_data"`
+	Strict               bool            `json:"strict"` // Refuse the registration if it overlaps another of the user's registrations
 }
 
 // AddUsersToWhitelistRequest represents the request body for adding users to a whitelist
 type AddUsersToWhitelistRequest struct {
-	UserIDs []string `json:"user_ids"`
+	UserIDs []string                `json:"user_ids"`
+	Entries []WhitelistContactInput `json:"entries"`
+}
+
+// WhitelistContactInput represents a person to whitelist by email and/or phone
+type WhitelistContactInput struct {
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+	Notes string `json:"notes"`
+}
+
+// ConfirmWhitelistLinkRequest represents the request body for linking a whitelist entry to an account
+type ConfirmWhitelistLinkRequest struct {
+	UserID string `json:"user_id" binding:"required"`
 }
 
 // UpdateEventRequest represents the request body for updating an event
@@ -786,6 +816,7 @@ type UpdateEventRequest struct {
 	MaxAttendees             NullInt32   `json:"max_attendees"`
 	WaitlistEnabled          bool        `json:"waitlist_enabled"`
 	MaxWaitlist              NullInt32   `json:"max_waitlist"`
+	PerSessionRegistration   bool        `json:"per_session_registration"`
 	RegistrationRequired     bool        `json:"registration_required"`
 	WhitelistOnly            bool        `json:"whitelist_only"`
 	RequireApproval          bool        `json:"require_approval"`
@@ -806,6 +837,271 @@ type CancelEventSessionRequest struct {
 	Reason string `json:"reason"`
 }
 
+// UpdateEventOccurrenceRequest represents the request body for editing one occurrence of an event.
+// Scope is one of "this", "following" or "all"; omitted fields are left unchanged.
+type UpdateEventOccurrenceRequest struct {
+	Scope                    string     `json:"scope"`
+	StartTime                *time.Time `json:"start_time"`
+	EndTime                  *time.Time `json:"end_time"`
+	Name                     *string    `json:"name"`
+	LocationOverride         *string    `json:"location_override"`
+	OnlineMeetingURLOverride *string    `json:"online_meeting_url_override"`
+	MaxAttendeesOverride     *int32     `json:"max_attendees_override"`
+}
+
+// RescheduleEventSessionRequest represents the request body for moving a session to another time or place.
+// Omitted fields are left unchanged
+type RescheduleEventSessionRequest struct {
+	StartTime                *time.Time `json:"start_time"`
+	EndTime                  *time.Time `json:"end_time"`
+	LocationOverride         *string    `json:"location_override"`
+	OnlineMeetingURLOverride *string    `json:"online_meeting_url_override"`
+	Reason                   string     `json:"reason"`
+	RequireReconfirmation    bool       `json:"require_reconfirmation"`
+}
+
+// AnswerReconfirmationRequest represents the request body for answering whether one still attends a
+// rescheduled session
+type AnswerReconfirmationRequest struct {
+	Status string `json:"status" binding:"required" enums:"confirmed,declined"`
+}
+
+// PreviewEventOccurrencesRequest represents the request body for a dry run of a recurrence rule
+type PreviewEventOccurrencesRequest struct {
+	StartTime             time.Time       `json:"start_time" binding:"required"`
+	EndTime               time.Time       `json:"end_time" binding:"required"`
+	Timezone              string          `json:"timezone"`
+	RecurrenceRule        json.RawMessage `json:"recurrence_rule" binding:"required"`
+	RecurrenceEndDate     *time.Time      `json:"recurrence_end_date"`
+	MaxOccurrences        *int32          `json:"max_occurrences"`
+	GenerationHorizonDays *int32          `json:"generation_horizon_days"`
+	Count                 int             `json:"count"`
+}
+
+// AddEventOccurrenceRequest represents the request body for adding an extra occurrence to a recurring event
+type AddEventOccurrenceRequest struct {
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+}
+
+// AssignEventStaffRequest represents the request body for giving a user a role within an event
+type AssignEventStaffRequest struct {
+	Role string `json:"role" binding:"required" enums:"co_host,checkin_staff,speaker,volunteer"`
+}
+
+// ChangeEventStatusRequest represents the request body for moving an event to another lifecycle status
+type ChangeEventStatusRequest struct {
+	Status string `json:"status" binding:"required" enums:"draft,published,registration_open,registration_closed,ongoing,completed,cancelled,archived"`
+	Reason string `json:"reason"`
+}
+
+// CreateFeedbackSurveyRequest represents the request body for attaching a feedback survey to an event or
+// one of its sessions
+type CreateFeedbackSurveyRequest struct {
+	SessionID        string                          `json:"session_id"`
+	Title            string                          `json:"title" binding:"required"`
+	Description      string                          `json:"description"`
+	Questions        []event_domain.FeedbackQuestion `json:"questions" binding:"required"`
+	PerSession       bool                            `json:"per_session"`
+	SendDelayMinutes int                             `json:"send_delay_minutes"`
+	IsActive         *bool                           `json:"is_active"`
+}
+
+// UpdateFeedbackSurveyRequest represents the request body for updating a feedback survey; omitted fields
+// are left unchanged
+type UpdateFeedbackSurveyRequest struct {
+	Title            *string                         `json:"title"`
+	Description      *string                         `json:"description"`
+	Questions        []event_domain.FeedbackQuestion `json:"questions"`
+	PerSession       *bool                           `json:"per_session"`
+	SendDelayMinutes *int                            `json:"send_delay_minutes"`
+	IsActive         *bool                           `json:"is_active"`
+}
+
+// SubmitFeedbackRequest represents the request body for answering a feedback survey. SessionID is only
+// needed for event surveys answered per session.
+type SubmitFeedbackRequest struct {
+	SessionID string                        `json:"session_id"`
+	Answers   []event_domain.FeedbackAnswer `json:"answers" binding:"required"`
+}
+
+// SaveCertificateTemplateRequest represents the request body for setting up the certificates of an event
+type SaveCertificateTemplateRequest struct {
+	Title                string `json:"title"`
+	Body                 string `json:"body"`
+	SignerName           string `json:"signer_name"`
+	SignerTitle          string `json:"signer_title"`
+	MinAttendancePercent int    `json:"min_attendance_percent"`
+	IsActive             *bool  `json:"is_active"`
+}
+
+// RevokeCertificateRequest represents the request body for revoking a certificate
+type RevokeCertificateRequest struct {
+	Reason string `json:"reason"`
+}
+
+// InviteToEventRequest represents the request body for inviting people to an event
+type InviteToEventRequest struct {
+	UserIDs []string `json:"user_ids"`
+	Emails  []string `json:"emails"`
+	Message string   `json:"message"`
+}
+
+// RespondToInvitationRequest represents the request body for answering an event invitation
+type RespondToInvitationRequest struct {
+	RSVP string `json:"rsvp" binding:"required"`
+}
+
+// RequestRegistrationTransferRequest represents the request body for transferring one's registration to
+// another user or to an email address
+type RequestRegistrationTransferRequest struct {
+	ToUserID string `json:"to_user_id"`
+	Email    string `json:"email"`
+	Message  string `json:"message"`
+}
+
+// CreateAnnouncementRequest represents the request body for broadcasting an announcement to an event's
+// attendees. Without scheduled_at it is sent right away
+type CreateAnnouncementRequest struct {
+	SessionID   string     `json:"session_id"`
+	Title       string     `json:"title" binding:"required"`
+	Message     string     `json:"message" binding:"required"`
+	Audience    string     `json:"audience"`
+	ScheduledAt *time.Time `json:"scheduled_at"`
+}
+
+// EventTemplateRequest represents the request body for saving an event as a template or editing a template
+type EventTemplateRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	IsShared    bool   `json:"is_shared"`
+}
+
+// CreateEventFromTemplateRequest represents the request body for creating an event from a template or
+// duplicating an event. Without a name the template's, or the event's, is used
+type CreateEventFromTemplateRequest struct {
+	Name      string    `json:"name"`
+	StartTime time.Time `json:"start_time" binding:"required"`
+}
+
+// VenueRequest represents the request body for creating or updating a venue. Latitude and longitude go together
+type VenueRequest struct {
+	Name               string   `json:"name" binding:"required"`
+	Address            string   `json:"address" binding:"required"`
+	Latitude           *float64 `json:"latitude"`
+	Longitude          *float64 `json:"longitude"`
+	Capacity           *int32   `json:"capacity"`
+	AccessibilityNotes string   `json:"accessibility_notes"`
+}
+
+// SetSessionVenueRequest represents the request body for holding a session at a venue. An empty venue_id holds
+// it at the event's venue again
+type SetSessionVenueRequest struct {
+	VenueID string `json:"venue_id"`
+}
+
+// RejectRegistrationRequest represents the optional request body for rejecting a registration
+type RejectRegistrationRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RegistrationDecisionRequest represents the request body for approving or rejecting pending registrations
+// in bulk: the given ones, or all of them with all
+type RegistrationDecisionRequest struct {
+	Decision        string   `json:"decision" binding:"required"` // approve or reject
+	RegistrationIDs []string `json:"registration_ids"`
+	All             bool     `json:"all"`
+	Reason          string   `json:"reason"`
+}
+
+// RegistrationApprovalRuleRequest represents the request body for creating a registration approval rule
+type RegistrationApprovalRuleRequest struct {
+	Kind              string   `json:"kind" binding:"required"` // community_member, email_domain or past_attendance
+	CommunityID       string   `json:"community_id"`
+	EmailDomains      []string `json:"email_domains"`
+	MinAttendedEvents *int32   `json:"min_attended_events"`
+}
+
+// EventTrackRequest represents the request body for creating or updating a track of an event's programme
+type EventTrackRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Color       string `json:"color"` // #rrggbb
+	Position    int    `json:"position"`
+}
+
+// AgendaItemRequest represents the request body for creating or updating an agenda item of a session. Speakers
+// must have the speaker role on the event
+type AgendaItemRequest struct {
+	Title      string    `json:"title" binding:"required"`
+	Abstract   string    `json:"abstract"`
+	Room       string    `json:"room"`
+	TrackID    string    `json:"track_id"`
+	StartTime  time.Time `json:"start_time" binding:"required"`
+	EndTime    time.Time `json:"end_time" binding:"required"`
+	SpeakerIDs []string  `json:"speaker_ids"`
+}
+
+// SessionQARequest represents the request body for opening or closing the Q&A of a session
+type SessionQARequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// SessionQuestionRequest represents the request body for asking a question in the Q&A of a session
+type SessionQuestionRequest struct {
+	Content     string `json:"content" binding:"required"`
+	IsAnonymous bool   `json:"is_anonymous"`
+}
+
+// SessionQuestionStatusRequest represents the request body for moderating a session question
+type SessionQuestionStatusRequest struct {
+	Status string `json:"status" binding:"required"` // open, answered or hidden
+}
+
+// SessionPollRequest represents the request body for preparing or changing a live poll of a session
+type SessionPollRequest struct {
+	Question        string     `json:"question" binding:"required"`
+	Options         []string   `json:"options" binding:"required"`
+	DurationSeconds int        `json:"duration_seconds"`    // 0 keeps the poll open until it is closed
+	OpensAt         *time.Time `json:"opens_at,omitempty"` // Opens the poll automatically
+}
+
+// SessionPollVoteRequest represents the request body for voting in a live poll
+type SessionPollVoteRequest struct {
+	OptionID string `json:"option_id" binding:"required"`
+}
+
+// SeatingLayoutRequest represents the request body for setting the seating layout of a session
+type SeatingLayoutRequest struct {
+	Name     string                  `json:"name" binding:"required"`
+	Sections []SeatingSectionRequest `json:"sections" binding:"required"`
+}
+
+// SeatingSectionRequest represents a section of a seating layout, such as a floor or a wing
+type SeatingSectionRequest struct {
+	Name  string               `json:"name" binding:"required"`
+	Units []SeatingUnitRequest `json:"units" binding:"required"`
+}
+
+// SeatingUnitRequest represents a row or a table of a seating section
+type SeatingUnitRequest struct {
+	Kind     string `json:"kind" binding:"required"` // row or table
+	Label    string `json:"label" binding:"required"`
+	Capacity int    `json:"capacity" binding:"required"`
+}
+
+// SeatGroupRequest represents the request body for seating a group of registrants together
+type SeatGroupRequest struct {
+	UserIDs   []string `json:"user_ids" binding:"required"`
+	SectionID string   `json:"section_id,omitempty"` // Limits the group to a section
+}
+
+// SeatAssignRequest represents the request body for giving a registrant a specific seat
+type SeatAssignRequest struct {
+	UnitID     string `json:"unit_id" binding:"required"`
+	SeatNumber int    `json:"seat_number" binding:"required"`
+}
+
 // CreateConversationRequest represents the request body for creating a conversation
 type CreateConversationRequest struct {
 	Type           string   `json:"type"`
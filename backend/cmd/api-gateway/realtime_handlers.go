@@ -3,11 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strings"
 	"time"
 
+	event_domain "github.com/attendwise/backend/internal/module/event/domain"
+	event_usecase "github.com/attendwise/backend/internal/module/event/usecase"
 	messaging_domain "github.com/attendwise/backend/internal/module/messaging/domain"
 	messaging_usecase "github.com/attendwise/backend/internal/module/messaging/usecase"
 	realtime_usecase "github.com/attendwise/backend/internal/module/realtime/usecase"
@@ -148,6 +151,87 @@ func serveDashboardWs(hub *realtime_usecase.Hub, c *gin.Context, jwtSecret strin
 	go dashboardReadPump(conn, client, hub)
 }
 
+// serveSessionWs handles websocket requests for the live activity of a SESSION.
+// @Summary Establish WebSocket connection for a session's live activity
+// @Description Streams the live activity of a session, such as new questions, votes and moderation of its Q&A. Limited to the event's approved registrants and staff. Authentication is done via a JWT token in the Authorization header or 'token' query parameter.
+// @ID websocket-session-live
+// @Produce json
+// @Param sessionID path string true "Session ID"
+// @Param token query string false "JWT Token for authentication (alternative to Authorization header)"
+// @Success 101 "Switching Protocols"
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Router /ws/sessions/{sessionID}/live [get]
+// @Security ApiKeyAuth
+func serveSessionWs(hub *realtime_usecase.Hub, c *gin.Context, jwtSecret string, eventService event_usecase.EventService) {
+	sessionID := c.Param("sessionID")
+	userID, ok := wsUserID(c, jwtSecret)
+	if !ok {
+		return
+	}
+
+	if err := eventService.AuthorizeSessionLive(c.Request.Context(), sessionID, userID); err != nil {
+		switch {
+		case errors.Is(err, event_domain.ErrNotEventParticipant):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, event_domain.ErrSessionNotFound), errors.Is(err, event_domain.ErrEventNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to authorize session connection"})
+		}
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("[ERROR] serveSessionWs - Failed to upgrade WebSocket for session %s: %v", sessionID, err)
+		return
+	}
+
+	client := &realtime_usecase.SessionClient{
+		SessionID: sessionID,
+		UserID:    userID,
+		Conn:      make(chan []byte, 256),
+	}
+	hub.RegisterSessionClient(client)
+
+	go sessionWritePump(conn, client, hub)
+	go sessionReadPump(conn, client, hub)
+}
+
+// wsUserID authenticates a websocket request with the JWT token of its Authorization header or 'token' query
+// parameter. It responds with 401 and returns false when the token is missing or invalid.
+func wsUserID(c *gin.Context, jwtSecret string) (string, bool) {
+	tokenString := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if tokenString == "" {
+		tokenString = c.Query("token")
+	}
+	if tokenString == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Missing auth token"})
+		return "", false
+	}
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(jwtSecret), nil
+	})
+	if err != nil || !token.Valid {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		return "", false
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+		return "", false
+	}
+	userID, ok := claims["sub"].(string)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID in token"})
+		return "", false
+	}
+	return userID, true
+}
+
 // Pumps for Chat
 func chatWritePump(conn *websocket.Conn, client *realtime_usecase.Client, hub *realtime_usecase.Hub) {
 	defer func() {
@@ -234,3 +318,38 @@ func dashboardReadPump(conn *websocket.Conn, client *realtime_usecase.DashboardC
 		// Further processing would depend on the specific dashboard functionality
 	}
 }
+
+// Pumps for Session Live Activity
+func sessionWritePump(conn *websocket.Conn, client *realtime_usecase.SessionClient, hub *realtime_usecase.Hub) {
+	defer func() {
+		hub.UnregisterSessionClient(client)
+		conn.Close()
+	}()
+	for message := range client.Conn {
+		if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+			return
+		}
+	}
+}
+
+func sessionReadPump(conn *websocket.Conn, client *realtime_usecase.SessionClient, hub *realtime_usecase.Hub) {
+	defer func() {
+		hub.UnregisterSessionClient(client)
+		conn.Close()
+	}()
+
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error { conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
+
+	// Questions and votes go through the REST API; the socket only streams updates, so incoming messages
+	// are read to keep the connection alive and otherwise ignored.
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.Printf("error: %v", err)
+			}
+			break
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"errors"
 	"net/http"
 
+	event_domain "github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
 	domain "github.com/attendwise/backend/internal/module/report/domain"
 	"github.com/gin-gonic/gin"
 )
@@ -109,6 +112,72 @@ func (h *ReportHandler) ExportEventAttendanceReportPDF(c *gin.Context) {
 	c.Data(http.StatusOK, "application/pdf", pdfData)
 }
 
+// @Summary Get event feedback report
+// @Description Aggregate the responses to the feedback surveys of an event, over the whole event and per session: average scores and score distributions, Net Promoter Scores and free-text answers. Requires permission to view the event's attendees.
+// @ID get-event-feedback-report
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/reports/events/{id}/feedback [get]
+// @Security ApiKeyAuth
+func (h *ReportHandler) GetEventFeedbackReport(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	report, err := h.service.GetEventFeedbackReport(c.Request.Context(), userID.(string), eventID)
+	if err != nil {
+		if errors.Is(err, permission_domain.ErrPermissionDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the feedback of this event."})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve event feedback report"})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// @Summary Export event feedback as CSV
+// @Description Export every answer to the feedback surveys of an event as a CSV file, one row per answered question. Requires permission to view the event's attendees.
+// @ID export-event-feedback-csv
+// @Produce text/csv
+// @Param id path string true "Event ID"
+// @Success 200 {file} string "CSV file"
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/reports/events/{id}/feedback.csv [get]
+// @Security ApiKeyAuth
+func (h *ReportHandler) ExportEventFeedbackCSV(c *gin.Context) {
+	eventID := c.Param("id")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	csvData, err := h.service.ExportEventFeedbackCSV(c.Request.Context(), userID.(string), eventID)
+	if err != nil {
+		if errors.Is(err, permission_domain.ErrPermissionDenied) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the feedback of this event."})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate feedback CSV"})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", "attachment;filename=event_feedback.csv")
+	c.Data(http.StatusOK, "text/csv", csvData)
+}
+
 // @Summary Get monthly summary
 // @Description Get a monthly summary report
 // @ID get-monthly-summary
@@ -178,3 +247,39 @@ func (h *ReportHandler) GetCommunityEngagementReport(c *gin.Context) {
 	c.JSON(http.StatusOK, report)
 
 }
+
+// @Summary Get session report
+// @Description Summarize a session: registrants and check-ins, questions asked and answered in its Q&A, and the results of the live polls run during it, with each option's share of the votes. Requires permission to view the event's attendees.
+// @ID get-session-report
+// @Produce json
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/reports/sessions/{sessionId}/summary [get]
+// @Security ApiKeyAuth
+func (h *ReportHandler) GetSessionReport(c *gin.Context) {
+	sessionID := c.Param("sessionId")
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	report, err := h.service.GetSessionReport(c.Request.Context(), userID.(string), sessionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to view the report of this session."})
+		case errors.Is(err, event_domain.ErrSessionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve session report"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -91,7 +91,23 @@ func registerRoutes(r *gin.Engine, userHandler *UserHandler, communityHandler *C
 
 		// Publicly accessible endpoints
 		// apiV1.GET("/events", eventHandler.ListEvents) // Moved to authenticated routes
-		apiV1.POST("/checkin", checkinHandler.VerifyCheckin)
+		public := apiV1.Group("/public")
+		{
+			public.GET("/events/:slug", eventHandler.GetPublicEvent)
+		}
+
+		// Calendar subscription feeds (token-authenticated or public)
+		calendar := apiV1.Group("/calendar")
+		{
+			calendar.GET("/users/:token/events.ics", eventHandler.GetUserCalendarFeed)
+			calendar.GET("/communities/:id/events.ics", eventHandler.GetCommunityCalendarFeed)
+		}
+
+		// Personal join links of online sessions (token-authenticated)
+		apiV1.GET("/join/:token", eventHandler.JoinOnlineSession)
+
+		// Public certificate verification
+		apiV1.GET("/certificates/verify/:code", eventHandler.VerifyCertificate)
 
 		// Authenticated routes
 		authRequired := apiV1.Group("/")
@@ -100,6 +116,7 @@ func registerRoutes(r *gin.Engine, userHandler *UserHandler, communityHandler *C
 			authRequired.GET("/feed", feedHandler.GetFeed)                  // News feed route
 			authRequired.GET("/feed/activity", feedHandler.GetActivityFeed) // Activity feed route
 
+			authRequired.POST("/checkin", checkinHandler.VerifyCheckin)
 			authRequired.POST("/checkin/manual-override", checkinHandler.ManualOverride)
 			authRequired.POST("/checkin/sync", checkinHandler.SyncOfflineCheckins)
 
@@ -119,6 +136,9 @@ func registerRoutes(r *gin.Engine, userHandler *UserHandler, communityHandler *C
 				users.POST("/:id/follow", userHandler.FollowUser)
 				users.DELETE("/:id/follow", userHandler.UnfollowUser)
 				users.GET("/me/registrations", eventHandler.ListMyRegistrations)
+				users.GET("/me/agenda", eventHandler.GetMyAgenda)
+				users.GET("/me/calendar-feed", eventHandler.GetMyCalendarFeed)
+				users.POST("/me/calendar-feed/rotate", eventHandler.RotateMyCalendarFeed)
 				users.GET("/:id", userHandler.GetUserByID)
 				users.GET("/:id/relationship", userHandler.GetUserRelationship) // New route for user relationship
 				users.POST("/change-password", userHandler.ChangePassword)
@@ -151,6 +171,7 @@ func registerRoutes(r *gin.Engine, userHandler *UserHandler, communityHandler *C
 			search := authRequired.Group("/search")
 			{
 				search.GET("/events", searchHandler.SearchEvents)
+				search.GET("/events/nearby", searchHandler.SearchEventsNearby)
 			}
 
 			authRequired.GET("/my-communities", communityHandler.ListUserCommunities)
@@ -184,6 +205,10 @@ func registerRoutes(r *gin.Engine, userHandler *UserHandler, communityHandler *C
 
 			communities.POST("/:id/follow-test", communityHandler.FollowTest) // Debugging route
 			communities.POST("/:id/polls", communityHandler.CreatePoll)
+			communities.POST("/:id/events/import", eventHandler.ImportEventsFromICal)
+			communities.GET("/:id/event-templates", eventHandler.ListEventTemplates)
+			communities.POST("/:id/venues", eventHandler.CreateVenue)
+			communities.GET("/:id/venues", eventHandler.ListVenues)
 		}
 		posts := authRequired.Group("/posts")
 		{
@@ -219,15 +244,123 @@ func registerRoutes(r *gin.Engine, userHandler *UserHandler, communityHandler *C
 			events.GET("/:id/attendance/summary", eventHandler.GetEventAttendanceSummary)
 			events.GET("/:id/attendance/attendees", eventHandler.GetEventAttendees)
 			events.GET("/:id", eventHandler.GetEvent)
+			events.GET("/:id/calendar.ics", eventHandler.ExportEventICal)
+			events.GET("/:id/join-link", eventHandler.GetMyJoinLink)
 			events.PATCH("/:id", eventHandler.UpdateEvent)
+			events.POST("/:id/status", eventHandler.ChangeEventStatus)
+			events.GET("/:id/status-history", eventHandler.GetEventStatusHistory)
+			events.POST("/:id/feedback-surveys", eventHandler.CreateFeedbackSurvey)
+			events.GET("/:id/feedback-surveys", eventHandler.ListFeedbackSurveys)
+			events.GET("/feedback-surveys/:id", eventHandler.GetFeedbackSurvey)
+			events.PATCH("/feedback-surveys/:id", eventHandler.UpdateFeedbackSurvey)
+			events.DELETE("/feedback-surveys/:id", eventHandler.DeleteFeedbackSurvey)
+			events.POST("/feedback-surveys/:id/responses", eventHandler.SubmitFeedback)
+			events.GET("/feedback/pending", eventHandler.ListPendingFeedback)
+			events.PUT("/:id/certificate-template", eventHandler.SaveCertificateTemplate)
+			events.GET("/:id/certificate-template", eventHandler.GetCertificateTemplate)
+			events.DELETE("/:id/certificate-template", eventHandler.DeleteCertificateTemplate)
+			events.POST("/:id/certificates/issue", eventHandler.IssueEventCertificates)
+			events.GET("/:id/certificates", eventHandler.ListEventCertificates)
 			events.POST("/:id/whitelist", eventHandler.AddUsersToWhitelist)
+			events.GET("/:id/whitelist", eventHandler.ListWhitelistEntries)
+			events.POST("/:id/whitelist/import", eventHandler.ImportWhitelistCSV)
+			events.DELETE("/:id/whitelist/:entryId", eventHandler.RemoveWhitelistEntry)
+			events.POST("/:id/whitelist/:entryId/link", eventHandler.ConfirmWhitelistLink)
+			events.POST("/:id/invitations", eventHandler.InviteToEvent)
+			events.GET("/:id/invitations", eventHandler.GetInvitationDashboard)
+			events.POST("/:id/invitations/remind", eventHandler.RemindPendingInvitations)
+			events.GET("/invitations/me", eventHandler.ListMyInvitations)
+			events.PUT("/invitations/:id/rsvp", eventHandler.RespondToInvitation)
+			events.DELETE("/invitations/:id", eventHandler.RevokeInvitation)
+			events.POST("/:id/transfers", eventHandler.RequestRegistrationTransfer)
+			events.GET("/:id/transfers", eventHandler.ListEventRegistrationTransfers)
+			events.GET("/transfers/me", eventHandler.ListMyRegistrationTransfers)
+			events.POST("/transfers/:id/accept", eventHandler.AcceptRegistrationTransfer)
+			events.POST("/transfers/:id/decline", eventHandler.DeclineRegistrationTransfer)
+			events.POST("/transfers/:id/approve", eventHandler.ApproveRegistrationTransfer)
+			events.POST("/transfers/:id/reject", eventHandler.RejectRegistrationTransfer)
+			events.DELETE("/transfers/:id", eventHandler.CancelRegistrationTransfer)
+			events.POST("/:id/announcements", eventHandler.CreateAnnouncement)
+			events.GET("/:id/announcements", eventHandler.ListAnnouncements)
+			events.DELETE("/announcements/:id", eventHandler.CancelAnnouncement)
 			events.GET("/:id/sessions", eventHandler.GetEventSessions)
 			events.GET("/sessions/:id", eventHandler.GetEventSessionByID)
 			events.GET("/:id/registrations/pending", eventHandler.ListPendingRegistrations)
 			events.POST("/:id/registrations/:registrationID/approve", eventHandler.ApproveRegistration)
+			events.POST("/:id/registrations/:registrationID/reject", eventHandler.RejectRegistration)
+			events.POST("/:id/registrations/decisions", eventHandler.DecideRegistrations)
+			events.POST("/:id/approval-rules", eventHandler.CreateRegistrationApprovalRule)
+			events.GET("/:id/approval-rules", eventHandler.ListRegistrationApprovalRules)
+			events.DELETE("/approval-rules/:id", eventHandler.DeleteRegistrationApprovalRule)
 			events.DELETE("/:id", eventHandler.DeleteEvent)
 			events.DELETE("/:id/hard", eventHandler.HardDeleteEvent)
 			events.POST("/sessions/:id/cancel", eventHandler.CancelEventSession)
+			events.PATCH("/sessions/:id", eventHandler.UpdateEventOccurrence)
+			events.PUT("/sessions/:id/venue", eventHandler.SetSessionVenue)
+			events.POST("/:id/tracks", eventHandler.CreateEventTrack)
+			events.GET("/:id/tracks", eventHandler.ListEventTracks)
+			events.PUT("/tracks/:id", eventHandler.UpdateEventTrack)
+			events.DELETE("/tracks/:id", eventHandler.DeleteEventTrack)
+			events.POST("/sessions/:id/agenda-items", eventHandler.CreateAgendaItem)
+			events.GET("/sessions/:id/agenda-items", eventHandler.ListSessionAgendaItems)
+			events.PUT("/agenda-items/:id", eventHandler.UpdateAgendaItem)
+			events.DELETE("/agenda-items/:id", eventHandler.DeleteAgendaItem)
+			events.POST("/agenda-items/:id/star", eventHandler.StarAgendaItem)
+			events.DELETE("/agenda-items/:id/star", eventHandler.UnstarAgendaItem)
+			events.GET("/:id/schedule", eventHandler.GetEventSchedule)
+			events.GET("/:id/schedule.ics", eventHandler.ExportEventScheduleICal)
+			events.GET("/:id/discussion", communityHandler.ListEventDiscussion)
+			events.POST("/:id/discussion", communityHandler.CreateEventDiscussionPost)
+			events.PUT("/sessions/:id/qa", eventHandler.SetSessionQA)
+			events.GET("/sessions/:id/questions", eventHandler.GetSessionQA)
+			events.POST("/sessions/:id/questions", eventHandler.AskSessionQuestion)
+			events.POST("/questions/:id/vote", eventHandler.VoteSessionQuestion)
+			events.DELETE("/questions/:id/vote", eventHandler.UnvoteSessionQuestion)
+			events.PUT("/questions/:id/status", eventHandler.ModerateSessionQuestion)
+			events.GET("/sessions/:id/polls", eventHandler.ListSessionPolls)
+			events.POST("/sessions/:id/polls", eventHandler.CreateSessionPoll)
+			events.PUT("/polls/:id", eventHandler.UpdateSessionPoll)
+			events.DELETE("/polls/:id", eventHandler.DeleteSessionPoll)
+			events.POST("/polls/:id/open", eventHandler.OpenSessionPoll)
+			events.POST("/polls/:id/close", eventHandler.CloseSessionPoll)
+			events.POST("/polls/:id/vote", eventHandler.VoteSessionPoll)
+			events.GET("/sessions/:id/seating", eventHandler.GetSeatingLayout)
+			events.PUT("/sessions/:id/seating", eventHandler.SaveSeatingLayout)
+			events.DELETE("/sessions/:id/seating", eventHandler.DeleteSeatingLayout)
+			events.GET("/sessions/:id/seats", eventHandler.ListSeatAssignments)
+			events.DELETE("/sessions/:id/seats", eventHandler.ClearSeatAssignments)
+			events.GET("/sessions/:id/seats/audit", eventHandler.ListSeatAssignmentAudit)
+			events.POST("/sessions/:id/seats/auto", eventHandler.AutoAssignSeats)
+			events.POST("/sessions/:id/seats/group", eventHandler.AssignSeatGroup)
+			events.PUT("/sessions/:id/seats/:userID", eventHandler.AssignSeat)
+			events.DELETE("/sessions/:id/seats/:userID", eventHandler.UnassignSeat)
+			events.GET("/sessions/:id/badges", eventHandler.ListSessionBadges)
+			events.POST("/sessions/:id/reschedule", eventHandler.RescheduleEventSession)
+			events.GET("/sessions/:id/reschedules", eventHandler.ListSessionReschedules)
+			events.GET("/reconfirmations/me", eventHandler.ListMyReconfirmations)
+			events.PUT("/reconfirmations/:id", eventHandler.AnswerReconfirmation)
+			events.POST("/:id/occurrences", eventHandler.AddEventOccurrence)
+			events.POST("/occurrences/preview", eventHandler.PreviewEventOccurrences)
+			events.GET("/:id/staff", eventHandler.ListEventStaff)
+			events.PUT("/:id/staff/:userId", eventHandler.AssignEventStaff)
+			events.DELETE("/:id/staff/:userId", eventHandler.RemoveEventStaff)
+			events.POST("/:id/templates", eventHandler.SaveEventAsTemplate)
+			events.POST("/:id/duplicate", eventHandler.DuplicateEvent)
+			events.GET("/templates/:id", eventHandler.GetEventTemplate)
+			events.PATCH("/templates/:id", eventHandler.UpdateEventTemplate)
+			events.DELETE("/templates/:id", eventHandler.DeleteEventTemplate)
+			events.POST("/templates/:id/events", eventHandler.CreateEventFromTemplate)
+			events.GET("/:id/my-sessions", eventHandler.ListMySessionRegistrations)
+			events.GET("/sessions/:id/registrations", eventHandler.ListSessionRegistrations)
+			events.POST("/sessions/:id/registrations", eventHandler.RegisterForSession)
+			events.DELETE("/sessions/:id/registrations", eventHandler.DropSessionRegistration)
+		}
+
+		certificates := authRequired.Group("/certificates")
+		{
+			certificates.GET("/me", eventHandler.ListMyCertificates)
+			certificates.GET("/:id/pdf", eventHandler.DownloadCertificate)
+			certificates.POST("/:id/revoke", eventHandler.RevokeCertificate)
 		}
 
 		messages := authRequired.Group("/messages")
@@ -260,9 +393,12 @@ func registerRoutes(r *gin.Engine, userHandler *UserHandler, communityHandler *C
 		reports := authRequired.Group("/reports")
 		{
 			reports.GET("/sessions/:sessionId/attendees-details", reportHandler.GetSessionAttendanceDetails) // New route for dashboard
+			reports.GET("/sessions/:sessionId/summary", reportHandler.GetSessionReport)
 			reports.GET("/events/:id/attendance", reportHandler.GetEventAttendanceReport)
 			reports.GET("/events/:id/attendance.csv", reportHandler.ExportEventAttendanceReportCSV)
 			reports.GET("/events/:id/attendance.pdf", reportHandler.ExportEventAttendanceReportPDF)
+			reports.GET("/events/:id/feedback", reportHandler.GetEventFeedbackReport)
+			reports.GET("/events/:id/feedback.csv", reportHandler.ExportEventFeedbackCSV)
 			reports.GET("/summary/monthly", reportHandler.GetMonthlySummary)
 			reports.GET("/communities/:id/engagement", reportHandler.GetCommunityEngagementReport)
 		}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
@@ -149,3 +150,54 @@ func (h *SearchHandler) SearchEvents(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"results": results})
 }
+
+// @Summary Search events near a point
+// @Description Find upcoming events with a session held at a venue within radius_km of a point, closest first. Each event is returned once, with its closest venue, the next session there and the distance in kilometres. Events are visible under the same rules as the event search: public communities and communities the user is an active member of.
+// @ID search-events-nearby
+// @Produce json
+// @Param lat query number true "Latitude of the point"
+// @Param lng query number true "Longitude of the point"
+// @Param radius_km query number false "Search radius in kilometres, up to 500 (default 10)"
+// @Param limit query int false "Number of items to return"
+// @Param offset query int false "Offset for pagination"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/search/events/nearby [get]
+// @Security ApiKeyAuth
+func (h *SearchHandler) SearchEventsNearby(c *gin.Context) {
+	latitude, latErr := strconv.ParseFloat(c.Query("lat"), 64)
+	longitude, lngErr := strconv.ParseFloat(c.Query("lng"), 64)
+	if latErr != nil || lngErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Query parameters 'lat' and 'lng' are required"})
+		return
+	}
+	radiusKm, err := strconv.ParseFloat(c.DefaultQuery("radius_km", "10"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Query parameter 'radius_km' must be a number"})
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	results, err := h.service.SearchEventsNearby(c.Request.Context(), userID.(string), latitude, longitude, radiusKm, limit, offset)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidGeoQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Printf("Failed to search events nearby: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search events nearby"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"results": results})
+}
@@ -139,6 +139,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 		ProfilePictureURL string `json:"profile_picture_url"`
 		Bio               string `json:"bio"`
 		Location          string `json:"location"`
+		RedirectTo        string `json:"redirect_to"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload: " + err.Error()})
@@ -155,7 +156,11 @@ func (h *UserHandler) Register(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"user": createdUser})
+	response := gin.H{"user": createdUser}
+	if redirectTo := safeRedirectPath(req.RedirectTo); redirectTo != "" {
+		response["redirect_to"] = redirectTo
+	}
+	c.JSON(http.StatusCreated, response)
 }
 
 // @Summary Login a user
@@ -172,8 +177,9 @@ func (h *UserHandler) Register(c *gin.Context) {
 // Login handles user authentication.
 func (h *UserHandler) Login(c *gin.Context) {
 	var req struct {
-		Email    string `json:"email" binding:"required,email"`
-		Password string `json:"password" binding:"required"`
+		Email      string `json:"email" binding:"required,email"`
+		Password   string `json:"password" binding:"required"`
+		RedirectTo string `json:"redirect_to"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
@@ -190,11 +196,28 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	response := gin.H{
 		"user":          user,
 		"access_token":  accessToken,
 		"refresh_token": refreshToken,
-	})
+	}
+	if redirectTo := safeRedirectPath(req.RedirectTo); redirectTo != "" {
+		response["redirect_to"] = redirectTo
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// safeRedirectPath returns path if it is a path on the frontend, such as an event's landing page, to send
+// people back to after they sign up or log in, and "" otherwise so that they cannot be sent to other sites.
+func safeRedirectPath(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return ""
+	}
+	parsed, err := url.Parse(path)
+	if err != nil || parsed.Scheme != "" || parsed.Host != "" {
+		return ""
+	}
+	return path
 }
 
 const defaultFrontendRedirect = "http://localhost:3000/dashboard"
@@ -21,9 +21,9 @@ func (r *CheckinRepository) GetEventAndAttendeeForTicketGeneration(ctx context.C
 	query := `
 		SELECT
 			e.id, e.community_id, e.created_by, e.name, e.slug, e.description, e.cover_image_url,
-			e.location_type, e.location_address, e.online_meeting_url, e.timezone, e.start_time, e.end_time,
-			e.is_recurring, e.recurrence_pattern, e.recurrence_rule, e.recurrence_end_date, e.max_occurrences,
-			e.max_attendees, e.current_attendees, e.waitlist_enabled, e.max_waitlist, e.registration_required,
+			e.location_type, e.location_address, e.online_meeting_url, e.venue_id, e.meeting_link_reveal_minutes, e.transfer_policy, e.transfer_deadline_minutes, e.timezone, e.start_time, e.end_time,
+			e.is_recurring, e.recurrence_pattern, e.recurrence_rule, e.recurrence_end_date, e.max_occurrences, e.generation_horizon_days,
+			e.max_attendees, e.current_attendees, e.waitlist_enabled, e.max_waitlist, e.per_session_registration, e.registration_required,
 			e.registration_opens_at, e.registration_closes_at, e.whitelist_only, e.require_approval,
 			e.face_verification_required, e.liveness_check_required, e.qr_code_enabled, e.fallback_code_enabled, e.manual_checkin_allowed,
 			e.is_paid, e.fee, e.currency, e.status, e.reminder_schedule,
@@ -54,9 +54,9 @@ func (r *CheckinRepository) GetEventAndAttendeeForTicketGeneration(ctx context.C
 	// Scan event fields
 	err := row.Scan(
 		&event.ID, &event.CommunityID, &event.CreatedBy, &event.Name, &event.Slug, &event.Description, &event.CoverImageURL,
-		&event.LocationType, &event.LocationAddress, &event.OnlineMeetingURL, &event.Timezone, &event.StartTime, &event.EndTime,
-		&event.IsRecurring, &event.RecurrencePattern, &event.RecurrenceRule, &event.RecurrenceEndDate, &event.MaxOccurrences,
-		&event.MaxAttendees, &event.CurrentAttendees, &event.WaitlistEnabled, &event.MaxWaitlist, &event.RegistrationRequired,
+		&event.LocationType, &event.LocationAddress, &event.OnlineMeetingURL, &event.VenueID, &event.MeetingLinkRevealMinutes, &event.TransferPolicy, &event.TransferDeadlineMinutes, &event.Timezone, &event.StartTime, &event.EndTime,
+		&event.IsRecurring, &event.RecurrencePattern, &event.RecurrenceRule, &event.RecurrenceEndDate, &event.MaxOccurrences, &event.GenerationHorizonDays,
+		&event.MaxAttendees, &event.CurrentAttendees, &event.WaitlistEnabled, &event.MaxWaitlist, &event.PerSessionRegistration, &event.RegistrationRequired,
 		&event.RegistrationOpensAt, &event.RegistrationClosesAt, &event.WhitelistOnly, &event.RequireApproval,
 		&event.FaceVerificationRequired, &event.LivenessCheckRequired, &event.QRCodeEnabled, &event.FallbackCodeEnabled, &event.ManualCheckinAllowed,
 		&event.IsPaid, &event.Fee, &event.Currency, &event.Status, &event.ReminderSchedule,
@@ -14,6 +14,7 @@ import (
 	pb "github.com/attendwise/backend/generated/go/ai"
 	"github.com/attendwise/backend/internal/module/checkin/domain"
 	event_domain "github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
 	user_domain "github.com/attendwise/backend/internal/module/user/domain"
 	"github.com/attendwise/backend/internal/platform"
 	"github.com/gin-gonic/gin"
@@ -39,38 +40,43 @@ type BatchProcessResult struct {
 
 // CheckinService interface updated to reflect new return values
 type CheckinService interface {
-	GenerateTicket(ctx context.Context, sessionID, userID, deviceFingerprint string) (string, string, error)
-	VerifyCheckinFromQR(ctx context.Context, qrPayload string, imageData []byte, livenessStream []byte, challengeType string, scannerDeviceFingerprint string) (*event_domain.EventAttendee, bool, string, error)
+	GenerateTicket(ctx context.Context, sessionID, userID, deviceFingerprint string) (string, string, *event_domain.SeatAssignment, error)
+	VerifyCheckinFromQR(ctx context.Context, scannerID, qrPayload string, imageData []byte, livenessStream []byte, challengeType string, scannerDeviceFingerprint string) (*event_domain.EventAttendee, bool, string, error)
 	ManualOverrideCheckin(ctx context.Context, sessionID, userID, hostID string) (*event_domain.EventAttendee, error)
-	VerifyCheckinFromFallback(ctx context.Context, fallbackCode string, imageData []byte) (*event_domain.EventAttendee, bool, string, error)
-	ProcessOfflineBatch(ctx context.Context, deviceID string, attempts []OfflineCheckinAttempt) ([]BatchProcessResult, error)
+	VerifyCheckinFromFallback(ctx context.Context, scannerID, fallbackCode string, imageData []byte) (*event_domain.EventAttendee, bool, string, error)
+	ProcessOfflineBatch(ctx context.Context, scannerID, deviceID string, attempts []OfflineCheckinAttempt) ([]BatchProcessResult, error)
 }
 
 type service struct {
 	checkinRepo domain.CheckinRepository
 	eventRepo   event_domain.EventRepository
 	userRepo    user_domain.UserRepository
+	permService permission_domain.PermissionService
 	jwtSecret   string
 	aiClient    *platform.AIClient
 	nc          *nats.Conn
 }
 
-func NewService(checkinRepo domain.CheckinRepository, eventRepo event_domain.EventRepository, userRepo user_domain.UserRepository, jwtSecret string, aiClient *platform.AIClient, nc *nats.Conn) CheckinService {
+func NewService(checkinRepo domain.CheckinRepository, eventRepo event_domain.EventRepository, userRepo user_domain.UserRepository, permService permission_domain.PermissionService, jwtSecret string, aiClient *platform.AIClient, nc *nats.Conn) CheckinService {
 	return &service{
 		checkinRepo: checkinRepo,
 		eventRepo:   eventRepo,
 		userRepo:    userRepo,
+		permService: permService,
 		jwtSecret:   jwtSecret,
 		aiClient:    aiClient,
 		nc:          nc,
 	}
 }
 
-func (s *service) GenerateTicket(ctx context.Context, sessionID, userID, deviceFingerprint string) (string, string, error) {
+func (s *service) GenerateTicket(ctx context.Context, sessionID, userID, deviceFingerprint string) (string, string, *event_domain.SeatAssignment, error) {
 	// 0. Get event and attendee details first
-	_, attendee, err := s.checkinRepo.GetEventAndAttendeeForTicketGeneration(ctx, sessionID, userID)
+	event, attendee, err := s.checkinRepo.GetEventAndAttendeeForTicketGeneration(ctx, sessionID, userID)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get event and attendee for ticket generation: %w", err)
+		return "", "", nil, fmt.Errorf("failed to get event and attendee for ticket generation: %w", err)
+	}
+	if err := s.ensureSessionRegistration(ctx, event, sessionID, userID); err != nil {
+		return "", "", nil, err
 	}
 
 	// 1. Create a nonce and fallback code
@@ -82,7 +88,7 @@ func (s *service) GenerateTicket(ctx context.Context, sessionID, userID, deviceF
 
 	// 3. Save the nonce hash to the check-ins table
 	if err := s.checkinRepo.SaveNonce(ctx, userID, sessionID, attendee.ID, nonceHash); err != nil {
-		return "", "", fmt.Errorf("could not save nonce: %w", err)
+		return "", "", nil, fmt.Errorf("could not save nonce: %w", err)
 	}
 
 	// 4. Create JWT claims
@@ -99,7 +105,7 @@ func (s *service) GenerateTicket(ctx context.Context, sessionID, userID, deviceF
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	signedToken, err := token.SignedString([]byte(s.jwtSecret))
 	if err != nil {
-		return "", "", fmt.Errorf("could not sign token: %w", err)
+		return "", "", nil, fmt.Errorf("could not sign token: %w", err)
 	}
 
 	// 6. Save codes and device fingerprint to the attendee record
@@ -107,10 +113,16 @@ func (s *service) GenerateTicket(ctx context.Context, sessionID, userID, deviceF
 		log.Printf("Warning: could not save ticket codes for attendee %s: %v", attendee.ID, err)
 	}
 
-	return signedToken, fallbackCode, nil
+	// 7. Print the attendee's seat on the ticket, if seating is assigned
+	seat, err := s.sessionSeat(ctx, sessionID, userID)
+	if err != nil {
+		log.Printf("Warning: could not get the seat of attendee %s for session %s: %v", attendee.ID, sessionID, err)
+	}
+
+	return signedToken, fallbackCode, seat, nil
 }
 
-func (s *service) VerifyCheckinFromQR(ctx context.Context, qrPayload string, imageData []byte, livenessStream []byte, challengeType string, scannerDeviceFingerprint string) (*event_domain.EventAttendee, bool, string, error) {
+func (s *service) VerifyCheckinFromQR(ctx context.Context, scannerID, qrPayload string, imageData []byte, livenessStream []byte, challengeType string, scannerDeviceFingerprint string) (*event_domain.EventAttendee, bool, string, error) {
 	// 1. Parse and validate claims from JWT
 	claims, err := s.parseAndValidateClaims(qrPayload)
 	if err != nil {
@@ -125,6 +137,12 @@ func (s *service) VerifyCheckinFromQR(ctx context.Context, qrPayload string, ima
 	if err != nil {
 		return nil, false, err.Error(), err
 	}
+	if err := s.authorizeCheckin(ctx, event.ID, scannerID); err != nil {
+		return nil, false, "Only event staff can check attendees in.", err
+	}
+	if err := s.ensureSessionRegistration(ctx, event, sessionID, userID); err != nil {
+		return nil, false, "Attendee is not registered for this session.", err
+	}
 
 	// 3. Check device fingerprint if applicable
 	if err := s.validateDeviceFingerprint(attendee, scannerDeviceFingerprint); err != nil {
@@ -198,6 +216,7 @@ func (s *service) VerifyCheckinFromQR(ctx context.Context, qrPayload string, ima
 		return nil, false, "Check-in successful, but updated attendee not found.", fmt.Errorf("updated attendee not found")
 	}
 
+	s.attachSeat(ctx, sessionID, updatedAttendee)
 	s.publishCheckinEvent(sessionID, updatedAttendee, true, "Check-in successful")
 
 	return updatedAttendee, true, "Check-in successful", nil
@@ -223,12 +242,12 @@ func (s *service) performLivenessCheck(ctx context.Context, userID, sessionID st
 	return true, 1.0, nil // Return true and a default confidence for now
 }
 
-func (s *service) ProcessOfflineBatch(ctx context.Context, deviceID string, attempts []OfflineCheckinAttempt) ([]BatchProcessResult, error) {
+func (s *service) ProcessOfflineBatch(ctx context.Context, scannerID, deviceID string, attempts []OfflineCheckinAttempt) ([]BatchProcessResult, error) {
 	results := make([]BatchProcessResult, 0, len(attempts))
 	log.Printf("Processing offline batch of %d attempts from device %s", len(attempts), deviceID)
 
 	for _, attempt := range attempts {
-		_, success, message, err := s.VerifyCheckinFromQR(ctx, attempt.QRPayload, attempt.ImageData, nil, "", "offline_scanner_"+deviceID)
+		_, success, message, err := s.VerifyCheckinFromQR(ctx, scannerID, attempt.QRPayload, attempt.ImageData, nil, "", "offline_scanner_"+deviceID)
 
 		resultMsg := message
 		if err != nil {
@@ -246,11 +265,14 @@ func (s *service) ProcessOfflineBatch(ctx context.Context, deviceID string, atte
 	return results, nil
 }
 
-func (s *service) VerifyCheckinFromFallback(ctx context.Context, fallbackCode string, imageData []byte) (*event_domain.EventAttendee, bool, string, error) {
+func (s *service) VerifyCheckinFromFallback(ctx context.Context, scannerID, fallbackCode string, imageData []byte) (*event_domain.EventAttendee, bool, string, error) {
 	attendee, err := s.checkinRepo.GetAttendeeByFallbackCode(ctx, fallbackCode)
 	if err != nil {
 		return nil, false, "Invalid fallback code.", err
 	}
+	if err := s.authorizeCheckin(ctx, attendee.EventID, scannerID); err != nil {
+		return nil, false, "Only event staff can check attendees in.", err
+	}
 
 	event, err := s.eventRepo.GetEventByID(ctx, attendee.EventID, attendee.UserID)
 	if err != nil {
@@ -260,6 +282,12 @@ func (s *service) VerifyCheckinFromFallback(ctx context.Context, fallbackCode st
 		return nil, false, "Event has no sessions.", fmt.Errorf("no sessions found for event %s", event.ID)
 	}
 	sessionID := event.Sessions[0].ID
+	if event.PerSessionRegistration {
+		sessionID, err = s.nextRegisteredSessionID(ctx, event.ID, attendee.UserID)
+		if err != nil {
+			return nil, false, "Attendee is not registered for an upcoming session.", err
+		}
+	}
 
 	if event.FaceVerificationRequired {
 		if _, err := s.verifyFace(ctx, attendee.UserID, sessionID, imageData); err != nil {
@@ -293,6 +321,7 @@ func (s *service) VerifyCheckinFromFallback(ctx context.Context, fallbackCode st
 		return nil, false, "Updated attendee not found after fallback check-in.", fmt.Errorf("updated attendee not found after fallback check-in")
 	}
 
+	s.attachSeat(ctx, sessionID, attendeeToReturn)
 	s.publishCheckinEvent(sessionID, attendeeToReturn, true, "Check-in successful via fallback code")
 
 	return attendeeToReturn, true, "Check-in successful via fallback code", nil
@@ -303,13 +332,16 @@ func (s *service) ManualOverrideCheckin(ctx context.Context, sessionID, userID,
 	if err != nil {
 		return nil, fmt.Errorf("event not found for session")
 	}
-	if event.CreatedBy != hostID {
-		return nil, fmt.Errorf("unauthorized: only the event host can perform manual override")
+	if err := s.authorizeCheckin(ctx, event.ID, hostID); err != nil {
+		return nil, err
 	}
 	attendee, err := s.eventRepo.GetEventAttendee(ctx, event.ID, userID)
 	if err != nil {
 		return nil, fmt.Errorf("user is not registered for this event")
 	}
+	if err := s.ensureSessionRegistration(ctx, event, sessionID, userID); err != nil {
+		return nil, err
+	}
 
 	if err := s.checkinRepo.OverrideCheckinStatus(ctx, userID, sessionID, attendee.ID); err != nil {
 		return nil, err
@@ -333,21 +365,93 @@ func (s *service) ManualOverrideCheckin(ctx context.Context, sessionID, userID,
 		return nil, fmt.Errorf("updated attendee not found after manual check-in")
 	}
 
+	s.attachSeat(ctx, sessionID, attendeeToReturn)
 	s.publishCheckinEvent(sessionID, attendeeToReturn, true, "Checked in by host")
 
 	return attendeeToReturn, nil
 }
 
+// authorizeCheckin allows the users whose event role grants check-in (host, co-hosts and check-in staff)
+// to check attendees of the event in.
+func (s *service) authorizeCheckin(ctx context.Context, eventID, userID string) error {
+	allowed, err := s.permService.HasEventPermission(ctx, eventID, userID, permission_domain.EventPermissionCheckIn)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return permission_domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+// ensureSessionRegistration rejects check-ins for sessions the attendee did not sign up for, on events
+// that use per-session registration.
+func (s *service) ensureSessionRegistration(ctx context.Context, event *event_domain.Event, sessionID, userID string) error {
+	if !event.PerSessionRegistration {
+		return nil
+	}
+	registration, err := s.eventRepo.GetSessionRegistration(ctx, sessionID, userID)
+	if errors.Is(err, event_domain.ErrSessionRegistrationNotFound) {
+		return event_domain.ErrNotRegisteredForSession
+	}
+	if err != nil {
+		return err
+	}
+	if registration.Status != event_domain.SessionRegistrationRegistered {
+		return event_domain.ErrNotRegisteredForSession
+	}
+	return nil
+}
+
+// sessionSeat returns the seat of the attendee for the session, or nil if the session has no assigned
+// seating or the attendee no seat.
+func (s *service) sessionSeat(ctx context.Context, sessionID, userID string) (*event_domain.SeatAssignment, error) {
+	seat, err := s.eventRepo.GetSeatAssignment(ctx, sessionID, userID)
+	if errors.Is(err, event_domain.ErrSeatAssignmentNotFound) {
+		return nil, nil
+	}
+	return seat, err
+}
+
+// attachSeat adds the attendee's seat to a successful check-in, so door staff can direct them to it.
+func (s *service) attachSeat(ctx context.Context, sessionID string, attendee *event_domain.EventAttendee) {
+	seat, err := s.sessionSeat(ctx, sessionID, attendee.UserID)
+	if err != nil {
+		log.Printf("Warning: could not get the seat of user %s for session %s: %v", attendee.UserID, sessionID, err)
+		return
+	}
+	attendee.Seat = seat
+}
+
+// nextRegisteredSessionID picks the session a fallback code checks the attendee in to on events with
+// per-session registration: the earliest session the attendee is registered for that has not ended.
+func (s *service) nextRegisteredSessionID(ctx context.Context, eventID, userID string) (string, error) {
+	registrations, err := s.eventRepo.ListUserSessionRegistrations(ctx, eventID, userID)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	for _, registration := range registrations {
+		if registration.Status == event_domain.SessionRegistrationRegistered && registration.SessionEndTime.After(now) {
+			return registration.SessionID, nil
+		}
+	}
+	return "", event_domain.ErrNotRegisteredForSession
+}
+
 func (s *service) publishCheckinEvent(sessionID string, attendee *event_domain.EventAttendee, success bool, message string) {
 	if s.nc == nil {
 		return
 	}
 
-	var userID, userName, profileURL string
+	var userID, userName, profileURL, seat string
 	if attendee != nil {
 		userID = attendee.UserID
 		userName = attendee.UserName
 		profileURL = attendee.UserProfilePictureURL.String
+		if attendee.Seat != nil {
+			seat = attendee.Seat.Label
+		}
 	}
 
 	eventPayload := gin.H{
@@ -358,6 +462,7 @@ func (s *service) publishCheckinEvent(sessionID string, attendee *event_domain.E
 		"success":      success,
 		"message":      message,
 		"checkin_time": time.Now(),
+		"seat":         seat,
 	}
 
 	payloadBytes, err := json.Marshal(eventPayload)
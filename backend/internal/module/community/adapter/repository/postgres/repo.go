@@ -724,7 +724,7 @@ func (r *communityRepository) GetPostsByCommunityID(ctx context.Context, communi
             COUNT(*) OVER() as total_count
         FROM posts p
         JOIN users u ON p.author_id = u.id
-        WHERE p.community_id = $2 AND p.deleted_at IS NULL`
+        WHERE p.community_id = $2 AND p.deleted_at IS NULL AND p.visibility <> 'event_only'`
 
 	args := []interface{}{userID, communityID}
 	argCount := 3 // Next placeholder is $3
@@ -823,6 +823,63 @@ func (r *communityRepository) GetPostsByCommunityID(ctx context.Context, communi
 	return posts, total, nil
 }
 
+// GetEventDiscussionPosts lists the approved posts of an event's discussion, pinned posts first, then newest
+// first, with their total.
+func (r *communityRepository) GetEventDiscussionPosts(ctx context.Context, eventID, userID string, limit, offset int) ([]*domain.Post, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM posts WHERE event_id = $1 AND visibility = 'event_only' AND status = 'approved' AND deleted_at IS NULL`
+	if err := r.db.QueryRow(ctx, countQuery, eventID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count event discussion posts: %w", err)
+	}
+
+	query := `
+        SELECT p.id, p.title, p.author_id, p.community_id, p.event_id, p.content, p.content_html,
+            COALESCE(to_json(p.media_urls), '[]'::json) AS media_urls_json,
+            p.file_attachments,
+            COALESCE(to_json(p.hashtags), '[]'::json) AS hashtags_json,
+            COALESCE(to_json(p.mentioned_user_ids), '[]'::json) AS mentioned_json,
+            p.visibility, p.status, p.post_type, p.reviewed_by,
+            p.reviewed_at, p.rejection_reason, p.flagged_count, p.comment_count, p.reaction_count,
+            p.share_count, p.view_count, p.is_pinned, p.pinned_until, p.created_at, p.updated_at,
+            p.published_at, p.deleted_at, u.id, u.name, u.profile_picture_url,
+            EXISTS(SELECT 1 FROM reactions WHERE target_id = p.id AND target_type = 'post' AND user_id = $1) as user_has_liked
+        FROM posts p
+        JOIN users u ON p.author_id = u.id
+        WHERE p.event_id = $2 AND p.visibility = 'event_only' AND p.status = 'approved' AND p.deleted_at IS NULL
+        ORDER BY p.is_pinned DESC, p.created_at DESC
+        LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.Query(ctx, query, userID, eventID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query event discussion posts: %w", err)
+	}
+	defer rows.Close()
+
+	posts := []*domain.Post{}
+	for rows.Next() {
+		post, err := r.scanPost(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan event discussion post: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	for _, post := range posts {
+		if post.PostType == "poll" {
+			pollOptions, err := r.GetPollOptionsByPostID(ctx, post.ID)
+			if err != nil {
+				log.Printf("[ERROR] GetEventDiscussionPosts: failed to get poll options for post %s: %v", post.ID, err)
+			}
+			post.PollOptions = pollOptions
+		}
+	}
+
+	return posts, total, nil
+}
+
 func (r *communityRepository) UpdatePostStatus(ctx context.Context, postID string, status string) error {
 	query := `UPDATE posts SET status = $2::content_status, updated_at = NOW() WHERE id = $1`
 	log.Printf("[DEBUG] Repo UpdatePostStatus for post %s to status %s", postID, status)
@@ -1170,7 +1227,7 @@ func (r *communityRepository) GetPostsForFeed(ctx context.Context, communityIDs
 		FROM posts p
 		JOIN users u ON p.author_id = u.id
 		WHERE (%s)
-			AND p.status = 'approved' AND p.deleted_at IS NULL
+			AND p.status = 'approved' AND p.deleted_at IS NULL AND p.visibility <> 'event_only'
 		ORDER BY p.created_at DESC
 		LIMIT $%d
 	`, strings.Join(visibilityClauses, " OR "), nextArg)
@@ -230,6 +230,7 @@ type CommunityRepository interface {
 	GetPostByID(ctx context.Context, postID, userID string) (*Post, error)
 	GetPostsByCommunityID(ctx context.Context, communityID, eventID, userID, status string, limit, offset int, authorID string) ([]*Post, int, error)
 	GetPostsForFeed(ctx context.Context, communityIDs []string, userID string, limit int, includeGeneral bool) ([]*Post, int, error)
+	GetEventDiscussionPosts(ctx context.Context, eventID, userID string, limit, offset int) ([]*Post, int, error)
 	UpdatePost(ctx context.Context, post *Post) (*Post, error)
 	DeletePost(ctx context.Context, postID string, userID string) error
 	UpdatePostStatus(ctx context.Context, postID string, status string) error
@@ -334,6 +335,10 @@ type CommunityService interface {
 	PinPost(ctx context.Context, postID, userID string, isPinned bool) error
 	GetRecommendedPosts(ctx context.Context, postID, userID string, limit int) ([]map[string]interface{}, error)
 
+	// Event discussions
+	GetEventDiscussion(ctx context.Context, eventID, userID string, limit, offset int) ([]*Post, int, error)
+	CreateEventDiscussionPost(ctx context.Context, eventID string, post *Post) (*Post, error)
+
 	// Comments
 	CreateComment(ctx context.Context, comment *Comment) (*Comment, error)
 	GetComments(ctx context.Context, postID string) ([]*Comment, error)
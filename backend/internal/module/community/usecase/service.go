@@ -262,6 +262,12 @@ func (s *Service) RemoveMember(ctx context.Context, communityID string, performi
 
 func (s *Service) CreatePost(ctx context.Context, post *domain.Post) (*domain.Post, error) {
 	log.Printf("--- DEBUG: Creating post in usecase: %+v ---", post)
+	if post.Visibility == "event_only" {
+		if !post.EventID.Valid {
+			return nil, domain.ErrPermissionDenied
+		}
+		return s.CreateEventDiscussionPost(ctx, post.EventID.String, post)
+	}
 	if post.CommunityID.Valid {
 		// Authorization: check if post.AuthorID is a member of post.CommunityID
 		isMember, err := s.permService.IsCommunityMember(ctx, post.CommunityID.String, post.AuthorID)
@@ -377,7 +383,54 @@ func (s *Service) CreatePost(ctx context.Context, post *domain.Post) (*domain.Po
 
 func (s *Service) GetPost(ctx context.Context, postID, userID string) (*domain.Post, error) {
 	// In a real app, add logic to check if the user has permission to view the post
-	return s.pgRepo.GetPostByID(ctx, postID, userID)
+	post, err := s.pgRepo.GetPostByID(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventOnlyPost(ctx, post, userID); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// GetEventDiscussion lists the posts of an event's discussion to its registrants and staff.
+func (s *Service) GetEventDiscussion(ctx context.Context, eventID, userID string, limit, offset int) ([]*domain.Post, int, error) {
+	if _, err := s.eventService.AuthorizeEventDiscussion(ctx, eventID, userID); err != nil {
+		return nil, 0, err
+	}
+	return s.pgRepo.GetEventDiscussionPosts(ctx, eventID, userID, limit, offset)
+}
+
+// CreateEventDiscussionPost adds a post to an event's discussion. Discussion posts belong to the event's
+// community but are only shown to the event's registrants and staff, so they are published right away and
+// kept out of the community's feed and activity.
+func (s *Service) CreateEventDiscussionPost(ctx context.Context, eventID string, post *domain.Post) (*domain.Post, error) {
+	event, err := s.eventService.AuthorizeEventDiscussion(ctx, eventID, post.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	post.ID = uuid.New().String()
+	post.CommunityID = sql.NullString{String: event.CommunityID, Valid: true}
+	post.EventID = sql.NullString{String: event.ID, Valid: true}
+	post.Visibility = "event_only"
+	post.Status = "approved"
+	post.PostType = "community"
+
+	createdPost, err := s.pgRepo.CreatePost(ctx, post)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create post in db: %w", err)
+	}
+	return createdPost, nil
+}
+
+// authorizeEventOnlyPost restricts the posts of an event's discussion to the event's registrants and staff.
+func (s *Service) authorizeEventOnlyPost(ctx context.Context, post *domain.Post, userID string) error {
+	if post.Visibility != "event_only" || !post.EventID.Valid {
+		return nil
+	}
+	_, err := s.eventService.AuthorizeEventDiscussion(ctx, post.EventID.String, userID)
+	return err
 }
 
 func (s *Service) GetPosts(ctx context.Context, communityID, eventID, userID, status string, limit, offset int, authorID string) ([]*domain.Post, int, error) {
@@ -464,6 +517,9 @@ func (s *Service) CreateComment(ctx context.Context, comment *domain.Comment) (*
 			return nil, domain.ErrNotMember
 		}
 	}
+	if err := s.authorizeEventOnlyPost(ctx, post, comment.AuthorID); err != nil {
+		return nil, err
+	}
 
 	comment.ID = uuid.New().String()
 	comment.Status = "approved"
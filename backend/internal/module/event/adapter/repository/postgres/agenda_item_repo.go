@@ -0,0 +1,287 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const eventTrackSelect = `
+	SELECT id, event_id, name, description, color, position, created_at, updated_at
+	FROM event_tracks
+`
+
+func scanEventTrack(scanner pgx.Row, track *domain.EventTrack) error {
+	return scanner.Scan(
+		&track.ID, &track.EventID, &track.Name, &track.Description, &track.Color, &track.Position,
+		&track.CreatedAt, &track.UpdatedAt,
+	)
+}
+
+// CreateEventTrack saves a new track. Track names are unique within an event.
+func (r *eventRepository) CreateEventTrack(ctx context.Context, track *domain.EventTrack) error {
+	if err := r.db.QueryRow(ctx, `
+		INSERT INTO event_tracks (event_id, name, description, color, position)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`, track.EventID, track.Name, track.Description, track.Color, track.Position,
+	).Scan(&track.ID, &track.CreatedAt, &track.UpdatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%w: the event already has a track with this name", domain.ErrInvalidTrack)
+		}
+		return fmt.Errorf("failed to create track: %w", err)
+	}
+	return nil
+}
+
+// UpdateEventTrack saves the details of a track.
+func (r *eventRepository) UpdateEventTrack(ctx context.Context, track *domain.EventTrack) error {
+	if err := r.db.QueryRow(ctx, `
+		UPDATE event_tracks
+		SET name = $2, description = $3, color = $4, position = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`, track.ID, track.Name, track.Description, track.Color, track.Position,
+	).Scan(&track.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrTrackNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("%w: the event already has a track with this name", domain.ErrInvalidTrack)
+		}
+		return fmt.Errorf("failed to update track: %w", err)
+	}
+	return nil
+}
+
+// DeleteEventTrack removes a track. Its agenda items stay on the agenda without a track.
+func (r *eventRepository) DeleteEventTrack(ctx context.Context, trackID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM event_tracks WHERE id = $1`, trackID)
+	if err != nil {
+		return fmt.Errorf("failed to delete track: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrTrackNotFound
+	}
+	return nil
+}
+
+// GetEventTrack retrieves a track by its ID.
+func (r *eventRepository) GetEventTrack(ctx context.Context, trackID string) (*domain.EventTrack, error) {
+	var track domain.EventTrack
+	if err := scanEventTrack(r.db.QueryRow(ctx, eventTrackSelect+`WHERE id = $1`, trackID), &track); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTrackNotFound
+		}
+		return nil, fmt.Errorf("failed to get track: %w", err)
+	}
+	return &track, nil
+}
+
+// ListEventTracks lists the tracks of an event in their order.
+func (r *eventRepository) ListEventTracks(ctx context.Context, eventID string) ([]*domain.EventTrack, error) {
+	rows, err := r.db.Query(ctx, eventTrackSelect+`WHERE event_id = $1 ORDER BY position, name`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracks: %w", err)
+	}
+	defer rows.Close()
+
+	var tracks []*domain.EventTrack
+	for rows.Next() {
+		var track domain.EventTrack
+		if err := scanEventTrack(rows, &track); err != nil {
+			return nil, fmt.Errorf("failed to scan track: %w", err)
+		}
+		tracks = append(tracks, &track)
+	}
+	return tracks, rows.Err()
+}
+
+// agendaItemSelect selects agenda items with their track, session, speakers and stars. $1 is the user whose
+// stars are reported in Starred.
+const agendaItemSelect = `
+	SELECT i.id, i.session_id, i.event_id, i.track_id, i.title, i.abstract, i.room, i.start_time, i.end_time,
+	       i.created_by, i.created_at, i.updated_at, t.name, es.name, es.is_cancelled,
+	       (SELECT COUNT(*) FROM session_agenda_item_stars st WHERE st.agenda_item_id = i.id)::int,
+	       EXISTS (SELECT 1 FROM session_agenda_item_stars st WHERE st.agenda_item_id = i.id AND st.user_id::text = $1),
+	       COALESCE((
+	           SELECT json_agg(json_build_object('user_id', u.id, 'name', u.name, 'avatar_url', u.profile_picture_url) ORDER BY sp.position)
+	           FROM session_agenda_item_speakers sp
+	           JOIN users u ON u.id = sp.user_id
+	           WHERE sp.agenda_item_id = i.id
+	       ), '[]')
+	FROM session_agenda_items i
+	JOIN event_sessions es ON es.id = i.session_id
+	LEFT JOIN event_tracks t ON t.id = i.track_id
+`
+
+func scanAgendaItem(scanner pgx.Row, item *domain.SessionAgendaItem) error {
+	var speakersJSON []byte
+	if err := scanner.Scan(
+		&item.ID, &item.SessionID, &item.EventID, &item.TrackID, &item.Title, &item.Abstract, &item.Room,
+		&item.StartTime, &item.EndTime, &item.CreatedBy, &item.CreatedAt, &item.UpdatedAt, &item.TrackName,
+		&item.SessionName, &item.SessionCancelled, &item.StarCount, &item.Starred, &speakersJSON,
+	); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(speakersJSON, &item.Speakers); err != nil {
+		return fmt.Errorf("failed to decode agenda item speakers: %w", err)
+	}
+	return nil
+}
+
+// CreateAgendaItem saves a new agenda item with its speakers, in the given order.
+func (r *eventRepository) CreateAgendaItem(ctx context.Context, item *domain.SessionAgendaItem, speakerIDs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO session_agenda_items (session_id, event_id, track_id, title, abstract, room, start_time, end_time, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`, item.SessionID, item.EventID, item.TrackID, item.Title, item.Abstract, item.Room, item.StartTime, item.EndTime,
+		item.CreatedBy,
+	).Scan(&item.ID, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create agenda item: %w", err)
+	}
+	if err := saveAgendaItemSpeakers(ctx, tx, item.ID, speakerIDs); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UpdateAgendaItem saves the details of an agenda item and replaces its speakers.
+func (r *eventRepository) UpdateAgendaItem(ctx context.Context, item *domain.SessionAgendaItem, speakerIDs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, `
+		UPDATE session_agenda_items
+		SET track_id = $2, title = $3, abstract = $4, room = $5, start_time = $6, end_time = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`, item.ID, item.TrackID, item.Title, item.Abstract, item.Room, item.StartTime, item.EndTime,
+	).Scan(&item.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrAgendaItemNotFound
+		}
+		return fmt.Errorf("failed to update agenda item: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM session_agenda_item_speakers WHERE agenda_item_id = $1`, item.ID); err != nil {
+		return fmt.Errorf("failed to clear agenda item speakers: %w", err)
+	}
+	if err := saveAgendaItemSpeakers(ctx, tx, item.ID, speakerIDs); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func saveAgendaItemSpeakers(ctx context.Context, tx pgx.Tx, itemID string, speakerIDs []string) error {
+	for position, userID := range speakerIDs {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO session_agenda_item_speakers (agenda_item_id, user_id, position)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (agenda_item_id, user_id) DO NOTHING
+		`, itemID, userID, position); err != nil {
+			return fmt.Errorf("failed to save agenda item speaker: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteAgendaItem removes an agenda item with its speakers and stars.
+func (r *eventRepository) DeleteAgendaItem(ctx context.Context, itemID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM session_agenda_items WHERE id = $1`, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to delete agenda item: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrAgendaItemNotFound
+	}
+	return nil
+}
+
+// GetAgendaItem retrieves an agenda item, with whether the user starred it.
+func (r *eventRepository) GetAgendaItem(ctx context.Context, itemID, userID string) (*domain.SessionAgendaItem, error) {
+	var item domain.SessionAgendaItem
+	if err := scanAgendaItem(r.db.QueryRow(ctx, agendaItemSelect+`WHERE i.id = $2`, userID, itemID), &item); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAgendaItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get agenda item: %w", err)
+	}
+	return &item, nil
+}
+
+// ListAgendaItems lists the agenda items of an event, or of one of its sessions if sessionID is set, in
+// chronological order. With starredOnly, only the items the user starred are listed.
+func (r *eventRepository) ListAgendaItems(ctx context.Context, eventID, sessionID, userID string, starredOnly bool) ([]*domain.SessionAgendaItem, error) {
+	rows, err := r.db.Query(ctx, agendaItemSelect+`
+		WHERE i.event_id = $2 AND ($3 = '' OR i.session_id::text = $3)
+		  AND (NOT $4 OR EXISTS (SELECT 1 FROM session_agenda_item_stars st WHERE st.agenda_item_id = i.id AND st.user_id::text = $1))
+		ORDER BY i.start_time, i.end_time, i.title
+	`, userID, eventID, sessionID, starredOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agenda items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.SessionAgendaItem
+	for rows.Next() {
+		var item domain.SessionAgendaItem
+		if err := scanAgendaItem(rows, &item); err != nil {
+			return nil, fmt.Errorf("failed to scan agenda item: %w", err)
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
+
+// StarAgendaItem adds an agenda item to the user's personal schedule. Starring it again does nothing.
+func (r *eventRepository) StarAgendaItem(ctx context.Context, itemID, userID string) error {
+	if _, err := r.db.Exec(ctx, `
+		INSERT INTO session_agenda_item_stars (agenda_item_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (agenda_item_id, user_id) DO NOTHING
+	`, itemID, userID); err != nil {
+		return fmt.Errorf("failed to star agenda item: %w", err)
+	}
+	return nil
+}
+
+// UnstarAgendaItem removes an agenda item from the user's personal schedule.
+func (r *eventRepository) UnstarAgendaItem(ctx context.Context, itemID, userID string) error {
+	if _, err := r.db.Exec(ctx, `
+		DELETE FROM session_agenda_item_stars WHERE agenda_item_id = $1 AND user_id = $2
+	`, itemID, userID); err != nil {
+		return fmt.Errorf("failed to unstar agenda item: %w", err)
+	}
+	return nil
+}
+
+// ShiftSessionAgendaItems moves the agenda items of a session by shift, so they follow the session when it is
+// rescheduled.
+func (r *eventRepository) ShiftSessionAgendaItems(ctx context.Context, sessionID string, shift time.Duration) error {
+	if _, err := r.db.Exec(ctx, `
+		UPDATE session_agenda_items
+		SET start_time = start_time + $2::interval, end_time = end_time + $2::interval, updated_at = NOW()
+		WHERE session_id = $1
+	`, sessionID, shift); err != nil {
+		return fmt.Errorf("failed to shift agenda items: %w", err)
+	}
+	return nil
+}
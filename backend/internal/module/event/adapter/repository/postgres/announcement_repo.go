@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// announcementSelect enriches announcements ('a') with their author, event and session.
+const announcementSelect = `
+	SELECT a.id, a.event_id, a.session_id, a.author_id, a.title, a.message, a.audience, a.status, a.scheduled_at,
+	       a.sent_at, a.recipient_count, a.created_at, a.updated_at, author.name, e.name, es.name
+	FROM %s a
+	JOIN events e ON e.id = a.event_id
+	LEFT JOIN users author ON author.id = a.author_id
+	LEFT JOIN event_sessions es ON es.id = a.session_id
+`
+
+// announcementAudiences selects the users of each audience. $1 is the event, $2 the session or NULL.
+// Registrants of a session are those registered for it on events with per-session registration, and all
+// the event's registrants otherwise.
+var announcementAudiences = map[string]string{
+	domain.AnnouncementAudienceAll:       `SELECT user_id FROM registrants`,
+	domain.AnnouncementAudienceCheckedIn: `SELECT user_id FROM checked_in`,
+	domain.AnnouncementAudienceNoShow:    `SELECT user_id FROM registrants EXCEPT SELECT user_id FROM checked_in`,
+	domain.AnnouncementAudienceWaitlist: `
+		SELECT ea.user_id FROM event_attendees ea, target t
+		WHERE ea.event_id = t.event_id AND ea.status = 'waitlist' AND (t.session_id IS NULL OR NOT t.per_session_registration)
+		UNION
+		SELECT esr.user_id FROM event_session_registrations esr, target t
+		WHERE esr.session_id = t.session_id AND t.per_session_registration AND esr.status = 'waitlisted'`,
+}
+
+func scanEventAnnouncement(scanner pgx.Row, announcement *domain.EventAnnouncement) error {
+	return scanner.Scan(
+		&announcement.ID, &announcement.EventID, &announcement.SessionID, &announcement.AuthorID, &announcement.Title,
+		&announcement.Message, &announcement.Audience, &announcement.Status, &announcement.ScheduledAt, &announcement.SentAt,
+		&announcement.RecipientCount, &announcement.CreatedAt, &announcement.UpdatedAt, &announcement.AuthorName,
+		&announcement.EventName, &announcement.SessionName,
+	)
+}
+
+func (r *eventRepository) queryEventAnnouncements(ctx context.Context, query string, args ...interface{}) ([]*domain.EventAnnouncement, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+	defer rows.Close()
+
+	var announcements []*domain.EventAnnouncement
+	for rows.Next() {
+		var announcement domain.EventAnnouncement
+		if err := scanEventAnnouncement(rows, &announcement); err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, &announcement)
+	}
+	return announcements, rows.Err()
+}
+
+// CreateEventAnnouncement saves an announcement. Announcements without a scheduled time are due right away.
+func (r *eventRepository) CreateEventAnnouncement(ctx context.Context, announcement *domain.EventAnnouncement) error {
+	var scheduledAt interface{}
+	if !announcement.ScheduledAt.IsZero() {
+		scheduledAt = announcement.ScheduledAt
+	}
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO event_announcements (event_id, session_id, author_id, title, message, audience, scheduled_at)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, NOW()))
+		RETURNING id, status, scheduled_at, created_at, updated_at
+	`, announcement.EventID, announcement.SessionID, announcement.AuthorID, announcement.Title, announcement.Message,
+		announcement.Audience, scheduledAt,
+	).Scan(&announcement.ID, &announcement.Status, &announcement.ScheduledAt, &announcement.CreatedAt, &announcement.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create announcement: %w", err)
+	}
+	return nil
+}
+
+// ListEventAnnouncements lists the announcements of an event, newest first.
+func (r *eventRepository) ListEventAnnouncements(ctx context.Context, eventID string) ([]*domain.EventAnnouncement, error) {
+	return r.queryEventAnnouncements(ctx, fmt.Sprintf(announcementSelect, "event_announcements")+`
+		WHERE a.event_id = $1
+		ORDER BY a.scheduled_at DESC, a.created_at DESC
+	`, eventID)
+}
+
+// GetEventAnnouncement retrieves an announcement by its ID.
+func (r *eventRepository) GetEventAnnouncement(ctx context.Context, announcementID string) (*domain.EventAnnouncement, error) {
+	var announcement domain.EventAnnouncement
+	if err := scanEventAnnouncement(r.db.QueryRow(ctx, fmt.Sprintf(announcementSelect, "event_announcements")+`WHERE a.id = $1`, announcementID), &announcement); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAnnouncementNotFound
+		}
+		return nil, fmt.Errorf("failed to get announcement: %w", err)
+	}
+	return &announcement, nil
+}
+
+// CancelEventAnnouncement cancels an announcement that has not been sent yet.
+func (r *eventRepository) CancelEventAnnouncement(ctx context.Context, announcementID string) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE event_announcements SET status = 'cancelled', updated_at = NOW()
+		WHERE id = $1 AND status = 'scheduled'
+	`, announcementID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel announcement: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrAnnouncementNotPending
+	}
+	return nil
+}
+
+// ClaimDueAnnouncements marks up to limit scheduled announcements whose time has come as sent, only the given
+// one if announcementID is set, and returns them with their recipients for delivery. The recipients are listed
+// in the same transaction, so an announcement is either claimed with its recipients or left scheduled.
+// Announcements of deleted events are skipped.
+func (r *eventRepository) ClaimDueAnnouncements(ctx context.Context, announcementID string, limit int) ([]*domain.EventAnnouncement, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for ClaimDueAnnouncements: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, fmt.Sprintf(announcementSelect, "event_announcements")+`
+		WHERE a.status = 'scheduled'
+		  AND a.scheduled_at <= NOW()
+		  AND ($1 = '' OR a.id::text = $1)
+		  AND e.deleted_at IS NULL
+		ORDER BY a.scheduled_at
+		LIMIT $2
+		FOR UPDATE OF a SKIP LOCKED
+	`, announcementID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due announcements: %w", err)
+	}
+	var announcements []*domain.EventAnnouncement
+	for rows.Next() {
+		var announcement domain.EventAnnouncement
+		if err := scanEventAnnouncement(rows, &announcement); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, &announcement)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, announcement := range announcements {
+		userIDs, err := listAnnouncementRecipients(ctx, tx, announcement)
+		if err != nil {
+			return nil, err
+		}
+		announcement.RecipientIDs = userIDs
+		if err := tx.QueryRow(ctx, `
+			UPDATE event_announcements
+			SET status = 'sent', sent_at = NOW(), recipient_count = $2, updated_at = NOW()
+			WHERE id = $1
+			RETURNING status, sent_at, recipient_count, updated_at
+		`, announcement.ID, len(userIDs)).Scan(&announcement.Status, &announcement.SentAt, &announcement.RecipientCount, &announcement.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to mark announcement as sent: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return announcements, nil
+}
+
+// listAnnouncementRecipients returns the IDs of the users in an announcement's audience, except its author.
+func listAnnouncementRecipients(ctx context.Context, tx pgx.Tx, announcement *domain.EventAnnouncement) ([]string, error) {
+	audience, ok := announcementAudiences[announcement.Audience]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown audience %q", domain.ErrInvalidAnnouncement, announcement.Audience)
+	}
+	rows, err := tx.Query(ctx, `
+		WITH target AS (
+			SELECT e.id AS event_id, $2::uuid AS session_id, e.per_session_registration
+			FROM events e WHERE e.id = $1
+		),
+		registrants AS (
+			SELECT ea.user_id FROM event_attendees ea, target t
+			WHERE ea.event_id = t.event_id
+			  AND ea.status IN ('registered', 'attended', 'no_show')
+			  AND (t.session_id IS NULL OR NOT t.per_session_registration OR EXISTS (
+			      SELECT 1 FROM event_session_registrations esr
+			      WHERE esr.session_id = t.session_id AND esr.user_id = ea.user_id AND esr.status = 'registered'
+			  ))
+		),
+		checked_in AS (
+			SELECT esc.user_id FROM event_session_checkins esc
+			JOIN event_sessions es ON es.id = esc.session_id, target t
+			WHERE es.event_id = t.event_id AND esc.status = 'success'
+			  AND (t.session_id IS NULL OR esc.session_id = t.session_id)
+		),
+		audience AS (`+audience+`)
+		SELECT DISTINCT user_id FROM audience WHERE user_id IS DISTINCT FROM $3
+	`, announcement.EventID, announcement.SessionID, announcement.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcement recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan announcement recipient: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
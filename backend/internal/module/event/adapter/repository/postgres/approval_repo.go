@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const approvalRuleSelect = `
+	SELECT id, event_id, kind, community_id, email_domains, min_attended_events, created_by, created_at
+	FROM registration_approval_rules
+`
+
+func scanApprovalRule(scanner pgx.Row, rule *domain.RegistrationApprovalRule) error {
+	return scanner.Scan(
+		&rule.ID, &rule.EventID, &rule.Kind, &rule.CommunityID, &rule.EmailDomains, &rule.MinAttendedEvents,
+		&rule.CreatedBy, &rule.CreatedAt,
+	)
+}
+
+// CreateRegistrationApprovalRule saves an approval rule of an event.
+func (r *eventRepository) CreateRegistrationApprovalRule(ctx context.Context, rule *domain.RegistrationApprovalRule) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO registration_approval_rules (event_id, kind, community_id, email_domains, min_attended_events, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, rule.EventID, rule.Kind, rule.CommunityID, rule.EmailDomains, rule.MinAttendedEvents, rule.CreatedBy,
+	).Scan(&rule.ID, &rule.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%w: the community does not exist", domain.ErrInvalidApprovalRule)
+		}
+		return fmt.Errorf("failed to create approval rule: %w", err)
+	}
+	return nil
+}
+
+// GetRegistrationApprovalRule retrieves an approval rule by its ID.
+func (r *eventRepository) GetRegistrationApprovalRule(ctx context.Context, ruleID string) (*domain.RegistrationApprovalRule, error) {
+	var rule domain.RegistrationApprovalRule
+	if err := scanApprovalRule(r.db.QueryRow(ctx, approvalRuleSelect+`WHERE id = $1`, ruleID), &rule); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrApprovalRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get approval rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// ListRegistrationApprovalRules lists the approval rules of an event, oldest first.
+func (r *eventRepository) ListRegistrationApprovalRules(ctx context.Context, eventID string) ([]*domain.RegistrationApprovalRule, error) {
+	rows, err := r.db.Query(ctx, approvalRuleSelect+`WHERE event_id = $1 ORDER BY created_at, id`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approval rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []*domain.RegistrationApprovalRule{}
+	for rows.Next() {
+		var rule domain.RegistrationApprovalRule
+		if err := scanApprovalRule(rows, &rule); err != nil {
+			return nil, fmt.Errorf("failed to scan approval rule: %w", err)
+		}
+		rules = append(rules, &rule)
+	}
+	return rules, rows.Err()
+}
+
+// DeleteRegistrationApprovalRule deletes an approval rule. Registrations it approved stay approved.
+func (r *eventRepository) DeleteRegistrationApprovalRule(ctx context.Context, ruleID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM registration_approval_rules WHERE id = $1`, ruleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete approval rule: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrApprovalRuleNotFound
+	}
+	return nil
+}
+
+// MatchRegistrationApprovalRules returns the pending registrations of an event that one of its approval
+// rules approves, with the oldest matching rule. With a userID, only that user's registration is checked.
+// Past attendance counts the other events of the event's community the user attended.
+func (r *eventRepository) MatchRegistrationApprovalRules(ctx context.Context, eventID, userID string) ([]*domain.ApprovalRuleMatch, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT ON (ea.id) ea.id, ea.user_id, rule.id
+		FROM event_attendees ea
+		JOIN events e ON e.id = ea.event_id
+		JOIN users u ON u.id = ea.user_id
+		JOIN registration_approval_rules rule ON rule.event_id = ea.event_id
+		WHERE ea.event_id = $1 AND ea.status = 'pending' AND ($2::uuid IS NULL OR ea.user_id = $2::uuid)
+		  AND (
+		      (rule.kind = 'community_member' AND EXISTS (
+		          SELECT 1 FROM community_members cm
+		          WHERE cm.community_id = rule.community_id AND cm.user_id = ea.user_id AND cm.status = 'active'))
+		   OR (rule.kind = 'email_domain' AND LOWER(SPLIT_PART(u.email, '@', 2)) = ANY(rule.email_domains))
+		   OR (rule.kind = 'past_attendance' AND (
+		          SELECT COUNT(DISTINCT past.event_id)
+		          FROM event_attendees past
+		          JOIN events pe ON pe.id = past.event_id
+		          WHERE past.user_id = ea.user_id AND past.status = 'attended'
+		            AND pe.community_id = e.community_id AND pe.id <> e.id) >= rule.min_attended_events)
+		  )
+		ORDER BY ea.id, rule.created_at
+	`, eventID, sql.NullString{String: userID, Valid: userID != ""})
+	if err != nil {
+		return nil, fmt.Errorf("failed to match approval rules: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []*domain.ApprovalRuleMatch
+	for rows.Next() {
+		var match domain.ApprovalRuleMatch
+		if err := rows.Scan(&match.RegistrationID, &match.UserID, &match.RuleID); err != nil {
+			return nil, fmt.Errorf("failed to scan approval rule match: %w", err)
+		}
+		matches = append(matches, &match)
+	}
+	return matches, rows.Err()
+}
+
+// DecideRegistrations approves (status 'registered') or rejects (status 'rejected') the given pending
+// registrations of an event, or all of them if registrationIDs is nil. Registrations that are not pending
+// registrations of the event are left alone. Rejected registrations give up any seat they were assigned.
+// deciderID is NULL for approvals by an approval rule.
+func (r *eventRepository) DecideRegistrations(ctx context.Context, eventID string, registrationIDs []string, status string, deciderID, reason sql.NullString) ([]*domain.DecidedRegistration, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for DecideRegistrations: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		UPDATE event_attendees
+		SET status = $2::text::event_attendee_status,
+		    decision_reason = $4,
+		    approved_at = CASE WHEN $2::text = 'registered' THEN NOW() ELSE approved_at END,
+		    approved_by = CASE WHEN $2::text = 'registered' THEN $3::uuid ELSE approved_by END,
+		    rejected_at = CASE WHEN $2::text = 'rejected' THEN NOW() END,
+		    rejected_by = CASE WHEN $2::text = 'rejected' THEN $3::uuid END
+		WHERE event_id = $1 AND status = 'pending' AND ($5::uuid[] IS NULL OR id = ANY($5::uuid[]))
+		RETURNING id, user_id
+	`, eventID, status, deciderID, reason, registrationIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decide registrations: %w", err)
+	}
+
+	decided := []*domain.DecidedRegistration{}
+	userIDs := []string{}
+	for rows.Next() {
+		var registration domain.DecidedRegistration
+		if err := rows.Scan(&registration.RegistrationID, &registration.UserID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan decided registration: %w", err)
+		}
+		decided = append(decided, &registration)
+		userIDs = append(userIDs, registration.UserID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if status == "rejected" && len(userIDs) > 0 {
+		if err := releaseSeats(ctx, tx, eventID, nil, userIDs, deciderID.String); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return decided, nil
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetPublicEventsByCommunityID retrieves the visible events of a public community, with their sessions.
+func (r *eventRepository) GetPublicEventsByCommunityID(ctx context.Context, communityID string) ([]*domain.Event, error) {
+	query := `
+		SELECT
+			e.id, e.community_id, e.created_by, e.name, e.slug, e.description, e.cover_image_url,
+			e.location_type, e.location_address, e.online_meeting_url, e.venue_id, e.meeting_link_reveal_minutes, e.transfer_policy, e.transfer_deadline_minutes, e.timezone, e.start_time, e.end_time,
+			e.is_recurring, e.recurrence_pattern, e.recurrence_rule, e.recurrence_end_date, e.max_occurrences, e.generation_horizon_days,
+			e.max_attendees, e.current_attendees, e.waitlist_enabled, e.max_waitlist, e.per_session_registration, e.registration_required,
+			e.registration_opens_at, e.registration_closes_at, e.whitelist_only, e.require_approval,
+			e.face_verification_required, e.liveness_check_required, e.qr_code_enabled, e.fallback_code_enabled, e.manual_checkin_allowed,
+			e.is_paid, e.fee, e.currency, e.status, e.reminder_schedule,
+			e.total_sessions, e.total_registrations, e.created_at, e.updated_at, e.published_at, e.deleted_at,
+			c.type as community_type, u.name as created_by_name, u.profile_picture_url as created_by_avatar,
+			FALSE AS is_registered
+		FROM events e
+		JOIN users u ON e.created_by = u.id
+		JOIN communities c ON e.community_id = c.id
+		WHERE e.community_id = $1 AND c.type = 'public'
+		  AND e.status IN ('published', 'registration_open', 'registration_closed', 'ongoing', 'completed')
+		  AND e.deleted_at IS NULL
+		ORDER BY e.start_time ASC
+	`
+	rows, err := r.db.Query(ctx, query, communityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public events by community ID: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.Event
+	for rows.Next() {
+		var event domain.Event
+		if err := r.scanEvent(rows, &event); err != nil {
+			return nil, fmt.Errorf("failed to scan public event: %w", err)
+		}
+		events = append(events, &event)
+	}
+	rows.Close()
+
+	for _, event := range events {
+		sessions, err := r.GetEventSessions(ctx, event.ID)
+		if err != nil {
+			return nil, err
+		}
+		event.Sessions = sessions
+	}
+	return events, nil
+}
+
+// GetCalendarFeedToken returns the user's calendar feed token, or an empty string if none was issued yet.
+func (r *eventRepository) GetCalendarFeedToken(ctx context.Context, userID string) (string, error) {
+	query := `SELECT token FROM calendar_feed_tokens WHERE user_id = $1`
+	var token string
+	err := r.db.QueryRow(ctx, query, userID).Scan(&token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get calendar feed token: %w", err)
+	}
+	return token, nil
+}
+
+// IssueCalendarFeedToken stores the token for a user who has none yet and returns the user's token, which
+// is the one of a concurrent request if that was stored first.
+func (r *eventRepository) IssueCalendarFeedToken(ctx context.Context, userID, token string) (string, error) {
+	query := `
+		INSERT INTO calendar_feed_tokens (user_id, token)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO NOTHING
+	`
+	if _, err := r.db.Exec(ctx, query, userID, token); err != nil {
+		return "", fmt.Errorf("failed to issue calendar feed token: %w", err)
+	}
+	issued, err := r.GetCalendarFeedToken(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if issued == "" {
+		return "", fmt.Errorf("failed to issue calendar feed token: none stored for user %s", userID)
+	}
+	return issued, nil
+}
+
+// SaveCalendarFeedToken stores a new feed token for the user, replacing any previous one.
+func (r *eventRepository) SaveCalendarFeedToken(ctx context.Context, userID, token string) error {
+	query := `
+		INSERT INTO calendar_feed_tokens (user_id, token)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET token = EXCLUDED.token, created_at = NOW()
+	`
+	if _, err := r.db.Exec(ctx, query, userID, token); err != nil {
+		return fmt.Errorf("failed to save calendar feed token: %w", err)
+	}
+	return nil
+}
+
+func (r *eventRepository) GetUserIDByCalendarFeedToken(ctx context.Context, token string) (string, error) {
+	query := `SELECT user_id FROM calendar_feed_tokens WHERE token = $1`
+	var userID string
+	err := r.db.QueryRow(ctx, query, token).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrInvalidFeedToken
+		}
+		return "", fmt.Errorf("failed to get user by calendar feed token: %w", err)
+	}
+	return userID, nil
+}
@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// SaveCertificateTemplate creates the certificate template of an event or replaces its settings.
+func (r *eventRepository) SaveCertificateTemplate(ctx context.Context, template *domain.CertificateTemplate) error {
+	if err := r.db.QueryRow(ctx, `
+		INSERT INTO event_certificate_templates (
+			event_id, title, body, signer_name, signer_title, min_attendance_percent, is_active, created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (event_id) DO UPDATE
+		SET title = EXCLUDED.title, body = EXCLUDED.body, signer_name = EXCLUDED.signer_name,
+		    signer_title = EXCLUDED.signer_title, min_attendance_percent = EXCLUDED.min_attendance_percent,
+		    is_active = EXCLUDED.is_active, updated_at = NOW()
+		RETURNING id, COALESCE(created_by::text, ''), created_at, updated_at
+	`, template.EventID, template.Title, template.Body, template.SignerName, template.SignerTitle,
+		template.MinAttendancePercent, template.IsActive, template.CreatedBy,
+	).Scan(&template.ID, &template.CreatedBy, &template.CreatedAt, &template.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save certificate template: %w", err)
+	}
+	return nil
+}
+
+// GetCertificateTemplate retrieves the certificate template of an event.
+func (r *eventRepository) GetCertificateTemplate(ctx context.Context, eventID string) (*domain.CertificateTemplate, error) {
+	var template domain.CertificateTemplate
+	err := r.db.QueryRow(ctx, `
+		SELECT id, event_id, title, body, signer_name, signer_title, min_attendance_percent, is_active,
+		       COALESCE(created_by::text, ''), created_at, updated_at
+		FROM event_certificate_templates
+		WHERE event_id = $1
+	`, eventID).Scan(
+		&template.ID, &template.EventID, &template.Title, &template.Body, &template.SignerName, &template.SignerTitle,
+		&template.MinAttendancePercent, &template.IsActive, &template.CreatedBy, &template.CreatedAt, &template.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCertificateTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get certificate template: %w", err)
+	}
+	return &template, nil
+}
+
+// DeleteCertificateTemplate removes the certificate template of an event. Certificates already issued are kept.
+func (r *eventRepository) DeleteCertificateTemplate(ctx context.Context, eventID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM event_certificate_templates WHERE event_id = $1`, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete certificate template: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrCertificateTemplateNotFound
+	}
+	return nil
+}
+
+// ListCertificateAttendance counts, for every user with a successful check-in, how many of the event's
+// sessions that are not cancelled they checked in to.
+func (r *eventRepository) ListCertificateAttendance(ctx context.Context, eventID string) ([]domain.CertificateAttendance, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH sessions AS (
+			SELECT id FROM event_sessions WHERE event_id = $1 AND is_cancelled = FALSE
+		)
+		SELECT u.id, u.name, COUNT(DISTINCT esc.session_id)::int, (SELECT COUNT(*) FROM sessions)::int
+		FROM event_session_checkins esc
+		JOIN sessions s ON s.id = esc.session_id
+		JOIN users u ON u.id = esc.user_id
+		WHERE esc.status = 'success'
+		GROUP BY u.id, u.name
+		ORDER BY u.name
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificate attendance: %w", err)
+	}
+	defer rows.Close()
+
+	var attendance []domain.CertificateAttendance
+	for rows.Next() {
+		var a domain.CertificateAttendance
+		if err := rows.Scan(&a.UserID, &a.UserName, &a.SessionsAttended, &a.SessionsTotal); err != nil {
+			return nil, fmt.Errorf("failed to scan certificate attendance: %w", err)
+		}
+		attendance = append(attendance, a)
+	}
+	return attendance, rows.Err()
+}
+
+// CreateCertificate saves an issued certificate and reports whether it was created. A user gets at most one
+// certificate per event, so nothing is saved if the user already has one.
+func (r *eventRepository) CreateCertificate(ctx context.Context, certificate *domain.Certificate) (bool, error) {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO event_certificates (
+			event_id, user_id, template_id, verification_code, recipient_name, event_name, title, body,
+			signer_name, signer_title, sessions_attended, sessions_total, attendance_percent, issued_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (event_id, user_id) DO NOTHING
+		RETURNING id
+	`, certificate.EventID, certificate.UserID, certificate.TemplateID, certificate.VerificationCode,
+		certificate.RecipientName, certificate.EventName, certificate.Title, certificate.Body,
+		certificate.SignerName, certificate.SignerTitle, certificate.SessionsAttended, certificate.SessionsTotal,
+		certificate.AttendancePercent, certificate.IssuedAt,
+	).Scan(&certificate.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create certificate: %w", err)
+	}
+	return true, nil
+}
+
+const certificateSelect = `
+	SELECT id, event_id, user_id, template_id, verification_code, recipient_name, event_name, title, body,
+	       signer_name, signer_title, sessions_attended, sessions_total, attendance_percent::float8, issued_at,
+	       revoked_at, revoked_reason
+	FROM event_certificates
+`
+
+func scanCertificate(scanner pgx.Row, certificate *domain.Certificate) error {
+	return scanner.Scan(
+		&certificate.ID, &certificate.EventID, &certificate.UserID, &certificate.TemplateID, &certificate.VerificationCode,
+		&certificate.RecipientName, &certificate.EventName, &certificate.Title, &certificate.Body,
+		&certificate.SignerName, &certificate.SignerTitle, &certificate.SessionsAttended, &certificate.SessionsTotal,
+		&certificate.AttendancePercent, &certificate.IssuedAt, &certificate.RevokedAt, &certificate.RevokedReason,
+	)
+}
+
+func (r *eventRepository) getCertificate(ctx context.Context, where string, arg string) (*domain.Certificate, error) {
+	var certificate domain.Certificate
+	if err := scanCertificate(r.db.QueryRow(ctx, certificateSelect+where, arg), &certificate); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCertificateNotFound
+		}
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
+	}
+	return &certificate, nil
+}
+
+// GetCertificate retrieves a certificate by its ID.
+func (r *eventRepository) GetCertificate(ctx context.Context, certificateID string) (*domain.Certificate, error) {
+	return r.getCertificate(ctx, `WHERE id = $1`, certificateID)
+}
+
+// GetCertificateByCode retrieves a certificate by its verification code.
+func (r *eventRepository) GetCertificateByCode(ctx context.Context, code string) (*domain.Certificate, error) {
+	return r.getCertificate(ctx, `WHERE verification_code = $1`, code)
+}
+
+func (r *eventRepository) listCertificates(ctx context.Context, where string, arg string) ([]*domain.Certificate, error) {
+	rows, err := r.db.Query(ctx, certificateSelect+where, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	defer rows.Close()
+
+	var certificates []*domain.Certificate
+	for rows.Next() {
+		var certificate domain.Certificate
+		if err := scanCertificate(rows, &certificate); err != nil {
+			return nil, fmt.Errorf("failed to scan certificate: %w", err)
+		}
+		certificates = append(certificates, &certificate)
+	}
+	return certificates, rows.Err()
+}
+
+// ListEventCertificates lists the certificates issued for an event, by recipient name.
+func (r *eventRepository) ListEventCertificates(ctx context.Context, eventID string) ([]*domain.Certificate, error) {
+	return r.listCertificates(ctx, `WHERE event_id = $1 ORDER BY recipient_name`, eventID)
+}
+
+// ListUserCertificates lists the certificates issued to a user, newest first.
+func (r *eventRepository) ListUserCertificates(ctx context.Context, userID string) ([]*domain.Certificate, error) {
+	return r.listCertificates(ctx, `WHERE user_id = $1 ORDER BY issued_at DESC`, userID)
+}
+
+// RevokeCertificate marks a certificate as revoked. It stays verifiable, as revoked.
+func (r *eventRepository) RevokeCertificate(ctx context.Context, certificateID, reason string) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE event_certificates
+		SET revoked_at = NOW(), revoked_reason = NULLIF($2, '')
+		WHERE id = $1 AND revoked_at IS NULL
+	`, certificateID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to revoke certificate: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrCertificateNotFound
+	}
+	return nil
+}
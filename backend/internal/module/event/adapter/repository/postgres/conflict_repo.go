@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// scheduleConflictSelect matches the slots $1, $2 and $3 (session IDs, start and end times) against the
+// sessions of other events than $4 that overlap them. Cancelled events and sessions are left out.
+const scheduleConflictSelect = `
+	WITH slots AS (
+	    SELECT * FROM unnest($1::text[], $2::timestamptz[], $3::timestamptz[]) AS s(session_id, start_time, end_time)
+	)
+	SELECT s.session_id, s.start_time, s.end_time, e.id, e.name, es.id, es.name, es.start_time, es.end_time%s
+	FROM slots s
+	JOIN event_sessions es ON es.start_time < s.end_time AND es.end_time > s.start_time AND es.is_cancelled = FALSE
+	JOIN events e ON e.id = es.event_id AND e.deleted_at IS NULL AND e.status <> 'cancelled' AND e.id::text <> $4
+`
+
+func (r *eventRepository) findScheduleConflicts(ctx context.Context, kind, eventID string, slots []domain.ScheduleSlot, columns, filter string, args ...interface{}) ([]*domain.ScheduleConflict, error) {
+	if len(slots) == 0 {
+		return nil, nil
+	}
+	sessionIDs := make([]string, len(slots))
+	starts := make([]time.Time, len(slots))
+	ends := make([]time.Time, len(slots))
+	for i, slot := range slots {
+		sessionIDs[i], starts[i], ends[i] = slot.SessionID, slot.StartTime, slot.EndTime
+	}
+	query := fmt.Sprintf(scheduleConflictSelect, columns) + filter + `
+		ORDER BY s.start_time, es.start_time`
+	rows, err := r.db.Query(ctx, query, append([]interface{}{sessionIDs, starts, ends, eventID}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s schedule conflicts: %w", kind, err)
+	}
+	defer rows.Close()
+
+	var conflicts []*domain.ScheduleConflict
+	for rows.Next() {
+		conflict := domain.ScheduleConflict{Kind: kind}
+		dest := []interface{}{
+			&conflict.SessionID, &conflict.StartTime, &conflict.EndTime,
+			&conflict.ConflictingEventID, &conflict.ConflictingEventName, &conflict.ConflictingSessionID,
+			&conflict.ConflictingSessionName, &conflict.ConflictingSessionStartTime, &conflict.ConflictingSessionEndTime,
+		}
+		switch kind {
+		case domain.ConflictKindVenue:
+			dest = append(dest, &conflict.VenueID)
+		case domain.ConflictKindStaff:
+			dest = append(dest, &conflict.UserID, &conflict.UserName)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan schedule conflict: %w", err)
+		}
+		conflicts = append(conflicts, &conflict)
+	}
+	return conflicts, rows.Err()
+}
+
+// FindAttendeeConflicts returns the sessions of the user's other active registrations that overlap the
+// slots of an event. On events with per-session registration only the sessions the user signed up for
+// count.
+func (r *eventRepository) FindAttendeeConflicts(ctx context.Context, userID, eventID string, slots []domain.ScheduleSlot) ([]*domain.ScheduleConflict, error) {
+	return r.findScheduleConflicts(ctx, domain.ConflictKindAttendee, eventID, slots, "", `
+		JOIN event_attendees ea ON ea.event_id = e.id AND ea.user_id = $5
+		     AND ea.status IN ('registered', 'pending', 'waitlist', 'attended')
+		WHERE NOT e.per_session_registration OR EXISTS (
+		    SELECT 1 FROM event_session_registrations r
+		    WHERE r.session_id = es.id AND r.user_id = $5 AND r.status <> 'cancelled'
+		)`, userID)
+}
+
+// FindVenueConflicts returns the sessions of other events held at the venue that overlap the slots. A
+// session is held at its own venue, or at its event's if it has none.
+func (r *eventRepository) FindVenueConflicts(ctx context.Context, venueID, eventID string, slots []domain.ScheduleSlot) ([]*domain.ScheduleConflict, error) {
+	return r.findScheduleConflicts(ctx, domain.ConflictKindVenue, eventID, slots, ", COALESCE(es.venue_id, e.venue_id)", `
+		WHERE COALESCE(es.venue_id, e.venue_id) = $5`, venueID)
+}
+
+// FindStaffConflicts returns, for each of the users, the sessions of other events they host or are staff
+// of that overlap the slots.
+func (r *eventRepository) FindStaffConflicts(ctx context.Context, userIDs []string, eventID string, slots []domain.ScheduleSlot) ([]*domain.ScheduleConflict, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	return r.findScheduleConflicts(ctx, domain.ConflictKindStaff, eventID, slots, ", u.id, u.name", `
+		JOIN users u ON u.id::text = ANY($5) AND (
+		    u.id = e.created_by OR EXISTS (SELECT 1 FROM event_staff st WHERE st.event_id = e.id AND st.user_id = u.id)
+		)`, userIDs)
+}
+
+// ListUserAgenda lists the sessions between from and to of the events the user holds an active registration
+// for, leaving out cancelled events and sessions. On events with per-session registration only the sessions
+// the user signed up for are listed.
+func (r *eventRepository) ListUserAgenda(ctx context.Context, userID string, from, to time.Time) ([]*domain.AgendaItem, error) {
+	query := `
+		SELECT e.id, e.name, e.slug, es.id, es.name, es.start_time, es.end_time, e.timezone, e.location_type,
+		       COALESCE(es.location_override, e.location_address),
+		       CASE WHEN e.per_session_registration THEN r.status ELSE ea.status::text END
+		FROM event_attendees ea
+		JOIN events e ON e.id = ea.event_id AND e.deleted_at IS NULL AND e.status <> 'cancelled'
+		JOIN event_sessions es ON es.event_id = e.id AND es.is_cancelled = FALSE
+		LEFT JOIN event_session_registrations r ON r.session_id = es.id AND r.user_id = ea.user_id AND r.status <> 'cancelled'
+		WHERE ea.user_id = $1 AND ea.status IN ('registered', 'pending', 'waitlist', 'attended')
+		  AND es.end_time > $2 AND es.start_time < $3
+		  AND (NOT e.per_session_registration OR r.id IS NOT NULL)
+		ORDER BY es.start_time, es.end_time`
+	rows, err := r.db.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agenda: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*domain.AgendaItem
+	for rows.Next() {
+		var item domain.AgendaItem
+		if err := rows.Scan(
+			&item.EventID, &item.EventName, &item.EventSlug, &item.SessionID, &item.SessionName, &item.StartTime,
+			&item.EndTime, &item.Timezone, &item.LocationType, &item.Location, &item.RegistrationStatus,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan agenda item: %w", err)
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,269 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+const feedbackSurveySelect = `
+	SELECT s.id, s.event_id, s.session_id, s.title, s.description, s.questions, s.per_session,
+	       s.send_delay_minutes, s.is_active, COALESCE(s.created_by::text, ''), s.created_at, s.updated_at,
+	       (SELECT COUNT(*) FROM event_feedback_responses fr WHERE fr.survey_id = s.id)::int
+	FROM event_feedback_surveys s
+`
+
+func scanFeedbackSurvey(scanner pgx.Row, survey *domain.FeedbackSurvey) error {
+	var questions []byte
+	if err := scanner.Scan(
+		&survey.ID, &survey.EventID, &survey.SessionID, &survey.Title, &survey.Description, &questions, &survey.PerSession,
+		&survey.SendDelayMinutes, &survey.IsActive, &survey.CreatedBy, &survey.CreatedAt, &survey.UpdatedAt,
+		&survey.ResponseCount,
+	); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(questions, &survey.Questions); err != nil {
+		return fmt.Errorf("failed to decode survey questions: %w", err)
+	}
+	return nil
+}
+
+// CreateFeedbackSurvey saves a new feedback survey.
+func (r *eventRepository) CreateFeedbackSurvey(ctx context.Context, survey *domain.FeedbackSurvey) error {
+	questions, err := json.Marshal(survey.Questions)
+	if err != nil {
+		return fmt.Errorf("failed to encode survey questions: %w", err)
+	}
+	if err := r.db.QueryRow(ctx, `
+		INSERT INTO event_feedback_surveys (
+			event_id, session_id, title, description, questions, per_session, send_delay_minutes, is_active, created_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`, survey.EventID, survey.SessionID, survey.Title, survey.Description, questions, survey.PerSession,
+		survey.SendDelayMinutes, survey.IsActive, survey.CreatedBy,
+	).Scan(&survey.ID, &survey.CreatedAt, &survey.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create feedback survey: %w", err)
+	}
+	return nil
+}
+
+// UpdateFeedbackSurvey saves the editable fields of a feedback survey.
+func (r *eventRepository) UpdateFeedbackSurvey(ctx context.Context, survey *domain.FeedbackSurvey) error {
+	questions, err := json.Marshal(survey.Questions)
+	if err != nil {
+		return fmt.Errorf("failed to encode survey questions: %w", err)
+	}
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE event_feedback_surveys
+		SET title = $2, description = $3, questions = $4, per_session = $5, send_delay_minutes = $6,
+		    is_active = $7, updated_at = NOW()
+		WHERE id = $1
+	`, survey.ID, survey.Title, survey.Description, questions, survey.PerSession, survey.SendDelayMinutes, survey.IsActive)
+	if err != nil {
+		return fmt.Errorf("failed to update feedback survey: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrFeedbackSurveyNotFound
+	}
+	return nil
+}
+
+// DeleteFeedbackSurvey removes a feedback survey together with its requests and responses.
+func (r *eventRepository) DeleteFeedbackSurvey(ctx context.Context, surveyID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM event_feedback_surveys WHERE id = $1`, surveyID)
+	if err != nil {
+		return fmt.Errorf("failed to delete feedback survey: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrFeedbackSurveyNotFound
+	}
+	return nil
+}
+
+// GetFeedbackSurvey retrieves a feedback survey by its ID.
+func (r *eventRepository) GetFeedbackSurvey(ctx context.Context, surveyID string) (*domain.FeedbackSurvey, error) {
+	var survey domain.FeedbackSurvey
+	if err := scanFeedbackSurvey(r.db.QueryRow(ctx, feedbackSurveySelect+` WHERE s.id = $1`, surveyID), &survey); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrFeedbackSurveyNotFound
+		}
+		return nil, fmt.Errorf("failed to get feedback survey: %w", err)
+	}
+	return &survey, nil
+}
+
+// ListFeedbackSurveys lists the feedback surveys of an event, event surveys first.
+func (r *eventRepository) ListFeedbackSurveys(ctx context.Context, eventID string) ([]*domain.FeedbackSurvey, error) {
+	rows, err := r.db.Query(ctx, feedbackSurveySelect+`
+		LEFT JOIN event_sessions es ON es.id = s.session_id
+		WHERE s.event_id = $1
+		ORDER BY es.start_time NULLS FIRST, s.created_at
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feedback surveys: %w", err)
+	}
+	defer rows.Close()
+
+	var surveys []*domain.FeedbackSurvey
+	for rows.Next() {
+		var survey domain.FeedbackSurvey
+		if err := scanFeedbackSurvey(rows, &survey); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback survey: %w", err)
+		}
+		surveys = append(surveys, &survey)
+	}
+	return surveys, rows.Err()
+}
+
+// HasCheckedIn reports whether the user successfully checked in to the session, or to any session of the
+// event if sessionID is empty.
+func (r *eventRepository) HasCheckedIn(ctx context.Context, eventID, sessionID, userID string) (bool, error) {
+	var checkedIn bool
+	if err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM event_session_checkins esc
+			JOIN event_sessions es ON es.id = esc.session_id
+			WHERE es.event_id = $1 AND esc.user_id = $2 AND esc.status = 'success'
+			  AND ($3 = '' OR es.id::text = $3)
+		)
+	`, eventID, userID, sessionID).Scan(&checkedIn); err != nil {
+		return false, fmt.Errorf("failed to check attendance: %w", err)
+	}
+	return checkedIn, nil
+}
+
+// SubmitFeedbackResponse saves a response. Each user answers a survey once per session, or once per event
+// for event surveys that are not per session.
+func (r *eventRepository) SubmitFeedbackResponse(ctx context.Context, response *domain.FeedbackResponse) error {
+	answers, err := json.Marshal(response.Answers)
+	if err != nil {
+		return fmt.Errorf("failed to encode feedback answers: %w", err)
+	}
+	err = r.db.QueryRow(ctx, `
+		INSERT INTO event_feedback_responses (survey_id, event_id, session_id, user_id, answers)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
+		RETURNING id, submitted_at
+	`, response.SurveyID, response.EventID, response.SessionID, response.UserID, answers).Scan(&response.ID, &response.SubmittedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrFeedbackAlreadySubmitted
+		}
+		return fmt.Errorf("failed to submit feedback: %w", err)
+	}
+	return nil
+}
+
+// feedbackRequestSelect enriches feedback requests ('fr') with their survey, event and session.
+const feedbackRequestSelect = `
+	SELECT fr.id, fr.survey_id, fr.event_id, fr.session_id, fr.user_id, fr.sent_at,
+	       s.title, e.name, es.name, es.start_time
+	FROM %s fr
+	JOIN event_feedback_surveys s ON s.id = fr.survey_id
+	JOIN events e ON e.id = fr.event_id
+	LEFT JOIN event_sessions es ON es.id = fr.session_id
+`
+
+func (r *eventRepository) queryFeedbackRequests(ctx context.Context, query string, args ...interface{}) ([]*domain.FeedbackRequest, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []*domain.FeedbackRequest
+	for rows.Next() {
+		var request domain.FeedbackRequest
+		if err := rows.Scan(
+			&request.ID, &request.SurveyID, &request.EventID, &request.SessionID, &request.UserID, &request.SentAt,
+			&request.SurveyTitle, &request.EventName, &request.SessionName, &request.SessionStartTime,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback request: %w", err)
+		}
+		requests = append(requests, &request)
+	}
+	return requests, rows.Err()
+}
+
+// ListPendingFeedbackRequests lists the feedback requests the user has not answered yet, newest first.
+func (r *eventRepository) ListPendingFeedbackRequests(ctx context.Context, userID string) ([]*domain.FeedbackRequest, error) {
+	requests, err := r.queryFeedbackRequests(ctx, fmt.Sprintf(feedbackRequestSelect, "event_feedback_requests")+`
+		WHERE fr.user_id = $1 AND s.is_active = TRUE AND e.deleted_at IS NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM event_feedback_responses resp
+			WHERE resp.survey_id = fr.survey_id AND resp.user_id = fr.user_id
+			  AND resp.session_id IS NOT DISTINCT FROM fr.session_id
+		  )
+		ORDER BY fr.sent_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending feedback requests: %w", err)
+	}
+	return requests, nil
+}
+
+// ClaimDueFeedbackRequests records up to limit feedback requests that are due and returns them for sending.
+// A request is due send_delay_minutes after the end of a session the attendee checked in to; event surveys
+// answered once per event are due after the event's last session. Sessions that ended more than 30 days
+// before the survey was created are skipped. Each request is created once, so attendees are never asked twice.
+func (r *eventRepository) ClaimDueFeedbackRequests(ctx context.Context, limit int) ([]*domain.FeedbackRequest, error) {
+	requests, err := r.queryFeedbackRequests(ctx, `
+		WITH due AS (
+			-- Session surveys and per-session event surveys: one request per attended session
+			SELECT s.id AS survey_id, s.event_id, es.id AS session_id, esc.user_id,
+			       es.end_time + (s.send_delay_minutes * INTERVAL '1 minute') AS due_at
+			FROM event_feedback_surveys s
+			JOIN event_sessions es ON es.event_id = s.event_id
+			     AND (es.id = s.session_id OR (s.session_id IS NULL AND s.per_session = TRUE))
+			JOIN event_session_checkins esc ON esc.session_id = es.id AND esc.status = 'success'
+			WHERE s.is_active = TRUE AND es.is_cancelled = FALSE
+			  AND es.end_time >= s.created_at - INTERVAL '30 days'
+
+			UNION ALL
+
+			-- Event surveys answered once: one request per attendee after the last session
+			SELECT s.id, s.event_id, NULL::uuid, attended.user_id,
+			       last_session.end_time + (s.send_delay_minutes * INTERVAL '1 minute')
+			FROM event_feedback_surveys s
+			CROSS JOIN LATERAL (
+				SELECT MAX(es.end_time) AS end_time FROM event_sessions es
+				WHERE es.event_id = s.event_id AND es.is_cancelled = FALSE
+			) last_session
+			JOIN LATERAL (
+				SELECT DISTINCT esc.user_id
+				FROM event_session_checkins esc
+				JOIN event_sessions es ON es.id = esc.session_id
+				WHERE es.event_id = s.event_id AND esc.status = 'success'
+			) attended ON TRUE
+			WHERE s.is_active = TRUE AND s.session_id IS NULL AND s.per_session = FALSE
+			  AND last_session.end_time >= s.created_at - INTERVAL '30 days'
+		),
+		inserted AS (
+			INSERT INTO event_feedback_requests (survey_id, event_id, session_id, user_id)
+			SELECT d.survey_id, d.event_id, d.session_id, d.user_id
+			FROM due d
+			JOIN events e ON e.id = d.event_id
+			WHERE d.due_at <= NOW()
+			  AND e.deleted_at IS NULL AND e.status <> 'cancelled'
+			  AND NOT EXISTS (
+				SELECT 1 FROM event_feedback_requests fr
+				WHERE fr.survey_id = d.survey_id AND fr.user_id = d.user_id
+				  AND fr.session_id IS NOT DISTINCT FROM d.session_id
+			  )
+			ORDER BY d.due_at
+			LIMIT $1
+			ON CONFLICT DO NOTHING
+			RETURNING id, survey_id, event_id, session_id, user_id, sent_at
+		)
+	`+fmt.Sprintf(feedbackRequestSelect, "inserted"), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due feedback requests: %w", err)
+	}
+	return requests, nil
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetImportedEventIDs maps previously imported iCalendar UIDs of a community to their event IDs.
+func (r *eventRepository) GetImportedEventIDs(ctx context.Context, communityID string, uids []string) (map[string]string, error) {
+	imported := make(map[string]string)
+	if len(uids) == 0 {
+		return imported, nil
+	}
+
+	query := `
+		SELECT external_uid, event_id
+		FROM event_import_sources
+		WHERE community_id = $1 AND external_uid = ANY($2)
+	`
+	rows, err := r.db.Query(ctx, query, communityID, uids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get imported event IDs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var uid, eventID string
+		if err := rows.Scan(&uid, &eventID); err != nil {
+			return nil, fmt.Errorf("failed to scan imported event ID: %w", err)
+		}
+		imported[uid] = eventID
+	}
+	return imported, nil
+}
+
+// insertEventImportSource records the iCalendar UID an event was created from, within the transaction that
+// creates the event, so a failed import leaves neither behind. If the UID was imported in the meantime it
+// returns domain.ErrAlreadyImported, and the transaction must be rolled back rather than create a duplicate.
+func insertEventImportSource(ctx context.Context, tx pgx.Tx, communityID, eventID, uid string) error {
+	query := `
+		INSERT INTO event_import_sources (community_id, event_id, external_uid)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (community_id, external_uid) DO NOTHING
+	`
+	commandTag, err := tx.Exec(ctx, query, communityID, eventID, uid)
+	if err != nil {
+		return fmt.Errorf("failed to save event import source: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrAlreadyImported
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// invitationMatch matches the invitations "i" of user "u": those linked to the account, and those not linked
+// yet sent to the account's verified email.
+const invitationMatch = `(i.invitee_user_id = u.id OR (i.invitee_user_id IS NULL AND u.is_verified AND i.invitee_email = LOWER(u.email)))`
+
+// invitationSelect enriches invitations ('i') with their invitee, inviter, event and registration.
+const invitationSelect = `
+	SELECT i.id, i.event_id, i.invitee_user_id, i.invitee_email, i.inviter_id, i.message, i.rsvp, i.responded_at,
+	       i.reminders_sent, i.last_reminded_at, i.created_at, i.updated_at,
+	       invitee.name, inviter.name, e.name, e.start_time, ea.status::text
+	FROM %s i
+	JOIN events e ON e.id = i.event_id
+	LEFT JOIN users invitee ON invitee.id = i.invitee_user_id
+	LEFT JOIN users inviter ON inviter.id = i.inviter_id
+	LEFT JOIN event_attendees ea ON ea.event_id = i.event_id AND ea.user_id = i.invitee_user_id
+`
+
+func scanEventInvitation(scanner pgx.Row, invitation *domain.EventInvitation) error {
+	return scanner.Scan(
+		&invitation.ID, &invitation.EventID, &invitation.InviteeUserID, &invitation.InviteeEmail, &invitation.InviterID,
+		&invitation.Message, &invitation.RSVP, &invitation.RespondedAt, &invitation.RemindersSent, &invitation.LastRemindedAt,
+		&invitation.CreatedAt, &invitation.UpdatedAt, &invitation.InviteeName, &invitation.InviterName, &invitation.EventName,
+		&invitation.EventStartTime, &invitation.RegistrationStatus,
+	)
+}
+
+func (r *eventRepository) queryEventInvitations(ctx context.Context, query string, args ...interface{}) ([]*domain.EventInvitation, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	defer rows.Close()
+
+	var invitations []*domain.EventInvitation
+	for rows.Next() {
+		var invitation domain.EventInvitation
+		if err := scanEventInvitation(rows, &invitation); err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+		invitations = append(invitations, &invitation)
+	}
+	return invitations, rows.Err()
+}
+
+// CreateEventInvitations saves invitations and returns those created. Invitations by email are linked right
+// away to the account that verified that email, if there is one. People already invited to the event are left out.
+func (r *eventRepository) CreateEventInvitations(ctx context.Context, invitations []*domain.EventInvitation) ([]*domain.EventInvitation, error) {
+	batch := &pgx.Batch{}
+	for _, invitation := range invitations {
+		batch.Queue(`
+			INSERT INTO event_invitations (event_id, invitee_user_id, invitee_email, inviter_id, message)
+			VALUES ($1, COALESCE($2::uuid, (SELECT id FROM users WHERE LOWER(email) = $3 AND is_verified AND deleted_at IS NULL LIMIT 1)), $3, $4, $5)
+			ON CONFLICT DO NOTHING
+			RETURNING id, invitee_user_id, rsvp, created_at, updated_at
+		`, invitation.EventID, invitation.InviteeUserID, invitation.InviteeEmail, invitation.InviterID, invitation.Message)
+	}
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	var created []*domain.EventInvitation
+	for _, invitation := range invitations {
+		err := results.QueryRow().Scan(&invitation.ID, &invitation.InviteeUserID, &invitation.RSVP, &invitation.CreatedAt, &invitation.UpdatedAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return created, fmt.Errorf("failed to create invitation: %w", err)
+		}
+		created = append(created, invitation)
+	}
+	return created, nil
+}
+
+// ListEventInvitations lists the invitations of an event, optionally only those with an RSVP, by invitee.
+func (r *eventRepository) ListEventInvitations(ctx context.Context, eventID, rsvp string) ([]*domain.EventInvitation, error) {
+	return r.queryEventInvitations(ctx, fmt.Sprintf(invitationSelect, "event_invitations")+`
+		WHERE i.event_id = $1 AND ($2 = '' OR i.rsvp = $2)
+		ORDER BY COALESCE(invitee.name, i.invitee_email), i.created_at
+	`, eventID, rsvp)
+}
+
+// GetEventInvitation retrieves an invitation by its ID.
+func (r *eventRepository) GetEventInvitation(ctx context.Context, invitationID string) (*domain.EventInvitation, error) {
+	var invitation domain.EventInvitation
+	if err := scanEventInvitation(r.db.QueryRow(ctx, fmt.Sprintf(invitationSelect, "event_invitations")+`WHERE i.id = $1`, invitationID), &invitation); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return &invitation, nil
+}
+
+// GetUserInvitation retrieves an invitation sent to a user, by account or by verified email.
+func (r *eventRepository) GetUserInvitation(ctx context.Context, invitationID, userID string) (*domain.EventInvitation, error) {
+	var invitation domain.EventInvitation
+	err := scanEventInvitation(r.db.QueryRow(ctx, fmt.Sprintf(invitationSelect, "event_invitations")+`
+		JOIN users u ON u.id = $2
+		WHERE i.id = $1 AND `+invitationMatch, invitationID, userID), &invitation)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return &invitation, nil
+}
+
+// ListUserInvitations lists the invitations sent to a user, by account or by verified email, to events that
+// were not deleted, optionally only those with an RSVP, newest first.
+func (r *eventRepository) ListUserInvitations(ctx context.Context, userID, rsvp string) ([]*domain.EventInvitation, error) {
+	return r.queryEventInvitations(ctx, fmt.Sprintf(invitationSelect, "event_invitations")+`
+		JOIN users u ON u.id = $1
+		WHERE `+invitationMatch+` AND e.deleted_at IS NULL AND ($2 = '' OR i.rsvp = $2)
+		ORDER BY i.created_at DESC
+	`, userID, rsvp)
+}
+
+// RespondToInvitation records the RSVP of an invitation and links it to the responding user, unless the user
+// already has another invitation to the event.
+func (r *eventRepository) RespondToInvitation(ctx context.Context, invitationID, userID, rsvp string) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE event_invitations i
+		SET rsvp = $2, responded_at = NOW(), updated_at = NOW(),
+		    invitee_user_id = CASE
+		        WHEN i.invitee_user_id IS NULL AND NOT EXISTS (
+		            SELECT 1 FROM event_invitations o WHERE o.event_id = i.event_id AND o.invitee_user_id = $3
+		        ) THEN $3::uuid
+		        ELSE i.invitee_user_id
+		    END
+		WHERE i.id = $1
+	`, invitationID, rsvp, userID)
+	if err != nil {
+		return fmt.Errorf("failed to respond to invitation: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrInvitationNotFound
+	}
+	return nil
+}
+
+// DeleteEventInvitation withdraws an invitation.
+func (r *eventRepository) DeleteEventInvitation(ctx context.Context, invitationID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM event_invitations WHERE id = $1`, invitationID)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrInvitationNotFound
+	}
+	return nil
+}
+
+// ClaimInvitationReminders records a reminder for up to limit pending invitations, of one event or of all
+// events if eventID is empty, and returns them for sending. An invitation is reminded when it was sent, or
+// last reminded, at least interval ago, it has had fewer than maxReminders reminders, and its event accepts
+// registrations and has not started yet.
+func (r *eventRepository) ClaimInvitationReminders(ctx context.Context, eventID string, interval time.Duration, maxReminders, limit int) ([]*domain.EventInvitation, error) {
+	return r.queryEventInvitations(ctx, `
+		WITH claimed AS (
+			UPDATE event_invitations
+			SET reminders_sent = reminders_sent + 1, last_reminded_at = NOW(), updated_at = NOW()
+			WHERE id IN (
+				SELECT i.id
+				FROM event_invitations i
+				JOIN events e ON e.id = i.event_id
+				WHERE i.rsvp = 'pending'
+				  AND ($1 = '' OR i.event_id::text = $1)
+				  AND COALESCE(i.last_reminded_at, i.created_at) <= NOW() - $2::interval
+				  AND i.reminders_sent < $3
+				  AND e.deleted_at IS NULL
+				  AND e.status IN ('published', 'registration_open')
+				  AND (e.start_time IS NULL OR e.start_time > NOW())
+				ORDER BY COALESCE(i.last_reminded_at, i.created_at)
+				LIMIT $4
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		)
+		`+fmt.Sprintf(invitationSelect, "claimed"),
+		eventID, interval, maxReminders, limit)
+}
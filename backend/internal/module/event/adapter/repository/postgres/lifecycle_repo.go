@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// TransitionEventStatus moves an event from transition.FromStatus to transition.ToStatus and records the
+// step in its status history. The event must still be in FromStatus, so concurrent transitions cannot
+// both succeed.
+func (r *eventRepository) TransitionEventStatus(ctx context.Context, transition *domain.EventStatusTransition) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE events
+		SET status = $3::event_status, updated_at = NOW(),
+		    published_at = CASE WHEN status = 'draft' AND $3 <> 'cancelled' THEN NOW() ELSE published_at END
+		WHERE id = $1 AND status = $2::event_status AND deleted_at IS NULL
+	`, transition.EventID, transition.FromStatus, transition.ToStatus)
+	if err != nil {
+		return fmt.Errorf("failed to update event status: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: event is no longer %s", domain.ErrInvalidStatusTransition, transition.FromStatus)
+	}
+
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO event_status_transitions (event_id, from_status, to_status, triggered_by, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`, transition.EventID, transition.FromStatus, transition.ToStatus, transition.TriggeredBy, transition.Reason).Scan(
+		&transition.ID, &transition.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to record event status transition: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListEventStatusTransitions returns the status history of an event, oldest first.
+func (r *eventRepository) ListEventStatusTransitions(ctx context.Context, eventID string) ([]*domain.EventStatusTransition, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, event_id, from_status, to_status, triggered_by, reason, created_at
+		FROM event_status_transitions
+		WHERE event_id = $1
+		ORDER BY created_at
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event status transitions: %w", err)
+	}
+	defer rows.Close()
+
+	var transitions []*domain.EventStatusTransition
+	for rows.Next() {
+		var transition domain.EventStatusTransition
+		if err := rows.Scan(
+			&transition.ID, &transition.EventID, &transition.FromStatus, &transition.ToStatus,
+			&transition.TriggeredBy, &transition.Reason, &transition.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event status transition: %w", err)
+		}
+		transitions = append(transitions, &transition)
+	}
+	return transitions, rows.Err()
+}
+
+// ListEventLifecycles loads the events whose status the lifecycle worker still moves on by itself (or just
+// the given event, if eventID is set) together with the bounds of their sessions that are not cancelled.
+func (r *eventRepository) ListEventLifecycles(ctx context.Context, eventID string) ([]*domain.EventLifecycle, error) {
+	eventFilter := sql.NullString{String: eventID, Valid: eventID != ""}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT
+			e.id, e.community_id, e.created_by, e.name, e.status, e.start_time, e.end_time,
+			e.is_recurring, e.recurrence_rule, e.recurrence_end_date, e.max_occurrences,
+			e.registration_opens_at, e.registration_closes_at,
+			MIN(es.start_time), MAX(es.end_time)
+		FROM events e
+		LEFT JOIN event_sessions es ON es.event_id = e.id AND es.is_cancelled = FALSE
+		WHERE ($1::uuid IS NULL OR e.id = $1::uuid)
+		  AND e.status IN ('published', 'registration_open', 'registration_closed', 'ongoing')
+		  AND e.deleted_at IS NULL
+		GROUP BY e.id
+	`, eventFilter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event lifecycles: %w", err)
+	}
+	defer rows.Close()
+
+	var lifecycles []*domain.EventLifecycle
+	for rows.Next() {
+		lifecycle := domain.EventLifecycle{Event: &domain.Event{}}
+		event := lifecycle.Event
+		if err := rows.Scan(
+			&event.ID, &event.CommunityID, &event.CreatedBy, &event.Name, &event.Status, &event.StartTime, &event.EndTime,
+			&event.IsRecurring, &event.RecurrenceRule, &event.RecurrenceEndDate, &event.MaxOccurrences,
+			&event.RegistrationOpensAt, &event.RegistrationClosesAt,
+			&lifecycle.FirstSessionStart, &lifecycle.LastSessionEnd,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event lifecycle: %w", err)
+		}
+		lifecycles = append(lifecycles, &lifecycle)
+	}
+	return lifecycles, rows.Err()
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// IssueJoinToken stores the token as the attendee's join token unless one was issued already, and returns
+// the attendee's join token.
+func (r *eventRepository) IssueJoinToken(ctx context.Context, attendeeID, token string) (string, error) {
+	var issued string
+	if err := r.db.QueryRow(ctx, `
+		UPDATE event_attendees SET join_token = COALESCE(join_token, $2)
+		WHERE id = $1
+		RETURNING join_token
+	`, attendeeID, token).Scan(&issued); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrAttendeeNotFound
+		}
+		return "", fmt.Errorf("failed to issue join token: %w", err)
+	}
+	return issued, nil
+}
+
+// GetAttendeeByJoinToken finds the attendee a join link belongs to.
+func (r *eventRepository) GetAttendeeByJoinToken(ctx context.Context, token string) (*domain.EventAttendee, error) {
+	query := `
+		SELECT
+			ea.id, ea.event_id, ea.user_id, ea.role, ea.status, ea.registration_form_data,
+			ea.registration_source, ea.payment_status, ea.payment_amount, ea.payment_id,
+			ea.face_sample_provided, ea.face_sample_quality_score, ea.qr_code_token, ea.fallback_code,
+			ea.qr_device_binding, ea.registered_at, ea.approved_at, ea.approved_by, ea.cancelled_at,
+			u.name, u.email, u.profile_picture_url,
+			NULL, NULL, NULL, NULL, NULL, NULL
+		FROM event_attendees ea
+		JOIN users u ON ea.user_id = u.id
+		WHERE ea.join_token = $1
+	`
+	var attendee domain.EventAttendee
+	if err := r.scanEventAttendee(r.db.QueryRow(ctx, query, token), &attendee); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvalidJoinLink
+		}
+		return nil, fmt.Errorf("failed to get attendee by join token: %w", err)
+	}
+	return &attendee, nil
+}
+
+// RecordVirtualCheckin checks the attendee in to the session as having joined its online meeting, unless
+// they were already checked in, and marks them as attended.
+func (r *eventRepository) RecordVirtualCheckin(ctx context.Context, sessionID, userID, attendeeID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for RecordVirtualCheckin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO event_session_checkins (id, user_id, session_id, attendee_id, status, method, checkin_time)
+		VALUES (gen_random_uuid(), $1, $2, $3, 'success', 'virtual', NOW())
+		ON CONFLICT (user_id, session_id) DO UPDATE
+		SET status = 'success', method = 'virtual', checkin_time = NOW(), updated_at = NOW()
+		WHERE event_session_checkins.status <> 'success'
+	`, userID, sessionID, attendeeID); err != nil {
+		return fmt.Errorf("failed to record virtual check-in: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE event_attendees
+		SET status = 'attended'
+		WHERE id = $1
+	`, attendeeID); err != nil {
+		return fmt.Errorf("failed to update event_attendees status: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// updateEventSessionQuery saves the timing and per-occurrence overrides of a session; see
+// updateEventSessionArgs for its arguments.
+const updateEventSessionQuery = `
+	UPDATE event_sessions
+	SET name = $2, start_time = $3, end_time = $4,
+	    location_override = $5, online_meeting_url_override = $6,
+	    original_start_time = COALESCE(original_start_time, $7), is_override = $8,
+	    max_attendees_override = $9
+	WHERE id = $1
+`
+
+func updateEventSessionArgs(session *domain.EventSession) []interface{} {
+	return []interface{}{
+		session.ID, session.Name, session.StartTime, session.EndTime,
+		session.LocationOverride, session.OnlineMeetingURLOverride,
+		originalStartTime(*session).Time, session.IsOverride, session.MaxAttendeesOverride,
+	}
+}
+
+// UpdateEventSession saves the timing and per-occurrence overrides of a single session.
+func (r *eventRepository) UpdateEventSession(ctx context.Context, session *domain.EventSession) error {
+	commandTag, err := r.db.Exec(ctx, updateEventSessionQuery, updateEventSessionArgs(session)...)
+	if err != nil {
+		return fmt.Errorf("failed to update event session: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
+
+// AddEventOccurrence saves the event's recurrence rule, which gained the occurrence, and inserts the
+// occurrence's session numbered after the event's last one, all at once.
+func (r *eventRepository) AddEventOccurrence(ctx context.Context, event *domain.Event, session *domain.EventSession) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE events SET recurrence_rule = $2, updated_at = NOW() WHERE id = $1
+	`, event.ID, event.RecurrenceRule)
+	if err != nil {
+		return fmt.Errorf("failed to update event recurrence: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrEventNotFound
+	}
+
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO event_sessions (id, event_id, session_number, name, start_time, end_time, timezone, original_start_time, is_override)
+		SELECT $1, $2, COALESCE(MAX(session_number), 0) + 1, $3, $4, $5, $6, $7, $8
+		FROM event_sessions WHERE event_id = $2
+		RETURNING session_number
+	`, session.ID, session.EventID, session.Name, session.StartTime, session.EndTime, session.Timezone,
+		originalStartTime(*session), session.IsOverride,
+	).Scan(&session.SessionNumber); err != nil {
+		return fmt.Errorf("failed to create occurrence session: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ShiftEventSeries moves a whole recurring series: the event gets its new start, end and recurrence rule,
+// every session is re-keyed by the shift on the event's wall clock, and upcoming sessions that were not edited individually are
+// moved to their new times and receive the changes of the edit.
+func (r *eventRepository) ShiftEventSeries(ctx context.Context, event *domain.Event, shift, duration time.Duration, edit *domain.OccurrenceEdit) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE events
+		SET start_time = $2, end_time = $3, recurrence_rule = $4, updated_at = NOW()
+		WHERE id = $1
+	`, event.ID, event.StartTime, event.EndTime, event.RecurrenceRule)
+	if err != nil {
+		return fmt.Errorf("failed to update event series: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrEventNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE event_sessions
+		SET original_start_time = ((COALESCE(original_start_time, start_time) AT TIME ZONE $3) + ($2::bigint * INTERVAL '1 microsecond')) AT TIME ZONE $3
+		WHERE event_id = $1
+	`, event.ID, shift.Microseconds(), event.Location().String()); err != nil {
+		return fmt.Errorf("failed to shift session keys: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE event_sessions
+		SET start_time = original_start_time,
+		    end_time = original_start_time + ($2::bigint * INTERVAL '1 microsecond'),
+		    name = COALESCE($3, name),
+		    location_override = COALESCE($4, location_override),
+		    online_meeting_url_override = COALESCE($5, online_meeting_url_override),
+		    max_attendees_override = COALESCE($6, max_attendees_override)
+		WHERE event_id = $1 AND start_time >= NOW() AND is_cancelled = FALSE AND is_override = FALSE
+	`, event.ID, duration.Microseconds(), edit.Name, edit.LocationOverride, edit.OnlineMeetingURLOverride, edit.MaxAttendeesOverride); err != nil {
+		return fmt.Errorf("failed to move upcoming sessions: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+	return nil
+}
+
+// SplitEventSeries ends the recurring event before 'from' and continues it as the new 'series' event.
+// Sessions from that point on move to the new event, and its registrations, whitelist and staff are copied
+// over.
+func (r *eventRepository) SplitEventSeries(ctx context.Context, event *domain.Event, series *domain.Event, from time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. End the original series
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE events
+		SET recurrence_rule = $2, recurrence_end_date = $3, updated_at = NOW()
+		WHERE id = $1
+	`, event.ID, event.RecurrenceRule, event.RecurrenceEndDate)
+	if err != nil {
+		return fmt.Errorf("failed to end event series: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrEventNotFound
+	}
+
+	// 2. Insert the continuation
+	if err := insertEvent(ctx, tx, series, series.CreatedBy); err != nil {
+		return err
+	}
+
+	// 3. Move the following sessions, numbering them from 1 within the new series
+	if _, err := tx.Exec(ctx, `
+		UPDATE event_sessions
+		SET event_id = $2, session_number = -session_number
+		WHERE event_id = $1 AND COALESCE(original_start_time, start_time) >= $3
+	`, event.ID, series.ID, from); err != nil {
+		return fmt.Errorf("failed to move sessions to the new series: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE event_sessions es
+		SET session_number = numbered.session_number
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY COALESCE(original_start_time, start_time)) AS session_number
+			FROM event_sessions
+			WHERE event_id = $1
+		) numbered
+		WHERE es.id = numbered.id
+	`, series.ID); err != nil {
+		return fmt.Errorf("failed to renumber sessions of the new series: %w", err)
+	}
+
+	// 4. Carry over registrations, the whitelist and the staff
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO event_attendees (
+			event_id, user_id, role, status, registration_form_data, registration_source,
+			payment_status, payment_amount, payment_id, face_sample_provided, face_sample_quality_score,
+			registered_at, approved_at, approved_by
+		)
+		SELECT
+			$2, user_id, role, status, registration_form_data, registration_source,
+			payment_status, payment_amount, payment_id, face_sample_provided, face_sample_quality_score,
+			registered_at, approved_at, approved_by
+		FROM event_attendees
+		WHERE event_id = $1 AND status != 'cancelled'
+		ON CONFLICT (event_id, user_id) DO NOTHING
+	`, event.ID, series.ID); err != nil {
+		return fmt.Errorf("failed to copy attendees to the new series: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO event_whitelists (event_id, user_id, email, phone, added_by, notes)
+		SELECT $2, user_id, email, phone, added_by, notes
+		FROM event_whitelists
+		WHERE event_id = $1
+		ON CONFLICT DO NOTHING
+	`, event.ID, series.ID); err != nil {
+		return fmt.Errorf("failed to copy whitelist to the new series: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO event_staff (event_id, user_id, role, assigned_by)
+		SELECT $2, user_id, role, assigned_by
+		FROM event_staff
+		WHERE event_id = $1
+		ON CONFLICT DO NOTHING
+	`, event.ID, series.ID); err != nil {
+		return fmt.Errorf("failed to copy staff to the new series: %w", err)
+	}
+
+	// 5. Re-link the session registrations of the moved sessions to the copied registrations
+	if _, err := tx.Exec(ctx, `
+		UPDATE event_session_registrations r
+		SET event_id = ea.event_id, attendee_id = ea.id
+		FROM event_attendees ea, event_sessions es
+		WHERE es.id = r.session_id AND es.event_id = $1
+		  AND ea.event_id = $1 AND ea.user_id = r.user_id
+	`, series.ID); err != nil {
+		return fmt.Errorf("failed to move session registrations to the new series: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM event_session_registrations r
+		USING event_sessions es
+		WHERE es.id = r.session_id AND es.event_id = $1 AND r.event_id <> $1
+	`, series.ID); err != nil {
+		return fmt.Errorf("failed to drop orphaned session registrations: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+	return nil
+}
@@ -0,0 +1,323 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// sessionPollSelect selects session polls with their total number of votes and the option the user ($1)
+// voted for.
+const sessionPollSelect = `
+	SELECT p.id, p.session_id, p.event_id, p.question, p.status, p.duration_seconds, p.opens_at, p.closes_at,
+	       p.opened_at, p.closed_at, p.created_by,
+	       (SELECT COUNT(*) FROM session_poll_votes v WHERE v.poll_id = p.id),
+	       (SELECT v.option_id FROM session_poll_votes v WHERE v.poll_id = p.id AND v.user_id::text = $1),
+	       p.created_at, p.updated_at
+	FROM session_polls p
+`
+
+func scanSessionPoll(scanner pgx.Row, poll *domain.SessionPoll) error {
+	return scanner.Scan(
+		&poll.ID, &poll.SessionID, &poll.EventID, &poll.Question, &poll.Status, &poll.DurationSeconds,
+		&poll.OpensAt, &poll.ClosesAt, &poll.OpenedAt, &poll.ClosedAt, &poll.CreatedBy, &poll.TotalVotes,
+		&poll.UserOptionID, &poll.CreatedAt, &poll.UpdatedAt,
+	)
+}
+
+// CreateSessionPoll saves a new draft poll of a session together with its options.
+func (r *eventRepository) CreateSessionPoll(ctx context.Context, poll *domain.SessionPoll) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for CreateSessionPoll: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO session_polls (session_id, event_id, question, duration_seconds, opens_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, status, created_at, updated_at
+	`, poll.SessionID, poll.EventID, poll.Question, poll.DurationSeconds, poll.OpensAt, poll.CreatedBy,
+	).Scan(&poll.ID, &poll.Status, &poll.CreatedAt, &poll.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create poll: %w", err)
+	}
+	if err := insertSessionPollOptions(ctx, tx, poll); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// UpdateSessionPoll replaces the question, options and timing of a poll that was not opened yet.
+func (r *eventRepository) UpdateSessionPoll(ctx context.Context, poll *domain.SessionPoll) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for UpdateSessionPoll: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := tx.QueryRow(ctx, `
+		UPDATE session_polls
+		SET question = $2, duration_seconds = $3, opens_at = $4, updated_at = NOW()
+		WHERE id = $1 AND status = 'draft'
+		RETURNING updated_at
+	`, poll.ID, poll.Question, poll.DurationSeconds, poll.OpensAt).Scan(&poll.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrPollNotDraft
+		}
+		return fmt.Errorf("failed to update poll: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM session_poll_options WHERE poll_id = $1`, poll.ID); err != nil {
+		return fmt.Errorf("failed to replace poll options: %w", err)
+	}
+	if err := insertSessionPollOptions(ctx, tx, poll); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func insertSessionPollOptions(ctx context.Context, tx pgx.Tx, poll *domain.SessionPoll) error {
+	for _, option := range poll.Options {
+		option.PollID = poll.ID
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO session_poll_options (poll_id, label, position) VALUES ($1, $2, $3) RETURNING id
+		`, poll.ID, option.Label, option.Position).Scan(&option.ID); err != nil {
+			return fmt.Errorf("failed to create poll option: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteSessionPoll removes a poll that was not opened yet.
+func (r *eventRepository) DeleteSessionPoll(ctx context.Context, pollID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM session_polls WHERE id = $1 AND status = 'draft'`, pollID)
+	if err != nil {
+		return fmt.Errorf("failed to delete poll: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrPollNotDraft
+	}
+	return nil
+}
+
+// GetSessionPoll retrieves a poll with its results by its ID, as seen by the user.
+func (r *eventRepository) GetSessionPoll(ctx context.Context, pollID, userID string) (*domain.SessionPoll, error) {
+	var poll domain.SessionPoll
+	if err := scanSessionPoll(r.db.QueryRow(ctx, sessionPollSelect+`WHERE p.id = $2`, userID, pollID), &poll); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+	if err := r.loadSessionPollOptions(ctx, []*domain.SessionPoll{&poll}); err != nil {
+		return nil, err
+	}
+	return &poll, nil
+}
+
+// ListSessionPolls lists the polls of a session with their results, open polls first, then by creation.
+// Drafts are only listed with includeDrafts.
+func (r *eventRepository) ListSessionPolls(ctx context.Context, sessionID, userID string, includeDrafts bool) ([]*domain.SessionPoll, error) {
+	rows, err := r.db.Query(ctx, sessionPollSelect+`
+		WHERE p.session_id = $2 AND ($3 OR p.status <> 'draft')
+		ORDER BY CASE p.status WHEN 'open' THEN 0 WHEN 'draft' THEN 1 ELSE 2 END, p.created_at`,
+		userID, sessionID, includeDrafts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list polls: %w", err)
+	}
+	defer rows.Close()
+
+	polls := []*domain.SessionPoll{}
+	for rows.Next() {
+		var poll domain.SessionPoll
+		if err := scanSessionPoll(rows, &poll); err != nil {
+			return nil, fmt.Errorf("failed to scan poll: %w", err)
+		}
+		polls = append(polls, &poll)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := r.loadSessionPollOptions(ctx, polls); err != nil {
+		return nil, err
+	}
+	return polls, nil
+}
+
+// loadSessionPollOptions fills in the options of the polls, in order.
+func (r *eventRepository) loadSessionPollOptions(ctx context.Context, polls []*domain.SessionPoll) error {
+	if len(polls) == 0 {
+		return nil
+	}
+	byID := make(map[string]*domain.SessionPoll, len(polls))
+	pollIDs := make([]string, 0, len(polls))
+	for _, poll := range polls {
+		poll.Options = []*domain.SessionPollOption{}
+		byID[poll.ID] = poll
+		pollIDs = append(pollIDs, poll.ID)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, poll_id, label, position, vote_count
+		FROM session_poll_options
+		WHERE poll_id = ANY($1::uuid[])
+		ORDER BY poll_id, position
+	`, pollIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get poll options: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var option domain.SessionPollOption
+		if err := rows.Scan(&option.ID, &option.PollID, &option.Label, &option.Position, &option.VoteCount); err != nil {
+			return fmt.Errorf("failed to scan poll option: %w", err)
+		}
+		if poll, ok := byID[option.PollID]; ok {
+			poll.Options = append(poll.Options, &option)
+		}
+	}
+	return rows.Err()
+}
+
+// OpenSessionPoll opens a draft poll now. Timed polls close their duration after opening.
+func (r *eventRepository) OpenSessionPoll(ctx context.Context, pollID string) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE session_polls
+		SET status = 'open', opened_at = NOW(), opens_at = NULL,
+		    closes_at = CASE WHEN duration_seconds > 0 THEN NOW() + make_interval(secs => duration_seconds) END,
+		    updated_at = NOW()
+		WHERE id = $1 AND status = 'draft'
+	`, pollID)
+	if err != nil {
+		return fmt.Errorf("failed to open poll: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrPollNotDraft
+	}
+	return nil
+}
+
+// CloseSessionPoll closes an open poll now, making its results final.
+func (r *eventRepository) CloseSessionPoll(ctx context.Context, pollID string) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE session_polls
+		SET status = 'closed', closed_at = LEAST(NOW(), COALESCE(closes_at, NOW())), updated_at = NOW()
+		WHERE id = $1 AND status = 'open'
+	`, pollID)
+	if err != nil {
+		return fmt.Errorf("failed to close poll: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrPollNotOpen
+	}
+	return nil
+}
+
+// OpenScheduledSessionPolls opens the draft polls whose opening time has come and returns their IDs.
+// Polls of cancelled sessions are left as drafts.
+func (r *eventRepository) OpenScheduledSessionPolls(ctx context.Context) ([]string, error) {
+	return r.advanceSessionPolls(ctx, `
+		UPDATE session_polls p
+		SET status = 'open', opened_at = NOW(), opens_at = NULL,
+		    closes_at = CASE WHEN p.duration_seconds > 0 THEN NOW() + make_interval(secs => p.duration_seconds) END,
+		    updated_at = NOW()
+		FROM event_sessions es
+		WHERE es.id = p.session_id AND NOT es.is_cancelled
+		  AND p.status = 'draft' AND p.opens_at <= NOW()
+		RETURNING p.id
+	`)
+}
+
+// CloseExpiredSessionPolls closes the open polls whose time has run out and returns their IDs.
+func (r *eventRepository) CloseExpiredSessionPolls(ctx context.Context) ([]string, error) {
+	return r.advanceSessionPolls(ctx, `
+		UPDATE session_polls
+		SET status = 'closed', closed_at = closes_at, updated_at = NOW()
+		WHERE status = 'open' AND closes_at <= NOW()
+		RETURNING id
+	`)
+}
+
+func (r *eventRepository) advanceSessionPolls(ctx context.Context, query string) ([]string, error) {
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to advance polls: %w", err)
+	}
+	defer rows.Close()
+
+	var pollIDs []string
+	for rows.Next() {
+		var pollID string
+		if err := rows.Scan(&pollID); err != nil {
+			return nil, fmt.Errorf("failed to scan poll ID: %w", err)
+		}
+		pollIDs = append(pollIDs, pollID)
+	}
+	return pollIDs, rows.Err()
+}
+
+// VoteSessionPoll records the user's vote for an option of an open poll, replacing any earlier vote, and
+// keeps the options' vote counts in step.
+func (r *eventRepository) VoteSessionPoll(ctx context.Context, pollID, optionID, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for VoteSessionPoll: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the poll so that votes are not recorded after it closed.
+	var status string
+	if err := tx.QueryRow(ctx, `
+		SELECT status FROM session_polls
+		WHERE id = $1 AND (closes_at IS NULL OR closes_at > NOW())
+		FOR UPDATE
+	`, pollID).Scan(&status); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to lock poll: %w", err)
+	}
+	if status != domain.PollStatusOpen {
+		return domain.ErrPollNotOpen
+	}
+
+	var previousOptionID string
+	if err := tx.QueryRow(ctx, `
+		SELECT option_id FROM session_poll_votes WHERE poll_id = $1 AND user_id = $2
+	`, pollID, userID).Scan(&previousOptionID); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get previous vote: %w", err)
+	}
+	if previousOptionID == optionID {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO session_poll_votes (poll_id, user_id, option_id) VALUES ($1, $2, $3)
+		ON CONFLICT (poll_id, user_id) DO UPDATE SET option_id = EXCLUDED.option_id, updated_at = NOW()
+	`, pollID, userID, optionID); err != nil {
+		return fmt.Errorf("failed to record vote: %w", err)
+	}
+	if previousOptionID != "" {
+		if _, err := tx.Exec(ctx, `UPDATE session_poll_options SET vote_count = vote_count - 1 WHERE id = $1`, previousOptionID); err != nil {
+			return fmt.Errorf("failed to update vote count: %w", err)
+		}
+	}
+	if _, err := tx.Exec(ctx, `UPDATE session_poll_options SET vote_count = vote_count + 1 WHERE id = $1`, optionID); err != nil {
+		return fmt.Errorf("failed to update vote count: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// IsCheckedInToSession reports whether the user has successfully checked in to the session, on site or online.
+func (r *eventRepository) IsCheckedInToSession(ctx context.Context, sessionID, userID string) (bool, error) {
+	var checkedIn bool
+	if err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM event_session_checkins
+			WHERE session_id = $1 AND user_id = $2 AND status = 'success'
+		)
+	`, sessionID, userID).Scan(&checkedIn); err != nil {
+		return false, fmt.Errorf("failed to check session check-in: %w", err)
+	}
+	return checkedIn, nil
+}
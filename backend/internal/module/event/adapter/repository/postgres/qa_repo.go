@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// sessionQuestionSelect selects session questions with whether the user ($1) voted for them and asked them.
+const sessionQuestionSelect = `
+	SELECT q.id, q.session_id, q.event_id, q.user_id, u.name, u.profile_picture_url, q.content, q.is_anonymous,
+	       q.status, q.vote_count,
+	       EXISTS (SELECT 1 FROM session_question_votes v WHERE v.question_id = q.id AND v.user_id::text = $1),
+	       q.user_id::text = $1,
+	       q.answered_at, q.moderated_by, q.moderated_at, q.created_at, q.updated_at
+	FROM session_questions q
+	JOIN users u ON u.id = q.user_id
+`
+
+func scanSessionQuestion(scanner pgx.Row, question *domain.SessionQuestion) error {
+	return scanner.Scan(
+		&question.ID, &question.SessionID, &question.EventID, &question.AuthorID, &question.AuthorName,
+		&question.AuthorAvatar, &question.Content, &question.IsAnonymous, &question.Status, &question.VoteCount,
+		&question.UserHasVoted, &question.IsOwn, &question.AnsweredAt, &question.ModeratedBy, &question.ModeratedAt,
+		&question.CreatedAt, &question.UpdatedAt,
+	)
+}
+
+// SetSessionQAEnabled turns the Q&A of a session on or off.
+func (r *eventRepository) SetSessionQAEnabled(ctx context.Context, sessionID string, enabled bool) error {
+	commandTag, err := r.db.Exec(ctx, `UPDATE event_sessions SET qa_enabled = $2, updated_at = NOW() WHERE id = $1`, sessionID, enabled)
+	if err != nil {
+		return fmt.Errorf("failed to update session Q&A: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
+
+// IsSessionQAEnabled reports whether the Q&A of a session is on.
+func (r *eventRepository) IsSessionQAEnabled(ctx context.Context, sessionID string) (bool, error) {
+	var enabled bool
+	if err := r.db.QueryRow(ctx, `SELECT qa_enabled FROM event_sessions WHERE id = $1`, sessionID).Scan(&enabled); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, domain.ErrSessionNotFound
+		}
+		return false, fmt.Errorf("failed to get session Q&A: %w", err)
+	}
+	return enabled, nil
+}
+
+// CreateSessionQuestion saves a new question of a session.
+func (r *eventRepository) CreateSessionQuestion(ctx context.Context, question *domain.SessionQuestion) error {
+	if err := r.db.QueryRow(ctx, `
+		INSERT INTO session_questions (session_id, event_id, user_id, content, is_anonymous)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, created_at, updated_at
+	`, question.SessionID, question.EventID, question.AuthorID, question.Content, question.IsAnonymous,
+	).Scan(&question.ID, &question.Status, &question.CreatedAt, &question.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create question: %w", err)
+	}
+	return nil
+}
+
+// GetSessionQuestion retrieves a question by its ID, as seen by the user.
+func (r *eventRepository) GetSessionQuestion(ctx context.Context, questionID, userID string) (*domain.SessionQuestion, error) {
+	var question domain.SessionQuestion
+	if err := scanSessionQuestion(r.db.QueryRow(ctx, sessionQuestionSelect+`WHERE q.id = $2`, userID, questionID), &question); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	return &question, nil
+}
+
+// ListSessionQuestions lists the questions of a session, most voted or newest first. Answered questions
+// come after open ones; hidden questions are only listed with includeHidden.
+func (r *eventRepository) ListSessionQuestions(ctx context.Context, sessionID, userID, sort string, includeHidden bool) ([]*domain.SessionQuestion, error) {
+	order := `q.vote_count DESC, q.created_at`
+	if sort == domain.QuestionSortRecent {
+		order = `q.created_at DESC`
+	}
+	rows, err := r.db.Query(ctx, sessionQuestionSelect+`
+		WHERE q.session_id = $2 AND ($3 OR q.status <> 'hidden')
+		ORDER BY CASE q.status WHEN 'open' THEN 0 WHEN 'answered' THEN 1 ELSE 2 END, `+order,
+		userID, sessionID, includeHidden)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list questions: %w", err)
+	}
+	defer rows.Close()
+
+	questions := []*domain.SessionQuestion{}
+	for rows.Next() {
+		var question domain.SessionQuestion
+		if err := scanSessionQuestion(rows, &question); err != nil {
+			return nil, fmt.Errorf("failed to scan question: %w", err)
+		}
+		questions = append(questions, &question)
+	}
+	return questions, rows.Err()
+}
+
+// VoteSessionQuestion records the user's upvote of a question. Voting twice counts once.
+func (r *eventRepository) VoteSessionQuestion(ctx context.Context, questionID, userID string) error {
+	if _, err := r.db.Exec(ctx, `
+		WITH vote AS (
+			INSERT INTO session_question_votes (question_id, user_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+			RETURNING question_id
+		)
+		UPDATE session_questions SET vote_count = vote_count + (SELECT COUNT(*) FROM vote) WHERE id = $1
+	`, questionID, userID); err != nil {
+		return fmt.Errorf("failed to vote for question: %w", err)
+	}
+	return nil
+}
+
+// UnvoteSessionQuestion withdraws the user's upvote of a question, if any.
+func (r *eventRepository) UnvoteSessionQuestion(ctx context.Context, questionID, userID string) error {
+	if _, err := r.db.Exec(ctx, `
+		WITH vote AS (
+			DELETE FROM session_question_votes WHERE question_id = $1 AND user_id = $2
+			RETURNING question_id
+		)
+		UPDATE session_questions SET vote_count = vote_count - (SELECT COUNT(*) FROM vote) WHERE id = $1
+	`, questionID, userID); err != nil {
+		return fmt.Errorf("failed to withdraw vote for question: %w", err)
+	}
+	return nil
+}
+
+// UpdateSessionQuestionStatus marks a question open, answered or hidden on behalf of a moderator.
+func (r *eventRepository) UpdateSessionQuestionStatus(ctx context.Context, questionID, status, moderatorID string) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE session_questions
+		SET status = $2::text::session_question_status,
+		    answered_at = CASE WHEN $2::text = 'answered' THEN COALESCE(answered_at, NOW()) WHEN $2::text = 'open' THEN NULL ELSE answered_at END,
+		    moderated_by = $3, moderated_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, questionID, status, moderatorID)
+	if err != nil {
+		return fmt.Errorf("failed to update question: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrQuestionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// desiredRemindersQuery selects the reminders that should currently be scheduled: one per offset of the
+// event's reminder_schedule, for every registered attendee of every upcoming session of a published event.
+// On events with per-session registration only the attendees registered for the session are reminded.
+// $1 restricts it to a single event; NULL selects all events. Unknown timezones fall back to UTC.
+const desiredRemindersQuery = `
+	SELECT
+		e.id AS event_id, es.id AS session_id, ea.user_id,
+		(reminder->>'offset_minutes')::int AS offset_minutes,
+		es.start_time + ((reminder->>'offset_minutes')::int * INTERVAL '1 minute') AS scheduled_for,
+		COALESCE(NULLIF(ARRAY(
+			SELECT channel::notification_channel
+			FROM jsonb_array_elements_text(
+				CASE WHEN jsonb_typeof(reminder->'channels') = 'array' THEN reminder->'channels' ELSE '[]'::jsonb END
+			) channel
+			WHERE channel IN ('email', 'push', 'sms', 'in_app')
+		), '{}'::notification_channel[]), '{in_app}'::notification_channel[]) AS channels,
+		format('Reminder: %s is starting soon!', e.name) AS title,
+		format('Your session for ''%s'' is scheduled to start at %s (%s).',
+			e.name, to_char(es.start_time AT TIME ZONE COALESCE(tz.name, 'UTC'), 'Mon DD, YYYY HH24:MI'), COALESCE(tz.name, 'UTC')) AS message
+	FROM events e
+	JOIN event_sessions es ON es.event_id = e.id
+	JOIN event_attendees ea ON ea.event_id = e.id AND ea.status IN ('registered', 'attended')
+	CROSS JOIN LATERAL jsonb_array_elements(
+		CASE WHEN jsonb_typeof(e.reminder_schedule) = 'array' THEN e.reminder_schedule ELSE '[]'::jsonb END
+	) reminder
+	LEFT JOIN pg_timezone_names tz ON tz.name = e.timezone
+	WHERE ($1::uuid IS NULL OR e.id = $1::uuid)
+	  AND e.status IN ('published', 'registration_open', 'registration_closed', 'ongoing')
+	  AND e.deleted_at IS NULL
+	  AND es.is_cancelled = FALSE
+	  AND (e.per_session_registration = FALSE OR EXISTS (
+		SELECT 1 FROM event_session_registrations sr
+		WHERE sr.session_id = es.id AND sr.user_id = ea.user_id AND sr.status = 'registered'
+	  ))
+	  AND jsonb_typeof(reminder->'offset_minutes') = 'number'
+	  AND es.start_time + ((reminder->>'offset_minutes')::int * INTERVAL '1 minute') > NOW()
+`
+
+// SyncEventReminders brings the pending reminders of an event (or of every event, if eventID is empty) in
+// line with its sessions, attendees and reminder schedule. Missing reminders are created, reminders of moved
+// sessions are rescheduled (and sent again if they already went out), and reminders that no longer apply,
+// e.g. for cancelled sessions or registrations, are cancelled.
+func (r *eventRepository) SyncEventReminders(ctx context.Context, eventID string) error {
+	eventFilter := sql.NullString{String: eventID, Valid: eventID != ""}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		WITH desired AS (`+desiredRemindersQuery+`)
+		UPDATE scheduled_notifications sn
+		SET status = 'cancelled', error_message = 'reminder no longer applies'
+		WHERE sn.status = 'pending'
+		  AND sn.type = 'event_reminder'
+		  AND ($1::uuid IS NULL OR sn.event_id = $1::uuid)
+		  AND NOT EXISTS (
+			SELECT 1 FROM desired d
+			WHERE d.session_id = sn.session_id AND d.user_id = sn.user_id AND d.offset_minutes = sn.offset_minutes
+		  )
+	`, eventFilter); err != nil {
+		return fmt.Errorf("failed to cancel stale reminders: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO scheduled_notifications (
+			user_id, event_id, session_id, offset_minutes, scheduled_for, type, title, message, channels, status
+		)
+		SELECT user_id, event_id, session_id, offset_minutes, scheduled_for, 'event_reminder', title, message, channels, 'pending'
+		FROM (`+desiredRemindersQuery+`) desired
+		ON CONFLICT (session_id, user_id, offset_minutes) DO UPDATE
+		SET event_id = EXCLUDED.event_id, scheduled_for = EXCLUDED.scheduled_for,
+		    title = EXCLUDED.title, message = EXCLUDED.message, channels = EXCLUDED.channels,
+		    status = 'pending', sent_at = NULL, error_message = NULL, claimed_at = NULL
+		WHERE scheduled_notifications.status IN ('pending', 'cancelled')
+		   OR (scheduled_notifications.status IN ('sent', 'failed') AND scheduled_notifications.scheduled_for <> EXCLUDED.scheduled_for)
+	`, eventFilter); err != nil {
+		return fmt.Errorf("failed to schedule reminders: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueReminders marks up to limit due reminders as being sent and returns them. Each reminder is claimed
+// by exactly one dispatcher; reminders whose session has already started are cancelled instead, and reminders
+// left claimed by an interrupted dispatcher are marked failed rather than sent twice.
+func (r *eventRepository) ClaimDueReminders(ctx context.Context, limit int) ([]*domain.ScheduledReminder, error) {
+	if _, err := r.db.Exec(ctx, `
+		UPDATE scheduled_notifications
+		SET status = 'failed', error_message = 'dispatch interrupted'
+		WHERE status = 'processing' AND claimed_at < NOW() - INTERVAL '10 minutes'
+	`); err != nil {
+		return nil, fmt.Errorf("failed to release interrupted reminders: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, `
+		UPDATE scheduled_notifications sn
+		SET status = 'cancelled', error_message = 'session already started'
+		FROM event_sessions es
+		WHERE sn.session_id = es.id
+		  AND sn.status = 'pending'
+		  AND sn.type = 'event_reminder'
+		  AND sn.scheduled_for <= NOW()
+		  AND (es.start_time <= NOW() OR es.is_cancelled = TRUE)
+	`); err != nil {
+		return nil, fmt.Errorf("failed to expire late reminders: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		UPDATE scheduled_notifications
+		SET status = 'processing', claimed_at = NOW()
+		WHERE id IN (
+			SELECT id FROM scheduled_notifications
+			WHERE status = 'pending' AND type = 'event_reminder' AND scheduled_for <= NOW()
+			ORDER BY scheduled_for
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, event_id, session_id, scheduled_for, title, message, channels::text[]
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due reminders: %w", err)
+	}
+	defer rows.Close()
+
+	var reminders []*domain.ScheduledReminder
+	for rows.Next() {
+		var reminder domain.ScheduledReminder
+		if err := rows.Scan(
+			&reminder.ID, &reminder.UserID, &reminder.EventID, &reminder.SessionID, &reminder.ScheduledFor,
+			&reminder.Title, &reminder.Message, &reminder.Channels,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan claimed reminder: %w", err)
+		}
+		reminders = append(reminders, &reminder)
+	}
+	return reminders, rows.Err()
+}
+
+// MarkReminderSent records that a claimed reminder has been delivered.
+func (r *eventRepository) MarkReminderSent(ctx context.Context, reminderID string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE scheduled_notifications
+		SET status = 'sent', sent_at = NOW(), error_message = NULL
+		WHERE id = $1 AND status = 'processing'
+	`, reminderID)
+	if err != nil {
+		return fmt.Errorf("failed to mark reminder as sent: %w", err)
+	}
+	return nil
+}
+
+// MarkReminderFailed records that a claimed reminder could not be delivered.
+func (r *eventRepository) MarkReminderFailed(ctx context.Context, reminderID, reason string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE scheduled_notifications
+		SET status = 'failed', error_message = $2
+		WHERE id = $1 AND status = 'processing'
+	`, reminderID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to mark reminder as failed: %w", err)
+	}
+	return nil
+}
@@ -34,9 +34,9 @@ func (r *eventRepository) scanEvent(scanner pgx.Row, event *domain.Event) error
 	// The 'sessions' field is omitted as it's populated separately
 	return scanner.Scan(
 		&event.ID, &event.CommunityID, &event.CreatedBy, &event.Name, &event.Slug, &event.Description, &event.CoverImageURL,
-		&event.LocationType, &event.LocationAddress, &event.OnlineMeetingURL, &event.Timezone, &event.StartTime, &event.EndTime,
-		&event.IsRecurring, &event.RecurrencePattern, &event.RecurrenceRule, &event.RecurrenceEndDate, &event.MaxOccurrences,
-		&event.MaxAttendees, &event.CurrentAttendees, &event.WaitlistEnabled, &event.MaxWaitlist, &event.RegistrationRequired,
+		&event.LocationType, &event.LocationAddress, &event.OnlineMeetingURL, &event.VenueID, &event.MeetingLinkRevealMinutes, &event.TransferPolicy, &event.TransferDeadlineMinutes, &event.Timezone, &event.StartTime, &event.EndTime,
+		&event.IsRecurring, &event.RecurrencePattern, &event.RecurrenceRule, &event.RecurrenceEndDate, &event.MaxOccurrences, &event.GenerationHorizonDays,
+		&event.MaxAttendees, &event.CurrentAttendees, &event.WaitlistEnabled, &event.MaxWaitlist, &event.PerSessionRegistration, &event.RegistrationRequired,
 		&event.RegistrationOpensAt, &event.RegistrationClosesAt, &event.WhitelistOnly, &event.RequireApproval,
 		&event.FaceVerificationRequired, &event.LivenessCheckRequired, &event.QRCodeEnabled, &event.FallbackCodeEnabled, &event.ManualCheckinAllowed,
 		&event.IsPaid, &event.Fee, &event.Currency, &event.Status, &event.ReminderSchedule,
@@ -51,7 +51,8 @@ func (r *eventRepository) scanEventSession(scanner pgx.Row, session *domain.Even
 		&session.ID, &session.EventID, &session.SessionNumber, &session.Name, &session.StartTime, &session.EndTime, &session.Timezone,
 		&session.LocationOverride, &session.OnlineMeetingURLOverride, &session.CheckinOpensAt, &session.CheckinClosesAt,
 		&session.MaxAttendeesOverride, &session.FaceVerificationRequiredOverride, &session.IsCancelled, &session.CancellationReason,
-		&session.TotalCheckins, &session.TotalNoShows, &session.CreatedAt, &session.UpdatedAt,
+		&session.OriginalStartTime, &session.IsOverride, &session.VenueID, &session.TotalCheckins, &session.TotalNoShows, &session.CreatedAt, &session.UpdatedAt,
+		&session.TotalRegistrations, &session.TotalWaitlisted,
 	)
 }
 
@@ -70,7 +71,7 @@ func (r *eventRepository) scanEventAttendee(scanner pgx.Row, attendee *domain.Ev
 
 // GetEventAttendees retrieves a list of attendees for an event, optionally filtered by session and status.
 func (r *eventRepository) CheckRegistrationEligibility(ctx context.Context, eventID, userID string) error {
-	var communityID string
+	var communityID, status string
 	var registrationRequired, whitelistOnly, requireApproval bool
 	var registrationOpensAt, registrationClosesAt sql.NullTime
 	var maxAttendees sql.NullInt32
@@ -80,6 +81,7 @@ func (r *eventRepository) CheckRegistrationEligibility(ctx context.Context, even
 	query := `
 		SELECT
 			e.community_id,
+			e.status,
 			e.registration_required,
 			e.whitelist_only,
 			e.require_approval,
@@ -88,13 +90,14 @@ func (r *eventRepository) CheckRegistrationEligibility(ctx context.Context, even
 			e.max_attendees,
 			e.current_attendees,
 			EXISTS(SELECT 1 FROM community_members cm WHERE cm.community_id = e.community_id AND cm.user_id = $2 AND cm.status = 'active'),
-			EXISTS(SELECT 1 FROM event_whitelists ew WHERE ew.event_id = e.id AND ew.user_id = $2),
+			EXISTS(SELECT 1 FROM event_whitelists ew JOIN users u ON u.id = $2 WHERE ew.event_id = e.id AND ` + whitelistMatch + `),
 			EXISTS(SELECT 1 FROM event_attendees ea WHERE ea.event_id = e.id AND ea.user_id = $2 AND ea.status = 'registered')
 		FROM events e
 		WHERE e.id = $1
 	`
 	err := r.db.QueryRow(ctx, query, eventID, userID).Scan(
 		&communityID,
+		&status,
 		&registrationRequired,
 		&whitelistOnly,
 		&requireApproval,
@@ -117,6 +120,13 @@ func (r *eventRepository) CheckRegistrationEligibility(ctx context.Context, even
 		return permission_domain.ErrPermissionDenied
 	}
 
+	if status == domain.EventStatusDraft {
+		return fmt.Errorf("event has not been published yet")
+	}
+	if !domain.AcceptsRegistrations(status) {
+		return domain.ErrRegistrationClosed
+	}
+
 	now := time.Now()
 	if registrationOpensAt.Valid && now.Before(registrationOpensAt.Time) {
 		return fmt.Errorf("registration has not opened yet")
@@ -200,32 +210,8 @@ func (r *eventRepository) CreateEvent(ctx context.Context, event *domain.Event,
 	defer tx.Rollback(ctx)
 
 	// 1. Insert the main event
-	eventQuery := `
-		INSERT INTO events (
-			id, community_id, created_by, name, slug, description, cover_image_url,
-			location_type, location_address, online_meeting_url, timezone, start_time, end_time,
-			is_recurring, recurrence_pattern, recurrence_rule, recurrence_end_date, max_occurrences,
-			max_attendees, waitlist_enabled, max_waitlist, registration_required,
-			registration_opens_at, registration_closes_at, whitelist_only, require_approval,
-			face_verification_required, liveness_check_required, qr_code_enabled, fallback_code_enabled, manual_checkin_allowed,
-			is_paid, fee, currency, status, reminder_schedule
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
-			$19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36
-		) RETURNING created_at, updated_at, published_at`
-
-	err = tx.QueryRow(ctx, eventQuery,
-		event.ID, event.CommunityID, hostID, event.Name, event.Slug, event.Description, event.CoverImageURL,
-		event.LocationType, event.LocationAddress, event.OnlineMeetingURL, event.Timezone, event.StartTime, event.EndTime,
-		event.IsRecurring, event.RecurrencePattern, event.RecurrenceRule, event.RecurrenceEndDate, event.MaxOccurrences,
-		event.MaxAttendees, event.WaitlistEnabled, event.MaxWaitlist, event.RegistrationRequired,
-		event.RegistrationOpensAt, event.RegistrationClosesAt, event.WhitelistOnly, event.RequireApproval,
-		event.FaceVerificationRequired, event.LivenessCheckRequired, event.QRCodeEnabled, event.FallbackCodeEnabled, event.ManualCheckinAllowed,
-		event.IsPaid, event.Fee, event.Currency, event.Status, event.ReminderSchedule,
-	).Scan(&event.CreatedAt, &event.UpdatedAt, &event.PublishedAt)
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to insert event: %w", err)
+	if err := insertEvent(ctx, tx, event, hostID); err != nil {
+		return nil, err
 	}
 
 	// 2. Insert event sessions
@@ -234,19 +220,24 @@ func (r *eventRepository) CreateEvent(ctx context.Context, event *domain.Event,
 		for i, s := range sessions {
 			rows[i] = []interface{}{s.ID, event.ID, s.SessionNumber, s.Name, s.StartTime, s.EndTime, s.Timezone,
 				s.LocationOverride, s.OnlineMeetingURLOverride, s.CheckinOpensAt, s.CheckinClosesAt,
-				s.MaxAttendeesOverride, s.FaceVerificationRequiredOverride, s.IsCancelled, s.CancellationReason}
+				s.MaxAttendeesOverride, s.FaceVerificationRequiredOverride, s.IsCancelled, s.CancellationReason,
+				originalStartTime(s), s.IsOverride, s.VenueID}
 		}
 		_, err := tx.CopyFrom(
 			ctx,
 			pgx.Identifier{"event_sessions"},
 			[]string{"id", "event_id", "session_number", "name", "start_time", "end_time", "timezone",
 				"location_override", "online_meeting_url_override", "checkin_opens_at", "checkin_closes_at",
-				"max_attendees_override", "face_verification_required_override", "is_cancelled", "cancellation_reason"},
+				"max_attendees_override", "face_verification_required_override", "is_cancelled", "cancellation_reason",
+				"original_start_time", "is_override", "venue_id"},
 			pgx.CopyFromRows(rows),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to bulk insert event sessions: %w", err)
 		}
+		if err := copySessionProgrammes(ctx, tx, event, sessions, hostID); err != nil {
+			return nil, err
+		}
 	}
 
 	// 3. Insert host into attendees
@@ -274,6 +265,13 @@ func (r *eventRepository) CreateEvent(ctx context.Context, event *domain.Event,
 		}
 	}
 
+	// 5. Record the iCalendar UID of imported events
+	if event.ImportUID != "" {
+		if err := insertEventImportSource(ctx, tx, event.CommunityID, event.ID, event.ImportUID); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -282,6 +280,38 @@ func (r *eventRepository) CreateEvent(ctx context.Context, event *domain.Event,
 	return event, nil
 }
 
+// insertEvent inserts the main row of an event within the given transaction.
+func insertEvent(ctx context.Context, tx pgx.Tx, event *domain.Event, hostID string) error {
+	eventQuery := `
+		INSERT INTO events (
+			id, community_id, created_by, name, slug, description, cover_image_url,
+			location_type, location_address, online_meeting_url, venue_id, meeting_link_reveal_minutes, transfer_policy, transfer_deadline_minutes, timezone, start_time, end_time,
+			is_recurring, recurrence_pattern, recurrence_rule, recurrence_end_date, max_occurrences, generation_horizon_days,
+			max_attendees, waitlist_enabled, max_waitlist, per_session_registration, registration_required,
+			registration_opens_at, registration_closes_at, whitelist_only, require_approval,
+			face_verification_required, liveness_check_required, qr_code_enabled, fallback_code_enabled, manual_checkin_allowed,
+			is_paid, fee, currency, status, reminder_schedule
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
+			$19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39, $40, $41, $42
+		) RETURNING created_at, updated_at, published_at`
+
+	err := tx.QueryRow(ctx, eventQuery,
+		event.ID, event.CommunityID, hostID, event.Name, event.Slug, event.Description, event.CoverImageURL,
+		event.LocationType, event.LocationAddress, event.OnlineMeetingURL, event.VenueID, event.MeetingLinkRevealMinutes, event.TransferPolicy, event.TransferDeadlineMinutes, event.Timezone, event.StartTime, event.EndTime,
+		event.IsRecurring, event.RecurrencePattern, event.RecurrenceRule, event.RecurrenceEndDate, event.MaxOccurrences, event.GenerationHorizonDays,
+		event.MaxAttendees, event.WaitlistEnabled, event.MaxWaitlist, event.PerSessionRegistration, event.RegistrationRequired,
+		event.RegistrationOpensAt, event.RegistrationClosesAt, event.WhitelistOnly, event.RequireApproval,
+		event.FaceVerificationRequired, event.LivenessCheckRequired, event.QRCodeEnabled, event.FallbackCodeEnabled, event.ManualCheckinAllowed,
+		event.IsPaid, event.Fee, event.Currency, event.Status, event.ReminderSchedule,
+	).Scan(&event.CreatedAt, &event.UpdatedAt, &event.PublishedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to insert event: %w", err)
+	}
+	return nil
+}
+
 func (r *eventRepository) GetEventByID(ctx context.Context, id string, userID string) (*domain.Event, error) {
 	// 1. Try to get from cache
 	cacheKey := fmt.Sprintf("event:%s:%s", id, userID) // User-specific key
@@ -305,9 +335,9 @@ func (r *eventRepository) GetEventByID(ctx context.Context, id string, userID st
 	query := `
 		SELECT 
 			e.id, e.community_id, e.created_by, e.name, e.slug, e.description, e.cover_image_url,
-			e.location_type, e.location_address, e.online_meeting_url, e.timezone, e.start_time, e.end_time,
-			e.is_recurring, e.recurrence_pattern, e.recurrence_rule, e.recurrence_end_date, e.max_occurrences,
-			e.max_attendees, e.current_attendees, e.waitlist_enabled, e.max_waitlist, e.registration_required,
+			e.location_type, e.location_address, e.online_meeting_url, e.venue_id, e.meeting_link_reveal_minutes, e.transfer_policy, e.transfer_deadline_minutes, e.timezone, e.start_time, e.end_time,
+			e.is_recurring, e.recurrence_pattern, e.recurrence_rule, e.recurrence_end_date, e.max_occurrences, e.generation_horizon_days,
+			e.max_attendees, e.current_attendees, e.waitlist_enabled, e.max_waitlist, e.per_session_registration, e.registration_required,
 			e.registration_opens_at, e.registration_closes_at, e.whitelist_only, e.require_approval,
 			e.face_verification_required, e.liveness_check_required, e.qr_code_enabled, e.fallback_code_enabled, e.manual_checkin_allowed,
 			e.is_paid, e.fee, e.currency, e.status, e.reminder_schedule,
@@ -356,9 +386,9 @@ func (r *eventRepository) GetEventBySessionID(ctx context.Context, sessionID str
 	query := `
 		SELECT 
 			e.id, e.community_id, e.created_by, e.name, e.slug, e.description, e.cover_image_url,
-			e.location_type, e.location_address, e.online_meeting_url, e.timezone, e.start_time, e.end_time,
-			e.is_recurring, e.recurrence_pattern, e.recurrence_rule, e.recurrence_end_date, e.max_occurrences,
-			e.max_attendees, e.current_attendees, e.waitlist_enabled, e.max_waitlist, e.registration_required,
+			e.location_type, e.location_address, e.online_meeting_url, e.venue_id, e.meeting_link_reveal_minutes, e.transfer_policy, e.transfer_deadline_minutes, e.timezone, e.start_time, e.end_time,
+			e.is_recurring, e.recurrence_pattern, e.recurrence_rule, e.recurrence_end_date, e.max_occurrences, e.generation_horizon_days,
+			e.max_attendees, e.current_attendees, e.waitlist_enabled, e.max_waitlist, e.per_session_registration, e.registration_required,
 			e.registration_opens_at, e.registration_closes_at, e.whitelist_only, e.require_approval,
 			e.face_verification_required, e.liveness_check_required, e.qr_code_enabled, e.fallback_code_enabled, e.manual_checkin_allowed,
 			e.is_paid, e.fee, e.currency, e.status, e.reminder_schedule,
@@ -422,7 +452,7 @@ WITH event_items AS (
     JOIN users u ON e.created_by = u.id
     WHERE e.is_recurring = FALSE
       AND e.deleted_at IS NULL
-      AND e.status = 'published'
+      AND e.status IN ('published', 'registration_open', 'registration_closed', 'ongoing', 'completed')
 
     UNION ALL
 
@@ -451,7 +481,7 @@ WITH event_items AS (
     WHERE e.is_recurring = TRUE
       AND es.is_cancelled = FALSE
       AND e.deleted_at IS NULL
-      AND e.status = 'published'
+      AND e.status IN ('published', 'registration_open', 'registration_closed', 'ongoing', 'completed')
 )
 SELECT
     ei.event_id,
@@ -606,7 +636,7 @@ func (r *eventRepository) RegisterForEvent(ctx context.Context, eventID, userID,
 
 func (r *eventRepository) AddUsersToWhitelist(ctx context.Context, eventID string, userIDs []string, addedBy string) error {
 	// Use ON CONFLICT DO NOTHING to avoid errors for duplicate entries.
-	insertQuery := `INSERT INTO event_whitelists (event_id, user_id, added_by) VALUES ($1, $2, $3) ON CONFLICT (event_id, user_id) DO NOTHING`
+	insertQuery := `INSERT INTO event_whitelists (event_id, user_id, added_by) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
 
 	batch := &pgx.Batch{}
 	for _, userID := range userIDs {
@@ -629,8 +659,9 @@ func (r *eventRepository) AddUsersToWhitelist(ctx context.Context, eventID strin
 	return nil
 }
 
+// IsUserInWhitelist reports whether a user is on an event's whitelist, by account or by verified email.
 func (r *eventRepository) IsUserInWhitelist(ctx context.Context, eventID, userID string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM event_whitelists WHERE event_id = $1 AND user_id = $2)`
+	query := `SELECT EXISTS(SELECT 1 FROM event_whitelists ew JOIN users u ON u.id = $2 WHERE ew.event_id = $1 AND ` + whitelistMatch + `)`
 	var exists bool
 	err := r.db.QueryRow(ctx, query, eventID, userID).Scan(&exists)
 	if err != nil {
@@ -671,35 +702,31 @@ func (r *eventRepository) GetPendingRegistrations(ctx context.Context, eventID s
 	return attendees, nil
 }
 
-func (r *eventRepository) UpdateRegistrationStatus(ctx context.Context, registrationID, status string, approverID sql.NullString) error {
-	query := `
-		UPDATE event_attendees 
-		SET status = $2, approved_at = NOW(), approved_by = $3 
-		WHERE id = $1 AND status = 'pending'`
-	commandTag, err := r.db.Exec(ctx, query, registrationID, status, approverID)
+// CancelRegistration cancels a user's own registration and frees the seats it held.
+func (r *eventRepository) CancelRegistration(ctx context.Context, registrationID, userID string) error {
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return err
-	}
-	if commandTag.RowsAffected() == 0 {
-		return domain.ErrAttendeeNotFound // Or a more specific error like "not in pending state"
+		return fmt.Errorf("failed to begin transaction for CancelRegistration: %w", err)
 	}
-	return nil
-}
+	defer tx.Rollback(ctx)
 
-func (r *eventRepository) CancelRegistration(ctx context.Context, registrationID, userID string) error {
 	// This query should only allow a user to cancel their own registration if it's currently 'registered' or 'pending'.
 	query := `
 		UPDATE event_attendees 
 		SET status = 'cancelled', cancelled_at = NOW() 
-		WHERE id = $1 AND user_id = $2 AND status IN ('registered', 'pending')`
-	commandTag, err := r.db.Exec(ctx, query, registrationID, userID)
-	if err != nil {
+		WHERE id = $1 AND user_id = $2 AND status IN ('registered', 'pending')
+		RETURNING event_id`
+	var eventID string
+	if err := tx.QueryRow(ctx, query, registrationID, userID).Scan(&eventID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrAttendeeNotFound // Or user is not in a cancellable state
+		}
 		return err
 	}
-	if commandTag.RowsAffected() == 0 {
-		return domain.ErrAttendeeNotFound // Or user is not in a cancellable state
+	if err := releaseSeats(ctx, tx, eventID, nil, []string{userID}, userID); err != nil {
+		return err
 	}
-	return nil
+	return tx.Commit(ctx)
 }
 
 func (r *eventRepository) GetRegistrationsByUserID(ctx context.Context, userID string, status string) ([]*domain.RegistrationWithEvent, error) {
@@ -837,6 +864,26 @@ func (r *eventRepository) UpdateEvent(ctx context.Context, event *domain.Event,
 			setClauses = append(setClauses, fmt.Sprintf("online_meeting_url = $%d", argCount))
 			args = append(args, event.OnlineMeetingURL)
 			argCount++
+		case "location_address":
+			setClauses = append(setClauses, fmt.Sprintf("location_address = $%d", argCount))
+			args = append(args, event.LocationAddress)
+			argCount++
+		case "venue_id":
+			setClauses = append(setClauses, fmt.Sprintf("venue_id = $%d", argCount))
+			args = append(args, event.VenueID)
+			argCount++
+		case "meeting_link_reveal_minutes":
+			setClauses = append(setClauses, fmt.Sprintf("meeting_link_reveal_minutes = $%d", argCount))
+			args = append(args, event.MeetingLinkRevealMinutes)
+			argCount++
+		case "transfer_policy":
+			setClauses = append(setClauses, fmt.Sprintf("transfer_policy = $%d", argCount))
+			args = append(args, event.TransferPolicy)
+			argCount++
+		case "transfer_deadline_minutes":
+			setClauses = append(setClauses, fmt.Sprintf("transfer_deadline_minutes = $%d", argCount))
+			args = append(args, event.TransferDeadlineMinutes)
+			argCount++
 		case "start_time":
 			setClauses = append(setClauses, fmt.Sprintf("start_time = $%d", argCount))
 			args = append(args, event.StartTime)
@@ -861,6 +908,18 @@ func (r *eventRepository) UpdateEvent(ctx context.Context, event *domain.Event,
 			setClauses = append(setClauses, fmt.Sprintf("recurrence_rule = $%d", argCount))
 			args = append(args, event.RecurrenceRule)
 			argCount++
+		case "recurrence_end_date":
+			setClauses = append(setClauses, fmt.Sprintf("recurrence_end_date = $%d", argCount))
+			args = append(args, event.RecurrenceEndDate)
+			argCount++
+		case "max_occurrences":
+			setClauses = append(setClauses, fmt.Sprintf("max_occurrences = $%d", argCount))
+			args = append(args, event.MaxOccurrences)
+			argCount++
+		case "generation_horizon_days":
+			setClauses = append(setClauses, fmt.Sprintf("generation_horizon_days = $%d", argCount))
+			args = append(args, event.GenerationHorizonDays)
+			argCount++
 		case "max_attendees":
 			setClauses = append(setClauses, fmt.Sprintf("max_attendees = $%d", argCount))
 			args = append(args, event.MaxAttendees)
@@ -873,6 +932,10 @@ func (r *eventRepository) UpdateEvent(ctx context.Context, event *domain.Event,
 			setClauses = append(setClauses, fmt.Sprintf("max_waitlist = $%d", argCount))
 			args = append(args, event.MaxWaitlist)
 			argCount++
+		case "per_session_registration":
+			setClauses = append(setClauses, fmt.Sprintf("per_session_registration = $%d", argCount))
+			args = append(args, event.PerSessionRegistration)
+			argCount++
 		case "registration_required":
 			setClauses = append(setClauses, fmt.Sprintf("registration_required = $%d", argCount))
 			args = append(args, event.RegistrationRequired)
@@ -1042,55 +1105,15 @@ func (r *eventRepository) GetEventAttendanceSummary(ctx context.Context, eventID
 	return &domain.AttendanceSummary{}, nil
 }
 
-func (r *eventRepository) GetUpcomingSessionsWithAttendees(ctx context.Context, from, to time.Time) ([]*domain.UpcomingSessionInfo, error) {
-	query := `
-		SELECT
-			es.id, es.event_id, e.name, es.start_time,
-			ARRAY_AGG(ea.user_id) FILTER (WHERE ea.user_id IS NOT NULL) AS attendee_ids
-		FROM event_sessions es
-		JOIN events e ON es.event_id = e.id
-		LEFT JOIN event_attendees ea ON es.event_id = ea.event_id AND ea.status = 'registered'
-		WHERE es.start_time BETWEEN $1 AND $2
-		  AND es.is_cancelled = FALSE
-		  AND e.status IN ('published', 'ongoing')
-		  AND e.deleted_at IS NULL
-		GROUP BY es.id, es.event_id, e.name, es.start_time
-		ORDER BY es.start_time ASC
-	`
-
-	rows, err := r.db.Query(ctx, query, from, to)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query upcoming sessions with attendees: %w", err)
-	}
-	defer rows.Close()
-
-	var sessions []*domain.UpcomingSessionInfo
-	for rows.Next() {
-		var session domain.UpcomingSessionInfo
-		var attendeeIDs []string
-		if err := rows.Scan(
-			&session.ID,
-			&session.EventID,
-			&session.EventName,
-			&session.StartTime,
-			&attendeeIDs,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan upcoming session info: %w", err)
-		}
-		session.AttendeeIDs = attendeeIDs
-		sessions = append(sessions, &session)
-	}
-
-	return sessions, nil
-}
-
 func (r *eventRepository) GetEventSessions(ctx context.Context, eventID string) ([]domain.EventSession, error) {
 	query := `
         SELECT
             id, event_id, session_number, name, start_time, end_time, timezone,
             location_override, online_meeting_url_override, checkin_opens_at, checkin_closes_at,
             max_attendees_override, face_verification_required_override, is_cancelled, cancellation_reason,
-            total_checkins, total_no_shows, created_at, updated_at
+            original_start_time, is_override, venue_id, total_checkins, total_no_shows, created_at, updated_at,
+            (SELECT COUNT(*) FROM event_session_registrations r WHERE r.session_id = event_sessions.id AND r.status = 'registered')::int,
+            (SELECT COUNT(*) FROM event_session_registrations r WHERE r.session_id = event_sessions.id AND r.status = 'waitlisted')::int
         FROM event_sessions
         WHERE event_id = $1
         ORDER BY session_number ASC
@@ -1118,7 +1141,9 @@ func (r *eventRepository) GetEventSessionByID(ctx context.Context, sessionID str
             id, event_id, session_number, name, start_time, end_time, timezone,
             location_override, online_meeting_url_override, checkin_opens_at, checkin_closes_at,
             max_attendees_override, face_verification_required_override, is_cancelled, cancellation_reason,
-            total_checkins, total_no_shows, created_at, updated_at
+            original_start_time, is_override, venue_id, total_checkins, total_no_shows, created_at, updated_at,
+            (SELECT COUNT(*) FROM event_session_registrations r WHERE r.session_id = event_sessions.id AND r.status = 'registered')::int,
+            (SELECT COUNT(*) FROM event_session_registrations r WHERE r.session_id = event_sessions.id AND r.status = 'waitlisted')::int
         FROM event_sessions
         WHERE id = $1
     `
@@ -1151,9 +1176,9 @@ func (r *eventRepository) GetActiveRecurringEvents(ctx context.Context) ([]*doma
 	query := `
 		SELECT 
 			e.id, e.community_id, e.created_by, e.name, e.slug, e.description, e.cover_image_url,
-			e.location_type, e.location_address, e.online_meeting_url, e.timezone, e.start_time, e.end_time,
-			e.is_recurring, e.recurrence_pattern, e.recurrence_rule, e.recurrence_end_date, e.max_occurrences,
-			e.max_attendees, e.current_attendees, e.waitlist_enabled, e.max_waitlist, e.registration_required,
+			e.location_type, e.location_address, e.online_meeting_url, e.venue_id, e.meeting_link_reveal_minutes, e.transfer_policy, e.transfer_deadline_minutes, e.timezone, e.start_time, e.end_time,
+			e.is_recurring, e.recurrence_pattern, e.recurrence_rule, e.recurrence_end_date, e.max_occurrences, e.generation_horizon_days,
+			e.max_attendees, e.current_attendees, e.waitlist_enabled, e.max_waitlist, e.per_session_registration, e.registration_required,
 			e.registration_opens_at, e.registration_closes_at, e.whitelist_only, e.require_approval,
 			e.face_verification_required, e.liveness_check_required, e.qr_code_enabled, e.fallback_code_enabled, e.manual_checkin_allowed,
 			e.is_paid, e.fee, e.currency, e.status, e.reminder_schedule,
@@ -1161,7 +1186,7 @@ func (r *eventRepository) GetActiveRecurringEvents(ctx context.Context) ([]*doma
 			'' as community_type, '' as created_by_name, '' as created_by_avatar,
 			FALSE AS is_registered
 		FROM events e
-		WHERE e.is_recurring = TRUE AND e.status NOT IN ('cancelled', 'completed')
+		WHERE e.is_recurring = TRUE AND e.status NOT IN ('cancelled', 'completed', 'archived')
 		  AND e.deleted_at IS NULL
 		  AND (e.recurrence_end_date IS NULL OR e.recurrence_end_date > NOW())
 	`
@@ -1189,12 +1214,13 @@ func (r *eventRepository) CreateSessions(ctx context.Context, sessions []domain.
 	}
 	rows := make([][]interface{}, len(sessions))
 	for i, s := range sessions {
-		rows[i] = []interface{}{s.ID, s.EventID, s.SessionNumber, s.Name, s.StartTime, s.EndTime, s.Timezone}
+		rows[i] = []interface{}{s.ID, s.EventID, s.SessionNumber, s.Name, s.StartTime, s.EndTime, s.Timezone,
+			originalStartTime(s), s.IsOverride}
 	}
 	_, err := r.db.CopyFrom(
 		ctx,
 		pgx.Identifier{"event_sessions"},
-		[]string{"id", "event_id", "session_number", "name", "start_time", "end_time", "timezone"},
+		[]string{"id", "event_id", "session_number", "name", "start_time", "end_time", "timezone", "original_start_time", "is_override"},
 		pgx.CopyFromRows(rows),
 	)
 
@@ -1205,9 +1231,10 @@ func (r *eventRepository) CreateSessions(ctx context.Context, sessions []domain.
 	return nil
 }
 
-// GetSessionStartTimes returns a map of existing start times for an event's sessions.
+// GetSessionStartTimes returns a map of the original (rule-generated) start times of an event's sessions, in UTC.
+// Moved and cancelled sessions keep their original start time, so they are never generated again.
 func (r *eventRepository) GetSessionStartTimes(ctx context.Context, eventID string) (map[time.Time]bool, error) {
-	query := `SELECT start_time FROM event_sessions WHERE event_id = $1`
+	query := `SELECT COALESCE(original_start_time, start_time) FROM event_sessions WHERE event_id = $1`
 	rows, err := r.db.Query(ctx, query, eventID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query session start times: %w", err)
@@ -1220,11 +1247,19 @@ func (r *eventRepository) GetSessionStartTimes(ctx context.Context, eventID stri
 		if err := rows.Scan(&startTime); err != nil {
 			return nil, fmt.Errorf("failed to scan start time: %w", err)
 		}
-		startTimes[startTime] = true
+		startTimes[startTime.UTC().Truncate(time.Second)] = true
 	}
 	return startTimes, nil
 }
 
+// originalStartTime defaults a new session's original start time to its start time.
+func originalStartTime(s domain.EventSession) sql.NullTime {
+	if s.OriginalStartTime.Valid {
+		return s.OriginalStartTime
+	}
+	return sql.NullTime{Time: s.StartTime, Valid: true}
+}
+
 func (r *eventRepository) GetMaxSessionNumber(ctx context.Context, eventID string) (int, error) {
 	query := `SELECT COALESCE(MAX(session_number), 0) FROM event_sessions WHERE event_id = $1`
 	var maxSessionNumber int
@@ -1282,9 +1317,9 @@ func (r *eventRepository) IncrementEventAttendeeCount(ctx context.Context, event
 }
 
 func (r *eventRepository) InvalidateEventCache(ctx context.Context, eventID, userID string) error {
-	// Invalidate specific user-event cache
+	// Invalidate specific user-event cache, along with the anonymous one public landing pages are built from
 	cacheKey := fmt.Sprintf("event:%s:%s", eventID, userID)
-	if err := r.redis.Del(ctx, cacheKey).Err(); err != nil && err != redis.Nil {
+	if err := r.redis.Del(ctx, cacheKey, fmt.Sprintf("event:%s:", eventID)).Err(); err != nil && err != redis.Nil {
 		log.Printf("Warning: failed to invalidate event cache for key %s: %v", cacheKey, err)
 	}
 
@@ -1318,6 +1353,24 @@ func (r *eventRepository) GetEventIDByRegistrationID(ctx context.Context, regist
 	return eventID, nil
 }
 
+// GetEventIDBySlug finds the event with the given slug, along with the name of its community.
+func (r *eventRepository) GetEventIDBySlug(ctx context.Context, slug string) (string, string, error) {
+	query := `
+		SELECT e.id, c.name
+		FROM events e
+		JOIN communities c ON c.id = e.community_id
+		WHERE e.slug = $1 AND e.deleted_at IS NULL AND c.deleted_at IS NULL`
+	var eventID, communityName string
+	err := r.db.QueryRow(ctx, query, slug).Scan(&eventID, &communityName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", domain.ErrEventNotFound
+		}
+		return "", "", fmt.Errorf("failed to get event by slug %s: %w", slug, err)
+	}
+	return eventID, communityName, nil
+}
+
 func (r *eventRepository) GetUpcomingEventsByCommunityIDs(ctx context.Context, communityIDs []string, limit int) ([]*domain.EventItem, error) {
 	query := `
 WITH event_items AS (
@@ -1345,7 +1398,7 @@ WITH event_items AS (
     JOIN users u ON e.created_by = u.id
     WHERE e.is_recurring = FALSE
       AND e.deleted_at IS NULL
-      AND e.status = 'published'
+      AND e.status IN ('published', 'registration_open', 'registration_closed', 'ongoing')
       AND e.community_id = ANY($1)
       AND NOW() < e.end_time -- Only upcoming or ongoing
 
@@ -1377,7 +1430,7 @@ WITH event_items AS (
     WHERE e.is_recurring = TRUE
       AND es.is_cancelled = FALSE
       AND e.deleted_at IS NULL
-      AND e.status = 'published'
+      AND e.status IN ('published', 'registration_open', 'registration_closed', 'ongoing')
       AND e.community_id = ANY($1)
       AND NOW() < es.end_time -- Only upcoming or ongoing
 )
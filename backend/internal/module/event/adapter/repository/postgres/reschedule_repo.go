@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// rescheduleColumns are the columns of a reschedule ('r') read by rescheduleScanTargets.
+const rescheduleColumns = `
+	r.id, r.session_id, r.event_id, r.previous_start_time, r.previous_end_time, r.previous_location,
+	r.previous_online_meeting_url, r.new_start_time, r.new_end_time, r.new_location, r.new_online_meeting_url,
+	r.reason, r.requires_reconfirmation, r.rescheduled_by, r.created_at`
+
+func rescheduleScanTargets(reschedule *domain.SessionReschedule) []interface{} {
+	return []interface{}{
+		&reschedule.ID, &reschedule.SessionID, &reschedule.EventID, &reschedule.PreviousStartTime, &reschedule.PreviousEndTime,
+		&reschedule.PreviousLocation, &reschedule.PreviousOnlineMeetingURL, &reschedule.NewStartTime, &reschedule.NewEndTime,
+		&reschedule.NewLocation, &reschedule.NewOnlineMeetingURL, &reschedule.Reason, &reschedule.RequiresReconfirmation,
+		&reschedule.RescheduledBy, &reschedule.CreatedAt,
+	}
+}
+
+// reconfirmationSelect enriches re-confirmation requests ('rc') with their reschedule, event and session.
+var reconfirmationSelect = `
+	SELECT rc.id, rc.reschedule_id, rc.session_id, rc.user_id, rc.status, rc.responded_at, rc.created_at,
+	       e.id, e.name, es.name, ` + rescheduleColumns + `
+	FROM event_session_reconfirmations rc
+	JOIN event_session_reschedules r ON r.id = rc.reschedule_id
+	JOIN event_sessions es ON es.id = rc.session_id
+	JOIN events e ON e.id = r.event_id
+`
+
+func scanSessionReconfirmation(scanner pgx.Row, reconfirmation *domain.SessionReconfirmation) error {
+	reconfirmation.Reschedule = &domain.SessionReschedule{}
+	targets := []interface{}{
+		&reconfirmation.ID, &reconfirmation.RescheduleID, &reconfirmation.SessionID, &reconfirmation.UserID,
+		&reconfirmation.Status, &reconfirmation.RespondedAt, &reconfirmation.CreatedAt, &reconfirmation.EventID,
+		&reconfirmation.EventName, &reconfirmation.SessionName,
+	}
+	return scanner.Scan(append(targets, rescheduleScanTargets(reconfirmation.Reschedule)...)...)
+}
+
+// ListSessionAttendees lists the users registered or waitlisted for a session: its session registrations on
+// events with per-session registration, and the event's registrations otherwise. Hosts are left out.
+func (r *eventRepository) ListSessionAttendees(ctx context.Context, sessionID string) ([]*domain.SessionAttendee, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT esr.user_id, esr.status = 'registered'
+		FROM event_session_registrations esr
+		JOIN events e ON e.id = esr.event_id
+		WHERE esr.session_id = $1 AND e.per_session_registration AND esr.status IN ('registered', 'waitlisted')
+		UNION ALL
+		SELECT ea.user_id, ea.status IN ('registered', 'attended')
+		FROM event_sessions es
+		JOIN events e ON e.id = es.event_id
+		JOIN event_attendees ea ON ea.event_id = es.event_id
+		WHERE es.id = $1 AND NOT e.per_session_registration
+		  AND ea.role <> 'host' AND ea.status IN ('registered', 'pending', 'waitlist', 'attended', 'no_show')
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session attendees: %w", err)
+	}
+	defer rows.Close()
+
+	var attendees []*domain.SessionAttendee
+	for rows.Next() {
+		var attendee domain.SessionAttendee
+		if err := rows.Scan(&attendee.UserID, &attendee.HoldsSeat); err != nil {
+			return nil, fmt.Errorf("failed to scan session attendee: %w", err)
+		}
+		attendees = append(attendees, &attendee)
+	}
+	return attendees, rows.Err()
+}
+
+// RescheduleSession saves the moved session, records the change, revokes the tickets issued for the session
+// that were not used to check in yet and asks the given users to re-confirm their attendance, all at once.
+// Re-confirmation requests of earlier changes of the session that were not answered yet are withdrawn,
+// since the new request replaces them.
+func (r *eventRepository) RescheduleSession(ctx context.Context, session *domain.EventSession, reschedule *domain.SessionReschedule, reconfirmUserIDs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	commandTag, err := tx.Exec(ctx, updateEventSessionQuery, updateEventSessionArgs(session)...)
+	if err != nil {
+		return fmt.Errorf("failed to update event session: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO event_session_reschedules (
+			session_id, event_id, previous_start_time, previous_end_time, previous_location, previous_online_meeting_url,
+			new_start_time, new_end_time, new_location, new_online_meeting_url, reason, requires_reconfirmation, rescheduled_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, created_at
+	`, reschedule.SessionID, reschedule.EventID, reschedule.PreviousStartTime, reschedule.PreviousEndTime,
+		reschedule.PreviousLocation, reschedule.PreviousOnlineMeetingURL, reschedule.NewStartTime, reschedule.NewEndTime,
+		reschedule.NewLocation, reschedule.NewOnlineMeetingURL, reschedule.Reason, reschedule.RequiresReconfirmation,
+		reschedule.RescheduledBy,
+	).Scan(&reschedule.ID, &reschedule.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create session reschedule: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM event_session_checkins WHERE session_id = $1 AND status = 'pending'
+	`, reschedule.SessionID); err != nil {
+		return fmt.Errorf("failed to invalidate session tickets: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM event_session_reconfirmations WHERE session_id = $1 AND status = 'pending'
+	`, reschedule.SessionID); err != nil {
+		return fmt.Errorf("failed to withdraw earlier re-confirmation requests: %w", err)
+	}
+	if len(reconfirmUserIDs) > 0 {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO event_session_reconfirmations (reschedule_id, session_id, user_id)
+			SELECT $1, $2, user_id FROM UNNEST($3::uuid[]) AS user_id
+			ON CONFLICT DO NOTHING
+		`, reschedule.ID, reschedule.SessionID, reconfirmUserIDs); err != nil {
+			return fmt.Errorf("failed to create re-confirmation requests: %w", err)
+		}
+	}
+	reschedule.Pending = len(reconfirmUserIDs)
+
+	return tx.Commit(ctx)
+}
+
+// ListSessionReschedules lists the changes of a session, newest first, with their re-confirmation counts.
+func (r *eventRepository) ListSessionReschedules(ctx context.Context, sessionID string) ([]*domain.SessionReschedule, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+rescheduleColumns+`,
+		       COUNT(rc.id) FILTER (WHERE rc.status = 'confirmed'),
+		       COUNT(rc.id) FILTER (WHERE rc.status = 'declined'),
+		       COUNT(rc.id) FILTER (WHERE rc.status = 'pending')
+		FROM event_session_reschedules r
+		LEFT JOIN event_session_reconfirmations rc ON rc.reschedule_id = r.id
+		WHERE r.session_id = $1
+		GROUP BY r.id
+		ORDER BY r.created_at DESC
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session reschedules: %w", err)
+	}
+	defer rows.Close()
+
+	var reschedules []*domain.SessionReschedule
+	for rows.Next() {
+		var reschedule domain.SessionReschedule
+		targets := append(rescheduleScanTargets(&reschedule), &reschedule.Confirmed, &reschedule.Declined, &reschedule.Pending)
+		if err := rows.Scan(targets...); err != nil {
+			return nil, fmt.Errorf("failed to scan session reschedule: %w", err)
+		}
+		reschedules = append(reschedules, &reschedule)
+	}
+	return reschedules, rows.Err()
+}
+
+// ListUserReconfirmations lists the re-confirmation requests of a user, optionally only those with a
+// status, newest first.
+func (r *eventRepository) ListUserReconfirmations(ctx context.Context, userID, status string) ([]*domain.SessionReconfirmation, error) {
+	rows, err := r.db.Query(ctx, reconfirmationSelect+`
+		WHERE rc.user_id = $1 AND ($2 = '' OR rc.status = $2) AND e.deleted_at IS NULL
+		ORDER BY rc.created_at DESC
+	`, userID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list re-confirmation requests: %w", err)
+	}
+	defer rows.Close()
+
+	var reconfirmations []*domain.SessionReconfirmation
+	for rows.Next() {
+		var reconfirmation domain.SessionReconfirmation
+		if err := scanSessionReconfirmation(rows, &reconfirmation); err != nil {
+			return nil, fmt.Errorf("failed to scan re-confirmation request: %w", err)
+		}
+		reconfirmations = append(reconfirmations, &reconfirmation)
+	}
+	return reconfirmations, rows.Err()
+}
+
+// GetUserReconfirmation retrieves a re-confirmation request of a user.
+func (r *eventRepository) GetUserReconfirmation(ctx context.Context, reconfirmationID, userID string) (*domain.SessionReconfirmation, error) {
+	var reconfirmation domain.SessionReconfirmation
+	err := scanSessionReconfirmation(r.db.QueryRow(ctx, reconfirmationSelect+`WHERE rc.id = $1 AND rc.user_id = $2`, reconfirmationID, userID), &reconfirmation)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrReconfirmationNotFound
+		}
+		return nil, fmt.Errorf("failed to get re-confirmation request: %w", err)
+	}
+	return &reconfirmation, nil
+}
+
+// AnswerReconfirmation records the answer to a re-confirmation request that is still pending.
+func (r *eventRepository) AnswerReconfirmation(ctx context.Context, reconfirmationID, status string) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE event_session_reconfirmations SET status = $2, responded_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, reconfirmationID, status)
+	if err != nil {
+		return fmt.Errorf("failed to answer re-confirmation request: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrReconfirmationAlreadyAnswered
+	}
+	return nil
+}
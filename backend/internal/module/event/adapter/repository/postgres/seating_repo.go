@@ -0,0 +1,441 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const seatAssignmentSelect = `
+	SELECT a.id, a.session_id, a.event_id, a.user_id, u.name, u.email, a.unit_id, s.name, su.kind, su.label,
+	       a.seat_number, a.method, a.assigned_by, a.created_at, a.updated_at
+	FROM seat_assignments a
+	JOIN users u ON u.id = a.user_id
+	JOIN seating_units su ON su.id = a.unit_id
+	JOIN seating_sections s ON s.id = su.section_id
+`
+
+func scanSeatAssignment(scanner pgx.Row, assignment *domain.SeatAssignment) error {
+	if err := scanner.Scan(
+		&assignment.ID, &assignment.SessionID, &assignment.EventID, &assignment.UserID, &assignment.UserName,
+		&assignment.UserEmail, &assignment.UnitID, &assignment.SectionName, &assignment.UnitKind, &assignment.UnitLabel,
+		&assignment.SeatNumber, &assignment.Method, &assignment.AssignedBy, &assignment.CreatedAt, &assignment.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	assignment.Label = domain.SeatLabel(assignment.SectionName, assignment.UnitKind, assignment.UnitLabel, assignment.SeatNumber)
+	return nil
+}
+
+// SaveSeatingLayout creates the seating layout of a session, replacing the one it had. The replaced layout
+// must not have seats assigned, as its assignments would go with it.
+func (r *eventRepository) SaveSeatingLayout(ctx context.Context, layout *domain.SeatingLayout) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for SaveSeatingLayout: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM seating_layouts WHERE session_id = $1`, layout.SessionID); err != nil {
+		return fmt.Errorf("failed to replace seating layout: %w", err)
+	}
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO seating_layouts (session_id, event_id, name, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`, layout.SessionID, layout.EventID, layout.Name, layout.CreatedBy,
+	).Scan(&layout.ID, &layout.CreatedAt, &layout.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create seating layout: %w", err)
+	}
+
+	for _, section := range layout.Sections {
+		section.LayoutID = layout.ID
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO seating_sections (layout_id, name, position) VALUES ($1, $2, $3) RETURNING id
+		`, layout.ID, section.Name, section.Position).Scan(&section.ID); err != nil {
+			return fmt.Errorf("failed to create seating section: %w", err)
+		}
+		for _, unit := range section.Units {
+			unit.SectionID = section.ID
+			if err := tx.QueryRow(ctx, `
+				INSERT INTO seating_units (section_id, kind, label, capacity, position) VALUES ($1, $2, $3, $4, $5) RETURNING id
+			`, section.ID, unit.Kind, unit.Label, unit.Capacity, unit.Position).Scan(&unit.ID); err != nil {
+				return fmt.Errorf("failed to create seating unit: %w", err)
+			}
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// GetSeatingLayout retrieves the seating layout of a session with its sections, units and capacities.
+func (r *eventRepository) GetSeatingLayout(ctx context.Context, sessionID string) (*domain.SeatingLayout, error) {
+	var layout domain.SeatingLayout
+	if err := r.db.QueryRow(ctx, `
+		SELECT l.id, l.session_id, l.event_id, l.name, l.created_by, l.created_at, l.updated_at,
+		       (SELECT COUNT(*) FROM seat_assignments a WHERE a.session_id = l.session_id)
+		FROM seating_layouts l
+		WHERE l.session_id = $1
+	`, sessionID).Scan(
+		&layout.ID, &layout.SessionID, &layout.EventID, &layout.Name, &layout.CreatedBy, &layout.CreatedAt,
+		&layout.UpdatedAt, &layout.AssignedCount,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSeatingLayoutNotFound
+		}
+		return nil, fmt.Errorf("failed to get seating layout: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT s.id, s.name, s.position, su.id, su.kind, su.label, su.capacity, su.position
+		FROM seating_sections s
+		JOIN seating_units su ON su.section_id = s.id
+		WHERE s.layout_id = $1
+		ORDER BY s.position, su.position
+	`, layout.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seating sections: %w", err)
+	}
+	defer rows.Close()
+
+	layout.Sections = []*domain.SeatingSection{}
+	var section *domain.SeatingSection
+	for rows.Next() {
+		var current domain.SeatingSection
+		var unit domain.SeatingUnit
+		if err := rows.Scan(
+			&current.ID, &current.Name, &current.Position,
+			&unit.ID, &unit.Kind, &unit.Label, &unit.Capacity, &unit.Position,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan seating unit: %w", err)
+		}
+		if section == nil || section.ID != current.ID {
+			current.LayoutID = layout.ID
+			section = &current
+			layout.Sections = append(layout.Sections, section)
+		}
+		unit.SectionID = section.ID
+		section.Units = append(section.Units, &unit)
+		section.Capacity += unit.Capacity
+		layout.Capacity += unit.Capacity
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// DeleteSeatingLayout removes the seating layout of a session.
+func (r *eventRepository) DeleteSeatingLayout(ctx context.Context, sessionID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM seating_layouts WHERE session_id = $1`, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to delete seating layout: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrSeatingLayoutNotFound
+	}
+	return nil
+}
+
+// ListSeatAssignments lists the seats assigned for a session, in layout order.
+func (r *eventRepository) ListSeatAssignments(ctx context.Context, sessionID string) ([]*domain.SeatAssignment, error) {
+	rows, err := r.db.Query(ctx, seatAssignmentSelect+`
+		WHERE a.session_id = $1
+		ORDER BY s.position, su.position, a.seat_number
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seat assignments: %w", err)
+	}
+	defer rows.Close()
+
+	assignments := []*domain.SeatAssignment{}
+	for rows.Next() {
+		var assignment domain.SeatAssignment
+		if err := scanSeatAssignment(rows, &assignment); err != nil {
+			return nil, fmt.Errorf("failed to scan seat assignment: %w", err)
+		}
+		assignments = append(assignments, &assignment)
+	}
+	return assignments, rows.Err()
+}
+
+// GetSeatAssignment retrieves the seat of a user for a session.
+func (r *eventRepository) GetSeatAssignment(ctx context.Context, sessionID, userID string) (*domain.SeatAssignment, error) {
+	var assignment domain.SeatAssignment
+	if err := scanSeatAssignment(r.db.QueryRow(ctx, seatAssignmentSelect+`WHERE a.session_id = $1 AND a.user_id = $2`, sessionID, userID), &assignment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSeatAssignmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get seat assignment: %w", err)
+	}
+	return &assignment, nil
+}
+
+// ListSeatableRegistrants lists the approved registrants of a session, in registration order: those of its
+// event, limited to the session's own registrants on events with per-session registration.
+func (r *eventRepository) ListSeatableRegistrants(ctx context.Context, sessionID string) ([]*domain.SeatBadge, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT ea.user_id, u.name, u.email, ea.role
+		FROM event_sessions es
+		JOIN events e ON e.id = es.event_id
+		JOIN event_attendees ea ON ea.event_id = es.event_id
+		JOIN users u ON u.id = ea.user_id
+		WHERE es.id = $1
+		  AND ea.status IN ('registered', 'attended')
+		  AND (NOT e.per_session_registration OR EXISTS (
+		      SELECT 1 FROM event_session_registrations esr
+		      WHERE esr.session_id = es.id AND esr.user_id = ea.user_id AND esr.status = 'registered'
+		  ))
+		ORDER BY ea.registered_at, ea.id
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list seatable registrants: %w", err)
+	}
+	defer rows.Close()
+
+	registrants := []*domain.SeatBadge{}
+	for rows.Next() {
+		var registrant domain.SeatBadge
+		if err := rows.Scan(&registrant.UserID, &registrant.UserName, &registrant.UserEmail, &registrant.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan seatable registrant: %w", err)
+		}
+		registrants = append(registrants, &registrant)
+	}
+	return registrants, rows.Err()
+}
+
+// SaveSeatAssignments seats users, moving those who already had a seat, and records each change in the
+// audit trail. Users are unseated before anyone is seated, so that members of a group can swap seats.
+func (r *eventRepository) SaveSeatAssignments(ctx context.Context, assignments []*domain.SeatAssignment, changedBy string) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+	sessionID := assignments[0].SessionID
+	userIDs := make([]string, 0, len(assignments))
+	for _, assignment := range assignments {
+		userIDs = append(userIDs, assignment.UserID)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for SaveSeatAssignments: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, seatAssignmentSelect+`
+		WHERE a.session_id = $1 AND a.user_id = ANY($2::uuid[])
+		FOR UPDATE OF a
+	`, sessionID, userIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get previous seat assignments: %w", err)
+	}
+	previous := make(map[string]*domain.SeatAssignment)
+	for rows.Next() {
+		var assignment domain.SeatAssignment
+		if err := scanSeatAssignment(rows, &assignment); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan previous seat assignment: %w", err)
+		}
+		previous[assignment.UserID] = &assignment
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM seat_assignments WHERE session_id = $1 AND user_id = ANY($2::uuid[])
+	`, sessionID, userIDs); err != nil {
+		return fmt.Errorf("failed to release previous seats: %w", err)
+	}
+
+	for _, assignment := range assignments {
+		assignment.AssignedBy.String, assignment.AssignedBy.Valid = changedBy, changedBy != ""
+		if err := tx.QueryRow(ctx, `
+			INSERT INTO seat_assignments (session_id, event_id, user_id, unit_id, seat_number, method, assigned_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, created_at, updated_at
+		`, assignment.SessionID, assignment.EventID, assignment.UserID, assignment.UnitID, assignment.SeatNumber,
+			assignment.Method, assignment.AssignedBy,
+		).Scan(&assignment.ID, &assignment.CreatedAt, &assignment.UpdatedAt); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return fmt.Errorf("%w: %s", domain.ErrSeatTaken, assignment.Label)
+			}
+			return fmt.Errorf("failed to assign seat: %w", err)
+		}
+
+		action, fromSeat := domain.SeatAuditAssigned, ""
+		if old, ok := previous[assignment.UserID]; ok {
+			if old.Label == assignment.Label {
+				continue
+			}
+			action, fromSeat = domain.SeatAuditMoved, old.Label
+		}
+		if err := insertSeatAudit(ctx, tx, sessionID, assignment.UserID, action, fromSeat, assignment.Label, assignment.Method, changedBy); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// DeleteSeatAssignments frees the seats of the given users for a session, or of everyone when userIDs is
+// nil, records each in the audit trail and returns how many seats were freed.
+func (r *eventRepository) DeleteSeatAssignments(ctx context.Context, sessionID string, userIDs []string, method, changedBy string) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction for DeleteSeatAssignments: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, seatAssignmentSelect+`
+		WHERE a.session_id = $1 AND ($2::uuid[] IS NULL OR a.user_id = ANY($2::uuid[]))
+		FOR UPDATE OF a
+	`, sessionID, userIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get seat assignments to release: %w", err)
+	}
+	var released []*domain.SeatAssignment
+	for rows.Next() {
+		var assignment domain.SeatAssignment
+		if err := scanSeatAssignment(rows, &assignment); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan seat assignment: %w", err)
+		}
+		released = append(released, &assignment)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, assignment := range released {
+		if _, err := tx.Exec(ctx, `DELETE FROM seat_assignments WHERE id = $1`, assignment.ID); err != nil {
+			return 0, fmt.Errorf("failed to release seat: %w", err)
+		}
+		if err := insertSeatAudit(ctx, tx, sessionID, assignment.UserID, domain.SeatAuditUnassigned, assignment.Label, "", method, changedBy); err != nil {
+			return 0, err
+		}
+	}
+	return len(released), tx.Commit(ctx)
+}
+
+// releaseSeats frees the seats the given users hold for an event, for the given sessions or for all of them
+// when sessionIDs is nil, and records each in the audit trail. It is used in the transactions that end a
+// registration, so that its seats go back to the layout.
+func releaseSeats(ctx context.Context, tx pgx.Tx, eventID string, sessionIDs, userIDs []string, changedBy string) error {
+	rows, err := tx.Query(ctx, `
+		DELETE FROM seat_assignments a
+		USING seating_units su, seating_sections s
+		WHERE su.id = a.unit_id AND s.id = su.section_id
+		  AND a.event_id = $1 AND a.user_id = ANY($2::uuid[]) AND ($3::uuid[] IS NULL OR a.session_id = ANY($3::uuid[]))
+		RETURNING a.session_id, a.user_id, s.name, su.kind::text, su.label, a.seat_number
+	`, eventID, userIDs, sessionIDs)
+	if err != nil {
+		return fmt.Errorf("failed to release seats: %w", err)
+	}
+	released, err := scanSeatChanges(rows)
+	if err != nil {
+		return err
+	}
+	for _, seat := range released {
+		if err := insertSeatAudit(ctx, tx, seat.SessionID, seat.UserID, domain.SeatAuditUnassigned, seat.Label, "", domain.SeatAssignmentAuto, changedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveSeats hands the seats a user holds for an event over to another user, recording the change for both
+// in the audit trail. Seats the recipient already held are released first.
+func moveSeats(ctx context.Context, tx pgx.Tx, eventID, fromUserID, toUserID, changedBy string) error {
+	if err := releaseSeats(ctx, tx, eventID, nil, []string{toUserID}, changedBy); err != nil {
+		return err
+	}
+	rows, err := tx.Query(ctx, `
+		UPDATE seat_assignments a SET user_id = $3, updated_at = NOW()
+		FROM seating_units su, seating_sections s
+		WHERE su.id = a.unit_id AND s.id = su.section_id AND a.event_id = $1 AND a.user_id = $2
+		RETURNING a.session_id, a.user_id, s.name, su.kind::text, su.label, a.seat_number
+	`, eventID, fromUserID, toUserID)
+	if err != nil {
+		return fmt.Errorf("failed to move seats: %w", err)
+	}
+	moved, err := scanSeatChanges(rows)
+	if err != nil {
+		return err
+	}
+	for _, seat := range moved {
+		if err := insertSeatAudit(ctx, tx, seat.SessionID, fromUserID, domain.SeatAuditUnassigned, seat.Label, "", domain.SeatAssignmentAuto, changedBy); err != nil {
+			return err
+		}
+		if err := insertSeatAudit(ctx, tx, seat.SessionID, toUserID, domain.SeatAuditAssigned, "", seat.Label, domain.SeatAssignmentAuto, changedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanSeatChanges reads the session, user and seat of the rows returned by releaseSeats and moveSeats.
+func scanSeatChanges(rows pgx.Rows) ([]*domain.SeatAssignment, error) {
+	defer rows.Close()
+	var seats []*domain.SeatAssignment
+	for rows.Next() {
+		var seat domain.SeatAssignment
+		if err := rows.Scan(&seat.SessionID, &seat.UserID, &seat.SectionName, &seat.UnitKind, &seat.UnitLabel, &seat.SeatNumber); err != nil {
+			return nil, fmt.Errorf("failed to scan seat: %w", err)
+		}
+		seat.Label = domain.SeatLabel(seat.SectionName, seat.UnitKind, seat.UnitLabel, seat.SeatNumber)
+		seats = append(seats, &seat)
+	}
+	return seats, rows.Err()
+}
+
+func insertSeatAudit(ctx context.Context, tx pgx.Tx, sessionID, userID, action, fromSeat, toSeat, method, changedBy string) error {
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO seat_assignment_audit (session_id, user_id, action, from_seat, to_seat, method, changed_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, NULLIF($7, '')::uuid)
+	`, sessionID, userID, action, fromSeat, toSeat, method, changedBy); err != nil {
+		return fmt.Errorf("failed to record seat change: %w", err)
+	}
+	return nil
+}
+
+// ListSeatAssignmentAudit lists the seat changes of a session, newest first, with the total count.
+func (r *eventRepository) ListSeatAssignmentAudit(ctx context.Context, sessionID string, limit, offset int) ([]*domain.SeatAssignmentAudit, int, error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM seat_assignment_audit WHERE session_id = $1`, sessionID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count seat changes: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT sa.id, sa.session_id, sa.user_id, u.name, sa.action, sa.from_seat, sa.to_seat, sa.method,
+		       sa.changed_by, cb.name, sa.created_at
+		FROM seat_assignment_audit sa
+		JOIN users u ON u.id = sa.user_id
+		LEFT JOIN users cb ON cb.id = sa.changed_by
+		WHERE sa.session_id = $1
+		ORDER BY sa.created_at DESC, sa.id
+		LIMIT $2 OFFSET $3
+	`, sessionID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list seat changes: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.SeatAssignmentAudit{}
+	for rows.Next() {
+		var entry domain.SeatAssignmentAudit
+		if err := rows.Scan(
+			&entry.ID, &entry.SessionID, &entry.UserID, &entry.UserName, &entry.Action, &entry.FromSeat,
+			&entry.ToSeat, &entry.Method, &entry.ChangedBy, &entry.ChangedByName, &entry.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan seat change: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, total, rows.Err()
+}
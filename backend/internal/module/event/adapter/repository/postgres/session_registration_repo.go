@@ -0,0 +1,343 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// sessionRegistrationSelect selects session registrations with their session and user details. Waitlisted
+// registrations get their 1-based position in the waitlist of their session.
+const sessionRegistrationSelect = `
+	SELECT r.id, r.session_id, r.event_id, r.attendee_id, r.user_id, r.status, r.registered_at, r.cancelled_at,
+	       CASE WHEN r.status = 'waitlisted' THEN (
+	           SELECT COUNT(*) FROM event_session_registrations w
+	           WHERE w.session_id = r.session_id AND w.status = 'waitlisted'
+	             AND (w.registered_at, w.id) <= (r.registered_at, r.id)
+	       )::int END AS waitlist_position,
+	       es.name, es.start_time, es.end_time, u.name, u.profile_picture_url
+	FROM event_session_registrations r
+	JOIN event_sessions es ON es.id = r.session_id
+	JOIN users u ON u.id = r.user_id
+`
+
+func (r *eventRepository) scanSessionRegistration(scanner pgx.Row, registration *domain.SessionRegistration) error {
+	return scanner.Scan(
+		&registration.ID, &registration.SessionID, &registration.EventID, &registration.AttendeeID, &registration.UserID,
+		&registration.Status, &registration.RegisteredAt, &registration.CancelledAt, &registration.WaitlistPosition,
+		&registration.SessionName, &registration.SessionStartTime, &registration.SessionEndTime,
+		&registration.UserName, &registration.UserAvatar,
+	)
+}
+
+func (r *eventRepository) querySessionRegistrations(ctx context.Context, query string, args ...interface{}) ([]*domain.SessionRegistration, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session registrations: %w", err)
+	}
+	defer rows.Close()
+
+	var registrations []*domain.SessionRegistration
+	for rows.Next() {
+		var registration domain.SessionRegistration
+		if err := r.scanSessionRegistration(rows, &registration); err != nil {
+			return nil, fmt.Errorf("failed to scan session registration: %w", err)
+		}
+		registrations = append(registrations, &registration)
+	}
+	return registrations, rows.Err()
+}
+
+// RegisterForSession signs an attendee up for a session. The session is locked while its capacity is checked:
+// once max_attendees_override is reached the registration goes on the session's waitlist if the event has
+// waitlists enabled (bounded by the event's max_waitlist), and fails with ErrSessionFull otherwise. It fails
+// with ErrSessionOverlap if the user holds a registration for an overlapping session.
+// The registration's ID, Status and RegisteredAt are filled in.
+func (r *eventRepository) RegisterForSession(ctx context.Context, registration *domain.SessionRegistration) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Sign-ups of the same user are serialized, so two sign-ups for overlapping sessions, of this event or
+	// another, cannot both pass the overlap check. NO KEY UPDATE leaves foreign keys to the user unblocked.
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM users WHERE id = $1 FOR NO KEY UPDATE`, registration.UserID); err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+
+	var (
+		maxAttendees    sql.NullInt32
+		waitlistEnabled bool
+		maxWaitlist     sql.NullInt32
+		start, end      time.Time
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT es.max_attendees_override, e.waitlist_enabled, e.max_waitlist, es.start_time, es.end_time
+		FROM event_sessions es
+		JOIN events e ON es.event_id = e.id
+		WHERE es.id = $1
+		FOR UPDATE OF es
+	`, registration.SessionID).Scan(&maxAttendees, &waitlistEnabled, &maxWaitlist, &start, &end)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to lock event session: %w", err)
+	}
+
+	overlapping, err := r.findOverlappingSessionRegistration(ctx, tx, registration.UserID, registration.SessionID, start, end)
+	if err != nil {
+		return err
+	}
+	if overlapping != nil {
+		name := overlapping.SessionName.String
+		if name == "" {
+			name = overlapping.SessionStartTime.Format(time.RFC3339)
+		}
+		return fmt.Errorf("%w: %s", domain.ErrSessionOverlap, name)
+	}
+
+	var active bool
+	var registered, waitlisted int
+	err = tx.QueryRow(ctx, `
+		SELECT
+			EXISTS(SELECT 1 FROM event_session_registrations WHERE session_id = $1 AND user_id = $2 AND status <> 'cancelled'),
+			COUNT(*) FILTER (WHERE status = 'registered'),
+			COUNT(*) FILTER (WHERE status = 'waitlisted')
+		FROM event_session_registrations
+		WHERE session_id = $1
+	`, registration.SessionID, registration.UserID).Scan(&active, &registered, &waitlisted)
+	if err != nil {
+		return fmt.Errorf("failed to count session registrations: %w", err)
+	}
+	if active {
+		return domain.ErrAlreadyRegistered
+	}
+
+	status := domain.SessionRegistrationRegistered
+	if maxAttendees.Valid && registered >= int(maxAttendees.Int32) {
+		if !waitlistEnabled || (maxWaitlist.Valid && waitlisted >= int(maxWaitlist.Int32)) {
+			return domain.ErrSessionFull
+		}
+		status = domain.SessionRegistrationWaitlisted
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO event_session_registrations (session_id, event_id, attendee_id, user_id, status)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (session_id, user_id) DO UPDATE
+		SET attendee_id = EXCLUDED.attendee_id, status = EXCLUDED.status, registered_at = NOW(), cancelled_at = NULL
+		RETURNING id, status, registered_at
+	`, registration.SessionID, registration.EventID, registration.AttendeeID, registration.UserID, status).Scan(
+		&registration.ID, &registration.Status, &registration.RegisteredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register for session: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CancelSessionRegistrations cancels a user's registration for one session of an event, or for all of the
+// event's sessions if sessionID is empty, frees the seats they held and fills the freed places from the
+// waitlists. It returns the waitlisted registrations that were promoted.
+func (r *eventRepository) CancelSessionRegistrations(ctx context.Context, eventID, userID, sessionID string) ([]*domain.SessionRegistration, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sessionIDs, err := lockEventSessions(ctx, tx, eventID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	commandTag, err := tx.Exec(ctx, `
+		UPDATE event_session_registrations
+		SET status = 'cancelled', cancelled_at = NOW()
+		WHERE event_id = $1 AND user_id = $2 AND session_id = ANY($3::uuid[]) AND status <> 'cancelled'
+	`, eventID, userID, sessionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel session registrations: %w", err)
+	}
+	if sessionID != "" && commandTag.RowsAffected() == 0 {
+		return nil, domain.ErrSessionRegistrationNotFound
+	}
+	if err := releaseSeats(ctx, tx, eventID, sessionIDs, []string{userID}, userID); err != nil {
+		return nil, err
+	}
+
+	promoted, err := promoteSessionWaitlists(ctx, tx, sessionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return promoted, nil
+}
+
+// PromoteSessionWaitlists fills the free seats of an event's upcoming sessions from their waitlists, e.g.
+// after a session's capacity was raised. It returns the registrations that were promoted.
+func (r *eventRepository) PromoteSessionWaitlists(ctx context.Context, eventID string) ([]*domain.SessionRegistration, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	sessionIDs, err := lockEventSessions(ctx, tx, eventID, "")
+	if err != nil {
+		return nil, err
+	}
+	promoted, err := promoteSessionWaitlists(ctx, tx, sessionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return promoted, nil
+}
+
+// lockEventSessions locks the given session of an event, or all of its sessions if sessionID is empty,
+// in a consistent order and returns their IDs.
+func lockEventSessions(ctx context.Context, tx pgx.Tx, eventID, sessionID string) ([]string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id::text FROM event_sessions
+		WHERE event_id = $1 AND ($2 = '' OR id::text = $2)
+		ORDER BY id
+		FOR UPDATE
+	`, eventID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock event sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessionIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan event session ID: %w", err)
+		}
+		sessionIDs = append(sessionIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if sessionID != "" && len(sessionIDs) == 0 {
+		return nil, domain.ErrSessionNotFound
+	}
+	return sessionIDs, nil
+}
+
+// promoteSessionWaitlists moves waitlisted registrations of the given (locked) sessions up, in the order
+// they joined the waitlist, for as long as the sessions have free seats. Cancelled and past sessions are
+// left alone.
+func promoteSessionWaitlists(ctx context.Context, tx pgx.Tx, sessionIDs []string) ([]*domain.SessionRegistration, error) {
+	if len(sessionIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := tx.Query(ctx, `
+		WITH capacity AS (
+			SELECT es.id AS session_id, es.max_attendees_override AS max_attendees,
+			       (SELECT COUNT(*) FROM event_session_registrations r
+			        WHERE r.session_id = es.id AND r.status = 'registered') AS registered
+			FROM event_sessions es
+			WHERE es.id = ANY($1::uuid[]) AND es.is_cancelled = FALSE AND es.start_time > NOW()
+		),
+		ranked AS (
+			SELECT r.id, c.max_attendees, c.registered,
+			       ROW_NUMBER() OVER (PARTITION BY r.session_id ORDER BY r.registered_at, r.id) AS position
+			FROM event_session_registrations r
+			JOIN capacity c ON c.session_id = r.session_id
+			WHERE r.status = 'waitlisted'
+		)
+		UPDATE event_session_registrations r
+		SET status = 'registered'
+		FROM ranked
+		WHERE r.id = ranked.id
+		  AND (ranked.max_attendees IS NULL OR ranked.position <= ranked.max_attendees - ranked.registered)
+		RETURNING r.id, r.session_id, r.event_id, r.attendee_id, r.user_id, r.status, r.registered_at
+	`, sessionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote session waitlists: %w", err)
+	}
+	defer rows.Close()
+
+	var promoted []*domain.SessionRegistration
+	for rows.Next() {
+		var registration domain.SessionRegistration
+		if err := rows.Scan(
+			&registration.ID, &registration.SessionID, &registration.EventID, &registration.AttendeeID,
+			&registration.UserID, &registration.Status, &registration.RegisteredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan promoted session registration: %w", err)
+		}
+		promoted = append(promoted, &registration)
+	}
+	return promoted, rows.Err()
+}
+
+// GetSessionRegistration retrieves a user's registration for a session, whatever its status.
+func (r *eventRepository) GetSessionRegistration(ctx context.Context, sessionID, userID string) (*domain.SessionRegistration, error) {
+	var registration domain.SessionRegistration
+	err := r.scanSessionRegistration(r.db.QueryRow(ctx, sessionRegistrationSelect+`
+		WHERE r.session_id = $1 AND r.user_id = $2
+	`, sessionID, userID), &registration)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSessionRegistrationNotFound
+		}
+		return nil, fmt.Errorf("failed to get session registration: %w", err)
+	}
+	return &registration, nil
+}
+
+// ListSessionRegistrations lists the registrations of a session, optionally only those with the given status.
+// Registered attendees come first, followed by the waitlist in order.
+func (r *eventRepository) ListSessionRegistrations(ctx context.Context, sessionID, status string) ([]*domain.SessionRegistration, error) {
+	return r.querySessionRegistrations(ctx, sessionRegistrationSelect+`
+		WHERE r.session_id = $1 AND ($2 = '' OR r.status = $2)
+		ORDER BY CASE r.status WHEN 'registered' THEN 0 WHEN 'waitlisted' THEN 1 ELSE 2 END, r.registered_at, r.id
+	`, sessionID, status)
+}
+
+// ListUserSessionRegistrations lists the sessions of an event a user is registered or waitlisted for.
+func (r *eventRepository) ListUserSessionRegistrations(ctx context.Context, eventID, userID string) ([]*domain.SessionRegistration, error) {
+	return r.querySessionRegistrations(ctx, sessionRegistrationSelect+`
+		WHERE r.event_id = $1 AND r.user_id = $2 AND r.status <> 'cancelled'
+		ORDER BY es.start_time
+	`, eventID, userID)
+}
+
+// findOverlappingSessionRegistration returns a registration of the user, on any event, for another session
+// that overlaps the given time range, or nil if there is none. Cancelled sessions do not count.
+func (r *eventRepository) findOverlappingSessionRegistration(ctx context.Context, tx pgx.Tx, userID, sessionID string, start, end time.Time) (*domain.SessionRegistration, error) {
+	var registration domain.SessionRegistration
+	err := r.scanSessionRegistration(tx.QueryRow(ctx, sessionRegistrationSelect+`
+		WHERE r.user_id = $1 AND r.session_id <> $2 AND r.status <> 'cancelled'
+		  AND es.is_cancelled = FALSE AND es.start_time < $4 AND es.end_time > $3
+		ORDER BY es.start_time
+		LIMIT 1
+	`, userID, sessionID, start, end), &registration)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to check overlapping session registrations: %w", err)
+	}
+	return &registration, nil
+}
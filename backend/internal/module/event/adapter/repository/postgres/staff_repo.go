@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// UpsertEventStaff assigns a role to a user within an event, replacing the role the user had before.
+func (r *eventRepository) UpsertEventStaff(ctx context.Context, member *domain.EventStaffMember) error {
+	query := `
+		INSERT INTO event_staff (event_id, user_id, role, assigned_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, user_id) DO UPDATE
+		SET role = EXCLUDED.role, assigned_by = EXCLUDED.assigned_by, updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, member.EventID, member.UserID, member.Role, member.AssignedBy).Scan(&member.CreatedAt, &member.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save event staff member: %w", err)
+	}
+	return nil
+}
+
+// RemoveEventStaff removes the role of a user within an event.
+func (r *eventRepository) RemoveEventStaff(ctx context.Context, eventID, userID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM event_staff WHERE event_id = $1 AND user_id = $2`, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove event staff member: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrStaffNotFound
+	}
+	return nil
+}
+
+// ListEventStaff lists the staff of an event, optionally only those holding the given role.
+func (r *eventRepository) ListEventStaff(ctx context.Context, eventID, role string) ([]*domain.EventStaffMember, error) {
+	query := `
+		SELECT s.event_id, s.user_id, s.role, s.assigned_by, s.created_at, s.updated_at,
+		       u.name, u.profile_picture_url
+		FROM event_staff s
+		JOIN users u ON s.user_id = u.id
+		WHERE s.event_id = $1 AND ($2 = '' OR s.role = $2)
+		ORDER BY s.role, u.name
+	`
+	rows, err := r.db.Query(ctx, query, eventID, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event staff: %w", err)
+	}
+	defer rows.Close()
+
+	var staff []*domain.EventStaffMember
+	for rows.Next() {
+		var member domain.EventStaffMember
+		if err := rows.Scan(
+			&member.EventID, &member.UserID, &member.Role, &member.AssignedBy, &member.CreatedAt, &member.UpdatedAt,
+			&member.UserName, &member.UserAvatar,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event staff member: %w", err)
+		}
+		staff = append(staff, &member)
+	}
+	return staff, rows.Err()
+}
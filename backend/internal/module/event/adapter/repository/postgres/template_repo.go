@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+const eventTemplateSelect = `
+	SELECT t.id, t.community_id, t.created_by, t.source_event_id, t.name, t.description, t.is_shared, t.settings,
+	       t.created_at, t.updated_at, COALESCE(u.name, '')
+	FROM event_templates t
+	LEFT JOIN users u ON u.id = t.created_by
+`
+
+func scanEventTemplate(scanner pgx.Row, template *domain.EventTemplate) error {
+	var settings []byte
+	if err := scanner.Scan(
+		&template.ID, &template.CommunityID, &template.CreatedBy, &template.SourceEventID, &template.Name,
+		&template.Description, &template.IsShared, &settings, &template.CreatedAt, &template.UpdatedAt,
+		&template.CreatedByName,
+	); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(settings, &template.Settings); err != nil {
+		return fmt.Errorf("failed to decode template settings: %w", err)
+	}
+	return nil
+}
+
+// CreateEventTemplate saves a new event template.
+func (r *eventRepository) CreateEventTemplate(ctx context.Context, template *domain.EventTemplate) error {
+	settings, err := json.Marshal(template.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to encode template settings: %w", err)
+	}
+	if err := r.db.QueryRow(ctx, `
+		INSERT INTO event_templates (community_id, created_by, source_event_id, name, description, is_shared, settings)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`, template.CommunityID, template.CreatedBy, template.SourceEventID, template.Name, template.Description,
+		template.IsShared, settings,
+	).Scan(&template.ID, &template.CreatedAt, &template.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create event template: %w", err)
+	}
+	return nil
+}
+
+// UpdateEventTemplate saves the name, description and sharing of a template.
+func (r *eventRepository) UpdateEventTemplate(ctx context.Context, template *domain.EventTemplate) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE event_templates SET name = $2, description = $3, is_shared = $4, updated_at = NOW()
+		WHERE id = $1
+	`, template.ID, template.Name, template.Description, template.IsShared)
+	if err != nil {
+		return fmt.Errorf("failed to update event template: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrEventTemplateNotFound
+	}
+	return nil
+}
+
+// DeleteEventTemplate removes a template. Events created from it are kept.
+func (r *eventRepository) DeleteEventTemplate(ctx context.Context, templateID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM event_templates WHERE id = $1`, templateID)
+	if err != nil {
+		return fmt.Errorf("failed to delete event template: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrEventTemplateNotFound
+	}
+	return nil
+}
+
+// GetEventTemplate retrieves a template by its ID.
+func (r *eventRepository) GetEventTemplate(ctx context.Context, templateID string) (*domain.EventTemplate, error) {
+	var template domain.EventTemplate
+	if err := scanEventTemplate(r.db.QueryRow(ctx, eventTemplateSelect+`WHERE t.id = $1`, templateID), &template); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrEventTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get event template: %w", err)
+	}
+	return &template, nil
+}
+
+// ListEventTemplates lists the templates of a community the user created, and the shared ones too if
+// includeShared is set, by name.
+func (r *eventRepository) ListEventTemplates(ctx context.Context, communityID, userID string, includeShared bool) ([]*domain.EventTemplate, error) {
+	rows, err := r.db.Query(ctx, eventTemplateSelect+`
+		WHERE t.community_id = $1 AND (t.created_by = $2 OR ($3 AND t.is_shared))
+		ORDER BY t.name, t.created_at
+	`, communityID, userID, includeShared)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*domain.EventTemplate
+	for rows.Next() {
+		var template domain.EventTemplate
+		if err := scanEventTemplate(rows, &template); err != nil {
+			return nil, fmt.Errorf("failed to scan event template: %w", err)
+		}
+		templates = append(templates, &template)
+	}
+	return templates, rows.Err()
+}
+
+// copySessionProgrammes gives the sessions of a duplicated event, within the transaction that creates them,
+// the agenda of the sessions they are copies of, with its tracks and speakers, and their session surveys.
+// Agenda items move by as much as their session did. Sessions that are not copies are left alone.
+func copySessionProgrammes(ctx context.Context, tx pgx.Tx, event *domain.Event, sessions []domain.EventSession, createdBy string) error {
+	var sourceIDs, sessionIDs []string
+	for _, session := range sessions {
+		if session.CopiedFromSessionID == "" {
+			continue
+		}
+		sourceIDs = append(sourceIDs, session.CopiedFromSessionID)
+		sessionIDs = append(sessionIDs, session.ID)
+	}
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+
+	// Tracks belong to the event; those of the source events are copied by name.
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO event_tracks (event_id, name, description, color, position)
+		SELECT DISTINCT ON (t.name) $1::uuid, t.name, t.description, t.color, t.position
+		FROM event_tracks t
+		WHERE t.event_id IN (SELECT event_id FROM event_sessions WHERE id = ANY($2::uuid[]))
+		ORDER BY t.name, t.position
+		ON CONFLICT (event_id, name) DO NOTHING
+	`, event.ID, sourceIDs); err != nil {
+		return fmt.Errorf("failed to copy event tracks: %w", err)
+	}
+
+	copies := `
+		WITH copies AS (
+			SELECT c.source_id, c.session_id, s.start_time - src.start_time AS shift
+			FROM unnest($1::uuid[], $2::uuid[]) AS c(source_id, session_id)
+			JOIN event_sessions src ON src.id = c.source_id
+			JOIN event_sessions s ON s.id = c.session_id
+		)
+	`
+	if _, err := tx.Exec(ctx, copies+`,
+		items AS (
+			SELECT gen_random_uuid() AS new_id, a.id, c.session_id, a.track_id, a.title, a.abstract, a.room,
+			       a.start_time + c.shift AS start_time, a.end_time + c.shift AS end_time
+			FROM session_agenda_items a
+			JOIN copies c ON c.source_id = a.session_id
+		),
+		inserted AS (
+			INSERT INTO session_agenda_items (id, session_id, event_id, track_id, title, abstract, room, start_time, end_time, created_by)
+			SELECT i.new_id, i.session_id, $3, nt.id, i.title, i.abstract, i.room, i.start_time, i.end_time, $4
+			FROM items i
+			LEFT JOIN event_tracks ot ON ot.id = i.track_id
+			LEFT JOIN event_tracks nt ON nt.event_id = $3 AND nt.name = ot.name
+		)
+		INSERT INTO session_agenda_item_speakers (agenda_item_id, user_id, position)
+		SELECT i.new_id, sp.user_id, sp.position
+		FROM items i
+		JOIN session_agenda_item_speakers sp ON sp.agenda_item_id = i.id
+	`, sourceIDs, sessionIDs, event.ID, createdBy); err != nil {
+		return fmt.Errorf("failed to copy session agenda items: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, copies+`
+		INSERT INTO event_feedback_surveys (event_id, session_id, title, description, questions, per_session, send_delay_minutes, is_active, created_by)
+		SELECT $3, c.session_id, f.title, f.description, f.questions, f.per_session, f.send_delay_minutes, f.is_active, $4
+		FROM event_feedback_surveys f
+		JOIN copies c ON c.source_id = f.session_id
+	`, sourceIDs, sessionIDs, event.ID, createdBy); err != nil {
+		return fmt.Errorf("failed to copy session feedback surveys: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// transferRecipientMatch matches the transfers "t" offered to user "u": those linked to the account, and
+// those not linked yet offered to the account's verified email. Whoever signs up first with an unverified
+// email must not be able to take someone else's registration.
+const transferRecipientMatch = `(t.to_user_id = u.id OR (t.to_user_id IS NULL AND u.is_verified AND t.to_email = LOWER(u.email)))`
+
+// transferSelect enriches registration transfers ('t') with the names of both parties and the event.
+const transferSelect = `
+	SELECT t.id, t.event_id, t.attendee_id, t.from_user_id, t.to_user_id, t.to_email, t.message, t.status,
+	       t.requires_approval, t.approved_by, t.approved_at, t.accepted_at, t.completed_at, t.created_at, t.updated_at,
+	       sender.name, recipient.name, e.name, e.start_time
+	FROM registration_transfers t
+	JOIN events e ON e.id = t.event_id
+	JOIN users sender ON sender.id = t.from_user_id
+	LEFT JOIN users recipient ON recipient.id = t.to_user_id
+`
+
+func scanRegistrationTransfer(scanner pgx.Row, transfer *domain.RegistrationTransfer) error {
+	return scanner.Scan(
+		&transfer.ID, &transfer.EventID, &transfer.AttendeeID, &transfer.FromUserID, &transfer.ToUserID, &transfer.ToEmail,
+		&transfer.Message, &transfer.Status, &transfer.RequiresApproval, &transfer.ApprovedBy, &transfer.ApprovedAt,
+		&transfer.AcceptedAt, &transfer.CompletedAt, &transfer.CreatedAt, &transfer.UpdatedAt,
+		&transfer.FromUserName, &transfer.ToUserName, &transfer.EventName, &transfer.EventStartTime,
+	)
+}
+
+func (r *eventRepository) queryRegistrationTransfers(ctx context.Context, query string, args ...interface{}) ([]*domain.RegistrationTransfer, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registration transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*domain.RegistrationTransfer
+	for rows.Next() {
+		var transfer domain.RegistrationTransfer
+		if err := scanRegistrationTransfer(rows, &transfer); err != nil {
+			return nil, fmt.Errorf("failed to scan registration transfer: %w", err)
+		}
+		transfers = append(transfers, &transfer)
+	}
+	return transfers, rows.Err()
+}
+
+// CreateRegistrationTransfer saves a pending transfer. A transfer to an email is linked right away to the
+// account that verified that email, if there is one.
+func (r *eventRepository) CreateRegistrationTransfer(ctx context.Context, transfer *domain.RegistrationTransfer) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO registration_transfers (event_id, attendee_id, from_user_id, to_user_id, to_email, message, requires_approval)
+		VALUES ($1, $2, $3, COALESCE($4::uuid, (SELECT id FROM users WHERE LOWER(email) = $5 AND is_verified AND deleted_at IS NULL LIMIT 1)), $5, $6, $7)
+		RETURNING id, to_user_id, status, created_at, updated_at
+	`, transfer.EventID, transfer.AttendeeID, transfer.FromUserID, transfer.ToUserID, transfer.ToEmail, transfer.Message, transfer.RequiresApproval,
+	).Scan(&transfer.ID, &transfer.ToUserID, &transfer.Status, &transfer.CreatedAt, &transfer.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ErrTransferPending
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%w: the recipient does not exist", domain.ErrInvalidTransfer)
+		}
+		return fmt.Errorf("failed to create registration transfer: %w", err)
+	}
+	return nil
+}
+
+// GetRegistrationTransfer retrieves a registration transfer by its ID.
+func (r *eventRepository) GetRegistrationTransfer(ctx context.Context, transferID string) (*domain.RegistrationTransfer, error) {
+	var transfer domain.RegistrationTransfer
+	if err := scanRegistrationTransfer(r.db.QueryRow(ctx, transferSelect+`WHERE t.id = $1`, transferID), &transfer); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get registration transfer: %w", err)
+	}
+	return &transfer, nil
+}
+
+// GetRecipientRegistrationTransfer retrieves a transfer offered to a user, by account or by verified email.
+func (r *eventRepository) GetRecipientRegistrationTransfer(ctx context.Context, transferID, userID string) (*domain.RegistrationTransfer, error) {
+	var transfer domain.RegistrationTransfer
+	err := scanRegistrationTransfer(r.db.QueryRow(ctx, transferSelect+`
+		JOIN users u ON u.id = $2
+		WHERE t.id = $1 AND `+transferRecipientMatch, transferID, userID), &transfer)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get registration transfer: %w", err)
+	}
+	return &transfer, nil
+}
+
+// ListEventRegistrationTransfers lists the transfers of an event, optionally only those with a status, newest
+// first.
+func (r *eventRepository) ListEventRegistrationTransfers(ctx context.Context, eventID, status string) ([]*domain.RegistrationTransfer, error) {
+	return r.queryRegistrationTransfers(ctx, transferSelect+`
+		WHERE t.event_id = $1 AND ($2 = '' OR t.status = $2)
+		ORDER BY t.created_at DESC
+	`, eventID, status)
+}
+
+// ListUserRegistrationTransfers lists the transfers a user requested or was offered, by account or by
+// verified email, optionally only those with a status, newest first.
+func (r *eventRepository) ListUserRegistrationTransfers(ctx context.Context, userID, status string) ([]*domain.RegistrationTransfer, error) {
+	return r.queryRegistrationTransfers(ctx, transferSelect+`
+		JOIN users u ON u.id = $1
+		WHERE (t.from_user_id = u.id OR `+transferRecipientMatch+`) AND e.deleted_at IS NULL AND ($2 = '' OR t.status = $2)
+		ORDER BY t.created_at DESC
+	`, userID, status)
+}
+
+// AcceptRegistrationTransfer records the recipient's acceptance of a pending transfer, linking it to their
+// account.
+func (r *eventRepository) AcceptRegistrationTransfer(ctx context.Context, transferID, userID string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE registration_transfers
+		SET to_user_id = $2, accepted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, transferID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to accept registration transfer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTransferNotPending
+	}
+	return nil
+}
+
+// ApproveRegistrationTransfer records a host's approval of a pending transfer.
+func (r *eventRepository) ApproveRegistrationTransfer(ctx context.Context, transferID, approverID string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE registration_transfers
+		SET approved_by = $2, approved_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, transferID, approverID)
+	if err != nil {
+		return fmt.Errorf("failed to approve registration transfer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTransferNotPending
+	}
+	return nil
+}
+
+// CloseRegistrationTransfer ends a pending transfer without completing it: declined, rejected or cancelled.
+func (r *eventRepository) CloseRegistrationTransfer(ctx context.Context, transferID, status string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE registration_transfers SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, transferID, status)
+	if err != nil {
+		return fmt.Errorf("failed to close registration transfer: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTransferNotPending
+	}
+	return nil
+}
+
+// CompleteRegistrationTransfer hands the registration over to the recipient. The registration keeps its
+// payment and form data, while its ticket codes, join link and face sample, which belonged to the sender,
+// are cleared, and tickets the sender was issued and did not use are revoked. The sender's session
+// registrations, pending re-confirmations and assigned seats move along. A cancelled registration of the
+// recipient is replaced.
+func (r *eventRepository) CompleteRegistrationTransfer(ctx context.Context, transferID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for CompleteRegistrationTransfer: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var eventID, attendeeID, fromUserID, toUserID string
+	err = tx.QueryRow(ctx, `
+		SELECT event_id, attendee_id, from_user_id, to_user_id
+		FROM registration_transfers
+		WHERE id = $1 AND status = 'pending' AND to_user_id IS NOT NULL
+		FOR UPDATE
+	`, transferID).Scan(&eventID, &attendeeID, &fromUserID, &toUserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrTransferNotPending
+		}
+		return fmt.Errorf("failed to lock registration transfer: %w", err)
+	}
+
+	var recipientStatus *string
+	if err := tx.QueryRow(ctx, `
+		SELECT status::text FROM event_attendees WHERE event_id = $1 AND user_id = $2
+	`, eventID, toUserID).Scan(&recipientStatus); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to check the recipient's registration: %w", err)
+	}
+	if recipientStatus != nil {
+		if *recipientStatus != "cancelled" {
+			return domain.ErrTransferRecipientActive
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM event_attendees WHERE event_id = $1 AND user_id = $2`, eventID, toUserID); err != nil {
+			return fmt.Errorf("failed to remove the recipient's cancelled registration: %w", err)
+		}
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE event_attendees
+		SET user_id = $3, registration_source = 'transfer',
+		    qr_code_token = NULL, fallback_code = NULL, qr_device_binding = NULL, join_token = NULL,
+		    face_sample_provided = FALSE, face_sample_quality_score = NULL
+		WHERE id = $1 AND user_id = $2 AND status = 'registered'
+	`, attendeeID, fromUserID, toUserID)
+	if err != nil {
+		return fmt.Errorf("failed to transfer registration: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotRegistered
+	}
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM event_session_checkins WHERE attendee_id = $1 AND status = 'pending'
+	`, attendeeID); err != nil {
+		return fmt.Errorf("failed to revoke the sender's tickets: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE event_session_registrations SET user_id = $2 WHERE attendee_id = $1
+	`, attendeeID, toUserID); err != nil {
+		return fmt.Errorf("failed to transfer session registrations: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE event_session_reconfirmations rc SET user_id = $3
+		FROM event_sessions s
+		WHERE s.id = rc.session_id AND s.event_id = $1 AND rc.user_id = $2 AND rc.status = 'pending'
+		  AND NOT EXISTS (SELECT 1 FROM event_session_reconfirmations o WHERE o.reschedule_id = rc.reschedule_id AND o.user_id = $3)
+	`, eventID, fromUserID, toUserID); err != nil {
+		return fmt.Errorf("failed to transfer re-confirmation requests: %w", err)
+	}
+	if err := moveSeats(ctx, tx, eventID, fromUserID, toUserID, ""); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE registration_transfers SET status = 'completed', completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, transferID); err != nil {
+		return fmt.Errorf("failed to complete registration transfer: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+const venueSelect = `
+	SELECT id, community_id, name, address, latitude, longitude, capacity, accessibility_notes, created_by,
+	       created_at, updated_at
+	FROM venues
+`
+
+func scanVenue(scanner pgx.Row, venue *domain.Venue) error {
+	return scanner.Scan(
+		&venue.ID, &venue.CommunityID, &venue.Name, &venue.Address, &venue.Latitude, &venue.Longitude, &venue.Capacity,
+		&venue.AccessibilityNotes, &venue.CreatedBy, &venue.CreatedAt, &venue.UpdatedAt,
+	)
+}
+
+// CreateVenue saves a new venue.
+func (r *eventRepository) CreateVenue(ctx context.Context, venue *domain.Venue) error {
+	if err := r.db.QueryRow(ctx, `
+		INSERT INTO venues (community_id, name, address, latitude, longitude, capacity, accessibility_notes, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`, venue.CommunityID, venue.Name, venue.Address, venue.Latitude, venue.Longitude, venue.Capacity,
+		venue.AccessibilityNotes, venue.CreatedBy,
+	).Scan(&venue.ID, &venue.CreatedAt, &venue.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to create venue: %w", err)
+	}
+	return nil
+}
+
+// UpdateVenue saves the details of a venue.
+func (r *eventRepository) UpdateVenue(ctx context.Context, venue *domain.Venue) error {
+	if err := r.db.QueryRow(ctx, `
+		UPDATE venues
+		SET name = $2, address = $3, latitude = $4, longitude = $5, capacity = $6, accessibility_notes = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`, venue.ID, venue.Name, venue.Address, venue.Latitude, venue.Longitude, venue.Capacity, venue.AccessibilityNotes,
+	).Scan(&venue.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrVenueNotFound
+		}
+		return fmt.Errorf("failed to update venue: %w", err)
+	}
+	return nil
+}
+
+// DeleteVenue removes a venue. Events and sessions held there keep their location text.
+func (r *eventRepository) DeleteVenue(ctx context.Context, venueID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM venues WHERE id = $1`, venueID)
+	if err != nil {
+		return fmt.Errorf("failed to delete venue: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrVenueNotFound
+	}
+	return nil
+}
+
+// GetVenue retrieves a venue by its ID.
+func (r *eventRepository) GetVenue(ctx context.Context, venueID string) (*domain.Venue, error) {
+	var venue domain.Venue
+	if err := scanVenue(r.db.QueryRow(ctx, venueSelect+`WHERE id = $1`, venueID), &venue); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrVenueNotFound
+		}
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+	return &venue, nil
+}
+
+// ListVenues lists the venues of a community by name.
+func (r *eventRepository) ListVenues(ctx context.Context, communityID string) ([]*domain.Venue, error) {
+	rows, err := r.db.Query(ctx, venueSelect+`WHERE community_id = $1 ORDER BY name`, communityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list venues: %w", err)
+	}
+	defer rows.Close()
+
+	var venues []*domain.Venue
+	for rows.Next() {
+		var venue domain.Venue
+		if err := scanVenue(rows, &venue); err != nil {
+			return nil, fmt.Errorf("failed to scan venue: %w", err)
+		}
+		venues = append(venues, &venue)
+	}
+	return venues, rows.Err()
+}
+
+// SetSessionVenue moves a session to a venue, or back to the event's if venueID is not set, along with the
+// session's location text.
+func (r *eventRepository) SetSessionVenue(ctx context.Context, sessionID string, venueID, location sql.NullString) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE event_sessions SET venue_id = $2, location_override = $3, updated_at = NOW()
+		WHERE id = $1
+	`, sessionID, venueID, location)
+	if err != nil {
+		return fmt.Errorf("failed to set session venue: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
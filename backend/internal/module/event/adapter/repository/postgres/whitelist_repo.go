@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// whitelistMatch matches the whitelist entries "ew" of user "u": those linked to the account, and those
+// not linked yet whose email is the account's verified email. Phones and unverified emails are typed in by
+// the users themselves, so entries matching only those wait for a host to confirm the link.
+const whitelistMatch = `(ew.user_id = u.id OR (ew.user_id IS NULL AND u.is_verified AND ew.email = LOWER(u.email)))`
+
+// whitelistCandidate matches the accounts "u" that have the email or phone of the unlinked whitelist entry
+// "ew" without it being verified, which a host may link to the entry.
+const whitelistCandidate = `(ew.user_id IS NULL AND (ew.email = LOWER(u.email) OR ew.phone = regexp_replace(u.phone, '[^0-9+]', '', 'g')))`
+
+// AddWhitelistEntries adds entries to the whitelists of events and returns how many were added. Entries whose
+// user, email or phone is already on the event's whitelist are left out.
+func (r *eventRepository) AddWhitelistEntries(ctx context.Context, entries []*domain.WhitelistEntry) (int, error) {
+	batch := &pgx.Batch{}
+	for _, entry := range entries {
+		batch.Queue(`
+			INSERT INTO event_whitelists (event_id, user_id, email, phone, added_by, notes)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT DO NOTHING
+		`, entry.EventID, entry.UserID, entry.Email, entry.Phone, entry.AddedBy, entry.Notes)
+	}
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	added := 0
+	for range entries {
+		commandTag, err := results.Exec()
+		if err != nil {
+			return added, fmt.Errorf("failed to add whitelist entry: %w", err)
+		}
+		added += int(commandTag.RowsAffected())
+	}
+	return added, nil
+}
+
+// ListWhitelistEntries lists the whitelist of an event, newest first, with the names of linked users and,
+// for entries not linked yet, the account a host may link them to.
+func (r *eventRepository) ListWhitelistEntries(ctx context.Context, eventID string) ([]*domain.WhitelistEntry, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT ew.id, ew.event_id, ew.user_id, ew.email, ew.phone, COALESCE(ew.added_by::text, ''), ew.added_at, ew.notes, u.name,
+		       c.id, c.name
+		FROM event_whitelists ew
+		LEFT JOIN users u ON u.id = ew.user_id
+		LEFT JOIN LATERAL (
+			SELECT u.id, u.name
+			FROM users u
+			WHERE `+whitelistCandidate+` AND u.deleted_at IS NULL
+			  AND NOT EXISTS (SELECT 1 FROM event_whitelists o WHERE o.event_id = ew.event_id AND o.user_id = u.id)
+			ORDER BY u.created_at
+			LIMIT 1
+		) c ON TRUE
+		WHERE ew.event_id = $1
+		ORDER BY ew.added_at DESC, ew.id
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list whitelist entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.WhitelistEntry
+	for rows.Next() {
+		var entry domain.WhitelistEntry
+		if err := rows.Scan(
+			&entry.ID, &entry.EventID, &entry.UserID, &entry.Email, &entry.Phone, &entry.AddedBy, &entry.AddedAt,
+			&entry.Notes, &entry.UserName, &entry.CandidateUserID, &entry.CandidateUserName,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan whitelist entry: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+// RemoveWhitelistEntry removes an entry from the whitelist of an event.
+func (r *eventRepository) RemoveWhitelistEntry(ctx context.Context, eventID, entryID string) error {
+	commandTag, err := r.db.Exec(ctx, `DELETE FROM event_whitelists WHERE id = $1 AND event_id = $2`, entryID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to remove whitelist entry: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.ErrWhitelistEntryNotFound
+	}
+	return nil
+}
+
+// ConfirmWhitelistLink links an entry of an event's whitelist that is not linked yet to an account with its
+// email or phone, on a host's word that the account is the person's.
+func (r *eventRepository) ConfirmWhitelistLink(ctx context.Context, eventID, entryID, userID string) error {
+	commandTag, err := r.db.Exec(ctx, `
+		UPDATE event_whitelists ew
+		SET user_id = u.id
+		FROM users u
+		WHERE ew.id = $1 AND ew.event_id = $2 AND u.id = $3 AND u.deleted_at IS NULL AND `+whitelistCandidate+`
+		  AND NOT EXISTS (SELECT 1 FROM event_whitelists o WHERE o.event_id = ew.event_id AND o.user_id = u.id)
+	`, entryID, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to link whitelist entry: %w", err)
+	}
+	if commandTag.RowsAffected() > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM event_whitelists WHERE id = $1 AND event_id = $2)`, entryID, eventID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to get whitelist entry: %w", err)
+	}
+	if !exists {
+		return domain.ErrWhitelistEntryNotFound
+	}
+	return domain.ErrWhitelistLinkMismatch
+}
+
+// LinkEventWhitelistEntries links the entries of an event's whitelist that are not linked yet to the
+// existing accounts with their verified email, and returns how many were linked.
+func (r *eventRepository) LinkEventWhitelistEntries(ctx context.Context, eventID string) (int64, error) {
+	return r.linkWhitelistEntries(ctx, `ew.event_id = $1`, eventID)
+}
+
+// LinkUserWhitelistEntries links the whitelist entries that are not linked yet and have the verified email
+// of a user to that user, and returns how many were linked.
+func (r *eventRepository) LinkUserWhitelistEntries(ctx context.Context, userID string) (int64, error) {
+	return r.linkWhitelistEntries(ctx, `u.id = $1`, userID)
+}
+
+// linkWhitelistEntries links at most one entry per event and user, skipping users already linked to an
+// entry of the event; the other matching entries keep matching by email.
+func (r *eventRepository) linkWhitelistEntries(ctx context.Context, where string, arg string) (int64, error) {
+	commandTag, err := r.db.Exec(ctx, `
+		WITH matches AS (
+			SELECT DISTINCT ON (ew.event_id, u.id) ew.id, u.id AS user_id
+			FROM event_whitelists ew
+			JOIN users u ON `+whitelistMatch+` AND u.deleted_at IS NULL
+			WHERE ew.user_id IS NULL AND `+where+`
+			  AND NOT EXISTS (SELECT 1 FROM event_whitelists o WHERE o.event_id = ew.event_id AND o.user_id = u.id)
+			ORDER BY ew.event_id, u.id, ew.added_at
+		)
+		UPDATE event_whitelists ew
+		SET user_id = m.user_id
+		FROM matches m
+		WHERE ew.id = m.id
+	`, arg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to link whitelist entries: %w", err)
+	}
+	return commandTag.RowsAffected(), nil
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTrackNotFound      = errors.New("track not found")
+	ErrInvalidTrack       = errors.New("invalid track")
+	ErrAgendaItemNotFound = errors.New("agenda item not found")
+	ErrInvalidAgendaItem  = errors.New("invalid agenda item")
+	ErrInvalidTimezone    = errors.New("invalid timezone")
+)
+
+var trackColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// EventTrack corresponds to the 'event_tracks' table: a parallel track of an event's programme. Tracks are
+// listed by Position, then name.
+type EventTrack struct {
+	ID          string         `json:"id"`
+	EventID     string         `json:"event_id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description,omitempty"`
+	Color       sql.NullString `json:"color,omitempty"` // #rrggbb
+	Position    int            `json:"position"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// Validate checks the track's name and color.
+func (t *EventTrack) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTrack)
+	}
+	if len(t.Name) > 100 {
+		return fmt.Errorf("%w: name is too long", ErrInvalidTrack)
+	}
+	if t.Color.Valid && !trackColorPattern.MatchString(t.Color.String) {
+		return fmt.Errorf("%w: color must be a #rrggbb hex color", ErrInvalidTrack)
+	}
+	return nil
+}
+
+// SessionAgendaItem corresponds to the 'session_agenda_items' table: a talk, workshop or break within a
+// session, in a time slot inside the session's and optionally in a track and room.
+type SessionAgendaItem struct {
+	ID        string         `json:"id"`
+	SessionID string         `json:"session_id"`
+	EventID   string         `json:"event_id"`
+	TrackID   sql.NullString `json:"track_id,omitempty"`
+	Title     string         `json:"title"`
+	Abstract  sql.NullString `json:"abstract,omitempty"`
+	Room      sql.NullString `json:"room,omitempty"`
+	StartTime time.Time      `json:"start_time"`
+	EndTime   time.Time      `json:"end_time"`
+	CreatedBy sql.NullString `json:"created_by,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+
+	// Enriched data (from joins)
+	TrackName        sql.NullString      `json:"track_name,omitempty"`
+	SessionName      sql.NullString      `json:"session_name,omitempty"`
+	SessionCancelled bool                `json:"session_cancelled"`
+	Speakers         []AgendaItemSpeaker `json:"speakers"`
+	StarCount        int                 `json:"star_count"`
+	Starred          bool                `json:"starred"` // Starred by the requesting user
+}
+
+// AgendaItemSpeaker is a speaker of an agenda item.
+type AgendaItemSpeaker struct {
+	UserID    string `json:"user_id"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// Validate checks the item's title and that its time slot lies within the session.
+func (i *SessionAgendaItem) Validate(session *EventSession) error {
+	i.Title = strings.TrimSpace(i.Title)
+	if i.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidAgendaItem)
+	}
+	if len(i.Title) > 255 {
+		return fmt.Errorf("%w: title is too long", ErrInvalidAgendaItem)
+	}
+	if !i.EndTime.After(i.StartTime) {
+		return fmt.Errorf("%w: end time must be after start time", ErrInvalidAgendaItem)
+	}
+	if i.StartTime.Before(session.StartTime) || i.EndTime.After(session.EndTime) {
+		return fmt.Errorf("%w: the time slot must lie within the session", ErrInvalidAgendaItem)
+	}
+	return nil
+}
+
+// EventSchedule is the programme of an event: its agenda items by day, in a timezone.
+type EventSchedule struct {
+	EventID   string         `json:"event_id"`
+	EventName string         `json:"event_name"`
+	Timezone  string         `json:"timezone"`
+	Tracks    []*EventTrack  `json:"tracks"`
+	Days      []*ScheduleDay `json:"days"`
+}
+
+// ScheduleDay holds the agenda items starting on one day of the schedule's timezone.
+type ScheduleDay struct {
+	Date  string               `json:"date"` // YYYY-MM-DD
+	Items []*SessionAgendaItem `json:"items"`
+}
+
+// NewEventSchedule orders the agenda items by start time, then track and room, and groups them by the day
+// they start on in loc. Their times are given in loc too.
+func NewEventSchedule(event *Event, tracks []*EventTrack, items []*SessionAgendaItem, loc *time.Location) *EventSchedule {
+	trackPositions := make(map[string]int, len(tracks))
+	for i, track := range tracks {
+		trackPositions[track.ID] = i
+	}
+	trackOrder := func(item *SessionAgendaItem) int {
+		if position, ok := trackPositions[item.TrackID.String]; ok && item.TrackID.Valid {
+			return position
+		}
+		return len(tracks)
+	}
+	sort.SliceStable(items, func(a, b int) bool {
+		x, y := items[a], items[b]
+		if !x.StartTime.Equal(y.StartTime) {
+			return x.StartTime.Before(y.StartTime)
+		}
+		if trackOrder(x) != trackOrder(y) {
+			return trackOrder(x) < trackOrder(y)
+		}
+		return x.Room.String < y.Room.String
+	})
+
+	if tracks == nil {
+		tracks = []*EventTrack{}
+	}
+	schedule := &EventSchedule{EventID: event.ID, EventName: event.Name, Timezone: loc.String(), Tracks: tracks, Days: []*ScheduleDay{}}
+	var day *ScheduleDay
+	for _, item := range items {
+		item.StartTime, item.EndTime = item.StartTime.In(loc), item.EndTime.In(loc)
+		date := item.StartTime.Format("2006-01-02")
+		if day == nil || day.Date != date {
+			day = &ScheduleDay{Date: date}
+			schedule.Days = append(schedule.Days, day)
+		}
+		day.Items = append(day.Items, item)
+	}
+	return schedule
+}
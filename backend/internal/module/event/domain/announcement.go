@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAnnouncementNotFound   = errors.New("announcement not found")
+	ErrInvalidAnnouncement    = errors.New("invalid announcement")
+	ErrAnnouncementNotPending = errors.New("only scheduled announcements can be cancelled")
+)
+
+// EventAnnouncementSubject is the NATS subject an EventAnnouncementSentEvent is published on.
+const EventAnnouncementSubject = "events.announcement.sent"
+
+// Limits of announcements.
+const (
+	MaxAnnouncementTitleLength   = 200
+	MaxAnnouncementMessageLength = 5000
+	AnnouncementBatchSize        = 50
+)
+
+// Audiences of an announcement. They are taken from the session's registrations and check-ins when the
+// announcement targets a session, and from the whole event's otherwise.
+const (
+	AnnouncementAudienceAll       = "all"        // Confirmed registrants
+	AnnouncementAudienceCheckedIn = "checked_in" // Attendees with a successful check-in
+	AnnouncementAudienceNoShow    = "no_show"    // Confirmed registrants without a successful check-in
+	AnnouncementAudienceWaitlist  = "waitlist"   // People on the waitlist
+)
+
+// Statuses of an announcement.
+const (
+	AnnouncementStatusScheduled = "scheduled"
+	AnnouncementStatusSent      = "sent"
+	AnnouncementStatusCancelled = "cancelled"
+)
+
+// EventAnnouncement corresponds to the 'event_announcements' table.
+type EventAnnouncement struct {
+	ID             string         `json:"id"`
+	EventID        string         `json:"event_id"`
+	SessionID      sql.NullString `json:"session_id,omitempty"`
+	AuthorID       sql.NullString `json:"author_id,omitempty"`
+	Title          string         `json:"title"`
+	Message        string         `json:"message"`
+	Audience       string         `json:"audience"`
+	Status         string         `json:"status"`
+	ScheduledAt    time.Time      `json:"scheduled_at"`
+	SentAt         sql.NullTime   `json:"sent_at,omitempty"`
+	RecipientCount int            `json:"recipient_count"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+
+	// Joined fields
+	AuthorName  sql.NullString `json:"author_name,omitempty"`
+	EventName   string         `json:"event_name"`
+	SessionName sql.NullString `json:"session_name,omitempty"`
+
+	RecipientIDs []string `json:"-"` // Set when the announcement is claimed for delivery
+}
+
+// Validate checks the announcement's content and audience. The audience defaults to everyone registered.
+func (a *EventAnnouncement) Validate() error {
+	a.Title = strings.TrimSpace(a.Title)
+	a.Message = strings.TrimSpace(a.Message)
+	if a.Title == "" || a.Message == "" {
+		return fmt.Errorf("%w: title and message are required", ErrInvalidAnnouncement)
+	}
+	if len(a.Title) > MaxAnnouncementTitleLength {
+		return fmt.Errorf("%w: title must be at most %d characters", ErrInvalidAnnouncement, MaxAnnouncementTitleLength)
+	}
+	if len(a.Message) > MaxAnnouncementMessageLength {
+		return fmt.Errorf("%w: message must be at most %d characters", ErrInvalidAnnouncement, MaxAnnouncementMessageLength)
+	}
+	if a.Audience == "" {
+		a.Audience = AnnouncementAudienceAll
+	}
+	switch a.Audience {
+	case AnnouncementAudienceAll, AnnouncementAudienceCheckedIn, AnnouncementAudienceNoShow, AnnouncementAudienceWaitlist:
+	default:
+		return fmt.Errorf("%w: audience must be all, checked_in, no_show or waitlist", ErrInvalidAnnouncement)
+	}
+	return nil
+}
+
+// EventAnnouncementSentEvent is published on EventAnnouncementSubject when an announcement is sent, with the
+// users it is delivered to.
+type EventAnnouncementSentEvent struct {
+	AnnouncementID string   `json:"announcement_id"`
+	EventID        string   `json:"event_id"`
+	EventName      string   `json:"event_name"`
+	SessionName    string   `json:"session_name,omitempty"`
+	AuthorID       string   `json:"author_id,omitempty"`
+	Title          string   `json:"title"`
+	Message        string   `json:"message"`
+	UserIDs        []string `json:"user_ids"`
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrApprovalRuleNotFound        = errors.New("approval rule not found")
+	ErrInvalidApprovalRule         = errors.New("invalid approval rule")
+	ErrInvalidRegistrationDecision = errors.New("invalid registration decision")
+	ErrRegistrationNotPending      = errors.New("registration not found or no longer pending")
+)
+
+// RegistrationDecisionSubject is the NATS subject a RegistrationDecisionEvent is published on.
+const RegistrationDecisionSubject = "events.registration.decided"
+
+// Kinds of RegistrationApprovalRule.
+const (
+	ApprovalRuleCommunityMember = "community_member" // Active members of CommunityID
+	ApprovalRuleEmailDomain     = "email_domain"     // Users whose email is at one of EmailDomains
+	ApprovalRulePastAttendance  = "past_attendance"  // Users who attended at least MinAttendedEvents other events of the community
+)
+
+// Decisions on pending registrations.
+const (
+	RegistrationDecisionApprove = "approve"
+	RegistrationDecisionReject  = "reject"
+)
+
+// Limits of approval rules and bulk decisions.
+const (
+	MaxApprovalRuleEmailDomains   = 50
+	MaxRegistrationDecisionBatch  = 500
+	MaxRegistrationDecisionReason = 1000
+)
+
+// RegistrationApprovalRule corresponds to the 'registration_approval_rules' table. On events requiring
+// approval, registrations matching any rule of the event are approved automatically.
+type RegistrationApprovalRule struct {
+	ID                string         `json:"id"`
+	EventID           string         `json:"event_id"`
+	Kind              string         `json:"kind"`
+	CommunityID       sql.NullString `json:"community_id,omitempty"`
+	EmailDomains      []string       `json:"email_domains,omitempty"`
+	MinAttendedEvents sql.NullInt32  `json:"min_attended_events,omitempty"`
+	CreatedBy         sql.NullString `json:"created_by,omitempty"`
+	CreatedAt         time.Time      `json:"created_at"`
+}
+
+// Validate checks that the rule has the settings of its kind, and only those. Email domains are lowercased
+// and stripped of a leading @.
+func (r *RegistrationApprovalRule) Validate() error {
+	switch r.Kind {
+	case ApprovalRuleCommunityMember:
+		if !r.CommunityID.Valid || r.CommunityID.String == "" {
+			return fmt.Errorf("%w: community_id is required", ErrInvalidApprovalRule)
+		}
+		r.EmailDomains, r.MinAttendedEvents = nil, sql.NullInt32{}
+	case ApprovalRuleEmailDomain:
+		domains := make([]string, 0, len(r.EmailDomains))
+		for _, domain := range r.EmailDomains {
+			domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "@")
+			if domain == "" {
+				continue
+			}
+			if len(domain) > 253 || !strings.Contains(domain, ".") || strings.ContainsAny(domain, "@ ") {
+				return fmt.Errorf("%w: %q is not an email domain", ErrInvalidApprovalRule, domain)
+			}
+			domains = append(domains, domain)
+		}
+		if len(domains) == 0 {
+			return fmt.Errorf("%w: email_domains is required", ErrInvalidApprovalRule)
+		}
+		if len(domains) > MaxApprovalRuleEmailDomains {
+			return fmt.Errorf("%w: at most %d email domains", ErrInvalidApprovalRule, MaxApprovalRuleEmailDomains)
+		}
+		r.EmailDomains, r.CommunityID, r.MinAttendedEvents = domains, sql.NullString{}, sql.NullInt32{}
+	case ApprovalRulePastAttendance:
+		if !r.MinAttendedEvents.Valid || r.MinAttendedEvents.Int32 < 1 {
+			return fmt.Errorf("%w: min_attended_events must be at least 1", ErrInvalidApprovalRule)
+		}
+		r.CommunityID, r.EmailDomains = sql.NullString{}, nil
+	default:
+		return fmt.Errorf("%w: kind must be community_member, email_domain or past_attendance", ErrInvalidApprovalRule)
+	}
+	return nil
+}
+
+// Describe explains why the rule approved a registration, for the registrant.
+func (r *RegistrationApprovalRule) Describe() string {
+	switch r.Kind {
+	case ApprovalRuleCommunityMember:
+		return "Approved automatically for community members."
+	case ApprovalRuleEmailDomain:
+		return "Approved automatically for your email domain."
+	case ApprovalRulePastAttendance:
+		return fmt.Sprintf("Approved automatically for having attended %d of our previous events.", r.MinAttendedEvents.Int32)
+	}
+	return "Approved automatically."
+}
+
+// ApprovalRuleMatch is a pending registration that an approval rule approves.
+type ApprovalRuleMatch struct {
+	RegistrationID string
+	UserID         string
+	RuleID         string
+}
+
+// RegistrationDecisionInput approves or rejects pending registrations of an event: the given ones, or all
+// of them with All. The reason is passed on to the registrants.
+type RegistrationDecisionInput struct {
+	Decision        string
+	RegistrationIDs []string
+	All             bool
+	Reason          string
+}
+
+// Validate checks the decision and the registrations it applies to.
+func (in *RegistrationDecisionInput) Validate() error {
+	if in.Decision != RegistrationDecisionApprove && in.Decision != RegistrationDecisionReject {
+		return fmt.Errorf("%w: decision must be approve or reject", ErrInvalidRegistrationDecision)
+	}
+	if in.All {
+		in.RegistrationIDs = nil
+	} else if len(in.RegistrationIDs) == 0 {
+		return fmt.Errorf("%w: registration_ids is required unless all is set", ErrInvalidRegistrationDecision)
+	}
+	if len(in.RegistrationIDs) > MaxRegistrationDecisionBatch {
+		return fmt.Errorf("%w: at most %d registrations at a time", ErrInvalidRegistrationDecision, MaxRegistrationDecisionBatch)
+	}
+	in.Reason = strings.TrimSpace(in.Reason)
+	if len(in.Reason) > MaxRegistrationDecisionReason {
+		return fmt.Errorf("%w: reason is too long", ErrInvalidRegistrationDecision)
+	}
+	return nil
+}
+
+// Status is the registration status the decision leads to.
+func (in *RegistrationDecisionInput) Status() string {
+	if in.Decision == RegistrationDecisionReject {
+		return "rejected"
+	}
+	return "registered"
+}
+
+// DecidedRegistration is a registration that was approved or rejected.
+type DecidedRegistration struct {
+	RegistrationID string `json:"registration_id"`
+	UserID         string `json:"user_id"`
+}
+
+// RegistrationDecisionResult lists the registrations a decision applied to, and those it skipped because
+// they are not pending registrations of the event.
+type RegistrationDecisionResult struct {
+	Decision string                 `json:"decision"`
+	Decided  []*DecidedRegistration `json:"decided"`
+	Skipped  []string               `json:"skipped"`
+}
+
+// RegistrationDecisionEvent is published on RegistrationDecisionSubject when pending registrations of an
+// event are approved or rejected, by a host or automatically by an approval rule.
+type RegistrationDecisionEvent struct {
+	EventID   string   `json:"event_id"`
+	EventName string   `json:"event_name"`
+	Decision  string   `json:"decision"`
+	Reason    string   `json:"reason,omitempty"`
+	Automatic bool     `json:"automatic"`
+	UserIDs   []string `json:"user_ids"`
+	DecidedBy string   `json:"decided_by,omitempty"`
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegistrationApprovalRuleValidate(t *testing.T) {
+	community := sql.NullString{String: "c1", Valid: true}
+	tests := []struct {
+		name        string
+		rule        RegistrationApprovalRule
+		wantErr     bool
+		wantDomains []string
+	}{
+		{name: "community members", rule: RegistrationApprovalRule{Kind: ApprovalRuleCommunityMember, CommunityID: community}},
+		{name: "community members without a community", rule: RegistrationApprovalRule{Kind: ApprovalRuleCommunityMember}, wantErr: true},
+		{
+			name:        "email domains are normalized",
+			rule:        RegistrationApprovalRule{Kind: ApprovalRuleEmailDomain, EmailDomains: []string{" @Example.COM ", "uni.edu", ""}},
+			wantDomains: []string{"example.com", "uni.edu"},
+		},
+		{name: "no email domains", rule: RegistrationApprovalRule{Kind: ApprovalRuleEmailDomain, EmailDomains: []string{" "}}, wantErr: true},
+		{name: "email address instead of a domain", rule: RegistrationApprovalRule{Kind: ApprovalRuleEmailDomain, EmailDomains: []string{"ada@example.com"}}, wantErr: true},
+		{name: "domain without a dot", rule: RegistrationApprovalRule{Kind: ApprovalRuleEmailDomain, EmailDomains: []string{"localhost"}}, wantErr: true},
+		{name: "too many domains", rule: RegistrationApprovalRule{Kind: ApprovalRuleEmailDomain, EmailDomains: strings.Split(strings.Repeat("a.io,", MaxApprovalRuleEmailDomains+1), ",")}, wantErr: true},
+		{name: "past attendance", rule: RegistrationApprovalRule{Kind: ApprovalRulePastAttendance, MinAttendedEvents: sql.NullInt32{Int32: 2, Valid: true}}},
+		{name: "past attendance of zero events", rule: RegistrationApprovalRule{Kind: ApprovalRulePastAttendance, MinAttendedEvents: sql.NullInt32{Int32: 0, Valid: true}}, wantErr: true},
+		{name: "past attendance without a minimum", rule: RegistrationApprovalRule{Kind: ApprovalRulePastAttendance}, wantErr: true},
+		{name: "unknown kind", rule: RegistrationApprovalRule{Kind: "vip"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := tt.rule
+			err := rule.Validate()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidApprovalRule) {
+					t.Fatalf("want ErrInvalidApprovalRule, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want valid, got %v", err)
+			}
+			if tt.wantDomains != nil && !reflect.DeepEqual(rule.EmailDomains, tt.wantDomains) {
+				t.Errorf("got domains %v, want %v", rule.EmailDomains, tt.wantDomains)
+			}
+		})
+	}
+}
+
+func TestRegistrationApprovalRuleValidateDropsOtherSettings(t *testing.T) {
+	rule := RegistrationApprovalRule{
+		Kind:              ApprovalRuleCommunityMember,
+		CommunityID:       sql.NullString{String: "c1", Valid: true},
+		EmailDomains:      []string{"example.com"},
+		MinAttendedEvents: sql.NullInt32{Int32: 3, Valid: true},
+	}
+	if err := rule.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if rule.EmailDomains != nil || rule.MinAttendedEvents.Valid {
+		t.Fatalf("settings of other kinds were kept: %+v", rule)
+	}
+}
+
+func TestRegistrationDecisionInputValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      RegistrationDecisionInput
+		wantErr    bool
+		wantStatus string
+	}{
+		{name: "approve some", input: RegistrationDecisionInput{Decision: RegistrationDecisionApprove, RegistrationIDs: []string{"r1"}}, wantStatus: "registered"},
+		{name: "reject all", input: RegistrationDecisionInput{Decision: RegistrationDecisionReject, All: true, RegistrationIDs: []string{"ignored"}}, wantStatus: "rejected"},
+		{name: "unknown decision", input: RegistrationDecisionInput{Decision: "maybe", All: true}, wantErr: true},
+		{name: "no registrations", input: RegistrationDecisionInput{Decision: RegistrationDecisionApprove}, wantErr: true},
+		{name: "batch too large", input: RegistrationDecisionInput{Decision: RegistrationDecisionApprove, RegistrationIDs: make([]string, MaxRegistrationDecisionBatch+1)}, wantErr: true},
+		{name: "reason too long", input: RegistrationDecisionInput{Decision: RegistrationDecisionReject, All: true, Reason: strings.Repeat("x", MaxRegistrationDecisionReason+1)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.input
+			err := input.Validate()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRegistrationDecision) {
+					t.Fatalf("want ErrInvalidRegistrationDecision, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want valid, got %v", err)
+			}
+			if input.All && input.RegistrationIDs != nil {
+				t.Errorf("all should clear the registration IDs, got %v", input.RegistrationIDs)
+			}
+			if got := input.Status(); got != tt.wantStatus {
+				t.Errorf("got status %s, want %s", got, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCertificateTemplateNotFound = errors.New("certificate template not found")
+	ErrInvalidCertificateTemplate  = errors.New("invalid certificate template")
+	ErrCertificateNotFound         = errors.New("certificate not found")
+	ErrCertificatesNotAvailable    = errors.New("certificates can only be issued once the event is completed")
+)
+
+// CertificateIssuedSubject is the NATS subject a CertificateIssuedEvent is published on.
+const CertificateIssuedSubject = "events.certificate.issued"
+
+// Defaults of certificate templates.
+const (
+	DefaultCertificateTitle             = "Certificate of Attendance"
+	DefaultCertificateBody              = "This is to certify that {{name}} attended {{event}}, taking part in {{attended}} of {{total}} sessions ({{percent}}%)."
+	DefaultCertificateAttendancePercent = 80
+)
+
+// CertificateTemplate corresponds to the 'event_certificate_templates' table. An event has at most one.
+// Title and Body may contain the placeholders {{name}}, {{event}}, {{attended}}, {{total}}, {{percent}}
+// and {{date}}, which are filled in when a certificate is issued.
+type CertificateTemplate struct {
+	ID                   string         `json:"id"`
+	EventID              string         `json:"event_id"`
+	Title                string         `json:"title"`
+	Body                 string         `json:"body"`
+	SignerName           sql.NullString `json:"signer_name,omitempty"`
+	SignerTitle          sql.NullString `json:"signer_title,omitempty"`
+	MinAttendancePercent int            `json:"min_attendance_percent"`
+	IsActive             bool           `json:"is_active"`
+	CreatedBy            string         `json:"created_by"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+}
+
+// Validate fills in the defaults of a template and checks its attendance rule.
+func (t *CertificateTemplate) Validate() error {
+	t.Title = strings.TrimSpace(t.Title)
+	if t.Title == "" {
+		t.Title = DefaultCertificateTitle
+	}
+	t.Body = strings.TrimSpace(t.Body)
+	if t.Body == "" {
+		t.Body = DefaultCertificateBody
+	}
+	if t.MinAttendancePercent == 0 {
+		t.MinAttendancePercent = DefaultCertificateAttendancePercent
+	}
+	if t.MinAttendancePercent < 1 || t.MinAttendancePercent > 100 {
+		return fmt.Errorf("%w: min_attendance_percent must be between 1 and 100", ErrInvalidCertificateTemplate)
+	}
+	if len(t.Title) > 255 {
+		return fmt.Errorf("%w: title is too long", ErrInvalidCertificateTemplate)
+	}
+	return nil
+}
+
+// CertificateAttendance is how many of an event's sessions that are not cancelled a user checked in to.
+type CertificateAttendance struct {
+	UserID           string
+	UserName         string
+	SessionsAttended int
+	SessionsTotal    int
+}
+
+// Percent returns the share of sessions attended, from 0 to 100.
+func (a CertificateAttendance) Percent() float64 {
+	if a.SessionsTotal == 0 {
+		return 0
+	}
+	return float64(a.SessionsAttended) / float64(a.SessionsTotal) * 100
+}
+
+// Qualifies reports whether the attendance meets the template's attendance rule.
+func (a CertificateAttendance) Qualifies(t *CertificateTemplate) bool {
+	return a.SessionsTotal > 0 && a.Percent() >= float64(t.MinAttendancePercent)
+}
+
+// Certificate corresponds to the 'event_certificates' table. Its text is rendered from the template
+// when it is issued and does not change afterwards.
+type Certificate struct {
+	ID                string         `json:"id"`
+	EventID           string         `json:"event_id"`
+	UserID            string         `json:"user_id"`
+	TemplateID        sql.NullString `json:"template_id,omitempty"`
+	VerificationCode  string         `json:"verification_code"`
+	RecipientName     string         `json:"recipient_name"`
+	EventName         string         `json:"event_name"`
+	Title             string         `json:"title"`
+	Body              string         `json:"body"`
+	SignerName        sql.NullString `json:"signer_name,omitempty"`
+	SignerTitle       sql.NullString `json:"signer_title,omitempty"`
+	SessionsAttended  int            `json:"sessions_attended"`
+	SessionsTotal     int            `json:"sessions_total"`
+	AttendancePercent float64        `json:"attendance_percent"`
+	IssuedAt          time.Time      `json:"issued_at"`
+	RevokedAt         sql.NullTime   `json:"revoked_at,omitempty"`
+	RevokedReason     sql.NullString `json:"revoked_reason,omitempty"`
+}
+
+// NewCertificate renders the template for an attendee of the event and gives the certificate a new
+// verification code.
+func NewCertificate(t *CertificateTemplate, eventName string, attendance CertificateAttendance, issuedAt time.Time) (*Certificate, error) {
+	code, err := newVerificationCode()
+	if err != nil {
+		return nil, err
+	}
+
+	percent := attendance.Percent()
+	replacer := strings.NewReplacer(
+		"{{name}}", attendance.UserName,
+		"{{event}}", eventName,
+		"{{attended}}", strconv.Itoa(attendance.SessionsAttended),
+		"{{total}}", strconv.Itoa(attendance.SessionsTotal),
+		"{{percent}}", strconv.FormatFloat(percent, 'f', 0, 64),
+		"{{date}}", issuedAt.Format("January 2, 2006"),
+	)
+	return &Certificate{
+		EventID:           t.EventID,
+		UserID:            attendance.UserID,
+		TemplateID:        sql.NullString{String: t.ID, Valid: t.ID != ""},
+		VerificationCode:  code,
+		RecipientName:     attendance.UserName,
+		EventName:         eventName,
+		Title:             replacer.Replace(t.Title),
+		Body:              replacer.Replace(t.Body),
+		SignerName:        t.SignerName,
+		SignerTitle:       t.SignerTitle,
+		SessionsAttended:  attendance.SessionsAttended,
+		SessionsTotal:     attendance.SessionsTotal,
+		AttendancePercent: percent,
+		IssuedAt:          issuedAt,
+	}, nil
+}
+
+// newVerificationCode returns a random, human-readable code such as "K3TQ-9XWD-M2PA-7HRC".
+func newVerificationCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	code := base32.StdEncoding.EncodeToString(b)
+	return strings.Join([]string{code[0:4], code[4:8], code[8:12], code[12:16]}, "-"), nil
+}
+
+// NormalizeVerificationCode makes codes typed by hand comparable to issued ones.
+func NormalizeVerificationCode(code string) string {
+	code = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+	if len(code) == 16 && !strings.Contains(code, "-") {
+		code = strings.Join([]string{code[0:4], code[4:8], code[8:12], code[12:16]}, "-")
+	}
+	return code
+}
+
+// CertificateVerification is what the public verification endpoint reveals about a certificate.
+type CertificateVerification struct {
+	Valid            bool         `json:"valid"`
+	VerificationCode string       `json:"verification_code"`
+	RecipientName    string       `json:"recipient_name"`
+	EventName        string       `json:"event_name"`
+	Title            string       `json:"title"`
+	IssuedAt         time.Time    `json:"issued_at"`
+	RevokedAt        sql.NullTime `json:"revoked_at,omitempty"`
+}
+
+// Verification returns the public view of the certificate.
+func (c *Certificate) Verification() *CertificateVerification {
+	return &CertificateVerification{
+		Valid:            !c.RevokedAt.Valid,
+		VerificationCode: c.VerificationCode,
+		RecipientName:    c.RecipientName,
+		EventName:        c.EventName,
+		Title:            c.Title,
+		IssuedAt:         c.IssuedAt,
+		RevokedAt:        c.RevokedAt,
+	}
+}
+
+// CertificateIssuedEvent is published on CertificateIssuedSubject for each certificate issued.
+type CertificateIssuedEvent struct {
+	CertificateID string `json:"certificate_id"`
+	EventID       string `json:"event_id"`
+	EventName     string `json:"event_name"`
+	UserID        string `json:"user_id"`
+}
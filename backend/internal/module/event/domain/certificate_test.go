@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCertificateAttendanceQualifies(t *testing.T) {
+	tests := []struct {
+		name       string
+		attended   int
+		total      int
+		minPercent int
+		want       bool
+	}{
+		{name: "all sessions", attended: 5, total: 5, minPercent: 80, want: true},
+		{name: "exactly 80%", attended: 4, total: 5, minPercent: 80, want: true},
+		{name: "just below 80%", attended: 7, total: 9, minPercent: 80, want: false},
+		{name: "79% rounds nothing up", attended: 79, total: 100, minPercent: 80, want: false},
+		{name: "single session attended", attended: 1, total: 1, minPercent: 100, want: true},
+		{name: "nothing attended", attended: 0, total: 3, minPercent: 1, want: false},
+		{name: "no sessions", attended: 0, total: 0, minPercent: 1, want: false},
+		{name: "lower threshold", attended: 1, total: 2, minPercent: 50, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attendance := CertificateAttendance{SessionsAttended: tt.attended, SessionsTotal: tt.total}
+			if got := attendance.Qualifies(&CertificateTemplate{MinAttendancePercent: tt.minPercent}); got != tt.want {
+				t.Fatalf("%d of %d at %d%%: got %v, want %v", tt.attended, tt.total, tt.minPercent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCertificateTemplateValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    CertificateTemplate
+		wantPercent int
+		wantErr     bool
+	}{
+		{name: "defaults to 80%", template: CertificateTemplate{}, wantPercent: DefaultCertificateAttendancePercent},
+		{name: "custom threshold", template: CertificateTemplate{MinAttendancePercent: 50}, wantPercent: 50},
+		{name: "every session", template: CertificateTemplate{MinAttendancePercent: 100}, wantPercent: 100},
+		{name: "above 100%", template: CertificateTemplate{MinAttendancePercent: 101}, wantErr: true},
+		{name: "negative", template: CertificateTemplate{MinAttendancePercent: -5}, wantErr: true},
+		{name: "title too long", template: CertificateTemplate{Title: strings.Repeat("x", 256)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := tt.template
+			err := template.Validate()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCertificateTemplate) {
+					t.Fatalf("want ErrInvalidCertificateTemplate, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want valid, got %v", err)
+			}
+			if template.MinAttendancePercent != tt.wantPercent {
+				t.Errorf("got threshold %d, want %d", template.MinAttendancePercent, tt.wantPercent)
+			}
+			if template.Title == "" || template.Body == "" {
+				t.Errorf("title and body should default, got %q and %q", template.Title, template.Body)
+			}
+		})
+	}
+}
+
+func TestNewCertificate(t *testing.T) {
+	template := &CertificateTemplate{ID: "t1", EventID: "e1"}
+	if err := template.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	attendance := CertificateAttendance{UserID: "u1", UserName: "Ada Lovelace", SessionsAttended: 4, SessionsTotal: 5}
+
+	certificate, err := NewCertificate(template, "Go Workshop", attendance, time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("new certificate: %v", err)
+	}
+	if certificate.AttendancePercent != 80 {
+		t.Errorf("got %v%%, want 80%%", certificate.AttendancePercent)
+	}
+	for _, want := range []string{"Ada Lovelace", "Go Workshop", "4 of 5", "80%"} {
+		if !strings.Contains(certificate.Body, want) {
+			t.Errorf("body %q does not contain %q", certificate.Body, want)
+		}
+	}
+	if certificate.VerificationCode == "" || NormalizeVerificationCode(strings.ToLower(certificate.VerificationCode)) != certificate.VerificationCode {
+		t.Errorf("verification code %q does not normalize to itself", certificate.VerificationCode)
+	}
+}
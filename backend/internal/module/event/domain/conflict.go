@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrScheduleConflict   = errors.New("the event overlaps another event the user is registered for")
+	ErrVenueDoubleBooked  = errors.New("the venue is already booked at that time")
+	ErrInvalidAgendaRange = errors.New("to must be after from, at most a year later")
+)
+
+// Time ranges of a personal agenda.
+const (
+	AgendaDefaultRange = 90 * 24 * time.Hour
+	AgendaMaxRange     = 366 * 24 * time.Hour
+)
+
+// Kinds of ScheduleConflict.
+const (
+	ConflictKindAttendee = "attendee" // The user is registered for another event at the same time
+	ConflictKindVenue    = "venue"    // Another event takes place at the same venue at the same time
+	ConflictKindStaff    = "staff"    // A host or staff member of the event works another event at the same time
+)
+
+// ScheduleSlot is a session being scheduled or registered for, checked against the rest of the schedule.
+type ScheduleSlot struct {
+	SessionID string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// ScheduleConflict is a session of another event that overlaps a slot: for the same attendee, at the same
+// venue or for the same staff member depending on its Kind. Cancelled events and sessions never conflict.
+type ScheduleConflict struct {
+	Kind      string    `json:"kind"`
+	SessionID string    `json:"session_id,omitempty"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	UserID    string    `json:"user_id,omitempty"`   // The double-booked staff member, for staff conflicts
+	UserName  string    `json:"user_name,omitempty"` // The double-booked staff member, for staff conflicts
+	VenueID   string    `json:"venue_id,omitempty"`  // The double-booked venue, for venue conflicts
+
+	ConflictingEventID          string         `json:"conflicting_event_id"`
+	ConflictingEventName        string         `json:"conflicting_event_name"`
+	ConflictingSessionID        string         `json:"conflicting_session_id"`
+	ConflictingSessionName      sql.NullString `json:"conflicting_session_name,omitempty"`
+	ConflictingSessionStartTime time.Time      `json:"conflicting_session_start_time"`
+	ConflictingSessionEndTime   time.Time      `json:"conflicting_session_end_time"`
+}
+
+// Describe names the conflicting event and when its session starts, for error messages.
+func (c *ScheduleConflict) Describe() string {
+	return c.ConflictingEventName + " at " + c.ConflictingSessionStartTime.Format(time.RFC3339)
+}
+
+// AgendaItem is one session on a user's personal agenda, from an event they registered for. Sessions of
+// events with per-session registration are only on the agenda if the user signed up for them.
+type AgendaItem struct {
+	EventID            string         `json:"event_id"`
+	EventName          string         `json:"event_name"`
+	EventSlug          string         `json:"event_slug"`
+	SessionID          string         `json:"session_id"`
+	SessionName        sql.NullString `json:"session_name,omitempty"`
+	StartTime          time.Time      `json:"start_time"`
+	EndTime            time.Time      `json:"end_time"`
+	Timezone           string         `json:"timezone"`
+	LocationType       string         `json:"location_type"`
+	Location           sql.NullString `json:"location,omitempty"`
+	RegistrationStatus string         `json:"registration_status"` // The event registration's, or the session registration's on per-session events
+
+	ConflictsWith []string `json:"conflicts_with"` // IDs of the other sessions on the agenda it overlaps
+	HasConflict   bool     `json:"has_conflict"`
+}
+
+// MarkAgendaConflicts sorts the agenda by start time and records, for each item, the other sessions on it
+// that overlap.
+func MarkAgendaConflicts(items []*AgendaItem) {
+	sort.SliceStable(items, func(i, j int) bool { return items[i].StartTime.Before(items[j].StartTime) })
+	for _, item := range items {
+		item.ConflictsWith = []string{}
+	}
+	for i, item := range items {
+		for _, other := range items[i+1:] {
+			if !other.StartTime.Before(item.EndTime) {
+				break
+			}
+			if other.EndTime.After(item.StartTime) {
+				item.ConflictsWith = append(item.ConflictsWith, other.SessionID)
+				other.ConflictsWith = append(other.ConflictsWith, item.SessionID)
+			}
+		}
+	}
+	for _, item := range items {
+		item.HasConflict = len(item.ConflictsWith) > 0
+	}
+}
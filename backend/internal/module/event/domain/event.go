@@ -20,6 +20,11 @@ var (
 	ErrEventFull          = errors.New("event is full")
 	ErrAlreadyRegistered  = errors.New("user is already registered for this event")
 	ErrPermissionDenied   = errors.New("permission denied")
+	ErrInvalidFeedToken   = errors.New("invalid calendar feed token")
+	ErrInvalidICalendar   = errors.New("invalid iCalendar data")
+	ErrAlreadyImported    = errors.New("event was already imported")
+	ErrEventNotRecurring  = errors.New("event is not recurring")
+	ErrInvalidOccurrence  = errors.New("invalid occurrence edit")
 )
 
 // Event corresponds to the 'events' table, holding all core event information.
@@ -34,6 +39,10 @@ type Event struct {
 	LocationType             string          `json:"location_type"`
 	LocationAddress          sql.NullString  `json:"location_address,omitempty"`
 	OnlineMeetingURL         sql.NullString  `json:"online_meeting_url,omitempty"`
+	VenueID                  sql.NullString  `json:"venue_id,omitempty"`
+	MeetingLinkRevealMinutes sql.NullInt32   `json:"meeting_link_reveal_minutes,omitempty"` // Minutes before a session its meeting link is revealed to registrants; revealed once they are approved if not set
+	TransferPolicy           string          `json:"transfer_policy"`                       // Whether attendees can transfer their registration: 'disabled', 'allowed' or 'approval'
+	TransferDeadlineMinutes  sql.NullInt32   `json:"transfer_deadline_minutes,omitempty"`   // Minutes before the next session transfers close; open until it starts if not set
 	Timezone                 string          `json:"timezone"`
 	StartTime                sql.NullTime    `json:"start_time,omitempty"`
 	EndTime                  sql.NullTime    `json:"end_time,omitempty"`
@@ -42,10 +51,12 @@ type Event struct {
 	RecurrenceRule           json.RawMessage `json:"recurrence_rule,omitempty"`
 	RecurrenceEndDate        sql.NullTime    `json:"recurrence_end_date,omitempty"`
 	MaxOccurrences           sql.NullInt32   `json:"max_occurrences,omitempty"`
+	GenerationHorizonDays    sql.NullInt32   `json:"generation_horizon_days,omitempty"` // Days ahead sessions are generated; defaults to 30
 	MaxAttendees             sql.NullInt32   `json:"max_attendees,omitempty"`
 	CurrentAttendees         int             `json:"current_attendees"`
 	WaitlistEnabled          bool            `json:"waitlist_enabled"`
 	MaxWaitlist              sql.NullInt32   `json:"max_waitlist,omitempty"`
+	PerSessionRegistration   bool            `json:"per_session_registration"` // Attendees sign up for individual sessions
 	RegistrationRequired     bool            `json:"registration_required"`
 	RegistrationOpensAt      sql.NullTime    `json:"registration_opens_at,omitempty"`
 	RegistrationClosesAt     sql.NullTime    `json:"registration_closes_at,omitempty"`
@@ -69,11 +80,18 @@ type Event struct {
 	DeletedAt                sql.NullTime    `json:"deleted_at,omitempty"`
 
 	// Enriched data (from joins)
-	CommunityType   string         `json:"community_type,omitempty"`
-	CreatedByName   string         `json:"created_by_name,omitempty"`
-	CreatedByAvatar sql.NullString `json:"created_by_avatar,omitempty"`
-	Sessions        []EventSession `json:"sessions,omitempty"`
-	IsRegistered    bool           `json:"is_registered"`
+	CommunityType     string              `json:"community_type,omitempty"`
+	CreatedByName     string              `json:"created_by_name,omitempty"`
+	CreatedByAvatar   sql.NullString      `json:"created_by_avatar,omitempty"`
+	Sessions          []EventSession      `json:"sessions,omitempty"`
+	IsRegistered      bool                `json:"is_registered"`
+	Speakers          []*EventStaffMember `json:"speakers,omitempty"`
+	Venue             *Venue              `json:"venue,omitempty"`
+	MeetingLinkHidden bool                `json:"meeting_link_hidden,omitempty"` // A meeting link is withheld from the caller
+	ScheduleConflicts []*ScheduleConflict `json:"schedule_conflicts,omitempty"`  // Staff double-bookings, when the event is scheduled or changed
+
+	// iCalendar UID the event is imported from, recorded in the same transaction as the event
+	ImportUID string `json:"-"`
 }
 
 // EventItem represents a single schedulable event or session in a list.
@@ -112,10 +130,23 @@ type EventSession struct {
 	FaceVerificationRequiredOverride sql.NullBool   `json:"face_verification_required_override,omitempty"`
 	IsCancelled                      bool           `json:"is_cancelled"`
 	CancellationReason               sql.NullString `json:"cancellation_reason,omitempty"`
+	OriginalStartTime                sql.NullTime   `json:"original_start_time,omitempty"` // Start time the recurrence rule generated for this occurrence
+	IsOverride                       bool           `json:"is_override"`                   // True once the occurrence was edited individually
+	VenueID                          sql.NullString `json:"venue_id,omitempty"`            // Set when the session takes place at another venue than the event
 	TotalCheckins                    int            `json:"total_checkins"`
 	TotalNoShows                     int            `json:"total_no_shows"`
 	CreatedAt                        time.Time      `json:"created_at"`
 	UpdatedAt                        time.Time      `json:"updated_at"`
+
+	// Per-session registration counts (from joins)
+	TotalRegistrations int `json:"total_registrations"`
+	TotalWaitlisted    int `json:"total_waitlisted"`
+
+	ScheduleConflicts []*ScheduleConflict `json:"schedule_conflicts,omitempty"` // Staff double-bookings, when the session is scheduled or changed
+
+	// Session of another event this one is a copy of, whose agenda and surveys are copied in the same
+	// transaction as the session
+	CopiedFromSessionID string `json:"-"`
 }
 
 // EventAttendee corresponds to the 'event_attendees' table.
@@ -152,6 +183,9 @@ type EventAttendee struct {
 	IsLate                sql.NullBool    `json:"is_late,omitempty"`
 	LivenessScore         sql.NullFloat64 `json:"liveness_score,omitempty"`
 	FailureReason         sql.NullString  `json:"failure_reason,omitempty"`
+
+	// Seat assigned for the session, returned on check-in so door staff can direct the attendee
+	Seat *SeatAssignment `json:"seat,omitempty"`
 }
 
 // EventSummary is a subset of the main Event struct for embedding in other responses.
@@ -170,15 +204,21 @@ type RegistrationWithEvent struct {
 	Event *EventSummary `json:"event"`
 }
 
-// WhitelistEntry represents an entry in the 'event_whitelists' table.
+// WhitelistEntry represents an entry in the 'event_whitelists' table. Entries added by email get a UserID
+// once an account verifies that email; entries matching only an unverified email or a phone name that
+// account as the candidate until a host confirms the link.
 type WhitelistEntry struct {
-	EventID string         `json:"event_id"`
-	UserID  sql.NullString `json:"user_id,omitempty"`
-	Email   sql.NullString `json:"email,omitempty"`
-	Phone   sql.NullString `json:"phone,omitempty"`
-	AddedBy string         `json:"added_by"`
-	AddedAt time.Time      `json:"added_at"`
-	Notes   sql.NullString `json:"notes,omitempty"`
+	ID                string         `json:"id"`
+	EventID           string         `json:"event_id"`
+	UserID            sql.NullString `json:"user_id,omitempty"`
+	Email             sql.NullString `json:"email,omitempty"`
+	Phone             sql.NullString `json:"phone,omitempty"`
+	AddedBy           string         `json:"added_by"`
+	AddedAt           time.Time      `json:"added_at"`
+	Notes             sql.NullString `json:"notes,omitempty"`
+	UserName          sql.NullString `json:"user_name,omitempty"`
+	CandidateUserID   sql.NullString `json:"candidate_user_id,omitempty"`
+	CandidateUserName sql.NullString `json:"candidate_user_name,omitempty"`
 }
 
 // AttendanceSummary is a DTO for reporting.
@@ -195,13 +235,33 @@ type AttendanceSummary struct {
 	AttendanceRate  float32   `json:"attendance_rate"`
 }
 
-// UpcomingSessionInfo is a DTO for the notification worker.
-type UpcomingSessionInfo struct {
-	ID          string
-	EventID     string
-	EventName   string
-	StartTime   time.Time
-	AttendeeIDs []string
+// ICalImportItem is one VEVENT series parsed from an uploaded .ics file, as shown in the import preview.
+type ICalImportItem struct {
+	UID             string         `json:"uid"`
+	Name            string         `json:"name"`
+	Description     string         `json:"description,omitempty"`
+	Location        string         `json:"location,omitempty"`
+	URL             string         `json:"url,omitempty"`
+	Timezone        string         `json:"timezone"`
+	StartTime       time.Time      `json:"start_time"`
+	EndTime         time.Time      `json:"end_time"`
+	RRule           string         `json:"rrule,omitempty"`
+	ExDates         []time.Time    `json:"exdates,omitempty"`
+	RDates          []time.Time    `json:"rdates,omitempty"`
+	Sessions        []EventSession `json:"sessions"`
+	Action          string         `json:"action"` // "create", "skip" or "invalid"
+	ExistingEventID string         `json:"existing_event_id,omitempty"`
+	CreatedEventID  string         `json:"created_event_id,omitempty"`
+	Warnings        []string       `json:"warnings,omitempty"`
+}
+
+// ICalImportResult summarizes an iCalendar import, either as a preview or after confirmation.
+type ICalImportResult struct {
+	Confirmed bool              `json:"confirmed"`
+	Items     []*ICalImportItem `json:"items"`
+	Created   int               `json:"created"`
+	Skipped   int               `json:"skipped"`
+	Invalid   int               `json:"invalid"`
 }
 
 // EventRepository defines the data access layer for event-related operations.
@@ -216,7 +276,7 @@ type EventRepository interface {
 	RegisterForEvent(ctx context.Context, eventID, userID, status string, formData json.RawMessage) error
 	AddUsersToWhitelist(ctx context.Context, eventID string, userIDs []string, addedBy string) error
 	GetPendingRegistrations(ctx context.Context, eventID string) ([]*EventAttendee, error)
-	UpdateRegistrationStatus(ctx context.Context, registrationID, status string, approverID sql.NullString) error
+	DecideRegistrations(ctx context.Context, eventID string, registrationIDs []string, status string, deciderID, reason sql.NullString) ([]*DecidedRegistration, error)
 	CancelRegistration(ctx context.Context, registrationID, userID string) error
 	GetRegistrationsByUserID(ctx context.Context, userID string, status string) ([]*RegistrationWithEvent, error)
 	UpdateEvent(ctx context.Context, event *Event, fieldMask []string) (*Event, error)
@@ -225,7 +285,6 @@ type EventRepository interface {
 	GetEventAttendees(ctx context.Context, eventID, sessionID, status string) ([]*EventAttendee, error)
 	GetEventSessions(ctx context.Context, eventID string) ([]EventSession, error)
 	GetEventSessionByID(ctx context.Context, sessionID string) (*EventSession, error)
-	GetUpcomingSessionsWithAttendees(ctx context.Context, from, to time.Time) ([]*UpcomingSessionInfo, error)
 	GetActiveRecurringEvents(ctx context.Context) ([]*Event, error)
 	CreateSessions(ctx context.Context, sessions []EventSession) error
 	GetSessionStartTimes(ctx context.Context, eventID string) (map[time.Time]bool, error)
@@ -237,7 +296,197 @@ type EventRepository interface {
 	InvalidateEventCache(ctx context.Context, eventID, userID string) error
 	DecrementEventAttendeeCount(ctx context.Context, eventID string) error
 	GetEventIDByRegistrationID(ctx context.Context, registrationID string) (string, error)
+	GetEventIDBySlug(ctx context.Context, slug string) (eventID, communityName string, err error)
 	GetUpcomingEventsByCommunityIDs(ctx context.Context, communityIDs []string, limit int) ([]*EventItem, error) // New method
+	GetPublicEventsByCommunityID(ctx context.Context, communityID string) ([]*Event, error)
+
+	// Calendar feed tokens
+	GetCalendarFeedToken(ctx context.Context, userID string) (string, error)
+	IssueCalendarFeedToken(ctx context.Context, userID, token string) (string, error)
+	SaveCalendarFeedToken(ctx context.Context, userID, token string) error
+	GetUserIDByCalendarFeedToken(ctx context.Context, token string) (string, error)
+
+	// iCalendar import
+	GetImportedEventIDs(ctx context.Context, communityID string, uids []string) (map[string]string, error)
+
+	// Recurrence exceptions
+	UpdateEventSession(ctx context.Context, session *EventSession) error
+	AddEventOccurrence(ctx context.Context, event *Event, session *EventSession) error
+	ShiftEventSeries(ctx context.Context, event *Event, shift, duration time.Duration, edit *OccurrenceEdit) error
+	SplitEventSeries(ctx context.Context, event *Event, series *Event, from time.Time) error
+
+	// Event staff
+	UpsertEventStaff(ctx context.Context, member *EventStaffMember) error
+	RemoveEventStaff(ctx context.Context, eventID, userID string) error
+	ListEventStaff(ctx context.Context, eventID, role string) ([]*EventStaffMember, error)
+
+	// Per-session registration
+	RegisterForSession(ctx context.Context, registration *SessionRegistration) error
+	CancelSessionRegistrations(ctx context.Context, eventID, userID, sessionID string) ([]*SessionRegistration, error)
+	PromoteSessionWaitlists(ctx context.Context, eventID string) ([]*SessionRegistration, error)
+	GetSessionRegistration(ctx context.Context, sessionID, userID string) (*SessionRegistration, error)
+	ListSessionRegistrations(ctx context.Context, sessionID, status string) ([]*SessionRegistration, error)
+	ListUserSessionRegistrations(ctx context.Context, eventID, userID string) ([]*SessionRegistration, error)
+
+	// Schedule conflicts
+	FindAttendeeConflicts(ctx context.Context, userID, eventID string, slots []ScheduleSlot) ([]*ScheduleConflict, error)
+	FindVenueConflicts(ctx context.Context, venueID, eventID string, slots []ScheduleSlot) ([]*ScheduleConflict, error)
+	FindStaffConflicts(ctx context.Context, userIDs []string, eventID string, slots []ScheduleSlot) ([]*ScheduleConflict, error)
+	ListUserAgenda(ctx context.Context, userID string, from, to time.Time) ([]*AgendaItem, error)
+
+	// Event lifecycle
+	TransitionEventStatus(ctx context.Context, transition *EventStatusTransition) error
+	ListEventStatusTransitions(ctx context.Context, eventID string) ([]*EventStatusTransition, error)
+	ListEventLifecycles(ctx context.Context, eventID string) ([]*EventLifecycle, error)
+
+	// Feedback surveys
+	CreateFeedbackSurvey(ctx context.Context, survey *FeedbackSurvey) error
+	UpdateFeedbackSurvey(ctx context.Context, survey *FeedbackSurvey) error
+	DeleteFeedbackSurvey(ctx context.Context, surveyID string) error
+	GetFeedbackSurvey(ctx context.Context, surveyID string) (*FeedbackSurvey, error)
+	ListFeedbackSurveys(ctx context.Context, eventID string) ([]*FeedbackSurvey, error)
+	HasCheckedIn(ctx context.Context, eventID, sessionID, userID string) (bool, error)
+	SubmitFeedbackResponse(ctx context.Context, response *FeedbackResponse) error
+	ListPendingFeedbackRequests(ctx context.Context, userID string) ([]*FeedbackRequest, error)
+	ClaimDueFeedbackRequests(ctx context.Context, limit int) ([]*FeedbackRequest, error)
+
+	// Certificates
+	SaveCertificateTemplate(ctx context.Context, template *CertificateTemplate) error
+	GetCertificateTemplate(ctx context.Context, eventID string) (*CertificateTemplate, error)
+	DeleteCertificateTemplate(ctx context.Context, eventID string) error
+	ListCertificateAttendance(ctx context.Context, eventID string) ([]CertificateAttendance, error)
+	CreateCertificate(ctx context.Context, certificate *Certificate) (bool, error)
+	GetCertificate(ctx context.Context, certificateID string) (*Certificate, error)
+	GetCertificateByCode(ctx context.Context, code string) (*Certificate, error)
+	ListEventCertificates(ctx context.Context, eventID string) ([]*Certificate, error)
+	ListUserCertificates(ctx context.Context, userID string) ([]*Certificate, error)
+	RevokeCertificate(ctx context.Context, certificateID, reason string) error
+
+	// Whitelist
+	AddWhitelistEntries(ctx context.Context, entries []*WhitelistEntry) (int, error)
+	ListWhitelistEntries(ctx context.Context, eventID string) ([]*WhitelistEntry, error)
+	RemoveWhitelistEntry(ctx context.Context, eventID, entryID string) error
+	ConfirmWhitelistLink(ctx context.Context, eventID, entryID, userID string) error
+	LinkEventWhitelistEntries(ctx context.Context, eventID string) (int64, error)
+	LinkUserWhitelistEntries(ctx context.Context, userID string) (int64, error)
+
+	// Event invitations
+	CreateEventInvitations(ctx context.Context, invitations []*EventInvitation) ([]*EventInvitation, error)
+	ListEventInvitations(ctx context.Context, eventID, rsvp string) ([]*EventInvitation, error)
+	GetEventInvitation(ctx context.Context, invitationID string) (*EventInvitation, error)
+	GetUserInvitation(ctx context.Context, invitationID, userID string) (*EventInvitation, error)
+	ListUserInvitations(ctx context.Context, userID, rsvp string) ([]*EventInvitation, error)
+	RespondToInvitation(ctx context.Context, invitationID, userID, rsvp string) error
+	DeleteEventInvitation(ctx context.Context, invitationID string) error
+	ClaimInvitationReminders(ctx context.Context, eventID string, interval time.Duration, maxReminders, limit int) ([]*EventInvitation, error)
+
+	// Event announcements
+	CreateEventAnnouncement(ctx context.Context, announcement *EventAnnouncement) error
+	ListEventAnnouncements(ctx context.Context, eventID string) ([]*EventAnnouncement, error)
+	GetEventAnnouncement(ctx context.Context, announcementID string) (*EventAnnouncement, error)
+	CancelEventAnnouncement(ctx context.Context, announcementID string) error
+	ClaimDueAnnouncements(ctx context.Context, announcementID string, limit int) ([]*EventAnnouncement, error)
+
+	// Session reschedules
+	ListSessionAttendees(ctx context.Context, sessionID string) ([]*SessionAttendee, error)
+	RescheduleSession(ctx context.Context, session *EventSession, reschedule *SessionReschedule, reconfirmUserIDs []string) error
+	ListSessionReschedules(ctx context.Context, sessionID string) ([]*SessionReschedule, error)
+	ListUserReconfirmations(ctx context.Context, userID, status string) ([]*SessionReconfirmation, error)
+	GetUserReconfirmation(ctx context.Context, reconfirmationID, userID string) (*SessionReconfirmation, error)
+	AnswerReconfirmation(ctx context.Context, reconfirmationID, status string) error
+
+	// Event templates
+	CreateEventTemplate(ctx context.Context, template *EventTemplate) error
+	UpdateEventTemplate(ctx context.Context, template *EventTemplate) error
+	DeleteEventTemplate(ctx context.Context, templateID string) error
+	GetEventTemplate(ctx context.Context, templateID string) (*EventTemplate, error)
+	ListEventTemplates(ctx context.Context, communityID, userID string, includeShared bool) ([]*EventTemplate, error)
+
+	// Venues
+	CreateVenue(ctx context.Context, venue *Venue) error
+	UpdateVenue(ctx context.Context, venue *Venue) error
+	DeleteVenue(ctx context.Context, venueID string) error
+	GetVenue(ctx context.Context, venueID string) (*Venue, error)
+	ListVenues(ctx context.Context, communityID string) ([]*Venue, error)
+	SetSessionVenue(ctx context.Context, sessionID string, venueID, location sql.NullString) error
+
+	// Registration approval rules
+	CreateRegistrationApprovalRule(ctx context.Context, rule *RegistrationApprovalRule) error
+	GetRegistrationApprovalRule(ctx context.Context, ruleID string) (*RegistrationApprovalRule, error)
+	ListRegistrationApprovalRules(ctx context.Context, eventID string) ([]*RegistrationApprovalRule, error)
+	DeleteRegistrationApprovalRule(ctx context.Context, ruleID string) error
+	MatchRegistrationApprovalRules(ctx context.Context, eventID, userID string) ([]*ApprovalRuleMatch, error)
+
+	// Agenda items and tracks
+	CreateEventTrack(ctx context.Context, track *EventTrack) error
+	UpdateEventTrack(ctx context.Context, track *EventTrack) error
+	DeleteEventTrack(ctx context.Context, trackID string) error
+	GetEventTrack(ctx context.Context, trackID string) (*EventTrack, error)
+	ListEventTracks(ctx context.Context, eventID string) ([]*EventTrack, error)
+	CreateAgendaItem(ctx context.Context, item *SessionAgendaItem, speakerIDs []string) error
+	UpdateAgendaItem(ctx context.Context, item *SessionAgendaItem, speakerIDs []string) error
+	DeleteAgendaItem(ctx context.Context, itemID string) error
+	GetAgendaItem(ctx context.Context, itemID, userID string) (*SessionAgendaItem, error)
+	ListAgendaItems(ctx context.Context, eventID, sessionID, userID string, starredOnly bool) ([]*SessionAgendaItem, error)
+	StarAgendaItem(ctx context.Context, itemID, userID string) error
+	UnstarAgendaItem(ctx context.Context, itemID, userID string) error
+	ShiftSessionAgendaItems(ctx context.Context, sessionID string, shift time.Duration) error
+
+	// Session Q&A
+	SetSessionQAEnabled(ctx context.Context, sessionID string, enabled bool) error
+	IsSessionQAEnabled(ctx context.Context, sessionID string) (bool, error)
+	CreateSessionQuestion(ctx context.Context, question *SessionQuestion) error
+	GetSessionQuestion(ctx context.Context, questionID, userID string) (*SessionQuestion, error)
+	ListSessionQuestions(ctx context.Context, sessionID, userID, sort string, includeHidden bool) ([]*SessionQuestion, error)
+	VoteSessionQuestion(ctx context.Context, questionID, userID string) error
+	UnvoteSessionQuestion(ctx context.Context, questionID, userID string) error
+	UpdateSessionQuestionStatus(ctx context.Context, questionID, status, moderatorID string) error
+
+	// Session polls
+	CreateSessionPoll(ctx context.Context, poll *SessionPoll) error
+	GetSessionPoll(ctx context.Context, pollID, userID string) (*SessionPoll, error)
+	ListSessionPolls(ctx context.Context, sessionID, userID string, includeDrafts bool) ([]*SessionPoll, error)
+	UpdateSessionPoll(ctx context.Context, poll *SessionPoll) error
+	DeleteSessionPoll(ctx context.Context, pollID string) error
+	OpenSessionPoll(ctx context.Context, pollID string) error
+	CloseSessionPoll(ctx context.Context, pollID string) error
+	OpenScheduledSessionPolls(ctx context.Context) ([]string, error)
+	CloseExpiredSessionPolls(ctx context.Context) ([]string, error)
+	VoteSessionPoll(ctx context.Context, pollID, optionID, userID string) error
+	IsCheckedInToSession(ctx context.Context, sessionID, userID string) (bool, error)
+
+	// Seating
+	SaveSeatingLayout(ctx context.Context, layout *SeatingLayout) error
+	GetSeatingLayout(ctx context.Context, sessionID string) (*SeatingLayout, error)
+	DeleteSeatingLayout(ctx context.Context, sessionID string) error
+	ListSeatAssignments(ctx context.Context, sessionID string) ([]*SeatAssignment, error)
+	GetSeatAssignment(ctx context.Context, sessionID, userID string) (*SeatAssignment, error)
+	ListSeatableRegistrants(ctx context.Context, sessionID string) ([]*SeatBadge, error)
+	SaveSeatAssignments(ctx context.Context, assignments []*SeatAssignment, changedBy string) error
+	DeleteSeatAssignments(ctx context.Context, sessionID string, userIDs []string, method, changedBy string) (int, error)
+	ListSeatAssignmentAudit(ctx context.Context, sessionID string, limit, offset int) ([]*SeatAssignmentAudit, int, error)
+
+	// Online meeting join links
+	IssueJoinToken(ctx context.Context, attendeeID, token string) (string, error)
+	GetAttendeeByJoinToken(ctx context.Context, token string) (*EventAttendee, error)
+	RecordVirtualCheckin(ctx context.Context, sessionID, userID, attendeeID string) error
+
+	// Registration transfers
+	CreateRegistrationTransfer(ctx context.Context, transfer *RegistrationTransfer) error
+	GetRegistrationTransfer(ctx context.Context, transferID string) (*RegistrationTransfer, error)
+	GetRecipientRegistrationTransfer(ctx context.Context, transferID, userID string) (*RegistrationTransfer, error)
+	ListEventRegistrationTransfers(ctx context.Context, eventID, status string) ([]*RegistrationTransfer, error)
+	ListUserRegistrationTransfers(ctx context.Context, userID, status string) ([]*RegistrationTransfer, error)
+	AcceptRegistrationTransfer(ctx context.Context, transferID, userID string) error
+	ApproveRegistrationTransfer(ctx context.Context, transferID, approverID string) error
+	CloseRegistrationTransfer(ctx context.Context, transferID, status string) error
+	CompleteRegistrationTransfer(ctx context.Context, transferID string) error
+
+	// Session reminders
+	SyncEventReminders(ctx context.Context, eventID string) error
+	ClaimDueReminders(ctx context.Context, limit int) ([]*ScheduledReminder, error)
+	MarkReminderSent(ctx context.Context, reminderID string) error
+	MarkReminderFailed(ctx context.Context, reminderID, reason string) error
 
 	// Transaction management
 	BeginTx(ctx context.Context) (pgx.Tx, error)
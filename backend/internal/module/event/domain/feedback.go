@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrFeedbackSurveyNotFound   = errors.New("feedback survey not found")
+	ErrInvalidFeedbackSurvey    = errors.New("invalid feedback survey")
+	ErrInvalidFeedbackResponse  = errors.New("invalid feedback response")
+	ErrFeedbackSurveyClosed     = errors.New("feedback survey is closed")
+	ErrFeedbackNotAllowed       = errors.New("only attendees who checked in can give feedback")
+	ErrFeedbackAlreadySubmitted = errors.New("feedback has already been submitted")
+)
+
+// Types of a feedback survey question.
+const (
+	FeedbackQuestionRating = "rating" // A score from 1 to the question's scale
+	FeedbackQuestionNPS    = "nps"    // Net Promoter Score: how likely the attendee would recommend it, 0 to 10
+	FeedbackQuestionText   = "text"   // Free text
+)
+
+// Limits of feedback surveys.
+const (
+	DefaultFeedbackRatingScale = 5
+	MaxFeedbackRatingScale     = 10
+	MaxFeedbackQuestions       = 30
+	MaxFeedbackTextLength      = 2000
+	NPSMaxScore                = 10
+)
+
+// FeedbackQuestion is one question of a feedback survey, stored in its 'questions' JSONB column.
+type FeedbackQuestion struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+	Scale    int    `json:"scale,omitempty"` // Highest score of a rating question
+}
+
+// FeedbackAnswer is the answer to one question, stored in a response's 'answers' JSONB column.
+// Score is set for rating and NPS questions, Text for text questions.
+type FeedbackAnswer struct {
+	QuestionID string `json:"question_id"`
+	Score      *int   `json:"score,omitempty"`
+	Text       string `json:"text,omitempty"`
+}
+
+// FeedbackSurvey corresponds to the 'event_feedback_surveys' table. A survey without a session covers the
+// whole event and is answered once, or once per attended session if PerSession is set.
+type FeedbackSurvey struct {
+	ID               string             `json:"id"`
+	EventID          string             `json:"event_id"`
+	SessionID        sql.NullString     `json:"session_id,omitempty"`
+	Title            string             `json:"title"`
+	Description      sql.NullString     `json:"description,omitempty"`
+	Questions        []FeedbackQuestion `json:"questions"`
+	PerSession       bool               `json:"per_session"`
+	SendDelayMinutes int                `json:"send_delay_minutes"` // How long after the session ends the request is sent
+	IsActive         bool               `json:"is_active"`
+	CreatedBy        string             `json:"created_by"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+
+	// Enriched data (from joins)
+	ResponseCount int `json:"response_count"`
+}
+
+// Validate checks the survey's settings and questions. Questions without an ID are numbered ("q1", "q2",
+// ...) and rating questions without a scale get the default one.
+func (s *FeedbackSurvey) Validate() error {
+	s.Title = strings.TrimSpace(s.Title)
+	if s.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidFeedbackSurvey)
+	}
+	if s.SendDelayMinutes < 0 {
+		return fmt.Errorf("%w: send_delay_minutes cannot be negative", ErrInvalidFeedbackSurvey)
+	}
+	if s.SessionID.Valid && s.PerSession {
+		return fmt.Errorf("%w: per_session only applies to event surveys", ErrInvalidFeedbackSurvey)
+	}
+	if len(s.Questions) == 0 || len(s.Questions) > MaxFeedbackQuestions {
+		return fmt.Errorf("%w: a survey needs between 1 and %d questions", ErrInvalidFeedbackSurvey, MaxFeedbackQuestions)
+	}
+
+	seen := make(map[string]bool, len(s.Questions))
+	for i := range s.Questions {
+		question := &s.Questions[i]
+		if question.ID == "" {
+			question.ID = fmt.Sprintf("q%d", i+1)
+		}
+		if seen[question.ID] {
+			return fmt.Errorf("%w: duplicate question id %q", ErrInvalidFeedbackSurvey, question.ID)
+		}
+		seen[question.ID] = true
+		if strings.TrimSpace(question.Label) == "" {
+			return fmt.Errorf("%w: question %q needs a label", ErrInvalidFeedbackSurvey, question.ID)
+		}
+
+		switch question.Type {
+		case FeedbackQuestionRating:
+			if question.Scale == 0 {
+				question.Scale = DefaultFeedbackRatingScale
+			}
+			if question.Scale < 2 || question.Scale > MaxFeedbackRatingScale {
+				return fmt.Errorf("%w: the scale of question %q must be between 2 and %d", ErrInvalidFeedbackSurvey, question.ID, MaxFeedbackRatingScale)
+			}
+		case FeedbackQuestionNPS, FeedbackQuestionText:
+			question.Scale = 0
+		default:
+			return fmt.Errorf("%w: question %q has unknown type %q", ErrInvalidFeedbackSurvey, question.ID, question.Type)
+		}
+	}
+	return nil
+}
+
+// ValidateAnswers checks a response against the survey's questions: every answer must belong to a question,
+// scores must be within the question's range and required questions must be answered.
+func (s *FeedbackSurvey) ValidateAnswers(answers []FeedbackAnswer) error {
+	questions := make(map[string]FeedbackQuestion, len(s.Questions))
+	for _, question := range s.Questions {
+		questions[question.ID] = question
+	}
+
+	answered := make(map[string]bool, len(answers))
+	for _, answer := range answers {
+		question, ok := questions[answer.QuestionID]
+		if !ok {
+			return fmt.Errorf("%w: unknown question %q", ErrInvalidFeedbackResponse, answer.QuestionID)
+		}
+		if answered[answer.QuestionID] {
+			return fmt.Errorf("%w: question %q is answered twice", ErrInvalidFeedbackResponse, answer.QuestionID)
+		}
+
+		switch question.Type {
+		case FeedbackQuestionRating, FeedbackQuestionNPS:
+			low, high := 1, question.Scale
+			if question.Type == FeedbackQuestionNPS {
+				low, high = 0, NPSMaxScore
+			}
+			if answer.Score == nil || *answer.Score < low || *answer.Score > high || answer.Text != "" {
+				return fmt.Errorf("%w: question %q needs a score from %d to %d", ErrInvalidFeedbackResponse, answer.QuestionID, low, high)
+			}
+		case FeedbackQuestionText:
+			if answer.Score != nil || len(answer.Text) > MaxFeedbackTextLength {
+				return fmt.Errorf("%w: question %q needs a text of at most %d characters", ErrInvalidFeedbackResponse, answer.QuestionID, MaxFeedbackTextLength)
+			}
+			if strings.TrimSpace(answer.Text) == "" {
+				continue
+			}
+		}
+		answered[answer.QuestionID] = true
+	}
+
+	for _, question := range s.Questions {
+		if question.Required && !answered[question.ID] {
+			return fmt.Errorf("%w: question %q is required", ErrInvalidFeedbackResponse, question.ID)
+		}
+	}
+	return nil
+}
+
+// ResponseSessionID returns the session a response to the survey is given for: the survey's own session,
+// the given session for per-session event surveys, and none for event surveys answered once.
+func (s *FeedbackSurvey) ResponseSessionID(sessionID string) (sql.NullString, error) {
+	switch {
+	case s.SessionID.Valid:
+		if sessionID != "" && sessionID != s.SessionID.String {
+			return sql.NullString{}, fmt.Errorf("%w: the survey belongs to another session", ErrInvalidFeedbackResponse)
+		}
+		return s.SessionID, nil
+	case s.PerSession:
+		if sessionID == "" {
+			return sql.NullString{}, fmt.Errorf("%w: session_id is required for this survey", ErrInvalidFeedbackResponse)
+		}
+		return sql.NullString{String: sessionID, Valid: true}, nil
+	}
+	return sql.NullString{}, nil
+}
+
+// FeedbackResponse corresponds to the 'event_feedback_responses' table.
+type FeedbackResponse struct {
+	ID          string           `json:"id"`
+	SurveyID    string           `json:"survey_id"`
+	EventID     string           `json:"event_id"`
+	SessionID   sql.NullString   `json:"session_id,omitempty"`
+	UserID      string           `json:"user_id"`
+	Answers     []FeedbackAnswer `json:"answers"`
+	SubmittedAt time.Time        `json:"submitted_at"`
+}
+
+// FeedbackRequest corresponds to the 'event_feedback_requests' table: a survey sent to an attendee who
+// checked in, for one session or for the whole event.
+type FeedbackRequest struct {
+	ID        string         `json:"id"`
+	SurveyID  string         `json:"survey_id"`
+	EventID   string         `json:"event_id"`
+	SessionID sql.NullString `json:"session_id,omitempty"`
+	UserID    string         `json:"user_id"`
+	SentAt    time.Time      `json:"sent_at"`
+
+	// Enriched data (from joins)
+	SurveyTitle      string         `json:"survey_title"`
+	EventName        string         `json:"event_name"`
+	SessionName      sql.NullString `json:"session_name,omitempty"`
+	SessionStartTime sql.NullTime   `json:"session_start_time,omitempty"`
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrInvalidInvitation  = errors.New("invalid invitation")
+	ErrInvalidRSVP        = errors.New("rsvp must be going, maybe or declined")
+	ErrInvitationsClosed  = errors.New("invitations can only be sent while the event accepts registrations")
+)
+
+// EventInvitationSubject is the NATS subject an EventInvitationSentEvent is published on.
+const EventInvitationSubject = "events.invitation.sent"
+
+// MaxInvitationsPerRequest limits the number of people invited at once.
+const MaxInvitationsPerRequest = 500
+
+// Reminders of invitations not responded to. Reminders are sent automatically every
+// InvitationReminderInterval, up to MaxAutomaticInvitationReminders times; hosts can send more, once per
+// ManualInvitationReminderInterval, up to MaxInvitationReminders in total.
+const (
+	InvitationReminderInterval       = 3 * 24 * time.Hour
+	ManualInvitationReminderInterval = 24 * time.Hour
+	MaxAutomaticInvitationReminders  = 2
+	MaxInvitationReminders           = 5
+)
+
+// RSVP responses of invitations. Invitations start as RSVPPending until the invitee responds.
+const (
+	RSVPPending  = "pending"
+	RSVPGoing    = "going"
+	RSVPMaybe    = "maybe"
+	RSVPDeclined = "declined"
+)
+
+// IsValidRSVP reports whether rsvp is a response an invitee can give.
+func IsValidRSVP(rsvp string) bool {
+	switch rsvp {
+	case RSVPGoing, RSVPMaybe, RSVPDeclined:
+		return true
+	}
+	return false
+}
+
+// EventInvitation corresponds to the 'event_invitations' table. Invitations by email have no InviteeUserID
+// until the account with that email responds.
+type EventInvitation struct {
+	ID             string         `json:"id"`
+	EventID        string         `json:"event_id"`
+	InviteeUserID  sql.NullString `json:"invitee_user_id,omitempty"`
+	InviteeEmail   sql.NullString `json:"invitee_email,omitempty"`
+	InviterID      sql.NullString `json:"inviter_id,omitempty"`
+	Message        sql.NullString `json:"message,omitempty"`
+	RSVP           string         `json:"rsvp"`
+	RespondedAt    sql.NullTime   `json:"responded_at,omitempty"`
+	RemindersSent  int            `json:"reminders_sent"`
+	LastRemindedAt sql.NullTime   `json:"last_reminded_at,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+
+	// Joined fields
+	InviteeName        sql.NullString `json:"invitee_name,omitempty"`
+	InviterName        sql.NullString `json:"inviter_name,omitempty"`
+	EventName          string         `json:"event_name"`
+	EventStartTime     sql.NullTime   `json:"event_start_time,omitempty"`
+	RegistrationStatus sql.NullString `json:"registration_status,omitempty"`
+}
+
+// InvitationSummary counts the invitations of an event by response, and how many invitees registered.
+type InvitationSummary struct {
+	Invited    int `json:"invited"`
+	Pending    int `json:"pending"`
+	Going      int `json:"going"`
+	Maybe      int `json:"maybe"`
+	Declined   int `json:"declined"`
+	Registered int `json:"registered"`
+}
+
+// InvitationDashboard is the RSVP overview hosts get of an event's invitations.
+type InvitationDashboard struct {
+	EventID     string             `json:"event_id"`
+	Summary     InvitationSummary  `json:"summary"`
+	Invitations []*EventInvitation `json:"invitations"`
+}
+
+// NewInvitationDashboard counts the invitations by response and registration.
+func NewInvitationDashboard(eventID string, invitations []*EventInvitation) *InvitationDashboard {
+	dashboard := &InvitationDashboard{EventID: eventID, Invitations: invitations}
+	for _, invitation := range invitations {
+		dashboard.Summary.Invited++
+		switch invitation.RSVP {
+		case RSVPPending:
+			dashboard.Summary.Pending++
+		case RSVPGoing:
+			dashboard.Summary.Going++
+		case RSVPMaybe:
+			dashboard.Summary.Maybe++
+		case RSVPDeclined:
+			dashboard.Summary.Declined++
+		}
+		if invitation.RegistrationStatus.Valid && invitation.RegistrationStatus.String != "cancelled" {
+			dashboard.Summary.Registered++
+		}
+	}
+	return dashboard
+}
+
+// InvitationResponse is the outcome of an RSVP. Responding "going" registers the invitee under the event's
+// usual rules; when that is not possible, e.g. because the event is full, RegistrationError says why and
+// the response is kept.
+type InvitationResponse struct {
+	Invitation         *EventInvitation `json:"invitation"`
+	RegistrationStatus string           `json:"registration_status,omitempty"`
+	RegistrationError  string           `json:"registration_error,omitempty"`
+}
+
+// EventInvitationSentEvent is published on EventInvitationSubject for each invitation sent, and again for each
+// reminder. UserID is empty for invitees without an account.
+type EventInvitationSentEvent struct {
+	InvitationID string `json:"invitation_id"`
+	EventID      string `json:"event_id"`
+	EventName    string `json:"event_name"`
+	UserID       string `json:"user_id,omitempty"`
+	Email        string `json:"email,omitempty"`
+	Message      string `json:"message,omitempty"`
+	Reminder     bool   `json:"reminder"`
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidStatusTransition = errors.New("invalid event status transition")
+
+// Statuses of an event's lifecycle, the values of the 'event_status' enum.
+const (
+	EventStatusDraft              = "draft"
+	EventStatusPublished          = "published"
+	EventStatusRegistrationOpen   = "registration_open"
+	EventStatusRegistrationClosed = "registration_closed"
+	EventStatusOngoing            = "ongoing"
+	EventStatusCompleted          = "completed"
+	EventStatusCancelled          = "cancelled"
+	EventStatusArchived           = "archived"
+)
+
+// EventStatusChangedSubjectPrefix is followed by the new status in the subject an EventStatusChangedEvent
+// is published on, e.g. "events.status.completed". Subscribe to "events.status.*" to receive all of them.
+const EventStatusChangedSubjectPrefix = "events.status."
+
+// eventStatusTransitions lists the statuses each status may move to.
+var eventStatusTransitions = map[string][]string{
+	EventStatusDraft:              {EventStatusPublished, EventStatusCancelled},
+	EventStatusPublished:          {EventStatusRegistrationOpen, EventStatusRegistrationClosed, EventStatusOngoing, EventStatusCompleted, EventStatusCancelled},
+	EventStatusRegistrationOpen:   {EventStatusRegistrationClosed, EventStatusOngoing, EventStatusCompleted, EventStatusCancelled},
+	EventStatusRegistrationClosed: {EventStatusRegistrationOpen, EventStatusOngoing, EventStatusCompleted, EventStatusCancelled},
+	EventStatusOngoing:            {EventStatusCompleted, EventStatusCancelled},
+	EventStatusCompleted:          {EventStatusArchived},
+	EventStatusCancelled:          {EventStatusArchived},
+	EventStatusArchived:           {},
+}
+
+// scheduledStatusOrder ranks the statuses the lifecycle worker moves events through. The worker only
+// ever moves an event forward, so manual changes such as closing registration early are kept.
+var scheduledStatusOrder = map[string]int{
+	EventStatusPublished:          1,
+	EventStatusRegistrationOpen:   2,
+	EventStatusRegistrationClosed: 3,
+	EventStatusOngoing:            4,
+	EventStatusCompleted:          5,
+}
+
+// ValidateStatusTransition reports whether an event may move from one status to another.
+func ValidateStatusTransition(from, to string) error {
+	allowed, ok := eventStatusTransitions[from]
+	if !ok {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidStatusTransition, from)
+	}
+	if _, known := eventStatusTransitions[to]; !known {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidStatusTransition, to)
+	}
+	for _, status := range allowed {
+		if status == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, from, to)
+}
+
+// AcceptsRegistrations reports whether an event in the given status takes new registrations,
+// subject to its registration window.
+func AcceptsRegistrations(status string) bool {
+	switch status {
+	case EventStatusPublished, EventStatusRegistrationOpen, EventStatusOngoing:
+		return true
+	}
+	return false
+}
+
+// EventStatusTransition corresponds to the 'event_status_transitions' table, one step in an event's lifecycle.
+type EventStatusTransition struct {
+	ID          string         `json:"id"`
+	EventID     string         `json:"event_id"`
+	FromStatus  string         `json:"from_status"`
+	ToStatus    string         `json:"to_status"`
+	TriggeredBy sql.NullString `json:"triggered_by,omitempty"` // NULL for transitions made by the lifecycle worker
+	Reason      sql.NullString `json:"reason,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+// EventStatusChangedEvent is published whenever an event changes status.
+type EventStatusChangedEvent struct {
+	EventID     string    `json:"event_id"`
+	CommunityID string    `json:"community_id"`
+	EventName   string    `json:"event_name"`
+	CreatedBy   string    `json:"created_by"`
+	FromStatus  string    `json:"from_status"`
+	ToStatus    string    `json:"to_status"`
+	TriggeredBy string    `json:"triggered_by,omitempty"` // Empty for transitions made by the lifecycle worker
+	Reason      string    `json:"reason,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// EventLifecycle holds what is needed to work out the status an event should currently have: the event
+// with its registration window, and the bounds of its sessions that are not cancelled.
+type EventLifecycle struct {
+	Event             *Event
+	FirstSessionStart sql.NullTime
+	LastSessionEnd    sql.NullTime
+}
+
+// ScheduledStatus returns the status the event's schedule calls for at the given time. Registration is
+// open from registration_opens_at (or publication) until registration_closes_at, the event is ongoing from
+// the start of its first session and completed after its last session ends. Open-ended recurring series
+// never complete on their own.
+func (l *EventLifecycle) ScheduledStatus(now time.Time) string {
+	event := l.Event
+	start, end := l.FirstSessionStart, l.LastSessionEnd
+	if !start.Valid {
+		start = event.StartTime
+	}
+	if !end.Valid {
+		end = event.EndTime
+	}
+
+	switch {
+	case end.Valid && !now.Before(end.Time) && !l.isOpenEnded():
+		return EventStatusCompleted
+	case start.Valid && !now.Before(start.Time):
+		return EventStatusOngoing
+	case event.RegistrationClosesAt.Valid && !now.Before(event.RegistrationClosesAt.Time):
+		return EventStatusRegistrationClosed
+	case event.RegistrationOpensAt.Valid && now.Before(event.RegistrationOpensAt.Time):
+		return EventStatusPublished
+	}
+	return EventStatusRegistrationOpen
+}
+
+// NextStatus returns the status the event should move to at the given time, if its schedule is ahead of
+// its current status.
+func (l *EventLifecycle) NextStatus(now time.Time) (string, bool) {
+	current, ok := scheduledStatusOrder[l.Event.Status]
+	if !ok {
+		return "", false
+	}
+	next := l.ScheduledStatus(now)
+	if scheduledStatusOrder[next] <= current {
+		return "", false
+	}
+	return next, true
+}
+
+// isOpenEnded reports whether the event is a recurring series without an end. A rule that cannot be
+// parsed counts as open-ended, so the event is not completed by mistake.
+func (l *EventLifecycle) isOpenEnded() bool {
+	if !l.Event.IsRecurring {
+		return false
+	}
+	recurrence, err := ParseRecurrence(l.Event.RecurrenceRule)
+	if err != nil {
+		return true
+	}
+	return recurrence != nil && !recurrence.IsBounded(l.Event)
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var allEventStatuses = []string{
+	EventStatusDraft,
+	EventStatusPublished,
+	EventStatusRegistrationOpen,
+	EventStatusRegistrationClosed,
+	EventStatusOngoing,
+	EventStatusCompleted,
+	EventStatusCancelled,
+	EventStatusArchived,
+}
+
+func TestValidateStatusTransition(t *testing.T) {
+	allowed := map[[2]string]bool{
+		{EventStatusDraft, EventStatusPublished}:                     true,
+		{EventStatusDraft, EventStatusCancelled}:                     true,
+		{EventStatusPublished, EventStatusRegistrationOpen}:          true,
+		{EventStatusPublished, EventStatusRegistrationClosed}:        true,
+		{EventStatusPublished, EventStatusOngoing}:                   true,
+		{EventStatusPublished, EventStatusCompleted}:                 true,
+		{EventStatusPublished, EventStatusCancelled}:                 true,
+		{EventStatusRegistrationOpen, EventStatusRegistrationClosed}: true,
+		{EventStatusRegistrationOpen, EventStatusOngoing}:            true,
+		{EventStatusRegistrationOpen, EventStatusCompleted}:          true,
+		{EventStatusRegistrationOpen, EventStatusCancelled}:          true,
+		{EventStatusRegistrationClosed, EventStatusRegistrationOpen}: true,
+		{EventStatusRegistrationClosed, EventStatusOngoing}:          true,
+		{EventStatusRegistrationClosed, EventStatusCompleted}:        true,
+		{EventStatusRegistrationClosed, EventStatusCancelled}:        true,
+		{EventStatusOngoing, EventStatusCompleted}:                   true,
+		{EventStatusOngoing, EventStatusCancelled}:                   true,
+		{EventStatusCompleted, EventStatusArchived}:                  true,
+		{EventStatusCancelled, EventStatusArchived}:                  true,
+	}
+
+	for _, from := range allEventStatuses {
+		for _, to := range allEventStatuses {
+			from, to := from, to
+			t.Run(from+" to "+to, func(t *testing.T) {
+				err := ValidateStatusTransition(from, to)
+				if allowed[[2]string{from, to}] {
+					if err != nil {
+						t.Fatalf("want allowed, got %v", err)
+					}
+					return
+				}
+				if !errors.Is(err, ErrInvalidStatusTransition) {
+					t.Fatalf("want ErrInvalidStatusTransition, got %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestValidateStatusTransitionUnknownStatus(t *testing.T) {
+	tests := []struct {
+		name, from, to, unknown string
+	}{
+		{name: "unknown target", from: EventStatusDraft, to: "postponed", unknown: "postponed"},
+		{name: "unknown source", from: "postponed", to: EventStatusPublished, unknown: "postponed"},
+		{name: "empty target", from: EventStatusPublished, to: "", unknown: `""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStatusTransition(tt.from, tt.to)
+			if !errors.Is(err, ErrInvalidStatusTransition) {
+				t.Fatalf("want ErrInvalidStatusTransition, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.unknown) {
+				t.Fatalf("error %q does not name the unknown status %s", err, tt.unknown)
+			}
+		})
+	}
+}
+
+func TestAcceptsRegistrations(t *testing.T) {
+	accepting := map[string]bool{
+		EventStatusPublished:        true,
+		EventStatusRegistrationOpen: true,
+		EventStatusOngoing:          true,
+	}
+	for _, status := range allEventStatuses {
+		if got := AcceptsRegistrations(status); got != accepting[status] {
+			t.Errorf("%s: got %v, want %v", status, got, accepting[status])
+		}
+	}
+}
+
+func TestEventLifecycleScheduledStatus(t *testing.T) {
+	base := time.Date(2026, time.June, 10, 12, 0, 0, 0, time.UTC)
+	at := func(hours int) sql.NullTime {
+		return sql.NullTime{Time: base.Add(time.Duration(hours) * time.Hour), Valid: true}
+	}
+	openEnded, _ := (&Recurrence{RRule: "FREQ=WEEKLY"}).Marshal()
+	bounded, _ := (&Recurrence{RRule: "FREQ=WEEKLY;COUNT=3"}).Marshal()
+
+	tests := []struct {
+		name  string
+		event Event
+		first sql.NullTime
+		last  sql.NullTime
+		want  string
+	}{
+		{name: "before registration opens", event: Event{RegistrationOpensAt: at(2), StartTime: at(48), EndTime: at(50)}, want: EventStatusPublished},
+		{name: "registration open", event: Event{RegistrationOpensAt: at(-2), StartTime: at(48), EndTime: at(50)}, want: EventStatusRegistrationOpen},
+		{name: "no registration window", event: Event{StartTime: at(48), EndTime: at(50)}, want: EventStatusRegistrationOpen},
+		{name: "registration closed", event: Event{RegistrationClosesAt: at(-1), StartTime: at(48), EndTime: at(50)}, want: EventStatusRegistrationClosed},
+		{name: "registration closes exactly now", event: Event{RegistrationClosesAt: at(0), StartTime: at(48), EndTime: at(50)}, want: EventStatusRegistrationClosed},
+		{name: "started", event: Event{StartTime: at(-1), EndTime: at(2)}, want: EventStatusOngoing},
+		{name: "ended", event: Event{StartTime: at(-3), EndTime: at(-1)}, want: EventStatusCompleted},
+		{name: "sessions override event times", event: Event{StartTime: at(-3), EndTime: at(-1)}, first: at(-3), last: at(24), want: EventStatusOngoing},
+		{name: "first session ahead of event start", event: Event{StartTime: at(-3), EndTime: at(-1)}, first: at(5), last: at(24), want: EventStatusRegistrationOpen},
+		{name: "open-ended series never completes", event: Event{IsRecurring: true, RecurrenceRule: openEnded, StartTime: at(-300), EndTime: at(-299)}, last: at(-1), want: EventStatusOngoing},
+		{name: "bounded series completes", event: Event{IsRecurring: true, RecurrenceRule: bounded, StartTime: at(-300), EndTime: at(-299)}, last: at(-1), want: EventStatusCompleted},
+		{name: "unparsable rule counts as open-ended", event: Event{IsRecurring: true, RecurrenceRule: json.RawMessage(`{"rrule":`), StartTime: at(-3), EndTime: at(-1)}, want: EventStatusOngoing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lifecycle := &EventLifecycle{Event: &tt.event, FirstSessionStart: tt.first, LastSessionEnd: tt.last}
+			if got := lifecycle.ScheduledStatus(base); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventLifecycleNextStatus(t *testing.T) {
+	now := time.Date(2026, time.June, 10, 12, 0, 0, 0, time.UTC)
+	upcoming := Event{StartTime: sql.NullTime{Time: now.Add(48 * time.Hour), Valid: true}, EndTime: sql.NullTime{Time: now.Add(50 * time.Hour), Valid: true}}
+	running := Event{StartTime: sql.NullTime{Time: now.Add(-time.Hour), Valid: true}, EndTime: sql.NullTime{Time: now.Add(time.Hour), Valid: true}}
+	ended := Event{StartTime: sql.NullTime{Time: now.Add(-3 * time.Hour), Valid: true}, EndTime: sql.NullTime{Time: now.Add(-time.Hour), Valid: true}}
+
+	tests := []struct {
+		name     string
+		event    Event
+		status   string
+		want     string
+		wantMove bool
+	}{
+		{name: "published opens registration", event: upcoming, status: EventStatusPublished, want: EventStatusRegistrationOpen, wantMove: true},
+		{name: "already open", event: upcoming, status: EventStatusRegistrationOpen},
+		{name: "closed early by hand is kept", event: upcoming, status: EventStatusRegistrationClosed},
+		{name: "starts", event: running, status: EventStatusRegistrationClosed, want: EventStatusOngoing, wantMove: true},
+		{name: "completes", event: ended, status: EventStatusOngoing, want: EventStatusCompleted, wantMove: true},
+		{name: "skips ahead", event: ended, status: EventStatusPublished, want: EventStatusCompleted, wantMove: true},
+		{name: "drafts are left alone", event: ended, status: EventStatusDraft},
+		{name: "cancelled events are left alone", event: ended, status: EventStatusCancelled},
+		{name: "archived events are left alone", event: ended, status: EventStatusArchived},
+		{name: "completed events stay completed", event: ended, status: EventStatusCompleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := tt.event
+			event.Status = tt.status
+			got, moved := (&EventLifecycle{Event: &event}).NextStatus(now)
+			if moved != tt.wantMove || got != tt.want {
+				t.Fatalf("got (%q, %v), want (%q, %v)", got, moved, tt.want, tt.wantMove)
+			}
+			if moved {
+				if err := ValidateStatusTransition(tt.status, got); err != nil {
+					t.Fatalf("scheduled move is not an allowed transition: %v", err)
+				}
+			}
+		})
+	}
+}
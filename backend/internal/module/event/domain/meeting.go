@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidMeetingLinkReveal = errors.New("meeting link reveal must be between 0 and 10080 minutes")
+	ErrInvalidJoinLink          = errors.New("invalid join link")
+	ErrNoOnlineMeeting          = errors.New("the event has no online meeting")
+	ErrSessionNotJoinable       = errors.New("no session is open to join right now")
+)
+
+const (
+	// MaxMeetingLinkRevealMinutes caps how long before a session its meeting link can be revealed: a week.
+	MaxMeetingLinkRevealMinutes = 7 * 24 * 60
+	// JoinOpensBefore is how long before a session starts a join link opens its meeting. The link stays open
+	// until the session ends.
+	JoinOpensBefore = 30 * time.Minute
+
+	// CheckinMethodVirtual is the method of check-ins recorded when an attendee joins the online meeting
+	// through their join link.
+	CheckinMethodVirtual = "virtual"
+)
+
+// ValidateMeetingLinkReveal checks the event's meeting link reveal window, if any.
+func (e *Event) ValidateMeetingLinkReveal() error {
+	if e.MeetingLinkRevealMinutes.Valid && (e.MeetingLinkRevealMinutes.Int32 < 0 || e.MeetingLinkRevealMinutes.Int32 > MaxMeetingLinkRevealMinutes) {
+		return ErrInvalidMeetingLinkReveal
+	}
+	return nil
+}
+
+// HasMeetingLink reports whether the event or one of its sessions has an online meeting link.
+func (e *Event) HasMeetingLink() bool {
+	if e.OnlineMeetingURL.Valid && e.OnlineMeetingURL.String != "" {
+		return true
+	}
+	for _, session := range e.Sessions {
+		if session.OnlineMeetingURLOverride.Valid && session.OnlineMeetingURLOverride.String != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// SessionMeetingURL returns the meeting link of a session: its own, or the event's.
+func (e *Event) SessionMeetingURL(session *EventSession) string {
+	if session.OnlineMeetingURLOverride.Valid && session.OnlineMeetingURLOverride.String != "" {
+		return session.OnlineMeetingURLOverride.String
+	}
+	if e.OnlineMeetingURL.Valid {
+		return e.OnlineMeetingURL.String
+	}
+	return ""
+}
+
+// MeetingLinkRevealed reports whether registrants see the meeting link of a session starting at start.
+func (e *Event) MeetingLinkRevealed(start, now time.Time) bool {
+	if !e.MeetingLinkRevealMinutes.Valid {
+		return true
+	}
+	return !now.Before(start.Add(-time.Duration(e.MeetingLinkRevealMinutes.Int32) * time.Minute))
+}
+
+// HideMeetingLinks clears the meeting links of the event and its sessions from what the caller sees. With
+// registrant set, the links of sessions within the reveal window are kept, and the event's own link once
+// its next session is within it. MeetingLinkHidden is set when a link was cleared.
+func (e *Event) HideMeetingLinks(registrant bool, now time.Time) {
+	var next *EventSession
+	for i := range e.Sessions {
+		session := &e.Sessions[i]
+		if !session.IsCancelled && session.EndTime.After(now) && (next == nil || session.StartTime.Before(next.StartTime)) {
+			next = session
+		}
+		if !session.OnlineMeetingURLOverride.Valid {
+			continue
+		}
+		if !registrant || !e.MeetingLinkRevealed(session.StartTime, now) {
+			session.OnlineMeetingURLOverride = sql.NullString{}
+			e.MeetingLinkHidden = true
+		}
+	}
+
+	if !e.OnlineMeetingURL.Valid {
+		return
+	}
+	revealed := registrant
+	if revealed && e.MeetingLinkRevealMinutes.Valid {
+		switch {
+		case next != nil:
+			revealed = e.MeetingLinkRevealed(next.StartTime, now)
+		case e.StartTime.Valid:
+			revealed = e.MeetingLinkRevealed(e.StartTime.Time, now)
+		}
+	}
+	if !revealed {
+		e.OnlineMeetingURL = sql.NullString{}
+		e.MeetingLinkHidden = true
+	}
+}
+
+// JoinableSession picks the session a join link opens at now: the earliest session that is not cancelled,
+// opens within JoinOpensBefore and has not ended. Sessions not in allowed are skipped when allowed is set.
+func (e *Event) JoinableSession(now time.Time, allowed map[string]bool) *EventSession {
+	var joinable *EventSession
+	for i := range e.Sessions {
+		session := &e.Sessions[i]
+		if session.IsCancelled || !session.EndTime.After(now) || now.Before(session.StartTime.Add(-JoinOpensBefore)) {
+			continue
+		}
+		if allowed != nil && !allowed[session.ID] {
+			continue
+		}
+		if joinable == nil || session.StartTime.Before(joinable.StartTime) {
+			joinable = session
+		}
+	}
+	return joinable
+}
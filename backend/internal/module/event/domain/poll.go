@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPollNotFound    = errors.New("poll not found")
+	ErrInvalidPoll     = errors.New("invalid poll")
+	ErrPollNotOpen     = errors.New("poll is not open for voting")
+	ErrPollNotDraft    = errors.New("only polls that were not opened yet can be opened or changed")
+	ErrNotCheckedIn    = errors.New("only attendees checked in to the session can vote")
+	ErrInvalidPollVote = errors.New("option does not belong to this poll")
+)
+
+// Statuses of a SessionPoll.
+const (
+	PollStatusDraft  = "draft"  // Prepared by staff, possibly scheduled to open
+	PollStatusOpen   = "open"   // Accepting votes
+	PollStatusClosed = "closed" // Results are final
+)
+
+// Limits of session polls.
+const (
+	MaxPollQuestionLength = 300
+	MaxPollOptionLength   = 200
+	MinPollOptions        = 2
+	MaxPollOptions        = 10
+	MaxPollDuration       = 24 * 60 * 60 // In seconds
+)
+
+// Types of SessionLiveEvent for polls.
+const (
+	SessionLivePollOpened  = "poll_opened"
+	SessionLivePollResults = "poll_results" // A vote was cast or changed
+	SessionLivePollClosed  = "poll_closed"
+)
+
+// SessionPoll corresponds to the 'session_polls' table: a single-choice poll run live during a session.
+// Polls open by hand or at OpensAt, and close by hand or DurationSeconds after opening.
+type SessionPoll struct {
+	ID              string               `json:"id"`
+	SessionID       string               `json:"session_id"`
+	EventID         string               `json:"event_id"`
+	Question        string               `json:"question"`
+	Status          string               `json:"status"`
+	DurationSeconds int                  `json:"duration_seconds"` // 0 keeps the poll open until it is closed
+	OpensAt         sql.NullTime         `json:"opens_at,omitempty"`
+	ClosesAt        sql.NullTime         `json:"closes_at,omitempty"`
+	OpenedAt        sql.NullTime         `json:"opened_at,omitempty"`
+	ClosedAt        sql.NullTime         `json:"closed_at,omitempty"`
+	CreatedBy       sql.NullString       `json:"created_by,omitempty"`
+	Options         []*SessionPollOption `json:"options"`
+	TotalVotes      int                  `json:"total_votes"`
+	UserOptionID    sql.NullString       `json:"user_option_id,omitempty"` // The option the user voted for
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
+}
+
+// SessionPollOption corresponds to the 'session_poll_options' table.
+type SessionPollOption struct {
+	ID        string `json:"id"`
+	PollID    string `json:"poll_id"`
+	Label     string `json:"label"`
+	Position  int    `json:"position"`
+	VoteCount int    `json:"vote_count"`
+}
+
+// Validate checks the poll's question, options, duration and opening time.
+func (p *SessionPoll) Validate() error {
+	p.Question = strings.TrimSpace(p.Question)
+	if p.Question == "" {
+		return fmt.Errorf("%w: question is required", ErrInvalidPoll)
+	}
+	if len([]rune(p.Question)) > MaxPollQuestionLength {
+		return fmt.Errorf("%w: question is limited to %d characters", ErrInvalidPoll, MaxPollQuestionLength)
+	}
+	if len(p.Options) < MinPollOptions || len(p.Options) > MaxPollOptions {
+		return fmt.Errorf("%w: a poll has between %d and %d options", ErrInvalidPoll, MinPollOptions, MaxPollOptions)
+	}
+	for i, option := range p.Options {
+		option.Label = strings.TrimSpace(option.Label)
+		if option.Label == "" {
+			return fmt.Errorf("%w: option %d has no label", ErrInvalidPoll, i+1)
+		}
+		if len([]rune(option.Label)) > MaxPollOptionLength {
+			return fmt.Errorf("%w: options are limited to %d characters", ErrInvalidPoll, MaxPollOptionLength)
+		}
+		option.Position = i
+	}
+	if p.DurationSeconds < 0 || p.DurationSeconds > MaxPollDuration {
+		return fmt.Errorf("%w: duration must be between 0 and %d seconds", ErrInvalidPoll, MaxPollDuration)
+	}
+	if p.OpensAt.Valid && !p.OpensAt.Time.After(time.Now()) {
+		return fmt.Errorf("%w: opens_at must be in the future", ErrInvalidPoll)
+	}
+	return nil
+}
+
+// IsAcceptingVotes reports whether the poll is open and its time, if limited, has not run out.
+func (p *SessionPoll) IsAcceptingVotes() bool {
+	return p.Status == PollStatusOpen && (!p.ClosesAt.Valid || p.ClosesAt.Time.After(time.Now()))
+}
+
+// HasOption reports whether optionID is one of the poll's options.
+func (p *SessionPoll) HasOption(optionID string) bool {
+	for _, option := range p.Options {
+		if option.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func pollOptions(labels ...string) []*SessionPollOption {
+	options := make([]*SessionPollOption, len(labels))
+	for i, label := range labels {
+		options[i] = &SessionPollOption{Label: label}
+	}
+	return options
+}
+
+func TestSessionPollValidate(t *testing.T) {
+	future := sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true}
+	past := sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+
+	tests := []struct {
+		name    string
+		poll    SessionPoll
+		wantErr bool
+	}{
+		{name: "open until closed", poll: SessionPoll{Question: " Lunch? ", Options: pollOptions("Yes", " No ")}},
+		{name: "longest duration", poll: SessionPoll{Question: "Lunch?", Options: pollOptions("Yes", "No"), DurationSeconds: MaxPollDuration}},
+		{name: "scheduled", poll: SessionPoll{Question: "Lunch?", Options: pollOptions("Yes", "No"), OpensAt: future, DurationSeconds: 60}},
+		{name: "scheduled in the past", poll: SessionPoll{Question: "Lunch?", Options: pollOptions("Yes", "No"), OpensAt: past}, wantErr: true},
+		{name: "negative duration", poll: SessionPoll{Question: "Lunch?", Options: pollOptions("Yes", "No"), DurationSeconds: -1}, wantErr: true},
+		{name: "duration over a day", poll: SessionPoll{Question: "Lunch?", Options: pollOptions("Yes", "No"), DurationSeconds: MaxPollDuration + 1}, wantErr: true},
+		{name: "blank question", poll: SessionPoll{Question: "  ", Options: pollOptions("Yes", "No")}, wantErr: true},
+		{name: "question too long", poll: SessionPoll{Question: strings.Repeat("?", MaxPollQuestionLength+1), Options: pollOptions("Yes", "No")}, wantErr: true},
+		{name: "single option", poll: SessionPoll{Question: "Lunch?", Options: pollOptions("Yes")}, wantErr: true},
+		{name: "too many options", poll: SessionPoll{Question: "Lunch?", Options: pollOptions(strings.Split(strings.Repeat("x,", MaxPollOptions), ",")...)}, wantErr: true},
+		{name: "blank option", poll: SessionPoll{Question: "Lunch?", Options: pollOptions("Yes", " ")}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := tt.poll
+			err := poll.Validate()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPoll) {
+					t.Fatalf("want ErrInvalidPoll, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want valid, got %v", err)
+			}
+			if poll.Question != strings.TrimSpace(tt.poll.Question) {
+				t.Errorf("question was not trimmed: %q", poll.Question)
+			}
+			for i, option := range poll.Options {
+				if option.Position != i || option.Label != strings.TrimSpace(option.Label) {
+					t.Errorf("option %d: got position %d and label %q", i, option.Position, option.Label)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionPollIsAcceptingVotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		closesAt sql.NullTime
+		want     bool
+	}{
+		{name: "open without a time limit", status: PollStatusOpen, want: true},
+		{name: "open with time left", status: PollStatusOpen, closesAt: sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true}, want: true},
+		{name: "open but time ran out", status: PollStatusOpen, closesAt: sql.NullTime{Time: time.Now().Add(-time.Second), Valid: true}, want: false},
+		{name: "draft", status: PollStatusDraft, want: false},
+		{name: "closed", status: PollStatusClosed, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := SessionPoll{Status: tt.status, ClosesAt: tt.closesAt}
+			if got := poll.IsAcceptingVotes(); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"time"
+)
+
+// PublicEventMaxAge is how long clients and shared caches may keep a public event page.
+const PublicEventMaxAge = 5 * time.Minute
+
+// IsPubliclyListed reports whether an event in the given status has a public landing page, provided its
+// community is public. Drafts and archived events have none.
+func IsPubliclyListed(status string) bool {
+	return status != EventStatusDraft && status != EventStatusArchived
+}
+
+// PublicEvent is what anyone, signed in or not, sees of an event of a public community on its landing page:
+// no meeting links, attendees, check-in settings or internal IDs of people.
+type PublicEvent struct {
+	ID                   string               `json:"id"`
+	Slug                 string               `json:"slug"`
+	Name                 string               `json:"name"`
+	Description          string               `json:"description,omitempty"`
+	CoverImageURL        string               `json:"cover_image_url,omitempty"`
+	CommunityID          string               `json:"community_id"`
+	CommunityName        string               `json:"community_name"`
+	HostName             string               `json:"host_name,omitempty"`
+	LocationType         string               `json:"location_type"`
+	LocationAddress      string               `json:"location_address,omitempty"`
+	Venue                *PublicVenue         `json:"venue,omitempty"`
+	Timezone             string               `json:"timezone"`
+	StartTime            *time.Time           `json:"start_time,omitempty"`
+	EndTime              *time.Time           `json:"end_time,omitempty"`
+	IsRecurring          bool                 `json:"is_recurring"`
+	Sessions             []PublicEventSession `json:"sessions"`
+	Speakers             []PublicEventSpeaker `json:"speakers,omitempty"`
+	Status               string               `json:"status"`
+	RegistrationRequired bool                 `json:"registration_required"`
+	RegistrationOpen     bool                 `json:"registration_open"`
+	RegistrationOpensAt  *time.Time           `json:"registration_opens_at,omitempty"`
+	RegistrationClosesAt *time.Time           `json:"registration_closes_at,omitempty"`
+	RequireApproval      bool                 `json:"require_approval"`
+	IsFull               bool                 `json:"is_full"`
+	IsPaid               bool                 `json:"is_paid"`
+	Fee                  *float64             `json:"fee,omitempty"`
+	Currency             string               `json:"currency,omitempty"`
+	UpdatedAt            time.Time            `json:"updated_at"`
+}
+
+// PublicEventSession is a session as shown on a public landing page.
+type PublicEventSession struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name,omitempty"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	Location    string    `json:"location,omitempty"`
+	IsCancelled bool      `json:"is_cancelled"`
+}
+
+// PublicEventSpeaker is a speaker as shown on a public landing page.
+type PublicEventSpeaker struct {
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+}
+
+// PublicVenue is a venue as shown on a public landing page.
+type PublicVenue struct {
+	Name               string   `json:"name"`
+	Address            string   `json:"address"`
+	Latitude           *float64 `json:"latitude,omitempty"`
+	Longitude          *float64 `json:"longitude,omitempty"`
+	AccessibilityNotes string   `json:"accessibility_notes,omitempty"`
+}
+
+// NewPublicEvent keeps the public fields of an event of the named community. Registration is reported open
+// when the event's status, registration window and capacity allow new registrations at now; whitelists and
+// community membership are checked when registering.
+func NewPublicEvent(e *Event, communityName string, now time.Time) *PublicEvent {
+	public := &PublicEvent{
+		ID:                   e.ID,
+		Slug:                 e.Slug,
+		Name:                 e.Name,
+		Description:          e.Description.String,
+		CoverImageURL:        e.CoverImageURL.String,
+		CommunityID:          e.CommunityID,
+		CommunityName:        communityName,
+		HostName:             e.CreatedByName,
+		LocationType:         e.LocationType,
+		LocationAddress:      e.LocationAddress.String,
+		Timezone:             e.Timezone,
+		StartTime:            nullTimePtr(e.StartTime.Time, e.StartTime.Valid),
+		EndTime:              nullTimePtr(e.EndTime.Time, e.EndTime.Valid),
+		IsRecurring:          e.IsRecurring,
+		Sessions:             []PublicEventSession{},
+		Status:               e.Status,
+		RegistrationRequired: e.RegistrationRequired,
+		RegistrationOpensAt:  nullTimePtr(e.RegistrationOpensAt.Time, e.RegistrationOpensAt.Valid),
+		RegistrationClosesAt: nullTimePtr(e.RegistrationClosesAt.Time, e.RegistrationClosesAt.Valid),
+		RequireApproval:      e.RequireApproval,
+		IsFull:               e.MaxAttendees.Valid && e.CurrentAttendees >= int(e.MaxAttendees.Int32) && !e.WaitlistEnabled,
+		IsPaid:               e.IsPaid,
+		UpdatedAt:            e.UpdatedAt,
+	}
+	if e.IsPaid {
+		if e.Fee.Valid {
+			fee := e.Fee.Float64
+			public.Fee = &fee
+		}
+		public.Currency = e.Currency
+	}
+	public.RegistrationOpen = AcceptsRegistrations(e.Status) && !public.IsFull &&
+		(!e.RegistrationOpensAt.Valid || !now.Before(e.RegistrationOpensAt.Time)) &&
+		(!e.RegistrationClosesAt.Valid || now.Before(e.RegistrationClosesAt.Time))
+
+	if e.Venue != nil {
+		public.Venue = &PublicVenue{
+			Name:               e.Venue.Name,
+			Address:            e.Venue.Address,
+			AccessibilityNotes: e.Venue.AccessibilityNotes.String,
+		}
+		if e.Venue.Latitude.Valid && e.Venue.Longitude.Valid {
+			latitude, longitude := e.Venue.Latitude.Float64, e.Venue.Longitude.Float64
+			public.Venue.Latitude, public.Venue.Longitude = &latitude, &longitude
+		}
+	}
+	for _, session := range e.Sessions {
+		public.Sessions = append(public.Sessions, PublicEventSession{
+			ID:          session.ID,
+			Name:        session.Name.String,
+			StartTime:   session.StartTime,
+			EndTime:     session.EndTime,
+			Location:    session.LocationOverride.String,
+			IsCancelled: session.IsCancelled,
+		})
+	}
+	for _, speaker := range e.Speakers {
+		public.Speakers = append(public.Speakers, PublicEventSpeaker{Name: speaker.UserName, AvatarURL: speaker.UserAvatar.String})
+	}
+	return public
+}
+
+func nullTimePtr(t time.Time, valid bool) *time.Time {
+	if !valid {
+		return nil
+	}
+	return &t
+}
+
+// StructuredData describes the event as a schema.org Event in JSON-LD, for search engines and link previews.
+// pageURL is the address of the event's landing page.
+func (p *PublicEvent) StructuredData(pageURL string) map[string]interface{} {
+	data := map[string]interface{}{
+		"@context":    "https://schema.org",
+		"@type":       "Event",
+		"name":        p.Name,
+		"url":         pageURL,
+		"eventStatus": "https://schema.org/EventScheduled",
+		"organizer": map[string]interface{}{
+			"@type": "Organization",
+			"name":  p.CommunityName,
+		},
+	}
+	if p.Description != "" {
+		data["description"] = p.Description
+	}
+	if p.CoverImageURL != "" {
+		data["image"] = []string{p.CoverImageURL}
+	}
+	if p.StartTime != nil {
+		data["startDate"] = p.StartTime.Format(time.RFC3339)
+	}
+	if p.EndTime != nil {
+		data["endDate"] = p.EndTime.Format(time.RFC3339)
+	}
+	if p.Status == EventStatusCancelled {
+		data["eventStatus"] = "https://schema.org/EventCancelled"
+	}
+
+	place := map[string]interface{}{"@type": "Place"}
+	if p.Venue != nil {
+		place["name"] = p.Venue.Name
+		place["address"] = p.Venue.Address
+		if p.Venue.Latitude != nil {
+			place["geo"] = map[string]interface{}{"@type": "GeoCoordinates", "latitude": *p.Venue.Latitude, "longitude": *p.Venue.Longitude}
+		}
+	} else {
+		place["address"] = p.LocationAddress
+	}
+	virtual := map[string]interface{}{"@type": "VirtualLocation", "url": pageURL}
+	switch p.LocationType {
+	case "online":
+		data["eventAttendanceMode"] = "https://schema.org/OnlineEventAttendanceMode"
+		data["location"] = virtual
+	case "hybrid":
+		data["eventAttendanceMode"] = "https://schema.org/MixedEventAttendanceMode"
+		data["location"] = []interface{}{place, virtual}
+	default:
+		data["eventAttendanceMode"] = "https://schema.org/OfflineEventAttendanceMode"
+		data["location"] = place
+	}
+
+	availability := "https://schema.org/InStock"
+	switch {
+	case p.IsFull:
+		availability = "https://schema.org/SoldOut"
+	case !p.RegistrationOpen:
+		availability = "https://schema.org/OutOfStock"
+	}
+	offer := map[string]interface{}{
+		"@type":        "Offer",
+		"url":          pageURL,
+		"availability": availability,
+		"price":        0,
+	}
+	if p.Fee != nil {
+		offer["price"] = *p.Fee
+		offer["priceCurrency"] = p.Currency
+	}
+	if p.RegistrationOpensAt != nil {
+		offer["validFrom"] = p.RegistrationOpensAt.Format(time.RFC3339)
+	}
+	data["offers"] = offer
+
+	if len(p.Speakers) > 0 {
+		performers := make([]map[string]interface{}, 0, len(p.Speakers))
+		for _, speaker := range p.Speakers {
+			performers = append(performers, map[string]interface{}{"@type": "Person", "name": speaker.Name})
+		}
+		data["performer"] = performers
+	}
+	return data
+}
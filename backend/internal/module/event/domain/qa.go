@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrQuestionNotFound    = errors.New("question not found")
+	ErrInvalidQuestion     = errors.New("invalid question")
+	ErrSessionQAClosed     = errors.New("Q&A is not open for this session")
+	ErrNotEventParticipant = errors.New("only registrants and staff of the event can take part")
+)
+
+// Statuses of a SessionQuestion.
+const (
+	QuestionStatusOpen     = "open"
+	QuestionStatusAnswered = "answered"
+	QuestionStatusHidden   = "hidden" // Removed by a moderator; only moderators still see it
+)
+
+// Orders of session questions.
+const (
+	QuestionSortTop    = "top"    // Most votes first
+	QuestionSortRecent = "recent" // Newest first
+)
+
+// MaxQuestionLength is the maximum length of a session question, in characters.
+const MaxQuestionLength = 1000
+
+// Types of SessionLiveEvent.
+const (
+	SessionLiveQuestionCreated = "question_created"
+	SessionLiveQuestionVoted   = "question_voted"
+	SessionLiveQuestionUpdated = "question_updated" // Answered, or reopened
+	SessionLiveQuestionHidden  = "question_hidden"
+)
+
+// SessionLiveSubject is the NATS subject the live activity of a session is published on, for the realtime hub
+// to push to the session's clients.
+func SessionLiveSubject(sessionID string) string {
+	return "session.live." + sessionID
+}
+
+// SessionQuestion corresponds to the 'session_questions' table: a question asked during a session's Q&A.
+// The author of an anonymous question is only shown to its author and to moderators.
+type SessionQuestion struct {
+	ID           string         `json:"id"`
+	SessionID    string         `json:"session_id"`
+	EventID      string         `json:"event_id"`
+	AuthorID     string         `json:"author_id,omitempty"`
+	AuthorName   string         `json:"author_name,omitempty"`
+	AuthorAvatar sql.NullString `json:"author_avatar,omitempty"`
+	Content      string         `json:"content"`
+	IsAnonymous  bool           `json:"is_anonymous"`
+	Status       string         `json:"status"`
+	VoteCount    int            `json:"vote_count"`
+	UserHasVoted bool           `json:"user_has_voted"`
+	IsOwn        bool           `json:"is_own"`
+	AnsweredAt   sql.NullTime   `json:"answered_at,omitempty"`
+	ModeratedBy  sql.NullString `json:"moderated_by,omitempty"`
+	ModeratedAt  sql.NullTime   `json:"moderated_at,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// Validate checks the question's content.
+func (q *SessionQuestion) Validate() error {
+	q.Content = strings.TrimSpace(q.Content)
+	if q.Content == "" {
+		return fmt.Errorf("%w: content is required", ErrInvalidQuestion)
+	}
+	if len([]rune(q.Content)) > MaxQuestionLength {
+		return fmt.Errorf("%w: content is limited to %d characters", ErrInvalidQuestion, MaxQuestionLength)
+	}
+	return nil
+}
+
+// Anonymize removes the author of an anonymous question.
+func (q *SessionQuestion) Anonymize() {
+	if q.IsAnonymous {
+		q.AuthorID, q.AuthorName, q.AuthorAvatar = "", "", sql.NullString{}
+	}
+}
+
+// IsValidQuestionStatus reports whether status is a status a moderator can give a question.
+func IsValidQuestionStatus(status string) bool {
+	return status == QuestionStatusOpen || status == QuestionStatusAnswered || status == QuestionStatusHidden
+}
+
+// SessionQA is the Q&A of a session as seen by a user.
+type SessionQA struct {
+	SessionID   string             `json:"session_id"`
+	Enabled     bool               `json:"enabled"`
+	CanModerate bool               `json:"can_moderate"`
+	Questions   []*SessionQuestion `json:"questions"`
+}
+
+// SessionLiveEvent is published on SessionLiveSubject when the live activity of a session changes: its
+// Q&A or its polls. Questions and polls carry no user-specific fields and questions are anonymized; hidden
+// questions are only referred to by their ID.
+type SessionLiveEvent struct {
+	Type       string           `json:"type"`
+	SessionID  string           `json:"session_id"`
+	QuestionID string           `json:"question_id,omitempty"`
+	Question   *SessionQuestion `json:"question,omitempty"`
+	PollID     string           `json:"poll_id,omitempty"`
+	Poll       *SessionPoll     `json:"poll,omitempty"`
+}
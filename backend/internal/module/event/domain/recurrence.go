@@ -0,0 +1,272 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+var (
+	ErrInvalidRecurrence        = errors.New("invalid recurrence rule")
+	ErrInvalidGenerationHorizon = errors.New("generation horizon must be between 1 and 366 days")
+)
+
+const (
+	// DefaultGenerationHorizonDays is how far ahead sessions of a recurring event are generated
+	// when the event does not configure its own horizon.
+	DefaultGenerationHorizonDays = 30
+	MaxGenerationHorizonDays     = 366
+	// MaxGeneratedSessions caps the sessions generated for a series in a single pass.
+	MaxGeneratedSessions = 500
+)
+
+// Recurrence is the document stored in the 'recurrence_rule' column of a recurring event.
+// ExDates are occurrences removed from the series (e.g. cancelled sessions) and RDates are
+// extra occurrences added to it. Both are keyed by the occurrence's original start time.
+type Recurrence struct {
+	RRule   string      `json:"rrule"`
+	ExDates []time.Time `json:"exdates,omitempty"`
+	RDates  []time.Time `json:"rdates,omitempty"`
+}
+
+// ParseRecurrence decodes an event's recurrence_rule. It returns nil if the event has no rule.
+func ParseRecurrence(raw json.RawMessage) (*Recurrence, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var recurrence Recurrence
+	if err := json.Unmarshal(raw, &recurrence); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	recurrence.RRule = normalizeRRule(recurrence.RRule)
+	if recurrence.RRule == "" {
+		return nil, nil
+	}
+	return &recurrence, nil
+}
+
+// normalizeRRule strips DTSTART lines and the "RRULE:" prefix, keeping only the rule parts.
+func normalizeRRule(rule string) string {
+	for _, line := range strings.Split(strings.ReplaceAll(rule, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(strings.ToUpper(line), "DTSTART") {
+			continue
+		}
+		return strings.TrimPrefix(line, "RRULE:")
+	}
+	return ""
+}
+
+// Marshal encodes the recurrence for storage in the 'recurrence_rule' column.
+func (r *Recurrence) Marshal() (json.RawMessage, error) {
+	return json.Marshal(r)
+}
+
+// Option parses the RRULE into rrule options.
+func (r *Recurrence) Option() (*rrule.ROption, error) {
+	option, err := rrule.StrToROption(r.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	return option, nil
+}
+
+// SeriesSet builds the recurrence set of the event. The rule is expanded from the event start in the event's
+// own timezone, so sessions keep their wall-clock time across daylight-saving changes, and the series is
+// bounded by the event's recurrence end date and max occurrences when they are stricter than the rule.
+func (r *Recurrence) SeriesSet(event *Event) (*rrule.Set, error) {
+	if !event.StartTime.Valid {
+		return nil, fmt.Errorf("%w: the event has no start time", ErrInvalidRecurrence)
+	}
+	loc := event.Location()
+	option, err := rrule.StrToROptionInLocation(r.RRule, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+	option.Dtstart = event.StartTime.Time.In(loc)
+	if event.RecurrenceEndDate.Valid && (option.Until.IsZero() || event.RecurrenceEndDate.Time.Before(option.Until)) {
+		option.Until = event.RecurrenceEndDate.Time.In(loc)
+	}
+	if event.MaxOccurrences.Valid && event.MaxOccurrences.Int32 > 0 && (option.Count == 0 || int(event.MaxOccurrences.Int32) < option.Count) {
+		option.Count = int(event.MaxOccurrences.Int32)
+	}
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecurrence, err)
+	}
+
+	set := &rrule.Set{}
+	set.RRule(rule)
+	for _, exdate := range r.ExDates {
+		set.ExDate(exdate.In(loc))
+	}
+	for _, rdate := range r.RDates {
+		set.RDate(rdate.In(loc))
+	}
+	return set, nil
+}
+
+// IsBounded reports whether the series ends, through its rule or the event's end conditions.
+func (r *Recurrence) IsBounded(event *Event) bool {
+	if event.RecurrenceEndDate.Valid || (event.MaxOccurrences.Valid && event.MaxOccurrences.Int32 > 0) {
+		return true
+	}
+	option, err := r.Option()
+	return err == nil && (option.Count > 0 || !option.Until.IsZero())
+}
+
+// Occurrences returns up to limit occurrences of the event's series that start at or after from and,
+// unless to is zero, not after to. Times are in the event's timezone.
+func (r *Recurrence) Occurrences(event *Event, from, to time.Time, limit int) ([]time.Time, error) {
+	if to.IsZero() && !r.IsBounded(event) {
+		return nil, fmt.Errorf("%w: an open-ended series needs an upper bound", ErrInvalidRecurrence)
+	}
+	set, err := r.SeriesSet(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences []time.Time
+	next := set.Iterator()
+	for len(occurrences) < limit {
+		occurrence, ok := next()
+		if !ok || (!to.IsZero() && occurrence.After(to)) {
+			break
+		}
+		if occurrence.Before(from) {
+			continue
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences, nil
+}
+
+// HasExDate reports whether the occurrence starting at t has been excluded from the series.
+func (r *Recurrence) HasExDate(t time.Time) bool {
+	for _, exdate := range r.ExDates {
+		if exdate.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddExDate excludes the occurrence starting at t from the series.
+func (r *Recurrence) AddExDate(t time.Time) {
+	if !r.HasExDate(t) {
+		r.ExDates = append(r.ExDates, t.UTC())
+	}
+}
+
+// AddRDate adds an extra occurrence starting at t to the series.
+func (r *Recurrence) AddRDate(t time.Time) {
+	for _, rdate := range r.RDates {
+		if rdate.Equal(t) {
+			return
+		}
+	}
+	r.RDates = append(r.RDates, t.UTC())
+}
+
+// Shift moves the series' exception and extra dates and the rule's UNTIL by shift on the wall clock of loc,
+// so they keep matching the occurrences of a series moved by the same shift across daylight-saving changes.
+func (r *Recurrence) Shift(shift time.Duration, loc *time.Location) error {
+	if shift == 0 {
+		return nil
+	}
+	option, err := r.Option()
+	if err != nil {
+		return err
+	}
+	for i := range r.ExDates {
+		r.ExDates[i] = ShiftLocal(r.ExDates[i], shift, loc).UTC()
+	}
+	for i := range r.RDates {
+		r.RDates[i] = ShiftLocal(r.RDates[i], shift, loc).UTC()
+	}
+	if !option.Until.IsZero() {
+		option.Until = ShiftLocal(option.Until, shift, loc)
+		r.RRule = option.RRuleString()
+	}
+	return nil
+}
+
+// LocalShift returns how far the wall clock of loc moves between from and to. Unlike to.Sub(from), it does
+// not count the hour gained or lost when a daylight-saving change lies in between.
+func LocalShift(from, to time.Time, loc *time.Location) time.Duration {
+	return wallClock(to, loc).Sub(wallClock(from, loc))
+}
+
+// ShiftLocal moves t by shift on the wall clock of loc, so it keeps its local time of day across
+// daylight-saving changes.
+func ShiftLocal(t time.Time, shift time.Duration, loc *time.Location) time.Time {
+	wall := wallClock(t, loc).Add(shift)
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), loc)
+}
+
+// wallClock returns the local date and time of t in loc as a UTC time, for arithmetic free of offsets.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+}
+
+// Scopes of an occurrence edit, matching the "this event / this and following events / all events"
+// choice offered by calendar clients.
+const (
+	OccurrenceScopeThis      = "this"
+	OccurrenceScopeFollowing = "following"
+	OccurrenceScopeAll       = "all"
+)
+
+// OccurrenceEdit is a change to a single occurrence of a recurring event. Scope decides whether it
+// applies to that occurrence only, to it and every later one (splitting the series), or to the whole series.
+type OccurrenceEdit struct {
+	Scope                    string
+	StartTime                sql.NullTime
+	EndTime                  sql.NullTime
+	Name                     sql.NullString
+	LocationOverride         sql.NullString
+	OnlineMeetingURLOverride sql.NullString
+	MaxAttendeesOverride     sql.NullInt32
+}
+
+// Location returns the event's IANA timezone, or UTC if it is unset or unknown.
+func (e *Event) Location() *time.Location {
+	if e.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(e.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// GenerationHorizon returns how far ahead the sessions of the recurring event are generated.
+func (e *Event) GenerationHorizon() time.Duration {
+	days := DefaultGenerationHorizonDays
+	if e.GenerationHorizonDays.Valid && e.GenerationHorizonDays.Int32 > 0 {
+		days = int(e.GenerationHorizonDays.Int32)
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// ValidateGenerationHorizon checks the event's configured generation horizon, if any.
+func (e *Event) ValidateGenerationHorizon() error {
+	if e.GenerationHorizonDays.Valid && (e.GenerationHorizonDays.Int32 < 1 || e.GenerationHorizonDays.Int32 > MaxGenerationHorizonDays) {
+		return ErrInvalidGenerationHorizon
+	}
+	return nil
+}
+
+// OccurrencePreview is an occurrence computed by a dry run of a recurrence rule.
+type OccurrencePreview struct {
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	WithinHorizon bool      `json:"within_horizon"` // Whether the worker would already have generated its session
+}
@@ -0,0 +1,300 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+	return loc
+}
+
+func localTime(loc *time.Location, year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, loc)
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string // Expected RRule; empty means no recurrence
+		wantErr bool
+	}{
+		{name: "empty", raw: ""},
+		{name: "null", raw: "null"},
+		{name: "plain rule", raw: `{"rrule":"FREQ=WEEKLY;COUNT=3"}`, want: "FREQ=WEEKLY;COUNT=3"},
+		{name: "rrule prefix", raw: `{"rrule":"RRULE:FREQ=DAILY"}`, want: "FREQ=DAILY"},
+		{name: "dtstart line", raw: `{"rrule":"DTSTART:20260301T100000Z\nRRULE:FREQ=DAILY;COUNT=2"}`, want: "FREQ=DAILY;COUNT=2"},
+		{name: "blank rule", raw: `{"rrule":""}`},
+		{name: "malformed", raw: `{"rrule":`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurrence, err := ParseRecurrence(json.RawMessage(tt.raw))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRecurrence) {
+					t.Fatalf("got error %v, want ErrInvalidRecurrence", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want == "" {
+				if recurrence != nil {
+					t.Fatalf("got %+v, want no recurrence", recurrence)
+				}
+				return
+			}
+			if recurrence == nil || recurrence.RRule != tt.want {
+				t.Fatalf("got %+v, want rule %q", recurrence, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	june := func(day int) time.Time { return localTime(ny, 2026, time.June, day, 9, 0) }
+
+	tests := []struct {
+		name     string
+		rrule    string
+		start    time.Time
+		exDates  []time.Time
+		rDates   []time.Time
+		endDate  sql.NullTime
+		maxOcc   sql.NullInt32
+		from, to time.Time
+		limit    int
+		want     []time.Time
+		wantErr  error
+	}{
+		{
+			name:  "weekly keeps local time across daylight saving",
+			rrule: "FREQ=WEEKLY;COUNT=4",
+			start: localTime(ny, 2026, time.March, 1, 10, 0),
+			want: []time.Time{
+				localTime(ny, 2026, time.March, 1, 10, 0),
+				localTime(ny, 2026, time.March, 8, 10, 0),
+				localTime(ny, 2026, time.March, 15, 10, 0),
+				localTime(ny, 2026, time.March, 22, 10, 0),
+			},
+		},
+		{
+			name:    "exdates remove and rdates add occurrences",
+			rrule:   "FREQ=DAILY;COUNT=3",
+			start:   june(1),
+			exDates: []time.Time{june(2).UTC()},
+			rDates:  []time.Time{june(10).UTC()},
+			want:    []time.Time{june(1), june(3), june(10)},
+		},
+		{
+			name:   "max occurrences stricter than count",
+			rrule:  "FREQ=DAILY;COUNT=10",
+			start:  june(1),
+			maxOcc: sql.NullInt32{Int32: 2, Valid: true},
+			want:   []time.Time{june(1), june(2)},
+		},
+		{
+			name:   "count stricter than max occurrences",
+			rrule:  "FREQ=DAILY;COUNT=2",
+			start:  june(1),
+			maxOcc: sql.NullInt32{Int32: 5, Valid: true},
+			want:   []time.Time{june(1), june(2)},
+		},
+		{
+			name:    "recurrence end date bounds an open rule",
+			rrule:   "FREQ=DAILY",
+			start:   june(1),
+			endDate: sql.NullTime{Time: june(3), Valid: true},
+			want:    []time.Time{june(1), june(2), june(3)},
+		},
+		{
+			name:    "rule until stricter than end date",
+			rrule:   "FREQ=DAILY;UNTIL=20260602T130000Z",
+			start:   june(1),
+			endDate: sql.NullTime{Time: june(5), Valid: true},
+			want:    []time.Time{june(1), june(2)},
+		},
+		{
+			name:  "window and limit",
+			rrule: "FREQ=DAILY",
+			start: june(1),
+			from:  june(3),
+			to:    june(30),
+			limit: 2,
+			want:  []time.Time{june(3), june(4)},
+		},
+		{
+			name:  "upper bound is inclusive",
+			rrule: "FREQ=DAILY",
+			start: june(1),
+			to:    june(2),
+			want:  []time.Time{june(1), june(2)},
+		},
+		{
+			name:    "open-ended series needs an upper bound",
+			rrule:   "FREQ=DAILY",
+			start:   june(1),
+			wantErr: ErrInvalidRecurrence,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &Event{
+				Timezone:          "America/New_York",
+				StartTime:         sql.NullTime{Time: tt.start.UTC(), Valid: true},
+				RecurrenceEndDate: tt.endDate,
+				MaxOccurrences:    tt.maxOcc,
+			}
+			recurrence := &Recurrence{RRule: tt.rrule, ExDates: tt.exDates, RDates: tt.rDates}
+			limit := tt.limit
+			if limit == 0 {
+				limit = 100
+			}
+			got, err := recurrence.Occurrences(event, tt.from, tt.to, limit)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got error %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %d %v", len(got), got, len(tt.want), tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d: got %v, want %v", i, got[i].In(ny), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRecurrenceIsBounded(t *testing.T) {
+	tests := []struct {
+		name  string
+		rrule string
+		event Event
+		want  bool
+	}{
+		{name: "open rule", rrule: "FREQ=WEEKLY", want: false},
+		{name: "rule count", rrule: "FREQ=WEEKLY;COUNT=3", want: true},
+		{name: "rule until", rrule: "FREQ=WEEKLY;UNTIL=20261231T000000Z", want: true},
+		{name: "event end date", rrule: "FREQ=WEEKLY", event: Event{RecurrenceEndDate: sql.NullTime{Time: time.Now(), Valid: true}}, want: true},
+		{name: "event max occurrences", rrule: "FREQ=WEEKLY", event: Event{MaxOccurrences: sql.NullInt32{Int32: 4, Valid: true}}, want: true},
+		{name: "zero max occurrences", rrule: "FREQ=WEEKLY", event: Event{MaxOccurrences: sql.NullInt32{Int32: 0, Valid: true}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurrence := &Recurrence{RRule: tt.rrule}
+			if got := recurrence.IsBounded(&tt.event); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalShift(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     time.Duration
+	}{
+		{name: "same day", from: localTime(ny, 2026, time.June, 1, 9, 0), to: localTime(ny, 2026, time.June, 1, 11, 30), want: 150 * time.Minute},
+		{name: "over spring forward", from: localTime(ny, 2026, time.March, 7, 10, 0), to: localTime(ny, 2026, time.March, 8, 10, 0), want: 24 * time.Hour},
+		{name: "over fall back", from: localTime(ny, 2026, time.October, 31, 10, 0), to: localTime(ny, 2026, time.November, 1, 10, 0), want: 24 * time.Hour},
+		{name: "backwards", from: localTime(ny, 2026, time.June, 2, 9, 0), to: localTime(ny, 2026, time.June, 1, 8, 0), want: -25 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LocalShift(tt.from, tt.to, ny); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShiftLocal(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	tests := []struct {
+		name  string
+		t     time.Time
+		shift time.Duration
+		want  time.Time
+	}{
+		{name: "within standard time", t: localTime(ny, 2026, time.February, 1, 10, 0), shift: time.Hour, want: localTime(ny, 2026, time.February, 1, 11, 0)},
+		{name: "a day over spring forward", t: localTime(ny, 2026, time.March, 7, 10, 0), shift: 24 * time.Hour, want: localTime(ny, 2026, time.March, 8, 10, 0)},
+		{name: "a week over fall back", t: localTime(ny, 2026, time.October, 28, 18, 0), shift: 7 * 24 * time.Hour, want: localTime(ny, 2026, time.November, 4, 18, 0)},
+		{name: "from a UTC instant", t: localTime(ny, 2026, time.March, 20, 10, 0).UTC(), shift: -time.Hour, want: localTime(ny, 2026, time.March, 20, 9, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShiftLocal(tt.t, tt.shift, ny); !got.Equal(tt.want) {
+				t.Fatalf("got %v, want %v", got.In(ny), tt.want)
+			}
+		})
+	}
+}
+
+func TestRecurrenceShift(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	recurrence := &Recurrence{
+		RRule:   "FREQ=WEEKLY;UNTIL=20260329T140000Z",
+		ExDates: []time.Time{localTime(ny, 2026, time.March, 1, 10, 0).UTC()},
+		RDates:  []time.Time{localTime(ny, 2026, time.March, 4, 10, 0).UTC()},
+	}
+	// The series moves a week later, over the change to daylight-saving time on March 8. Its dates must keep
+	// their 10:00 local time rather than move by 168 hours and land at 11:00.
+	if err := recurrence.Shift(7*24*time.Hour, ny); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := localTime(ny, 2026, time.March, 8, 10, 0); !recurrence.ExDates[0].Equal(want) {
+		t.Errorf("exdate: got %v, want %v", recurrence.ExDates[0].In(ny), want)
+	}
+	if want := localTime(ny, 2026, time.March, 11, 10, 0); !recurrence.RDates[0].Equal(want) {
+		t.Errorf("rdate: got %v, want %v", recurrence.RDates[0].In(ny), want)
+	}
+	option, err := recurrence.Option()
+	if err != nil {
+		t.Fatalf("shifted rule does not parse: %v", err)
+	}
+	if want := localTime(ny, 2026, time.April, 5, 10, 0); !option.Until.Equal(want) {
+		t.Errorf("until: got %v, want %v", option.Until.In(ny), want)
+	}
+
+	// The shifted dates still match the occurrences of the shifted series.
+	event := &Event{Timezone: "America/New_York", StartTime: sql.NullTime{Time: localTime(ny, 2026, time.March, 8, 10, 0), Valid: true}}
+	occurrences, err := recurrence.Occurrences(event, time.Time{}, time.Time{}, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []time.Time{
+		localTime(ny, 2026, time.March, 11, 10, 0),
+		localTime(ny, 2026, time.March, 15, 10, 0),
+		localTime(ny, 2026, time.March, 22, 10, 0),
+		localTime(ny, 2026, time.March, 29, 10, 0),
+		localTime(ny, 2026, time.April, 5, 10, 0),
+	}
+	if len(occurrences) != len(want) {
+		t.Fatalf("got %v, want %v", occurrences, want)
+	}
+	for i := range want {
+		if !occurrences[i].Equal(want[i]) {
+			t.Errorf("occurrence %d: got %v, want %v", i, occurrences[i].In(ny), want[i])
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrInvalidReminderSchedule = errors.New("invalid reminder schedule")
+
+// Statuses of a reminder in the 'scheduled_notifications' table.
+const (
+	ReminderStatusPending    = "pending"
+	ReminderStatusProcessing = "processing" // Claimed by the dispatcher, being sent
+	ReminderStatusSent       = "sent"
+	ReminderStatusFailed     = "failed"
+	ReminderStatusCancelled  = "cancelled"
+)
+
+// MaxReminderOffsetMinutes is how long before a session its earliest reminder may be sent (30 days).
+const MaxReminderOffsetMinutes = 30 * 24 * 60
+
+// reminderChannels are the values of the 'notification_channel' enum.
+var reminderChannels = map[string]bool{"email": true, "push": true, "sms": true, "in_app": true}
+
+// ReminderOffset is one entry of an event's reminder_schedule: a reminder sent OffsetMinutes
+// relative to the start of each session (negative means before it) on the given channels.
+type ReminderOffset struct {
+	OffsetMinutes int      `json:"offset_minutes"`
+	Channels      []string `json:"channels"`
+}
+
+// ValidateReminderSchedule checks that a reminder_schedule is a list of distinct offsets before the session
+// start, each with known channels. An empty schedule is valid and disables reminders for the event.
+func ValidateReminderSchedule(raw json.RawMessage) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var offsets []ReminderOffset
+	if err := json.Unmarshal(raw, &offsets); err != nil {
+		return ErrInvalidReminderSchedule
+	}
+	seen := make(map[int]bool, len(offsets))
+	for _, offset := range offsets {
+		if offset.OffsetMinutes > 0 || offset.OffsetMinutes < -MaxReminderOffsetMinutes || seen[offset.OffsetMinutes] {
+			return ErrInvalidReminderSchedule
+		}
+		seen[offset.OffsetMinutes] = true
+		for _, channel := range offset.Channels {
+			if !reminderChannels[channel] {
+				return ErrInvalidReminderSchedule
+			}
+		}
+	}
+	return nil
+}
+
+// ScheduledReminder is a session reminder claimed by the dispatcher for sending.
+type ScheduledReminder struct {
+	ID           string
+	UserID       string
+	EventID      string
+	SessionID    string
+	ScheduledFor time.Time
+	Title        string
+	Message      string
+	Channels     []string
+}
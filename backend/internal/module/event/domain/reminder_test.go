@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestValidateReminderSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule string
+		wantErr  bool
+	}{
+		{name: "empty", schedule: ""},
+		{name: "null", schedule: "null"},
+		{name: "no reminders", schedule: "[]"},
+		{name: "day and quarter hour before", schedule: `[{"offset_minutes": -1440, "channels": ["email", "push"]}, {"offset_minutes": -15, "channels": ["in_app", "sms"]}]`},
+		{name: "at the start", schedule: `[{"offset_minutes": 0, "channels": ["push"]}]`},
+		{name: "earliest allowed offset", schedule: `[{"offset_minutes": -43200, "channels": ["email"]}]`},
+		{name: "without channels", schedule: `[{"offset_minutes": -60}]`},
+		{name: "after the start", schedule: `[{"offset_minutes": 5, "channels": ["push"]}]`, wantErr: true},
+		{name: "too early", schedule: `[{"offset_minutes": -43201, "channels": ["email"]}]`, wantErr: true},
+		{name: "duplicate offsets", schedule: `[{"offset_minutes": -60, "channels": ["email"]}, {"offset_minutes": -60, "channels": ["push"]}]`, wantErr: true},
+		{name: "unknown channel", schedule: `[{"offset_minutes": -60, "channels": ["pager"]}]`, wantErr: true},
+		{name: "not a list", schedule: `{"offset_minutes": -60}`, wantErr: true},
+		{name: "malformed", schedule: `[{"offset_minutes": "soon"}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReminderSchedule(json.RawMessage(tt.schedule))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidReminderSchedule) {
+					t.Fatalf("want ErrInvalidReminderSchedule, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want valid, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidReschedule             = errors.New("invalid reschedule")
+	ErrReconfirmationNotFound        = errors.New("re-confirmation request not found")
+	ErrInvalidReconfirmation         = errors.New("response must be confirmed or declined")
+	ErrReconfirmationAlreadyAnswered = errors.New("re-confirmation request was already answered")
+)
+
+// NATS subjects of session changes.
+const (
+	SessionRescheduledSubject = "events.session.rescheduled"
+	SessionCancelledSubject   = "events.session.cancelled"
+)
+
+// Statuses of a re-confirmation request.
+const (
+	ReconfirmationPending   = "pending"
+	ReconfirmationConfirmed = "confirmed"
+	ReconfirmationDeclined  = "declined"
+)
+
+// SessionRescheduleInput moves a session to another time or place. Fields that are not set are left
+// unchanged.
+type SessionRescheduleInput struct {
+	StartTime                sql.NullTime
+	EndTime                  sql.NullTime
+	LocationOverride         sql.NullString
+	OnlineMeetingURLOverride sql.NullString
+	Reason                   string
+	RequireReconfirmation    bool
+}
+
+// SessionReschedule corresponds to the 'event_session_reschedules' table: one change of a session's time
+// or place, with its previous and new details. The meeting URLs are never serialized: reschedules reach
+// attendees who may not see the links, which only the gated session and event responses carry.
+type SessionReschedule struct {
+	ID                       string         `json:"id"`
+	SessionID                string         `json:"session_id"`
+	EventID                  string         `json:"event_id"`
+	PreviousStartTime        time.Time      `json:"previous_start_time"`
+	PreviousEndTime          time.Time      `json:"previous_end_time"`
+	PreviousLocation         sql.NullString `json:"previous_location,omitempty"`
+	PreviousOnlineMeetingURL sql.NullString `json:"-"`
+	NewStartTime             time.Time      `json:"new_start_time"`
+	NewEndTime               time.Time      `json:"new_end_time"`
+	NewLocation              sql.NullString `json:"new_location,omitempty"`
+	NewOnlineMeetingURL      sql.NullString `json:"-"`
+	Reason                   sql.NullString `json:"reason,omitempty"`
+	RequiresReconfirmation   bool           `json:"requires_reconfirmation"`
+	RescheduledBy            sql.NullString `json:"rescheduled_by,omitempty"`
+	CreatedAt                time.Time      `json:"created_at"`
+
+	// Re-confirmation counts (from joins)
+	Confirmed int `json:"confirmed"`
+	Declined  int `json:"declined"`
+	Pending   int `json:"pending"`
+
+	ScheduleConflicts []*ScheduleConflict `json:"schedule_conflicts,omitempty"` // Staff double-bookings at the new time
+}
+
+// SessionReconfirmation corresponds to the 'event_session_reconfirmations' table.
+type SessionReconfirmation struct {
+	ID           string       `json:"id"`
+	RescheduleID string       `json:"reschedule_id"`
+	SessionID    string       `json:"session_id"`
+	UserID       string       `json:"user_id"`
+	Status       string       `json:"status"`
+	RespondedAt  sql.NullTime `json:"responded_at,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+
+	// Joined fields
+	EventID     string             `json:"event_id"`
+	EventName   string             `json:"event_name"`
+	SessionName sql.NullString     `json:"session_name,omitempty"`
+	Reschedule  *SessionReschedule `json:"reschedule"`
+}
+
+// SessionAttendee is a user registered or waitlisted for a session. HoldsSeat is false for the waitlist and
+// for registrations still awaiting approval.
+type SessionAttendee struct {
+	UserID    string
+	HoldsSeat bool
+}
+
+// SessionRescheduledEvent is published on SessionRescheduledSubject with the attendees to tell about the
+// change. Those in ReconfirmUserIDs are asked to confirm they still attend.
+type SessionRescheduledEvent struct {
+	RescheduleID      string    `json:"reschedule_id"`
+	SessionID         string    `json:"session_id"`
+	EventID           string    `json:"event_id"`
+	EventName         string    `json:"event_name"`
+	SessionName       string    `json:"session_name,omitempty"`
+	Timezone          string    `json:"timezone"`
+	PreviousStartTime time.Time `json:"previous_start_time"`
+	PreviousLocation  string    `json:"previous_location,omitempty"`
+	NewStartTime      time.Time `json:"new_start_time"`
+	NewLocation       string    `json:"new_location,omitempty"` // Never holds meeting URLs
+	MeetingURLChanged bool      `json:"meeting_url_changed,omitempty"`
+	Reason            string    `json:"reason,omitempty"`
+	RescheduledBy     string    `json:"rescheduled_by"`
+	UserIDs           []string  `json:"user_ids"`
+	ReconfirmUserIDs  []string  `json:"reconfirm_user_ids,omitempty"`
+}
+
+// SessionCancelledEvent is published on SessionCancelledSubject with the attendees of the cancelled session.
+type SessionCancelledEvent struct {
+	SessionID   string    `json:"session_id"`
+	EventID     string    `json:"event_id"`
+	EventName   string    `json:"event_name"`
+	SessionName string    `json:"session_name,omitempty"`
+	Timezone    string    `json:"timezone"`
+	StartTime   time.Time `json:"start_time"`
+	Reason      string    `json:"reason,omitempty"`
+	TriggeredBy string    `json:"triggered_by"`
+	UserIDs     []string  `json:"user_ids"`
+}
@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSeatingLayoutNotFound  = errors.New("seating layout not found")
+	ErrInvalidSeatingLayout   = errors.New("invalid seating layout")
+	ErrSeatingLayoutInUse     = errors.New("seats of this layout are assigned; clear the assignments first")
+	ErrSeatNotFound           = errors.New("seat not found in the session's layout")
+	ErrSeatTaken              = errors.New("seat is already assigned")
+	ErrNotEnoughSeats         = errors.New("not enough free seats")
+	ErrNotSeatable            = errors.New("only registrants of the session can be seated")
+	ErrSeatAssignmentNotFound = errors.New("seat assignment not found")
+	ErrInvalidSeatGroup       = errors.New("invalid seat group")
+)
+
+// Kinds of SeatingUnit.
+const (
+	SeatingUnitRow   = "row"   // Seats side by side; groups get consecutive seats
+	SeatingUnitTable = "table" // Seats around a table; groups get seats at the same table
+)
+
+// Ways a seat is assigned, as recorded on SeatAssignment and SeatAssignmentAudit.
+const (
+	SeatAssignmentAuto   = "auto"
+	SeatAssignmentGroup  = "group"
+	SeatAssignmentManual = "manual"
+)
+
+// Actions of a SeatAssignmentAudit.
+const (
+	SeatAuditAssigned   = "assigned"
+	SeatAuditMoved      = "moved"
+	SeatAuditUnassigned = "unassigned"
+)
+
+// Limits of seating layouts.
+const (
+	MaxSeatingSections     = 50
+	MaxSeatingUnits        = 500 // Per section
+	MaxSeatingUnitCapacity = 500
+	MaxSeatGroupSize       = 50
+)
+
+// SeatingLayout corresponds to the 'seating_layouts' table: the assigned seating of a session.
+type SeatingLayout struct {
+	ID            string            `json:"id"`
+	SessionID     string            `json:"session_id"`
+	EventID       string            `json:"event_id"`
+	Name          string            `json:"name"`
+	Sections      []*SeatingSection `json:"sections"`
+	Capacity      int               `json:"capacity"`       // Seats over all sections
+	AssignedCount int               `json:"assigned_count"` // Seats assigned
+	CreatedBy     sql.NullString    `json:"created_by,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// SeatingSection corresponds to the 'seating_sections' table: an area of a layout, such as a floor or a
+// wing, holding rows or tables.
+type SeatingSection struct {
+	ID       string         `json:"id"`
+	LayoutID string         `json:"layout_id"`
+	Name     string         `json:"name"`
+	Position int            `json:"position"`
+	Capacity int            `json:"capacity"` // Seats over all units
+	Units    []*SeatingUnit `json:"units"`
+}
+
+// SeatingUnit corresponds to the 'seating_units' table: a row or a table of a section. Its seats are
+// numbered from 1 to its capacity.
+type SeatingUnit struct {
+	ID        string `json:"id"`
+	SectionID string `json:"section_id"`
+	Kind      string `json:"kind"`
+	Label     string `json:"label"`
+	Capacity  int    `json:"capacity"`
+	Position  int    `json:"position"`
+}
+
+// Validate checks the layout's name, sections and units, numbers their positions and computes the
+// capacities.
+func (l *SeatingLayout) Validate() error {
+	l.Name = strings.TrimSpace(l.Name)
+	if l.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSeatingLayout)
+	}
+	if len(l.Sections) == 0 || len(l.Sections) > MaxSeatingSections {
+		return fmt.Errorf("%w: a layout has between 1 and %d sections", ErrInvalidSeatingLayout, MaxSeatingSections)
+	}
+	l.Capacity = 0
+	sectionNames := make(map[string]bool, len(l.Sections))
+	for i, section := range l.Sections {
+		section.Name = strings.TrimSpace(section.Name)
+		if section.Name == "" {
+			return fmt.Errorf("%w: section %d has no name", ErrInvalidSeatingLayout, i+1)
+		}
+		if sectionNames[strings.ToLower(section.Name)] {
+			return fmt.Errorf("%w: section %q appears twice", ErrInvalidSeatingLayout, section.Name)
+		}
+		sectionNames[strings.ToLower(section.Name)] = true
+		if len(section.Units) == 0 || len(section.Units) > MaxSeatingUnits {
+			return fmt.Errorf("%w: section %q has between 1 and %d rows or tables", ErrInvalidSeatingLayout, section.Name, MaxSeatingUnits)
+		}
+		section.Position = i
+		section.Capacity = 0
+		unitLabels := make(map[string]bool, len(section.Units))
+		for j, unit := range section.Units {
+			unit.Label = strings.TrimSpace(unit.Label)
+			if unit.Kind != SeatingUnitRow && unit.Kind != SeatingUnitTable {
+				return fmt.Errorf("%w: kind must be row or table", ErrInvalidSeatingLayout)
+			}
+			if unit.Label == "" {
+				return fmt.Errorf("%w: %s %d of section %q has no label", ErrInvalidSeatingLayout, unit.Kind, j+1, section.Name)
+			}
+			key := unit.Kind + "/" + strings.ToLower(unit.Label)
+			if unitLabels[key] {
+				return fmt.Errorf("%w: %s %q appears twice in section %q", ErrInvalidSeatingLayout, unit.Kind, unit.Label, section.Name)
+			}
+			unitLabels[key] = true
+			if unit.Capacity < 1 || unit.Capacity > MaxSeatingUnitCapacity {
+				return fmt.Errorf("%w: capacity must be between 1 and %d", ErrInvalidSeatingLayout, MaxSeatingUnitCapacity)
+			}
+			unit.Position = j
+			section.Capacity += unit.Capacity
+		}
+		l.Capacity += section.Capacity
+	}
+	return nil
+}
+
+// FindUnit returns the unit with the given ID and its section, if the layout has it.
+func (l *SeatingLayout) FindUnit(unitID string) (*SeatingSection, *SeatingUnit) {
+	for _, section := range l.Sections {
+		for _, unit := range section.Units {
+			if unit.ID == unitID {
+				return section, unit
+			}
+		}
+	}
+	return nil, nil
+}
+
+// SeatAssignment corresponds to the 'seat_assignments' table: the seat of a user during a session.
+type SeatAssignment struct {
+	ID          string         `json:"id"`
+	SessionID   string         `json:"session_id"`
+	EventID     string         `json:"event_id"`
+	UserID      string         `json:"user_id"`
+	UserName    string         `json:"user_name,omitempty"`
+	UserEmail   string         `json:"user_email,omitempty"`
+	UnitID      string         `json:"unit_id"`
+	SectionName string         `json:"section_name"`
+	UnitKind    string         `json:"unit_kind"`
+	UnitLabel   string         `json:"unit_label"`
+	SeatNumber  int            `json:"seat_number"`
+	Label       string         `json:"label"` // e.g. "Balcony, row C, seat 12"
+	Method      string         `json:"method"`
+	AssignedBy  sql.NullString `json:"assigned_by,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// SetSeat places the assignment at a seat of a unit and fills in its label.
+func (a *SeatAssignment) SetSeat(section *SeatingSection, unit *SeatingUnit, seatNumber int) {
+	a.UnitID = unit.ID
+	a.SectionName = section.Name
+	a.UnitKind = unit.Kind
+	a.UnitLabel = unit.Label
+	a.SeatNumber = seatNumber
+	a.Label = SeatLabel(section.Name, unit.Kind, unit.Label, seatNumber)
+}
+
+// SeatLabel is the human-readable name of a seat, as printed on tickets and badges.
+func SeatLabel(sectionName, unitKind, unitLabel string, seatNumber int) string {
+	return fmt.Sprintf("%s, %s %s, seat %d", sectionName, unitKind, unitLabel, seatNumber)
+}
+
+// SeatAssignmentResult reports the outcome of an automatic or group assignment.
+type SeatAssignmentResult struct {
+	Assigned []*SeatAssignment `json:"assigned"`
+	Released int               `json:"released"`           // Seats freed because their holder is no longer registered
+	Unseated []string          `json:"unseated,omitempty"` // Registrants left without a seat for lack of room
+}
+
+// SeatAssignmentAudit corresponds to the 'seat_assignment_audit' table: a change of the seat of a user.
+type SeatAssignmentAudit struct {
+	ID            string         `json:"id"`
+	SessionID     string         `json:"session_id"`
+	UserID        string         `json:"user_id"`
+	UserName      string         `json:"user_name"`
+	Action        string         `json:"action"`
+	FromSeat      sql.NullString `json:"from_seat,omitempty"`
+	ToSeat        sql.NullString `json:"to_seat,omitempty"`
+	Method        string         `json:"method"`
+	ChangedBy     sql.NullString `json:"changed_by,omitempty"`
+	ChangedByName sql.NullString `json:"changed_by_name,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+// SeatBadge is what is printed on the badge of a registrant of a session.
+type SeatBadge struct {
+	UserID    string          `json:"user_id"`
+	UserName  string          `json:"user_name"`
+	UserEmail string          `json:"user_email"`
+	Role      string          `json:"role"`
+	Seat      *SeatAssignment `json:"seat,omitempty"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrSessionRegistrationDisabled = errors.New("event does not use per-session registration")
+	ErrSessionRegistrationNotFound = errors.New("session registration not found")
+	ErrSessionUnavailable          = errors.New("session is cancelled or has already started")
+	ErrSessionFull                 = errors.New("session is full")
+	ErrSessionOverlap              = errors.New("session overlaps another session the user is registered for")
+	ErrNotRegisteredForSession     = errors.New("user is not registered for this session")
+)
+
+// Statuses of a row in the 'event_session_registrations' table.
+const (
+	SessionRegistrationRegistered = "registered"
+	SessionRegistrationWaitlisted = "waitlisted"
+	SessionRegistrationCancelled  = "cancelled"
+)
+
+// SessionRegistration corresponds to the 'event_session_registrations' table: an attendee's sign-up
+// for one session of an event that uses per-session registration.
+type SessionRegistration struct {
+	ID           string       `json:"id"`
+	SessionID    string       `json:"session_id"`
+	EventID      string       `json:"event_id"`
+	AttendeeID   string       `json:"attendee_id"`
+	UserID       string       `json:"user_id"`
+	Status       string       `json:"status"`
+	RegisteredAt time.Time    `json:"registered_at"`
+	CancelledAt  sql.NullTime `json:"cancelled_at,omitempty"`
+
+	// Enriched data (from joins)
+	WaitlistPosition sql.NullInt32  `json:"waitlist_position,omitempty"` // 1-based, for waitlisted registrations
+	SessionName      sql.NullString `json:"session_name,omitempty"`
+	SessionStartTime time.Time      `json:"session_start_time"`
+	SessionEndTime   time.Time      `json:"session_end_time"`
+	UserName         string         `json:"user_name,omitempty"`
+	UserAvatar       sql.NullString `json:"user_avatar,omitempty"`
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidEventRole = errors.New("invalid event role")
+	ErrStaffNotFound    = errors.New("event staff member not found")
+)
+
+// EventStaffMember corresponds to the 'event_staff' table: a user holding a role within an event.
+// The roles and their permission sets are defined by the permission module.
+type EventStaffMember struct {
+	EventID    string         `json:"event_id"`
+	UserID     string         `json:"user_id"`
+	Role       string         `json:"role"`
+	AssignedBy sql.NullString `json:"assigned_by,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+
+	// Enriched data (from joins)
+	UserName   string         `json:"user_name"`
+	UserAvatar sql.NullString `json:"user_avatar,omitempty"`
+}
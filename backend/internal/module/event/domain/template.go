@@ -0,0 +1,258 @@
+package domain
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEventTemplateNotFound = errors.New("event template not found")
+	ErrInvalidEventTemplate  = errors.New("invalid event template")
+)
+
+// EventTemplate corresponds to the 'event_templates' table: the settings of an event saved for reuse within
+// a community. A template is visible to its creator, and to all the community's admins once shared.
+type EventTemplate struct {
+	ID            string                `json:"id"`
+	CommunityID   string                `json:"community_id"`
+	CreatedBy     string                `json:"created_by"`
+	SourceEventID sql.NullString        `json:"source_event_id,omitempty"`
+	Name          string                `json:"name"`
+	Description   sql.NullString        `json:"description,omitempty"`
+	IsShared      bool                  `json:"is_shared"`
+	Settings      EventTemplateSettings `json:"settings"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+
+	// Enriched data (from joins)
+	CreatedByName string `json:"created_by_name,omitempty"`
+}
+
+// Validate checks the template's name.
+func (t *EventTemplate) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidEventTemplate)
+	}
+	if len(t.Name) > 255 {
+		return fmt.Errorf("%w: name is too long", ErrInvalidEventTemplate)
+	}
+	return nil
+}
+
+// EventTemplateSettings is the document stored in the 'settings' column of a template. Times are kept
+// relative to the start of the event: registration windows in minutes before it, the end of a series in
+// days after it. Occurrences that were cancelled, added or edited individually are not part of it.
+type EventTemplateSettings struct {
+	Description                     string          `json:"description,omitempty"`
+	CoverImageURL                   string          `json:"cover_image_url,omitempty"`
+	LocationType                    string          `json:"location_type"`
+	LocationAddress                 string          `json:"location_address,omitempty"`
+	OnlineMeetingURL                string          `json:"online_meeting_url,omitempty"`
+	VenueID                         string          `json:"venue_id,omitempty"`
+	MeetingLinkRevealMinutes        *int32          `json:"meeting_link_reveal_minutes,omitempty"`
+	TransferPolicy                  string          `json:"transfer_policy,omitempty"`
+	TransferDeadlineMinutes         *int32          `json:"transfer_deadline_minutes,omitempty"`
+	Timezone                        string          `json:"timezone"`
+	DurationMinutes                 int             `json:"duration_minutes"`
+	IsRecurring                     bool            `json:"is_recurring"`
+	RecurrenceRule                  string          `json:"recurrence_rule,omitempty"`
+	RecurrenceEndsAfterDays         *int            `json:"recurrence_ends_after_days,omitempty"`
+	MaxOccurrences                  *int32          `json:"max_occurrences,omitempty"`
+	GenerationHorizonDays           *int32          `json:"generation_horizon_days,omitempty"`
+	MaxAttendees                    *int32          `json:"max_attendees,omitempty"`
+	WaitlistEnabled                 bool            `json:"waitlist_enabled"`
+	MaxWaitlist                     *int32          `json:"max_waitlist,omitempty"`
+	PerSessionRegistration          bool            `json:"per_session_registration"`
+	RegistrationRequired            bool            `json:"registration_required"`
+	RegistrationOpensBeforeMinutes  *int            `json:"registration_opens_before_minutes,omitempty"`
+	RegistrationClosesBeforeMinutes *int            `json:"registration_closes_before_minutes,omitempty"`
+	WhitelistOnly                   bool            `json:"whitelist_only"`
+	RequireApproval                 bool            `json:"require_approval"`
+	FaceVerificationRequired        bool            `json:"face_verification_required"`
+	LivenessCheckRequired           bool            `json:"liveness_check_required"`
+	QRCodeEnabled                   bool            `json:"qr_code_enabled"`
+	FallbackCodeEnabled             bool            `json:"fallback_code_enabled"`
+	ManualCheckinAllowed            bool            `json:"manual_checkin_allowed"`
+	IsPaid                          bool            `json:"is_paid"`
+	Fee                             *float64        `json:"fee,omitempty"`
+	Currency                        string          `json:"currency,omitempty"`
+	ReminderSchedule                json.RawMessage `json:"reminder_schedule,omitempty"`
+
+	Staff               []EventTemplateStaff      `json:"staff,omitempty"`
+	FeedbackSurveys     []EventTemplateSurvey     `json:"feedback_surveys,omitempty"`
+	CertificateTemplate *EventTemplateCertificate `json:"certificate_template,omitempty"`
+}
+
+// EventTemplateStaff is a role a user is given within events created from a template.
+type EventTemplateStaff struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// EventTemplateSurvey is an event-wide feedback survey of a template. Surveys of single sessions are not kept.
+type EventTemplateSurvey struct {
+	Title            string             `json:"title"`
+	Description      string             `json:"description,omitempty"`
+	Questions        []FeedbackQuestion `json:"questions"`
+	PerSession       bool               `json:"per_session"`
+	SendDelayMinutes int                `json:"send_delay_minutes"`
+	IsActive         bool               `json:"is_active"`
+}
+
+// EventTemplateCertificate is the certificate template of a template.
+type EventTemplateCertificate struct {
+	Title                string `json:"title"`
+	Body                 string `json:"body"`
+	SignerName           string `json:"signer_name,omitempty"`
+	SignerTitle          string `json:"signer_title,omitempty"`
+	MinAttendancePercent int    `json:"min_attendance_percent"`
+	IsActive             bool   `json:"is_active"`
+}
+
+// NewEventTemplateSettings captures the settings of an event. Its staff, surveys and certificate template are
+// added by the caller.
+func NewEventTemplateSettings(event *Event) (EventTemplateSettings, error) {
+	settings := EventTemplateSettings{
+		Description:              event.Description.String,
+		CoverImageURL:            event.CoverImageURL.String,
+		LocationType:             event.LocationType,
+		LocationAddress:          event.LocationAddress.String,
+		OnlineMeetingURL:         event.OnlineMeetingURL.String,
+		VenueID:                  event.VenueID.String,
+		TransferPolicy:           event.TransferPolicy,
+		Timezone:                 event.Timezone,
+		IsRecurring:              event.IsRecurring,
+		WaitlistEnabled:          event.WaitlistEnabled,
+		PerSessionRegistration:   event.PerSessionRegistration,
+		RegistrationRequired:     event.RegistrationRequired,
+		WhitelistOnly:            event.WhitelistOnly,
+		RequireApproval:          event.RequireApproval,
+		FaceVerificationRequired: event.FaceVerificationRequired,
+		LivenessCheckRequired:    event.LivenessCheckRequired,
+		QRCodeEnabled:            event.QRCodeEnabled,
+		FallbackCodeEnabled:      event.FallbackCodeEnabled,
+		ManualCheckinAllowed:     event.ManualCheckinAllowed,
+		IsPaid:                   event.IsPaid,
+		Currency:                 event.Currency,
+		ReminderSchedule:         event.ReminderSchedule,
+	}
+	if !event.StartTime.Valid || !event.EndTime.Valid {
+		return settings, fmt.Errorf("%w: the event has no start or end time", ErrInvalidEventTemplate)
+	}
+	start := event.StartTime.Time
+	settings.DurationMinutes = int(event.EndTime.Time.Sub(start).Minutes())
+
+	if event.IsRecurring {
+		recurrence, err := ParseRecurrence(event.RecurrenceRule)
+		if err != nil {
+			return settings, err
+		}
+		if recurrence != nil {
+			settings.RecurrenceRule = recurrence.RRule
+		}
+	}
+	if event.RecurrenceEndDate.Valid {
+		days := int(event.RecurrenceEndDate.Time.Sub(start).Hours() / 24)
+		settings.RecurrenceEndsAfterDays = &days
+	}
+	if event.RegistrationOpensAt.Valid {
+		minutes := int(start.Sub(event.RegistrationOpensAt.Time).Minutes())
+		settings.RegistrationOpensBeforeMinutes = &minutes
+	}
+	if event.RegistrationClosesAt.Valid {
+		minutes := int(start.Sub(event.RegistrationClosesAt.Time).Minutes())
+		settings.RegistrationClosesBeforeMinutes = &minutes
+	}
+	settings.MaxOccurrences = nullInt32Ptr(event.MaxOccurrences)
+	settings.GenerationHorizonDays = nullInt32Ptr(event.GenerationHorizonDays)
+	settings.MeetingLinkRevealMinutes = nullInt32Ptr(event.MeetingLinkRevealMinutes)
+	settings.TransferDeadlineMinutes = nullInt32Ptr(event.TransferDeadlineMinutes)
+	settings.MaxAttendees = nullInt32Ptr(event.MaxAttendees)
+	settings.MaxWaitlist = nullInt32Ptr(event.MaxWaitlist)
+	if event.Fee.Valid {
+		fee := event.Fee.Float64
+		settings.Fee = &fee
+	}
+	return settings, nil
+}
+
+// NewEvent builds a draft event of the community from the settings, starting at the given time.
+func (s *EventTemplateSettings) NewEvent(communityID, name string, start time.Time) (*Event, error) {
+	if s.DurationMinutes <= 0 {
+		return nil, fmt.Errorf("%w: the template has no duration", ErrInvalidEventTemplate)
+	}
+	event := &Event{
+		CommunityID:              communityID,
+		Name:                     name,
+		Description:              sql.NullString{String: s.Description, Valid: s.Description != ""},
+		CoverImageURL:            sql.NullString{String: s.CoverImageURL, Valid: s.CoverImageURL != ""},
+		LocationType:             s.LocationType,
+		LocationAddress:          sql.NullString{String: s.LocationAddress, Valid: s.LocationAddress != ""},
+		OnlineMeetingURL:         sql.NullString{String: s.OnlineMeetingURL, Valid: s.OnlineMeetingURL != ""},
+		VenueID:                  sql.NullString{String: s.VenueID, Valid: s.VenueID != ""},
+		TransferPolicy:           s.TransferPolicy,
+		Timezone:                 s.Timezone,
+		StartTime:                sql.NullTime{Time: start, Valid: true},
+		EndTime:                  sql.NullTime{Time: start.Add(time.Duration(s.DurationMinutes) * time.Minute), Valid: true},
+		IsRecurring:              s.IsRecurring,
+		WaitlistEnabled:          s.WaitlistEnabled,
+		PerSessionRegistration:   s.PerSessionRegistration,
+		RegistrationRequired:     s.RegistrationRequired,
+		WhitelistOnly:            s.WhitelistOnly,
+		RequireApproval:          s.RequireApproval,
+		FaceVerificationRequired: s.FaceVerificationRequired,
+		LivenessCheckRequired:    s.LivenessCheckRequired,
+		QRCodeEnabled:            s.QRCodeEnabled,
+		FallbackCodeEnabled:      s.FallbackCodeEnabled,
+		ManualCheckinAllowed:     s.ManualCheckinAllowed,
+		IsPaid:                   s.IsPaid,
+		Currency:                 s.Currency,
+		ReminderSchedule:         s.ReminderSchedule,
+		Status:                   EventStatusDraft,
+	}
+	if s.IsRecurring {
+		rule, err := (&Recurrence{RRule: s.RecurrenceRule}).Marshal()
+		if err != nil {
+			return nil, err
+		}
+		event.RecurrenceRule = rule
+	}
+	if s.RecurrenceEndsAfterDays != nil {
+		event.RecurrenceEndDate = sql.NullTime{Time: start.AddDate(0, 0, *s.RecurrenceEndsAfterDays), Valid: true}
+	}
+	if s.RegistrationOpensBeforeMinutes != nil {
+		event.RegistrationOpensAt = sql.NullTime{Time: start.Add(-time.Duration(*s.RegistrationOpensBeforeMinutes) * time.Minute), Valid: true}
+	}
+	if s.RegistrationClosesBeforeMinutes != nil {
+		event.RegistrationClosesAt = sql.NullTime{Time: start.Add(-time.Duration(*s.RegistrationClosesBeforeMinutes) * time.Minute), Valid: true}
+	}
+	event.MaxOccurrences = int32PtrNull(s.MaxOccurrences)
+	event.GenerationHorizonDays = int32PtrNull(s.GenerationHorizonDays)
+	event.MeetingLinkRevealMinutes = int32PtrNull(s.MeetingLinkRevealMinutes)
+	event.TransferDeadlineMinutes = int32PtrNull(s.TransferDeadlineMinutes)
+	event.MaxAttendees = int32PtrNull(s.MaxAttendees)
+	event.MaxWaitlist = int32PtrNull(s.MaxWaitlist)
+	if s.Fee != nil {
+		event.Fee = sql.NullFloat64{Float64: *s.Fee, Valid: true}
+	}
+	return event, nil
+}
+
+func nullInt32Ptr(v sql.NullInt32) *int32 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int32
+}
+
+func int32PtrNull(v *int32) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *v, Valid: true}
+}
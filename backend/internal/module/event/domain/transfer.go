@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTransferNotFound        = errors.New("registration transfer not found")
+	ErrInvalidTransfer         = errors.New("invalid registration transfer")
+	ErrInvalidTransferPolicy   = errors.New("transfer_policy must be disabled, allowed or approval, with a transfer deadline of at least 0 minutes")
+	ErrTransfersDisabled       = errors.New("the event does not allow registration transfers")
+	ErrTransfersClosed         = errors.New("registration transfers are closed for this event")
+	ErrTransferPending         = errors.New("the registration already has a pending transfer")
+	ErrTransferNotPending      = errors.New("registration transfer is no longer pending")
+	ErrTransferRecipientActive = errors.New("the recipient is already registered for the event")
+)
+
+// RegistrationTransferSubject is the NATS subject a RegistrationTransferEvent is published on.
+const RegistrationTransferSubject = "events.registration.transfer"
+
+// Transfer policies of an event.
+const (
+	TransferPolicyDisabled = "disabled"
+	TransferPolicyAllowed  = "allowed"
+	TransferPolicyApproval = "approval"
+)
+
+// Statuses of a registration transfer. A transfer is completed once the recipient accepted it and, under the
+// approval policy, a host approved it.
+const (
+	TransferPending   = "pending"
+	TransferCompleted = "completed"
+	TransferDeclined  = "declined"
+	TransferRejected  = "rejected"
+	TransferCancelled = "cancelled"
+)
+
+// Kinds of RegistrationTransferEvent.
+const (
+	TransferKindOffered   = "offered"
+	TransferKindCompleted = "completed"
+	TransferKindDeclined  = "declined"
+	TransferKindRejected  = "rejected"
+)
+
+// ValidateTransferPolicy checks the event's transfer policy and deadline.
+func (e *Event) ValidateTransferPolicy() error {
+	switch e.TransferPolicy {
+	case TransferPolicyDisabled, TransferPolicyAllowed, TransferPolicyApproval:
+	default:
+		return ErrInvalidTransferPolicy
+	}
+	if e.TransferDeadlineMinutes.Valid && e.TransferDeadlineMinutes.Int32 < 0 {
+		return ErrInvalidTransferPolicy
+	}
+	return nil
+}
+
+// TransfersOpen reports whether registrations of the event can change hands at now: until the transfer
+// deadline before its next session that is not cancelled, or before the event's start if it has no sessions.
+func (e *Event) TransfersOpen(now time.Time) bool {
+	var next *time.Time
+	for i := range e.Sessions {
+		session := &e.Sessions[i]
+		if session.IsCancelled || !session.StartTime.After(now) {
+			continue
+		}
+		if next == nil || session.StartTime.Before(*next) {
+			next = &session.StartTime
+		}
+	}
+	if next == nil && len(e.Sessions) == 0 && e.StartTime.Valid && e.StartTime.Time.After(now) {
+		next = &e.StartTime.Time
+	}
+	if next == nil {
+		return false
+	}
+	deadline := *next
+	if e.TransferDeadlineMinutes.Valid {
+		deadline = deadline.Add(-time.Duration(e.TransferDeadlineMinutes.Int32) * time.Minute)
+	}
+	return now.Before(deadline)
+}
+
+// RegistrationTransfer corresponds to the 'registration_transfers' table. Transfers to an email address have
+// no ToUserID until the account with that email accepts them.
+type RegistrationTransfer struct {
+	ID               string         `json:"id"`
+	EventID          string         `json:"event_id"`
+	AttendeeID       string         `json:"attendee_id"`
+	FromUserID       string         `json:"from_user_id"`
+	ToUserID         sql.NullString `json:"to_user_id,omitempty"`
+	ToEmail          sql.NullString `json:"to_email,omitempty"`
+	Message          sql.NullString `json:"message,omitempty"`
+	Status           string         `json:"status"`
+	RequiresApproval bool           `json:"requires_approval"`
+	ApprovedBy       sql.NullString `json:"approved_by,omitempty"`
+	ApprovedAt       sql.NullTime   `json:"approved_at,omitempty"`
+	AcceptedAt       sql.NullTime   `json:"accepted_at,omitempty"`
+	CompletedAt      sql.NullTime   `json:"completed_at,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+
+	// Joined fields
+	FromUserName   string         `json:"from_user_name"`
+	ToUserName     sql.NullString `json:"to_user_name,omitempty"`
+	EventName      string         `json:"event_name"`
+	EventStartTime sql.NullTime   `json:"event_start_time,omitempty"`
+}
+
+// ReadyToComplete reports whether the transfer has everything it needs to complete: the recipient's
+// acceptance and, if required, a host's approval.
+func (t *RegistrationTransfer) ReadyToComplete() bool {
+	return t.AcceptedAt.Valid && (!t.RequiresApproval || t.ApprovedAt.Valid)
+}
+
+// RegistrationTransferEvent is published on RegistrationTransferSubject when a transfer is offered to its
+// recipient and when it completes, is declined or is rejected. ToUserID is empty for recipients without an
+// account.
+type RegistrationTransferEvent struct {
+	TransferID string `json:"transfer_id"`
+	EventID    string `json:"event_id"`
+	EventName  string `json:"event_name"`
+	Kind       string `json:"kind"`
+	FromUserID string `json:"from_user_id"`
+	ToUserID   string `json:"to_user_id,omitempty"`
+	ToEmail    string `json:"to_email,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestEventTransfersOpen(t *testing.T) {
+	now := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+	session := func(start time.Time, cancelled bool) EventSession {
+		return EventSession{StartTime: start, EndTime: start.Add(time.Hour), IsCancelled: cancelled}
+	}
+	deadline := func(minutes int32) sql.NullInt32 { return sql.NullInt32{Int32: minutes, Valid: true} }
+
+	tests := []struct {
+		name  string
+		event Event
+		want  bool
+	}{
+		{
+			name:  "event ahead without sessions",
+			event: Event{StartTime: sql.NullTime{Time: now.Add(time.Hour), Valid: true}},
+			want:  true,
+		},
+		{
+			name:  "event already started",
+			event: Event{StartTime: sql.NullTime{Time: now.Add(-time.Hour), Valid: true}},
+			want:  false,
+		},
+		{
+			name:  "before the deadline",
+			event: Event{StartTime: sql.NullTime{Time: now.Add(2 * time.Hour), Valid: true}, TransferDeadlineMinutes: deadline(60)},
+			want:  true,
+		},
+		{
+			name:  "at the deadline",
+			event: Event{StartTime: sql.NullTime{Time: now.Add(time.Hour), Valid: true}, TransferDeadlineMinutes: deadline(60)},
+			want:  false,
+		},
+		{
+			name:  "next session is ahead",
+			event: Event{Sessions: []EventSession{session(now.Add(-24*time.Hour), false), session(now.Add(24*time.Hour), false)}},
+			want:  true,
+		},
+		{
+			name:  "deadline counts from the next session",
+			event: Event{Sessions: []EventSession{session(now.Add(48*time.Hour), false), session(now.Add(30*time.Minute), false)}, TransferDeadlineMinutes: deadline(60)},
+			want:  false,
+		},
+		{
+			name:  "cancelled sessions are skipped",
+			event: Event{Sessions: []EventSession{session(now.Add(30*time.Minute), true), session(now.Add(48*time.Hour), false)}, TransferDeadlineMinutes: deadline(60)},
+			want:  true,
+		},
+		{
+			name:  "all sessions are over",
+			event: Event{StartTime: sql.NullTime{Time: now.Add(time.Hour), Valid: true}, Sessions: []EventSession{session(now.Add(-time.Hour), false)}},
+			want:  false,
+		},
+		{
+			name:  "no start time",
+			event: Event{},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.TransfersOpen(now); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistrationTransferReadyToComplete(t *testing.T) {
+	at := sql.NullTime{Time: time.Now(), Valid: true}
+	tests := []struct {
+		name     string
+		transfer RegistrationTransfer
+		want     bool
+	}{
+		{name: "not accepted yet", transfer: RegistrationTransfer{}, want: false},
+		{name: "accepted", transfer: RegistrationTransfer{AcceptedAt: at}, want: true},
+		{name: "accepted, awaiting approval", transfer: RegistrationTransfer{AcceptedAt: at, RequiresApproval: true}, want: false},
+		{name: "approved, awaiting acceptance", transfer: RegistrationTransfer{ApprovedAt: at, RequiresApproval: true}, want: false},
+		{name: "accepted and approved", transfer: RegistrationTransfer{AcceptedAt: at, ApprovedAt: at, RequiresApproval: true}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.transfer.ReadyToComplete(); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrVenueNotFound = errors.New("venue not found")
+	ErrInvalidVenue  = errors.New("invalid venue")
+)
+
+// Venue corresponds to the 'venues' table: a place a community holds events at. Coordinates are optional,
+// but only venues that have them are found by the nearby event search.
+type Venue struct {
+	ID                 string          `json:"id"`
+	CommunityID        string          `json:"community_id"`
+	Name               string          `json:"name"`
+	Address            string          `json:"address"`
+	Latitude           sql.NullFloat64 `json:"latitude,omitempty"`
+	Longitude          sql.NullFloat64 `json:"longitude,omitempty"`
+	Capacity           sql.NullInt32   `json:"capacity,omitempty"`
+	AccessibilityNotes sql.NullString  `json:"accessibility_notes,omitempty"`
+	CreatedBy          sql.NullString  `json:"created_by,omitempty"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+}
+
+// Validate checks the venue's name, address, coordinates and capacity.
+func (v *Venue) Validate() error {
+	v.Name = strings.TrimSpace(v.Name)
+	v.Address = strings.TrimSpace(v.Address)
+	if v.Name == "" || v.Address == "" {
+		return fmt.Errorf("%w: name and address are required", ErrInvalidVenue)
+	}
+	if len(v.Name) > 255 {
+		return fmt.Errorf("%w: name is too long", ErrInvalidVenue)
+	}
+	if v.Latitude.Valid != v.Longitude.Valid {
+		return fmt.Errorf("%w: latitude and longitude go together", ErrInvalidVenue)
+	}
+	if v.Latitude.Valid && (v.Latitude.Float64 < -90 || v.Latitude.Float64 > 90) {
+		return fmt.Errorf("%w: latitude must be between -90 and 90", ErrInvalidVenue)
+	}
+	if v.Longitude.Valid && (v.Longitude.Float64 < -180 || v.Longitude.Float64 > 180) {
+		return fmt.Errorf("%w: longitude must be between -180 and 180", ErrInvalidVenue)
+	}
+	if v.Capacity.Valid && v.Capacity.Int32 <= 0 {
+		return fmt.Errorf("%w: capacity must be positive", ErrInvalidVenue)
+	}
+	return nil
+}
+
+// Location describes the venue as the free-text location of the events and sessions held there.
+func (v *Venue) Location() string {
+	return v.Name + ", " + v.Address
+}
@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+)
+
+var (
+	ErrWhitelistEntryNotFound = errors.New("whitelist entry not found")
+	ErrInvalidWhitelistCSV    = errors.New("invalid whitelist CSV")
+	ErrWhitelistLinkMismatch  = errors.New("the account cannot be linked to this whitelist entry")
+)
+
+// MaxWhitelistImportRows limits the number of rows of a whitelist import.
+const MaxWhitelistImportRows = 5000
+
+// Actions of whitelist import rows.
+const (
+	WhitelistImportActionAdd     = "add"
+	WhitelistImportActionSkip    = "skip"
+	WhitelistImportActionInvalid = "invalid"
+)
+
+// WhitelistImportRow is one person to whitelist by email and/or phone, as parsed from a CSV upload or a
+// request, together with what the import does with it.
+type WhitelistImportRow struct {
+	Line   int    `json:"line,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Phone  string `json:"phone,omitempty"`
+	Notes  string `json:"notes,omitempty"`
+	Action string `json:"action"` // "add", "skip" or "invalid"
+	Error  string `json:"error,omitempty"`
+}
+
+// WhitelistImportResult is the validation report of a whitelist import, either as a preview or after
+// confirmation.
+type WhitelistImportResult struct {
+	Confirmed bool                  `json:"confirmed"`
+	Rows      []*WhitelistImportRow `json:"rows"`
+	Added     int                   `json:"added"`
+	Skipped   int                   `json:"skipped"`
+	Invalid   int                   `json:"invalid"`
+}
+
+// NormalizeWhitelistEmail trims and lowercases an email and checks that it is a plain address.
+func NormalizeWhitelistEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", nil
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || len(email) > 255 {
+		return "", fmt.Errorf("invalid email %q", email)
+	}
+	return email, nil
+}
+
+// NormalizeWhitelistPhone strips a phone number down to its digits, keeping a leading "+", and checks
+// its length.
+func NormalizeWhitelistPhone(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return "", nil
+	}
+	var b strings.Builder
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", fmt.Errorf("invalid phone %q", phone)
+		}
+	}
+	normalized := b.String()
+	digits := len(strings.TrimPrefix(normalized, "+"))
+	if digits < 7 || digits > 15 {
+		return "", fmt.Errorf("invalid phone %q", phone)
+	}
+	return normalized, nil
+}
+
+// ParseWhitelistCSV reads the rows of a whitelist CSV. The first line is a header naming the columns
+// "email", "phone" and "notes", in any order; at least one of "email" and "phone" is required and other
+// columns are ignored. Rows are returned as read, without validation.
+func ParseWhitelistCSV(data []byte) ([]*WhitelistImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: the file is empty", ErrInvalidWhitelistCSV)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWhitelistCSV, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	emailColumn, hasEmail := columns["email"]
+	phoneColumn, hasPhone := columns["phone"]
+	notesColumn, hasNotes := columns["notes"]
+	if !hasEmail && !hasPhone {
+		return nil, fmt.Errorf("%w: the header must name an email or phone column", ErrInvalidWhitelistCSV)
+	}
+
+	field := func(record []string, column int, ok bool) string {
+		if !ok || column >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[column])
+	}
+
+	var rows []*WhitelistImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidWhitelistCSV, err)
+		}
+		line, _ := reader.FieldPos(0)
+		row := &WhitelistImportRow{
+			Line:  line,
+			Email: field(record, emailColumn, hasEmail),
+			Phone: field(record, phoneColumn, hasPhone),
+			Notes: field(record, notesColumn, hasNotes),
+		}
+		if row.Email == "" && row.Phone == "" && row.Notes == "" {
+			continue
+		}
+		rows = append(rows, row)
+		if len(rows) > MaxWhitelistImportRows {
+			return nil, fmt.Errorf("%w: at most %d rows can be imported at once", ErrInvalidWhitelistCSV, MaxWhitelistImportRows)
+		}
+	}
+	return rows, nil
+}
+
+// PlanWhitelistImport validates the rows of an import against each other and the event's current whitelist,
+// normalizing their email and phone, and sets the action of each. Rows whose email or phone is already on
+// the whitelist, or earlier in the import, are skipped. It returns the entries to add.
+func PlanWhitelistImport(eventID, addedBy string, rows []*WhitelistImportRow, existing []*WhitelistEntry) []*WhitelistEntry {
+	listed := map[string]bool{}
+	for _, entry := range existing {
+		if entry.Email.Valid {
+			listed["email:"+entry.Email.String] = true
+		}
+		if entry.Phone.Valid {
+			listed["phone:"+entry.Phone.String] = true
+		}
+	}
+	seen := map[string]bool{}
+
+	var entries []*WhitelistEntry
+	for _, row := range rows {
+		email, emailErr := NormalizeWhitelistEmail(row.Email)
+		phone, phoneErr := NormalizeWhitelistPhone(row.Phone)
+		switch {
+		case emailErr != nil:
+			row.Action, row.Error = WhitelistImportActionInvalid, emailErr.Error()
+			continue
+		case phoneErr != nil:
+			row.Action, row.Error = WhitelistImportActionInvalid, phoneErr.Error()
+			continue
+		case email == "" && phone == "":
+			row.Action, row.Error = WhitelistImportActionInvalid, "an email or phone is required"
+			continue
+		}
+		row.Email, row.Phone = email, phone
+
+		if (email != "" && listed["email:"+email]) || (phone != "" && listed["phone:"+phone]) {
+			row.Action, row.Error = WhitelistImportActionSkip, "already on the whitelist"
+			continue
+		}
+		if (email != "" && seen["email:"+email]) || (phone != "" && seen["phone:"+phone]) {
+			row.Action, row.Error = WhitelistImportActionSkip, "repeated in the import"
+			continue
+		}
+		if email != "" {
+			seen["email:"+email] = true
+		}
+		if phone != "" {
+			seen["phone:"+phone] = true
+		}
+
+		row.Action = WhitelistImportActionAdd
+		entries = append(entries, &WhitelistEntry{
+			EventID: eventID,
+			Email:   sql.NullString{String: email, Valid: email != ""},
+			Phone:   sql.NullString{String: phone, Valid: phone != ""},
+			Notes:   sql.NullString{String: row.Notes, Valid: row.Notes != ""},
+			AddedBy: addedBy,
+		})
+	}
+	return entries
+}
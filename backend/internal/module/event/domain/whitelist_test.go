@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestNormalizeWhitelistEmail(t *testing.T) {
+	tests := []struct {
+		email   string
+		want    string
+		wantErr bool
+	}{
+		{email: " Ada@Example.COM ", want: "ada@example.com"},
+		{email: "", want: ""},
+		{email: "Ada <ada@example.com>", wantErr: true},
+		{email: "not an email", wantErr: true},
+		{email: "ada@", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			got, err := NormalizeWhitelistEmail(tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeWhitelistPhone(t *testing.T) {
+	tests := []struct {
+		phone   string
+		want    string
+		wantErr bool
+	}{
+		{phone: "+1 (555) 123-4567", want: "+15551234567"},
+		{phone: "030.1234.5678", want: "03012345678"},
+		{phone: "", want: ""},
+		{phone: "12345", wantErr: true},
+		{phone: "+1234567890123456", wantErr: true},
+		{phone: "555-CALL-NOW", wantErr: true},
+		{phone: "555+1234567", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.phone, func(t *testing.T) {
+			got, err := NormalizeWhitelistPhone(tt.phone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWhitelistCSV(t *testing.T) {
+	rows, err := ParseWhitelistCSV([]byte("\xef\xbb\xbfNotes, Email ,extra\nSpeaker,ada@example.com,x\n,,\n,grace@example.com\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2 without the blank one", len(rows))
+	}
+	if rows[0].Email != "ada@example.com" || rows[0].Notes != "Speaker" || rows[0].Line != 2 {
+		t.Errorf("got first row %+v", rows[0])
+	}
+	if rows[1].Email != "grace@example.com" || rows[1].Line != 4 {
+		t.Errorf("got second row %+v", rows[1])
+	}
+
+	for _, data := range []string{"", "name,notes\nAda,x\n"} {
+		if _, err := ParseWhitelistCSV([]byte(data)); !errors.Is(err, ErrInvalidWhitelistCSV) {
+			t.Errorf("%q: want ErrInvalidWhitelistCSV, got %v", data, err)
+		}
+	}
+}
+
+func TestPlanWhitelistImport(t *testing.T) {
+	existing := []*WhitelistEntry{
+		{Email: sql.NullString{String: "ada@example.com", Valid: true}},
+		{Phone: sql.NullString{String: "+15551234567", Valid: true}},
+	}
+	rows := []*WhitelistImportRow{
+		{Email: "GRACE@example.com", Notes: "Speaker"},
+		{Email: "Ada@Example.com"},
+		{Phone: "+1 555 123 4567"},
+		{Email: "grace@example.com "},
+		{Email: "linus@example.com", Phone: "+44 20 7946 0958"},
+		{Email: "broken"},
+		{Notes: "nobody"},
+	}
+	want := []struct{ action, email, phone string }{
+		{WhitelistImportActionAdd, "grace@example.com", ""},
+		{WhitelistImportActionSkip, "ada@example.com", ""},
+		{WhitelistImportActionSkip, "", "+15551234567"},
+		{WhitelistImportActionSkip, "grace@example.com", ""},
+		{WhitelistImportActionAdd, "linus@example.com", "+442079460958"},
+		{WhitelistImportActionInvalid, "broken", ""},
+		{WhitelistImportActionInvalid, "", ""},
+	}
+
+	entries := PlanWhitelistImport("e1", "u1", rows, existing)
+	for i, row := range rows {
+		if row.Action != want[i].action || row.Email != want[i].email || row.Phone != want[i].phone {
+			t.Errorf("row %d: got %s %q %q, want %s %q %q", i, row.Action, row.Email, row.Phone, want[i].action, want[i].email, want[i].phone)
+		}
+		if (row.Action == WhitelistImportActionAdd) != (row.Error == "") {
+			t.Errorf("row %d: %s with error %q", i, row.Action, row.Error)
+		}
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if entries[0].EventID != "e1" || entries[0].AddedBy != "u1" || entries[0].Notes.String != "Speaker" || entries[0].Phone.Valid {
+		t.Errorf("got entry %+v", entries[0])
+	}
+}
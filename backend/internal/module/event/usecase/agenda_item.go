@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// CreateEventTrack adds a track to an event's programme. Tracks are managed by those who can edit the event.
+func (s *Service) CreateEventTrack(ctx context.Context, eventID, userID string, track *domain.EventTrack) (*domain.EventTrack, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if err := track.Validate(); err != nil {
+		return nil, err
+	}
+	track.EventID = event.ID
+	if err := s.repo.CreateEventTrack(ctx, track); err != nil {
+		return nil, err
+	}
+	return track, nil
+}
+
+// ListEventTracks lists the tracks of an event to those who can view it.
+func (s *Service) ListEventTracks(ctx context.Context, eventID, userID string) ([]*domain.EventTrack, error) {
+	event, err := s.viewableEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListEventTracks(ctx, event.ID)
+}
+
+// UpdateEventTrack saves the name, description, color and position of a track.
+func (s *Service) UpdateEventTrack(ctx context.Context, track *domain.EventTrack, userID string) (*domain.EventTrack, error) {
+	existing, err := s.repo.GetEventTrack(ctx, track.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeTrackManagement(ctx, existing, userID); err != nil {
+		return nil, err
+	}
+	if err := track.Validate(); err != nil {
+		return nil, err
+	}
+	track.EventID = existing.EventID
+	track.CreatedAt = existing.CreatedAt
+	if err := s.repo.UpdateEventTrack(ctx, track); err != nil {
+		return nil, err
+	}
+	return track, nil
+}
+
+// DeleteEventTrack removes a track. Its agenda items stay on the agenda without a track.
+func (s *Service) DeleteEventTrack(ctx context.Context, trackID, userID string) error {
+	track, err := s.repo.GetEventTrack(ctx, trackID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeTrackManagement(ctx, track, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteEventTrack(ctx, trackID)
+}
+
+// CreateAgendaItem adds a talk, workshop or break to a session. Its speakers must be speakers of the event.
+func (s *Service) CreateAgendaItem(ctx context.Context, sessionID, userID string, item *domain.SessionAgendaItem, speakerIDs []string) (*domain.SessionAgendaItem, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	item.SessionID = session.ID
+	item.EventID = event.ID
+	if err := s.checkAgendaItem(ctx, item, session, speakerIDs); err != nil {
+		return nil, err
+	}
+	item.CreatedBy.String, item.CreatedBy.Valid = userID, true
+	if err := s.repo.CreateAgendaItem(ctx, item, speakerIDs); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAgendaItem(ctx, item.ID, userID)
+}
+
+// ListSessionAgendaItems lists the agenda items of a session to those who can view its event.
+func (s *Service) ListSessionAgendaItems(ctx context.Context, sessionID, userID string) ([]*domain.SessionAgendaItem, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.viewableEvent(ctx, event.ID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAgendaItems(ctx, event.ID, sessionID, userID, false)
+}
+
+// UpdateAgendaItem saves the details of an agenda item and replaces its speakers. The item stays in its
+// session.
+func (s *Service) UpdateAgendaItem(ctx context.Context, item *domain.SessionAgendaItem, speakerIDs []string, userID string) (*domain.SessionAgendaItem, error) {
+	existing, err := s.repo.GetAgendaItem(ctx, item.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.repo.GetEventByID(ctx, existing.EventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	session, err := s.repo.GetEventSessionByID(ctx, existing.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	item.SessionID = existing.SessionID
+	item.EventID = existing.EventID
+	if err := s.checkAgendaItem(ctx, item, session, speakerIDs); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateAgendaItem(ctx, item, speakerIDs); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAgendaItem(ctx, item.ID, userID)
+}
+
+// DeleteAgendaItem removes an agenda item from its session.
+func (s *Service) DeleteAgendaItem(ctx context.Context, itemID, userID string) error {
+	item, err := s.repo.GetAgendaItem(ctx, itemID, userID)
+	if err != nil {
+		return err
+	}
+	event, err := s.repo.GetEventByID(ctx, item.EventID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteAgendaItem(ctx, itemID)
+}
+
+// StarAgendaItem adds an agenda item to the user's personal schedule.
+func (s *Service) StarAgendaItem(ctx context.Context, itemID, userID string) (*domain.SessionAgendaItem, error) {
+	item, err := s.repo.GetAgendaItem(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.viewableEvent(ctx, item.EventID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.StarAgendaItem(ctx, itemID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAgendaItem(ctx, itemID, userID)
+}
+
+// UnstarAgendaItem removes an agenda item from the user's personal schedule.
+func (s *Service) UnstarAgendaItem(ctx context.Context, itemID, userID string) error {
+	if _, err := s.repo.GetAgendaItem(ctx, itemID, userID); err != nil {
+		return err
+	}
+	return s.repo.UnstarAgendaItem(ctx, itemID, userID)
+}
+
+// GetEventSchedule returns the programme of an event by day, in the given timezone or the event's. Items
+// of cancelled sessions are left out; with starredOnly, so are those the user did not star.
+func (s *Service) GetEventSchedule(ctx context.Context, eventID, userID, timezone string, starredOnly bool) (*domain.EventSchedule, error) {
+	event, err := s.viewableEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc := event.Location()
+	if timezone != "" {
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("%w: %s", domain.ErrInvalidTimezone, timezone)
+		}
+	}
+	tracks, err := s.repo.ListEventTracks(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.scheduledAgendaItems(ctx, event.ID, userID, starredOnly)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewEventSchedule(event, tracks, items, loc), nil
+}
+
+// ExportEventScheduleICal renders the programme of an event as an .ics document in the event's timezone,
+// one entry per agenda item. With starredOnly, only the items the user starred are exported.
+func (s *Service) ExportEventScheduleICal(ctx context.Context, eventID, userID string, starredOnly bool) ([]byte, error) {
+	event, err := s.viewableEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.scheduledAgendaItems(ctx, event.ID, userID, starredOnly)
+	if err != nil {
+		return nil, err
+	}
+	name := event.Name + " - Agenda"
+	if starredOnly {
+		name = event.Name + " - My Schedule"
+	}
+	return buildAgendaICalendar(name, event, items), nil
+}
+
+// scheduledAgendaItems lists the agenda items of an event that are not in cancelled sessions.
+func (s *Service) scheduledAgendaItems(ctx context.Context, eventID, userID string, starredOnly bool) ([]*domain.SessionAgendaItem, error) {
+	items, err := s.repo.ListAgendaItems(ctx, eventID, "", userID, starredOnly)
+	if err != nil {
+		return nil, err
+	}
+	scheduled := make([]*domain.SessionAgendaItem, 0, len(items))
+	for _, item := range items {
+		if !item.SessionCancelled {
+			scheduled = append(scheduled, item)
+		}
+	}
+	return scheduled, nil
+}
+
+// shiftAgendaItems moves the agenda items of a session along with it. Errors are logged, since the session
+// has already moved.
+func (s *Service) shiftAgendaItems(ctx context.Context, sessionID string, previousStart, newStart time.Time) {
+	if newStart.Equal(previousStart) {
+		return
+	}
+	if err := s.repo.ShiftSessionAgendaItems(ctx, sessionID, newStart.Sub(previousStart)); err != nil {
+		log.Printf("Error moving the agenda items of session %s: %v", sessionID, err)
+	}
+}
+
+// checkAgendaItem validates an agenda item, its track and its speakers.
+func (s *Service) checkAgendaItem(ctx context.Context, item *domain.SessionAgendaItem, session *domain.EventSession, speakerIDs []string) error {
+	if err := item.Validate(session); err != nil {
+		return err
+	}
+	if item.TrackID.Valid {
+		track, err := s.repo.GetEventTrack(ctx, item.TrackID.String)
+		if err != nil {
+			return err
+		}
+		if track.EventID != item.EventID {
+			return fmt.Errorf("%w: the track belongs to another event", domain.ErrInvalidAgendaItem)
+		}
+	}
+	if len(speakerIDs) == 0 {
+		return nil
+	}
+	speakers, err := s.repo.ListEventStaff(ctx, item.EventID, permission_domain.EventRoleSpeaker)
+	if err != nil {
+		return err
+	}
+	for _, speakerID := range speakerIDs {
+		if !slices.ContainsFunc(speakers, func(member *domain.EventStaffMember) bool { return member.UserID == speakerID }) {
+			return fmt.Errorf("%w: speakers must be assigned the speaker role on the event first", domain.ErrInvalidAgendaItem)
+		}
+	}
+	return nil
+}
+
+// authorizeTrackManagement allows those who can edit the track's event to manage it.
+func (s *Service) authorizeTrackManagement(ctx context.Context, track *domain.EventTrack, userID string) error {
+	event, err := s.repo.GetEventByID(ctx, track.EventID, userID)
+	if err != nil {
+		return err
+	}
+	return s.authorizeSessionManagement(ctx, event, userID)
+}
+
+// viewableEvent returns an event if the user can view its community's content.
+func (s *Service) viewableEvent(ctx context.Context, eventID, userID string) (*domain.Event, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	canView, err := s.permService.CanViewCommunityContent(ctx, event.CommunityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+	return event, nil
+}
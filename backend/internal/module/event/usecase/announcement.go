@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// CreateAnnouncement broadcasts an announcement to an audience of an event, or of one of its sessions if
+// announcement.SessionID is set. Announcements scheduled in the future are sent by a worker once their time
+// has come; others are sent right away.
+func (s *Service) CreateAnnouncement(ctx context.Context, eventID, userID string, announcement *domain.EventAnnouncement) (*domain.EventAnnouncement, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if announcement.SessionID.Valid {
+		if err := s.checkEventSession(ctx, eventID, announcement.SessionID.String); err != nil {
+			return nil, err
+		}
+	}
+	if err := announcement.Validate(); err != nil {
+		return nil, err
+	}
+	if !announcement.ScheduledAt.IsZero() && announcement.ScheduledAt.Before(time.Now().Add(-time.Minute)) {
+		return nil, fmt.Errorf("%w: scheduled_at must not be in the past", domain.ErrInvalidAnnouncement)
+	}
+
+	sendNow := !announcement.ScheduledAt.After(time.Now())
+
+	announcement.EventID = eventID
+	announcement.AuthorID = sql.NullString{String: userID, Valid: true}
+	if err := s.repo.CreateEventAnnouncement(ctx, announcement); err != nil {
+		return nil, err
+	}
+	if sendNow {
+		if err := s.sendAnnouncements(ctx, announcement.ID); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.GetEventAnnouncement(ctx, announcement.ID)
+}
+
+// ListAnnouncements returns the history of an event's announcements, including scheduled and cancelled
+// ones, to its editors.
+func (s *Service) ListAnnouncements(ctx context.Context, eventID, userID string) ([]*domain.EventAnnouncement, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListEventAnnouncements(ctx, eventID)
+}
+
+// CancelAnnouncement cancels a scheduled announcement before it is sent.
+func (s *Service) CancelAnnouncement(ctx context.Context, announcementID, userID string) error {
+	announcement, err := s.repo.GetEventAnnouncement(ctx, announcementID)
+	if err != nil {
+		return err
+	}
+	event, err := s.repo.GetEventByID(ctx, announcement.EventID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return err
+	}
+	return s.repo.CancelEventAnnouncement(ctx, announcementID)
+}
+
+// SendDueAnnouncements sends the scheduled announcements whose time has come. It is run by a worker.
+func (s *Service) SendDueAnnouncements(ctx context.Context) error {
+	return s.sendAnnouncements(ctx, "")
+}
+
+// sendAnnouncements claims the due announcements, only the given one if announcementID is set, and publishes
+// an EventAnnouncementSentEvent with the recipients of each for the notification worker to deliver.
+func (s *Service) sendAnnouncements(ctx context.Context, announcementID string) error {
+	for {
+		announcements, err := s.repo.ClaimDueAnnouncements(ctx, announcementID, domain.AnnouncementBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, announcement := range announcements {
+			s.publishAnnouncement(announcement, announcement.RecipientIDs)
+		}
+		if len(announcements) < domain.AnnouncementBatchSize {
+			return nil
+		}
+	}
+}
+
+// publishAnnouncement publishes an EventAnnouncementSentEvent for an announcement.
+func (s *Service) publishAnnouncement(announcement *domain.EventAnnouncement, userIDs []string) {
+	if s.publisher == nil || len(userIDs) == 0 {
+		return
+	}
+	payload, err := json.Marshal(domain.EventAnnouncementSentEvent{
+		AnnouncementID: announcement.ID,
+		EventID:        announcement.EventID,
+		EventName:      announcement.EventName,
+		SessionName:    announcement.SessionName.String,
+		AuthorID:       announcement.AuthorID.String,
+		Title:          announcement.Title,
+		Message:        announcement.Message,
+		UserIDs:        userIDs,
+	})
+	if err != nil {
+		log.Printf("Error marshalling event announcement: %v", err)
+		return
+	}
+	if err := s.publisher.Publish(domain.EventAnnouncementSubject, payload); err != nil {
+		log.Printf("Error publishing event announcement message: %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+	"github.com/google/uuid"
+)
+
+// ApproveRegistration approves a pending registration of an event and notifies the registrant.
+func (s *Service) ApproveRegistration(ctx context.Context, eventID, registrationID, userID string) error {
+	return s.decideRegistration(ctx, eventID, registrationID, userID, domain.RegistrationDecisionApprove, "")
+}
+
+// RejectRegistration rejects a pending registration of an event and notifies the registrant, with the
+// reason if there is one.
+func (s *Service) RejectRegistration(ctx context.Context, eventID, registrationID, userID, reason string) error {
+	return s.decideRegistration(ctx, eventID, registrationID, userID, domain.RegistrationDecisionReject, reason)
+}
+
+func (s *Service) decideRegistration(ctx context.Context, eventID, registrationID, userID, decision, reason string) error {
+	result, err := s.DecideRegistrations(ctx, eventID, userID, &domain.RegistrationDecisionInput{
+		Decision:        decision,
+		RegistrationIDs: []string{registrationID},
+		Reason:          reason,
+	})
+	if err != nil {
+		return err
+	}
+	if len(result.Decided) == 0 {
+		return domain.ErrRegistrationNotPending
+	}
+	return nil
+}
+
+// DecideRegistrations approves or rejects pending registrations of an event in bulk and notifies the
+// registrants. Registrations that are not pending registrations of the event are skipped.
+func (s *Service) DecideRegistrations(ctx context.Context, eventID, userID string, input *domain.RegistrationDecisionInput) (*domain.RegistrationDecisionResult, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionApproveRegistrations); err != nil {
+		return nil, err
+	}
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &domain.RegistrationDecisionResult{Decision: input.Decision, Decided: []*domain.DecidedRegistration{}, Skipped: []string{}}
+	var registrationIDs []string
+	if !input.All {
+		for _, registrationID := range input.RegistrationIDs {
+			if _, err := uuid.Parse(registrationID); err != nil {
+				result.Skipped = append(result.Skipped, registrationID)
+				continue
+			}
+			registrationIDs = append(registrationIDs, registrationID)
+		}
+		if len(registrationIDs) == 0 {
+			return result, nil
+		}
+	}
+
+	decided, err := s.decideRegistrations(ctx, event, registrationIDs, input.Status(), sql.NullString{String: userID, Valid: true}, input.Reason, false)
+	if err != nil {
+		return nil, err
+	}
+	result.Decided = decided
+	isDecided := make(map[string]bool, len(decided))
+	for _, registration := range decided {
+		isDecided[registration.RegistrationID] = true
+	}
+	for _, registrationID := range registrationIDs {
+		if !isDecided[registrationID] {
+			result.Skipped = append(result.Skipped, registrationID)
+		}
+	}
+	return result, nil
+}
+
+// CreateRegistrationApprovalRule adds an approval rule to an event and applies it to the registrations
+// already pending. Community member rules default to the event's community. It returns the rule and the
+// number of registrations it approved.
+func (s *Service) CreateRegistrationApprovalRule(ctx context.Context, eventID, userID string, rule *domain.RegistrationApprovalRule) (*domain.RegistrationApprovalRule, int, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionApproveRegistrations); err != nil {
+		return nil, 0, err
+	}
+	if rule.Kind == domain.ApprovalRuleCommunityMember && !rule.CommunityID.Valid {
+		rule.CommunityID = sql.NullString{String: event.CommunityID, Valid: true}
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, 0, err
+	}
+	rule.EventID = event.ID
+	rule.CreatedBy = sql.NullString{String: userID, Valid: true}
+	if err := s.repo.CreateRegistrationApprovalRule(ctx, rule); err != nil {
+		return nil, 0, err
+	}
+
+	approved, err := s.applyApprovalRules(ctx, event, "")
+	if err != nil {
+		log.Printf("Error applying the approval rules of event %s: %v", event.ID, err)
+	}
+	return rule, approved, nil
+}
+
+// ListRegistrationApprovalRules lists the approval rules of an event.
+func (s *Service) ListRegistrationApprovalRules(ctx context.Context, eventID, userID string) ([]*domain.RegistrationApprovalRule, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionApproveRegistrations); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRegistrationApprovalRules(ctx, event.ID)
+}
+
+// DeleteRegistrationApprovalRule deletes an approval rule. Registrations it approved stay approved.
+func (s *Service) DeleteRegistrationApprovalRule(ctx context.Context, ruleID, userID string) error {
+	rule, err := s.repo.GetRegistrationApprovalRule(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+	event, err := s.repo.GetEventByID(ctx, rule.EventID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionApproveRegistrations); err != nil {
+		return err
+	}
+	return s.repo.DeleteRegistrationApprovalRule(ctx, ruleID)
+}
+
+// applyApprovalRules approves the pending registrations of an event that its approval rules match: the
+// user's, or all of them if userID is empty. It returns the number of registrations approved.
+func (s *Service) applyApprovalRules(ctx context.Context, event *domain.Event, userID string) (int, error) {
+	matches, err := s.repo.MatchRegistrationApprovalRules(ctx, event.ID, userID)
+	if err != nil || len(matches) == 0 {
+		return 0, err
+	}
+	rules, err := s.repo.ListRegistrationApprovalRules(ctx, event.ID)
+	if err != nil {
+		return 0, err
+	}
+	registrationIDsByRule := make(map[string][]string)
+	for _, match := range matches {
+		registrationIDsByRule[match.RuleID] = append(registrationIDsByRule[match.RuleID], match.RegistrationID)
+	}
+
+	approved := 0
+	for _, rule := range rules {
+		registrationIDs := registrationIDsByRule[rule.ID]
+		if len(registrationIDs) == 0 {
+			continue
+		}
+		decided, err := s.decideRegistrations(ctx, event, registrationIDs, "registered", sql.NullString{}, rule.Describe(), true)
+		if err != nil {
+			return approved, err
+		}
+		approved += len(decided)
+	}
+	return approved, nil
+}
+
+// decideRegistrations moves pending registrations of an event to the given status and notifies the
+// registrants. registrationIDs nil decides all pending registrations.
+func (s *Service) decideRegistrations(ctx context.Context, event *domain.Event, registrationIDs []string, status string, deciderID sql.NullString, reason string, automatic bool) ([]*domain.DecidedRegistration, error) {
+	decided, err := s.repo.DecideRegistrations(ctx, event.ID, registrationIDs, status, deciderID, sql.NullString{String: reason, Valid: reason != ""})
+	if err != nil || len(decided) == 0 {
+		return decided, err
+	}
+	if status == "registered" {
+		s.syncReminders(ctx, event.ID)
+	}
+
+	userIDs := make([]string, 0, len(decided))
+	for _, registration := range decided {
+		userIDs = append(userIDs, registration.UserID)
+		s.repo.InvalidateEventCache(ctx, event.ID, registration.UserID)
+	}
+	s.repo.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+
+	if s.publisher != nil {
+		decision := domain.RegistrationDecisionApprove
+		if status == "rejected" {
+			decision = domain.RegistrationDecisionReject
+		}
+		payload, err := json.Marshal(domain.RegistrationDecisionEvent{
+			EventID:   event.ID,
+			EventName: event.Name,
+			Decision:  decision,
+			Reason:    reason,
+			Automatic: automatic,
+			UserIDs:   userIDs,
+			DecidedBy: deciderID.String,
+		})
+		if err != nil {
+			log.Printf("Error marshalling registration decision event: %v", err)
+		} else if err := s.publisher.Publish(domain.RegistrationDecisionSubject, payload); err != nil {
+			log.Printf("Error publishing registration decision message: %v", err)
+		}
+	}
+	return decided, nil
+}
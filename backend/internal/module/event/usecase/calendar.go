@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+	"github.com/google/uuid"
+)
+
+// ExportEventICal renders a single event, with all of its sessions, as an .ics document.
+func (s *Service) ExportEventICal(ctx context.Context, eventID, userID string) ([]byte, error) {
+	event, err := s.GetEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return buildICalendar(event.Name, []*domain.Event{event}), nil
+}
+
+// GetCalendarFeedToken returns the user's personal feed token, issuing one on first use. Concurrent first
+// requests get the same token.
+func (s *Service) GetCalendarFeedToken(ctx context.Context, userID string) (string, error) {
+	token, err := s.repo.GetCalendarFeedToken(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		return token, nil
+	}
+	return s.repo.IssueCalendarFeedToken(ctx, userID, uuid.New().String())
+}
+
+// RotateCalendarFeedToken issues a new feed token, invalidating any previously shared feed URL.
+func (s *Service) RotateCalendarFeedToken(ctx context.Context, userID string) (string, error) {
+	token := uuid.New().String()
+	if err := s.repo.SaveCalendarFeedToken(ctx, userID, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// GetUserCalendarFeed builds the personal feed for the owner of the token from their active registrations.
+func (s *Service) GetUserCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	userID, err := s.repo.GetUserIDByCalendarFeedToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	registrations, err := s.repo.GetRegistrationsByUserID(ctx, userID, "")
+	if err != nil {
+		return nil, fmt.Errorf("could not load registrations for calendar feed: %w", err)
+	}
+
+	var events []*domain.Event
+	for _, registration := range registrations {
+		if registration.Status == "cancelled" {
+			continue
+		}
+		event, err := s.repo.GetEventByID(ctx, registration.EventID, userID)
+		if err != nil {
+			if errors.Is(err, domain.ErrEventNotFound) {
+				continue
+			}
+			log.Printf("Warning: could not load event %s for calendar feed: %v", registration.EventID, err)
+			continue
+		}
+		events = append(events, event)
+	}
+
+	return buildICalendar("AttendWise - My Events", events), nil
+}
+
+// GetCommunityCalendarFeed builds the public feed of a community. Only public communities expose one.
+func (s *Service) GetCommunityCalendarFeed(ctx context.Context, communityID string) ([]byte, error) {
+	communityType, err := s.permService.GetCommunityType(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if communityType != "public" {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+
+	events, err := s.repo.GetPublicEventsByCommunityID(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildICalendar("AttendWise - Community Events", events), nil
+}
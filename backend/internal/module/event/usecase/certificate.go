@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+	"github.com/jung-kurt/gofpdf"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// SaveCertificateTemplate creates or replaces the certificate template of an event.
+func (s *Service) SaveCertificateTemplate(ctx context.Context, eventID, userID string, template *domain.CertificateTemplate) (*domain.CertificateTemplate, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	template.EventID = eventID
+	template.CreatedBy = userID
+	if err := s.repo.SaveCertificateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// GetCertificateTemplate retrieves the certificate template of an event.
+func (s *Service) GetCertificateTemplate(ctx context.Context, eventID, userID string) (*domain.CertificateTemplate, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetCertificateTemplate(ctx, eventID)
+}
+
+// DeleteCertificateTemplate removes the certificate template of an event. Issued certificates stay valid.
+func (s *Service) DeleteCertificateTemplate(ctx context.Context, eventID, userID string) error {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteCertificateTemplate(ctx, eventID)
+}
+
+// IssueEventCertificates issues certificates to the attendees of a completed event who meet the template's
+// attendance rule and do not have one yet, e.g. after the template was created or changed. It returns the
+// certificates issued.
+func (s *Service) IssueEventCertificates(ctx context.Context, eventID, userID string) ([]*domain.Certificate, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if event.Status != domain.EventStatusCompleted && event.Status != domain.EventStatusArchived {
+		return nil, domain.ErrCertificatesNotAvailable
+	}
+
+	template, err := s.repo.GetCertificateTemplate(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return s.issueCertificates(ctx, event, template)
+}
+
+// ListEventCertificates lists the certificates issued for an event to the staff who can view its attendees.
+func (s *Service) ListEventCertificates(ctx context.Context, eventID, userID string) ([]*domain.Certificate, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionViewAttendees); err != nil {
+		return nil, err
+	}
+	return s.repo.ListEventCertificates(ctx, eventID)
+}
+
+// ListMyCertificates lists the certificates issued to the user, newest first.
+func (s *Service) ListMyCertificates(ctx context.Context, userID string) ([]*domain.Certificate, error) {
+	return s.repo.ListUserCertificates(ctx, userID)
+}
+
+// GetCertificatePDF renders a certificate as a PDF for its recipient or the event staff who can view its
+// attendees. The QR code on it links to verificationBaseURL followed by the verification code.
+func (s *Service) GetCertificatePDF(ctx context.Context, certificateID, userID, verificationBaseURL string) ([]byte, *domain.Certificate, error) {
+	certificate, err := s.repo.GetCertificate(ctx, certificateID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if certificate.UserID != userID {
+		event, err := s.repo.GetEventByID(ctx, certificate.EventID, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionViewAttendees); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	pdf, err := renderCertificatePDF(certificate, verificationBaseURL+certificate.VerificationCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pdf, certificate, nil
+}
+
+// RevokeCertificate revokes a certificate, e.g. one issued by mistake. It then verifies as revoked.
+func (s *Service) RevokeCertificate(ctx context.Context, certificateID, userID, reason string) error {
+	certificate, err := s.repo.GetCertificate(ctx, certificateID)
+	if err != nil {
+		return err
+	}
+	event, err := s.repo.GetEventByID(ctx, certificate.EventID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return err
+	}
+	return s.repo.RevokeCertificate(ctx, certificateID, reason)
+}
+
+// VerifyCertificate looks a certificate up by its verification code and returns only what is needed to
+// confirm that it is genuine.
+func (s *Service) VerifyCertificate(ctx context.Context, code string) (*domain.CertificateVerification, error) {
+	certificate, err := s.repo.GetCertificateByCode(ctx, domain.NormalizeVerificationCode(code))
+	if err != nil {
+		return nil, err
+	}
+	return certificate.Verification(), nil
+}
+
+// issueCertificatesOnCompletion issues the certificates of an event that just completed, if it has an
+// active template. Errors are logged; hosts can issue the missing certificates by hand.
+func (s *Service) issueCertificatesOnCompletion(ctx context.Context, event *domain.Event) {
+	template, err := s.repo.GetCertificateTemplate(ctx, event.ID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrCertificateTemplateNotFound) {
+			log.Printf("Error loading the certificate template of event %s: %v", event.ID, err)
+		}
+		return
+	}
+	if !template.IsActive {
+		return
+	}
+	if _, err := s.issueCertificates(ctx, event, template); err != nil {
+		log.Printf("Error issuing certificates for event %s: %v", event.ID, err)
+	}
+}
+
+// issueCertificates issues a certificate to every attendee who qualifies and does not have one yet, and
+// publishes a CertificateIssuedEvent for each.
+func (s *Service) issueCertificates(ctx context.Context, event *domain.Event, template *domain.CertificateTemplate) ([]*domain.Certificate, error) {
+	attendance, err := s.repo.ListCertificateAttendance(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	issued := []*domain.Certificate{}
+	now := time.Now()
+	for _, a := range attendance {
+		if !a.Qualifies(template) {
+			continue
+		}
+		certificate, err := domain.NewCertificate(template, event.Name, a, now)
+		if err != nil {
+			return issued, err
+		}
+		created, err := s.repo.CreateCertificate(ctx, certificate)
+		if err != nil {
+			return issued, err
+		}
+		if !created {
+			continue
+		}
+		issued = append(issued, certificate)
+
+		if s.publisher != nil {
+			payload, err := json.Marshal(domain.CertificateIssuedEvent{
+				CertificateID: certificate.ID,
+				EventID:       event.ID,
+				EventName:     event.Name,
+				UserID:        certificate.UserID,
+			})
+			if err != nil {
+				log.Printf("Error marshalling certificate issued event: %v", err)
+				continue
+			}
+			if err := s.publisher.Publish(domain.CertificateIssuedSubject, payload); err != nil {
+				log.Printf("Error publishing certificate issued message: %v", err)
+			}
+		}
+	}
+	return issued, nil
+}
+
+// renderCertificatePDF lays a certificate out on a landscape A4 page with a QR code linking to its
+// verification URL.
+func renderCertificatePDF(certificate *domain.Certificate, verificationURL string) ([]byte, error) {
+	qr, err := qrcode.Encode(verificationURL, qrcode.Medium, 256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate QR code: %w", err)
+	}
+
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+	width, height := pdf.GetPageSize()
+
+	pdf.SetDrawColor(60, 60, 60)
+	pdf.SetLineWidth(1.5)
+	pdf.Rect(10, 10, width-20, height-20, "D")
+	pdf.SetLineWidth(0.3)
+	pdf.Rect(14, 14, width-28, height-28, "D")
+
+	pdf.SetY(38)
+	pdf.SetFont("Arial", "B", 30)
+	pdf.CellFormat(0, 14, tr(certificate.Title), "", 1, "C", false, 0, "")
+
+	pdf.Ln(10)
+	pdf.SetFont("Arial", "", 14)
+	pdf.CellFormat(0, 8, "Awarded to", "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "B", 26)
+	pdf.CellFormat(0, 16, tr(certificate.RecipientName), "", 1, "C", false, 0, "")
+
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "", 13)
+	pdf.SetX(40)
+	pdf.MultiCell(width-80, 7, tr(certificate.Body), "", "C", false)
+
+	pdf.SetY(height - 62)
+	pdf.SetFont("Arial", "", 11)
+	pdf.CellFormat(0, 6, "Issued on "+certificate.IssuedAt.Format("January 2, 2006"), "", 1, "L", false, 0, "")
+	if certificate.SignerName.Valid {
+		pdf.SetY(height - 48)
+		pdf.Line(20, height-49, 100, height-49)
+		pdf.SetFont("Arial", "B", 11)
+		pdf.CellFormat(80, 6, tr(certificate.SignerName.String), "", 1, "L", false, 0, "")
+		if certificate.SignerTitle.Valid {
+			pdf.SetFont("Arial", "", 10)
+			pdf.CellFormat(80, 5, tr(certificate.SignerTitle.String), "", 1, "L", false, 0, "")
+		}
+	}
+
+	qrSize := 34.0
+	qrX, qrY := width-20-qrSize, height-26-qrSize
+	options := gofpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader("verification-qr", options, bytes.NewReader(qr))
+	pdf.ImageOptions("verification-qr", qrX, qrY, qrSize, qrSize, false, options, 0, verificationURL)
+	pdf.SetFont("Arial", "", 8)
+	pdf.SetXY(qrX-20, qrY+qrSize)
+	pdf.CellFormat(qrSize+20, 4, "Verification code: "+certificate.VerificationCode, "", 0, "R", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
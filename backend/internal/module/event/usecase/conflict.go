@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// GetMyAgenda lists the sessions between from and to of all the user's registrations, marking those that
+// overlap another one. from defaults to now and to to AgendaDefaultRange after from.
+func (s *Service) GetMyAgenda(ctx context.Context, userID string, from, to time.Time) ([]*domain.AgendaItem, error) {
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if to.IsZero() {
+		to = from.Add(domain.AgendaDefaultRange)
+	}
+	if !to.After(from) || to.Sub(from) > domain.AgendaMaxRange {
+		return nil, domain.ErrInvalidAgendaRange
+	}
+	items, err := s.repo.ListUserAgenda(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []*domain.AgendaItem{}
+	}
+	domain.MarkAgendaConflicts(items)
+	return items, nil
+}
+
+// findAttendeeConflicts returns the sessions of the user's other registrations that overlap the upcoming
+// sessions of an event. Events with per-session registration are left out: their sessions are checked as
+// the user signs up for them.
+func (s *Service) findAttendeeConflicts(ctx context.Context, event *domain.Event, userID string) ([]*domain.ScheduleConflict, error) {
+	if event.PerSessionRegistration {
+		return nil, nil
+	}
+	return s.repo.FindAttendeeConflicts(ctx, userID, event.ID, scheduleSlots(event.Sessions))
+}
+
+// checkVenueConflicts refuses to hold the upcoming sessions of an event at a venue another event has booked
+// at the same time. A session takes place at its own venue, or at the event's if it has none.
+func (s *Service) checkVenueConflicts(ctx context.Context, event *domain.Event, sessions []domain.EventSession) error {
+	slotsByVenue := make(map[string][]domain.ScheduleSlot)
+	for _, session := range sessions {
+		venueID := event.VenueID.String
+		if session.VenueID.Valid {
+			venueID = session.VenueID.String
+		}
+		if venueID == "" {
+			continue
+		}
+		slotsByVenue[venueID] = append(slotsByVenue[venueID], scheduleSlots([]domain.EventSession{session})...)
+	}
+	for venueID, slots := range slotsByVenue {
+		conflicts, err := s.repo.FindVenueConflicts(ctx, venueID, event.ID, slots)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("%w: %s", domain.ErrVenueDoubleBooked, conflicts[0].Describe())
+		}
+	}
+	return nil
+}
+
+// findStaffConflicts returns the sessions of other events that the host or the staff of an event work at the
+// same time as its upcoming sessions. They are warnings only: staff may well split their time.
+func (s *Service) findStaffConflicts(ctx context.Context, event *domain.Event, hostID string, sessions []domain.EventSession) ([]*domain.ScheduleConflict, error) {
+	staff, err := s.repo.ListEventStaff(ctx, event.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	userIDs := []string{hostID}
+	for _, member := range staff {
+		if member.UserID != hostID {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	return s.repo.FindStaffConflicts(ctx, userIDs, event.ID, scheduleSlots(sessions))
+}
+
+// checkScheduleConflicts checks the sessions of an event being scheduled: double-booked venues are refused
+// and double-booked staff are returned.
+func (s *Service) checkScheduleConflicts(ctx context.Context, event *domain.Event, hostID string, sessions []domain.EventSession) ([]*domain.ScheduleConflict, error) {
+	if err := s.checkVenueConflicts(ctx, event, sessions); err != nil {
+		return nil, err
+	}
+	return s.findStaffConflicts(ctx, event, hostID, sessions)
+}
+
+// scheduleSlots returns the sessions that are neither cancelled nor over, as slots to check for conflicts.
+func scheduleSlots(sessions []domain.EventSession) []domain.ScheduleSlot {
+	now := time.Now()
+	var slots []domain.ScheduleSlot
+	for _, session := range sessions {
+		if session.IsCancelled || !session.EndTime.After(now) {
+			continue
+		}
+		slots = append(slots, domain.ScheduleSlot{SessionID: session.ID, StartTime: session.StartTime, EndTime: session.EndTime})
+	}
+	return slots
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// CreateFeedbackSurvey attaches a feedback survey to an event, or to one of its sessions if
+// survey.SessionID is set. Only the event's editors and the community's admins can manage surveys.
+func (s *Service) CreateFeedbackSurvey(ctx context.Context, eventID, userID string, survey *domain.FeedbackSurvey) (*domain.FeedbackSurvey, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if survey.SessionID.Valid {
+		if err := s.checkEventSession(ctx, eventID, survey.SessionID.String); err != nil {
+			return nil, err
+		}
+	}
+	if err := survey.Validate(); err != nil {
+		return nil, err
+	}
+
+	survey.EventID = eventID
+	survey.CreatedBy = userID
+	if err := s.repo.CreateFeedbackSurvey(ctx, survey); err != nil {
+		return nil, err
+	}
+	return survey, nil
+}
+
+// UpdateFeedbackSurvey saves changes to a survey. The event and session a survey belongs to cannot be
+// changed, and neither can its questions once responses came in, so that results stay comparable.
+func (s *Service) UpdateFeedbackSurvey(ctx context.Context, survey *domain.FeedbackSurvey, userID string) (*domain.FeedbackSurvey, error) {
+	existing, err := s.authorizeFeedbackSurveyManagement(ctx, survey.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	survey.EventID = existing.EventID
+	survey.SessionID = existing.SessionID
+	if err := survey.Validate(); err != nil {
+		return nil, err
+	}
+	if existing.ResponseCount > 0 && !reflect.DeepEqual(survey.Questions, existing.Questions) {
+		return nil, fmt.Errorf("%w: questions cannot be changed once responses were submitted", domain.ErrInvalidFeedbackSurvey)
+	}
+	if existing.ResponseCount > 0 && survey.PerSession != existing.PerSession {
+		return nil, fmt.Errorf("%w: per_session cannot be changed once responses were submitted", domain.ErrInvalidFeedbackSurvey)
+	}
+
+	if err := s.repo.UpdateFeedbackSurvey(ctx, survey); err != nil {
+		return nil, err
+	}
+	return s.repo.GetFeedbackSurvey(ctx, survey.ID)
+}
+
+// DeleteFeedbackSurvey removes a survey together with its responses.
+func (s *Service) DeleteFeedbackSurvey(ctx context.Context, surveyID, userID string) error {
+	if _, err := s.authorizeFeedbackSurveyManagement(ctx, surveyID, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteFeedbackSurvey(ctx, surveyID)
+}
+
+// GetFeedbackSurvey retrieves a survey. Anyone who can view its event can see it.
+func (s *Service) GetFeedbackSurvey(ctx context.Context, surveyID, userID string) (*domain.FeedbackSurvey, error) {
+	survey, err := s.repo.GetFeedbackSurvey(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetEvent(ctx, survey.EventID, userID); err != nil {
+		return nil, err
+	}
+	return survey, nil
+}
+
+// ListFeedbackSurveys lists the surveys of an event, event surveys first.
+func (s *Service) ListFeedbackSurveys(ctx context.Context, eventID, userID string) ([]*domain.FeedbackSurvey, error) {
+	if _, err := s.GetEvent(ctx, eventID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListFeedbackSurveys(ctx, eventID)
+}
+
+// SubmitFeedback saves a user's answers to a survey. Only attendees who checked in can respond: to the
+// survey's session, to the given session for per-session surveys, or to any session of the event.
+func (s *Service) SubmitFeedback(ctx context.Context, surveyID, sessionID, userID string, answers []domain.FeedbackAnswer) (*domain.FeedbackResponse, error) {
+	survey, err := s.repo.GetFeedbackSurvey(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	if !survey.IsActive {
+		return nil, domain.ErrFeedbackSurveyClosed
+	}
+
+	responseSessionID, err := survey.ResponseSessionID(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if responseSessionID.Valid && !survey.SessionID.Valid {
+		if err := s.checkEventSession(ctx, survey.EventID, responseSessionID.String); err != nil {
+			return nil, err
+		}
+	}
+
+	checkedIn, err := s.repo.HasCheckedIn(ctx, survey.EventID, responseSessionID.String, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !checkedIn {
+		return nil, domain.ErrFeedbackNotAllowed
+	}
+	if err := survey.ValidateAnswers(answers); err != nil {
+		return nil, err
+	}
+
+	response := &domain.FeedbackResponse{
+		SurveyID:  survey.ID,
+		EventID:   survey.EventID,
+		SessionID: responseSessionID,
+		UserID:    userID,
+		Answers:   answers,
+	}
+	if err := s.repo.SubmitFeedbackResponse(ctx, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListPendingFeedback lists the feedback requests sent to the user that are still unanswered.
+func (s *Service) ListPendingFeedback(ctx context.Context, userID string) ([]*domain.FeedbackRequest, error) {
+	return s.repo.ListPendingFeedbackRequests(ctx, userID)
+}
+
+// authorizeFeedbackSurveyManagement loads a survey and checks that the user can edit its event.
+func (s *Service) authorizeFeedbackSurveyManagement(ctx context.Context, surveyID, userID string) (*domain.FeedbackSurvey, error) {
+	survey, err := s.repo.GetFeedbackSurvey(ctx, surveyID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.repo.GetEventByID(ctx, survey.EventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	return survey, nil
+}
+
+// checkEventSession makes sure the session exists and belongs to the event.
+func (s *Service) checkEventSession(ctx context.Context, eventID, sessionID string) error {
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.EventID != eventID {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
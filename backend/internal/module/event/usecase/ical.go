@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+const (
+	icalProdID      = "-//AttendWise//Events//EN"
+	icalUIDDomain   = "attendwise"
+	icalLocalFormat = "20060102T150405"
+	icalUTCFormat   = "20060102T150405Z"
+	icalMaxLineLen  = 75
+)
+
+// icalWriter accumulates content lines of an RFC 5545 document.
+type icalWriter struct {
+	b strings.Builder
+}
+
+// line writes a single content line, folding it at 75 octets as required by the spec.
+func (w *icalWriter) line(content string) {
+	limit := icalMaxLineLen
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.b.WriteString(content[:cut])
+		w.b.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		limit = icalMaxLineLen - 1
+	}
+	w.b.WriteString(content)
+	w.b.WriteString("\r\n")
+}
+
+func (w *icalWriter) text(name, value string) {
+	if value == "" {
+		return
+	}
+	w.line(name + ":" + escapeICalText(value))
+}
+
+func (w *icalWriter) time(name string, t time.Time, loc *time.Location) {
+	if loc == time.UTC {
+		w.line(fmt.Sprintf("%s:%s", name, t.UTC().Format(icalUTCFormat)))
+		return
+	}
+	w.line(fmt.Sprintf("%s;TZID=%s:%s", name, loc.String(), t.In(loc).Format(icalLocalFormat)))
+}
+
+func escapeICalText(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, ";", `\;`)
+	s = strings.ReplaceAll(s, ",", `\,`)
+	s = strings.ReplaceAll(s, "\r\n", `\n`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return s
+}
+
+// buildICalendar renders the given events, including all of their sessions, as a VCALENDAR document.
+// Recurring events are emitted as a master VEVENT carrying the RRULE, with each generated session
+// attached as an override (RECURRENCE-ID) so cancellations and per-session changes are reflected.
+func buildICalendar(calendarName string, events []*domain.Event) []byte {
+	w := &icalWriter{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + icalProdID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.text("X-WR-CALNAME", calendarName)
+	// Hint subscribed clients to refresh regularly so new or cancelled sessions show up.
+	w.line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	w.line("X-PUBLISHED-TTL:PT1H")
+
+	stamp := time.Now().UTC()
+	for _, event := range events {
+		writeEventComponents(w, event, stamp)
+	}
+
+	w.line("END:VCALENDAR")
+	return []byte(w.b.String())
+}
+
+func writeEventComponents(w *icalWriter, event *domain.Event, stamp time.Time) {
+	loc := event.Location()
+
+	rule, recurrence := icalRecurrenceRule(event)
+	if rule != "" && event.StartTime.Valid && event.EndTime.Valid {
+		uid := fmt.Sprintf("%s@%s", event.ID, icalUIDDomain)
+
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + uid)
+		w.line("DTSTAMP:" + stamp.Format(icalUTCFormat))
+		w.time("DTSTART", event.StartTime.Time, loc)
+		w.time("DTEND", event.EndTime.Time, loc)
+		w.line("RRULE:" + rule)
+		// Excluded occurrences that still have a session are published as cancelled overrides instead.
+		generated := make(map[int64]bool, len(event.Sessions))
+		for i := range event.Sessions {
+			generated[sessionRecurrenceID(&event.Sessions[i]).Unix()] = true
+		}
+		for _, exdate := range recurrence.ExDates {
+			if !generated[exdate.Unix()] {
+				w.time("EXDATE", exdate, loc)
+			}
+		}
+		for _, rdate := range recurrence.RDates {
+			w.time("RDATE", rdate, loc)
+		}
+		w.text("SUMMARY", event.Name)
+		w.text("DESCRIPTION", event.Description.String)
+		w.text("LOCATION", icalLocation(event, nil))
+		w.line("STATUS:" + icalEventStatus(event.Status == "cancelled"))
+		w.line("LAST-MODIFIED:" + event.UpdatedAt.UTC().Format(icalUTCFormat))
+		w.line("END:VEVENT")
+
+		for i := range event.Sessions {
+			writeSessionComponent(w, event, &event.Sessions[i], uid, loc, stamp)
+		}
+		return
+	}
+
+	if len(event.Sessions) == 0 {
+		if !event.StartTime.Valid || !event.EndTime.Valid {
+			return
+		}
+		w.line("BEGIN:VEVENT")
+		w.line(fmt.Sprintf("UID:%s@%s", event.ID, icalUIDDomain))
+		w.line("DTSTAMP:" + stamp.Format(icalUTCFormat))
+		w.time("DTSTART", event.StartTime.Time, loc)
+		w.time("DTEND", event.EndTime.Time, loc)
+		w.text("SUMMARY", event.Name)
+		w.text("DESCRIPTION", event.Description.String)
+		w.text("LOCATION", icalLocation(event, nil))
+		w.line("STATUS:" + icalEventStatus(event.Status == "cancelled"))
+		w.line("LAST-MODIFIED:" + event.UpdatedAt.UTC().Format(icalUTCFormat))
+		w.line("END:VEVENT")
+		return
+	}
+
+	for i := range event.Sessions {
+		writeSessionComponent(w, event, &event.Sessions[i], "", loc, stamp)
+	}
+}
+
+// writeSessionComponent emits a single session. When seriesUID is set the session is written
+// as an override of that recurring series, otherwise as a standalone VEVENT.
+func writeSessionComponent(w *icalWriter, event *domain.Event, session *domain.EventSession, seriesUID string, loc *time.Location, stamp time.Time) {
+	summary := event.Name
+	if session.Name.Valid && session.Name.String != "" {
+		summary = fmt.Sprintf("%s - %s", event.Name, session.Name.String)
+	}
+	description := event.Description.String
+	if session.IsCancelled && session.CancellationReason.Valid {
+		description = strings.TrimSpace(fmt.Sprintf("Cancelled: %s\n\n%s", session.CancellationReason.String, description))
+	}
+
+	w.line("BEGIN:VEVENT")
+	if seriesUID != "" {
+		w.line("UID:" + seriesUID)
+		w.time("RECURRENCE-ID", sessionRecurrenceID(session), loc)
+	} else {
+		w.line(fmt.Sprintf("UID:%s@%s", session.ID, icalUIDDomain))
+	}
+	w.line("DTSTAMP:" + stamp.Format(icalUTCFormat))
+	w.time("DTSTART", session.StartTime, loc)
+	w.time("DTEND", session.EndTime, loc)
+	w.text("SUMMARY", summary)
+	w.text("DESCRIPTION", description)
+	w.text("LOCATION", icalLocation(event, session))
+	w.line("STATUS:" + icalEventStatus(session.IsCancelled || event.Status == "cancelled"))
+	w.line("LAST-MODIFIED:" + session.UpdatedAt.UTC().Format(icalUTCFormat))
+	w.line("END:VEVENT")
+}
+
+// buildAgendaICalendar renders the agenda items of an event as a VCALENDAR document, one VEVENT per item
+// in the event's timezone, with its speakers in the description and its track as the category.
+func buildAgendaICalendar(calendarName string, event *domain.Event, items []*domain.SessionAgendaItem) []byte {
+	w := &icalWriter{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + icalProdID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.text("X-WR-CALNAME", calendarName)
+	w.line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	w.line("X-PUBLISHED-TTL:PT1H")
+
+	loc := event.Location()
+	stamp := time.Now().UTC()
+	for _, item := range items {
+		description := item.Abstract.String
+		if len(item.Speakers) > 0 {
+			names := make([]string, 0, len(item.Speakers))
+			for _, speaker := range item.Speakers {
+				names = append(names, speaker.Name)
+			}
+			description = strings.TrimSpace(fmt.Sprintf("Speakers: %s\n\n%s", strings.Join(names, ", "), description))
+		}
+		location := icalLocation(event, nil)
+		if item.Room.Valid && item.Room.String != "" {
+			location = strings.TrimSuffix(item.Room.String+", "+location, ", ")
+		}
+
+		w.line("BEGIN:VEVENT")
+		w.line(fmt.Sprintf("UID:%s@%s", item.ID, icalUIDDomain))
+		w.line("DTSTAMP:" + stamp.Format(icalUTCFormat))
+		w.time("DTSTART", item.StartTime, loc)
+		w.time("DTEND", item.EndTime, loc)
+		w.text("SUMMARY", item.Title)
+		w.text("DESCRIPTION", description)
+		w.text("LOCATION", location)
+		w.text("CATEGORIES", item.TrackName.String)
+		w.line("STATUS:" + icalEventStatus(event.Status == "cancelled"))
+		w.line("LAST-MODIFIED:" + item.UpdatedAt.UTC().Format(icalUTCFormat))
+		w.line("END:VEVENT")
+	}
+
+	w.line("END:VCALENDAR")
+	return []byte(w.b.String())
+}
+
+func icalEventStatus(cancelled bool) string {
+	if cancelled {
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
+
+func icalLocation(event *domain.Event, session *domain.EventSession) string {
+	if session != nil && session.LocationOverride.Valid && session.LocationOverride.String != "" {
+		return session.LocationOverride.String
+	}
+	if event.LocationAddress.Valid && event.LocationAddress.String != "" {
+		return event.LocationAddress.String
+	}
+	if event.LocationType == "online" {
+		return "Online"
+	}
+	return ""
+}
+
+// icalRecurrenceRule extracts the RRULE value from the event's recurrence_rule JSON and bounds it
+// with the event's recurrence end date or max occurrences when the rule itself is open-ended.
+func icalRecurrenceRule(event *domain.Event) (string, *domain.Recurrence) {
+	if !event.IsRecurring {
+		return "", nil
+	}
+	recurrence, err := domain.ParseRecurrence(event.RecurrenceRule)
+	if err != nil || recurrence == nil {
+		return "", nil
+	}
+
+	rule := recurrence.RRule
+	upper := strings.ToUpper(rule)
+	if !strings.Contains(upper, "UNTIL=") && !strings.Contains(upper, "COUNT=") {
+		if event.RecurrenceEndDate.Valid {
+			rule += ";UNTIL=" + event.RecurrenceEndDate.Time.UTC().Format(icalUTCFormat)
+		} else if event.MaxOccurrences.Valid && event.MaxOccurrences.Int32 > 0 {
+			rule += fmt.Sprintf(";COUNT=%d", event.MaxOccurrences.Int32)
+		}
+	}
+	return rule, recurrence
+}
+
+// sessionRecurrenceID returns the start time identifying the session within its series.
+func sessionRecurrenceID(session *domain.EventSession) time.Time {
+	if session.OriginalStartTime.Valid {
+		return session.OriginalStartTime.Time
+	}
+	return session.StartTime
+}
@@ -0,0 +1,586 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+	"github.com/google/uuid"
+	"github.com/gosimple/slug"
+	"github.com/teambition/rrule-go"
+)
+
+const (
+	// importGenerationWindow bounds the expansion of open-ended rules; the recurring
+	// event worker keeps generating sessions past it.
+	importGenerationWindow = domain.DefaultGenerationHorizonDays * 24 * time.Hour
+	// maxImportedSessions guards against rules that would expand into an unreasonable number of sessions.
+	maxImportedSessions = domain.MaxGeneratedSessions
+)
+
+var defaultImportReminderSchedule = json.RawMessage(`[{"offset_minutes": -1440, "channels": ["email", "push"]},{"offset_minutes": -15, "channels": ["push", "sms"]}]`)
+
+// icalProperty is a single content line: NAME;PARAM=VALUE:value
+type icalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+type icalComponent struct {
+	Name       string
+	Properties []icalProperty
+	Children   []*icalComponent
+}
+
+func (c *icalComponent) get(name string) *icalProperty {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+func (c *icalComponent) all(name string) []icalProperty {
+	var props []icalProperty
+	for _, p := range c.Properties {
+		if p.Name == name {
+			props = append(props, p)
+		}
+	}
+	return props
+}
+
+func (c *icalComponent) text(name string) string {
+	if p := c.get(name); p != nil {
+		return unescapeICalText(p.Value)
+	}
+	return ""
+}
+
+// parseICalendar parses an RFC 5545 document into its component tree and returns the VCALENDAR.
+func parseICalendar(data []byte) (*icalComponent, error) {
+	// Unfold continuation lines first.
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	data = bytes.ReplaceAll(data, []byte("\n "), nil)
+	data = bytes.ReplaceAll(data, []byte("\n\t"), nil)
+
+	var root *icalComponent
+	var stack []*icalComponent
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prop, err := parseICalLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			component := &icalComponent{Name: strings.ToUpper(prop.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, component)
+			} else if root == nil {
+				root = component
+			}
+			stack = append(stack, component)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("%w: unexpected END:%s", domain.ErrInvalidICalendar, prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: property %s outside of a component", domain.ErrInvalidICalendar, prop.Name)
+			}
+			current := stack[len(stack)-1]
+			current.Properties = append(current.Properties, prop)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidICalendar, err)
+	}
+	if root == nil || root.Name != "VCALENDAR" {
+		return nil, fmt.Errorf("%w: missing VCALENDAR", domain.ErrInvalidICalendar)
+	}
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("%w: unterminated %s", domain.ErrInvalidICalendar, stack[len(stack)-1].Name)
+	}
+	return root, nil
+}
+
+func parseICalLine(line string) (icalProperty, error) {
+	// The value starts at the first colon that is not inside a quoted parameter value.
+	inQuotes := false
+	sep := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			sep = i
+			break
+		}
+	}
+	if sep < 0 {
+		return icalProperty{}, fmt.Errorf("%w: malformed line %q", domain.ErrInvalidICalendar, line)
+	}
+
+	prop := icalProperty{Params: map[string]string{}, Value: line[sep+1:]}
+	parts := strings.Split(line[:sep], ";")
+	prop.Name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		prop.Params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return prop, nil
+}
+
+func unescapeICalText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'n', 'N':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// resolveICalLocation maps a TZID parameter onto an IANA location.
+func resolveICalLocation(tzid string, fallback *time.Location) (*time.Location, bool) {
+	if tzid == "" {
+		return fallback, true
+	}
+	// Some producers prefix the TZID with a slash to denote a globally unique id.
+	loc, err := time.LoadLocation(strings.TrimPrefix(tzid, "/"))
+	if err != nil {
+		return fallback, false
+	}
+	return loc, true
+}
+
+// parseICalTime parses a DATE or DATE-TIME property value. All-day values are anchored at midnight in loc.
+func parseICalTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icalUTCFormat, value)
+		return t, false, err
+	}
+	t, err := time.ParseInLocation(icalLocalFormat, value, loc)
+	return t, false, err
+}
+
+var icalDurationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+func parseICalDuration(value string) (time.Duration, error) {
+	m := icalDurationPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[i+2])
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// icalEventOverride is a VEVENT carrying a RECURRENCE-ID, modifying one occurrence of a series.
+type icalEventOverride struct {
+	RecurrenceID time.Time
+	StartTime    time.Time
+	EndTime      time.Time
+	Summary      string
+	Location     string
+	Cancelled    bool
+}
+
+// buildImportItems turns the VEVENTs of a calendar into import items, grouping occurrence overrides by UID.
+func buildImportItems(calendar *icalComponent) []*domain.ICalImportItem {
+	defaultLoc := time.UTC
+	if tz := calendar.text("X-WR-TIMEZONE"); tz != "" {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			defaultLoc = loc
+		}
+	}
+
+	var items []*domain.ICalImportItem
+	locations := make(map[string]*time.Location)
+	overrides := make(map[string][]icalEventOverride)
+
+	for _, component := range calendar.Children {
+		if component.Name != "VEVENT" {
+			continue
+		}
+
+		uid := component.text("UID")
+		var warnings []string
+
+		dtstart := component.get("DTSTART")
+		if dtstart == nil {
+			items = append(items, &domain.ICalImportItem{UID: uid, Name: component.text("SUMMARY"), Action: "invalid", Warnings: []string{"missing DTSTART"}})
+			continue
+		}
+		loc, known := resolveICalLocation(dtstart.Params["TZID"], defaultLoc)
+		if !known {
+			warnings = append(warnings, fmt.Sprintf("unknown timezone %q, using %s", dtstart.Params["TZID"], loc.String()))
+		}
+		start, allDay, err := parseICalTime(dtstart.Value, dtstart.Params, loc)
+		if err != nil {
+			items = append(items, &domain.ICalImportItem{UID: uid, Name: component.text("SUMMARY"), Action: "invalid", Warnings: []string{"invalid DTSTART: " + err.Error()}})
+			continue
+		}
+
+		end := start.Add(time.Hour)
+		if allDay {
+			end = start.AddDate(0, 0, 1)
+		}
+		if dtend := component.get("DTEND"); dtend != nil {
+			endLoc, _ := resolveICalLocation(dtend.Params["TZID"], loc)
+			if t, _, err := parseICalTime(dtend.Value, dtend.Params, endLoc); err == nil {
+				end = t
+			} else {
+				warnings = append(warnings, "invalid DTEND, defaulting the duration")
+			}
+		} else if duration := component.get("DURATION"); duration != nil {
+			if d, err := parseICalDuration(duration.Value); err == nil {
+				end = start.Add(d)
+			} else {
+				warnings = append(warnings, "invalid DURATION, defaulting the duration")
+			}
+		}
+		if !end.After(start) {
+			warnings = append(warnings, "end is not after start, defaulting the duration")
+			end = start.Add(time.Hour)
+		}
+
+		if recurrenceID := component.get("RECURRENCE-ID"); recurrenceID != nil {
+			ridLoc, _ := resolveICalLocation(recurrenceID.Params["TZID"], loc)
+			rid, _, err := parseICalTime(recurrenceID.Value, recurrenceID.Params, ridLoc)
+			if err == nil && uid != "" {
+				overrides[uid] = append(overrides[uid], icalEventOverride{
+					RecurrenceID: rid,
+					StartTime:    start,
+					EndTime:      end,
+					Summary:      component.text("SUMMARY"),
+					Location:     component.text("LOCATION"),
+					Cancelled:    strings.EqualFold(component.text("STATUS"), "CANCELLED"),
+				})
+			}
+			continue
+		}
+
+		item := &domain.ICalImportItem{
+			UID:         uid,
+			Name:        component.text("SUMMARY"),
+			Description: component.text("DESCRIPTION"),
+			Location:    component.text("LOCATION"),
+			URL:         component.text("URL"),
+			Timezone:    loc.String(),
+			StartTime:   start,
+			EndTime:     end,
+			Action:      "create",
+			Warnings:    warnings,
+		}
+		if item.Name == "" {
+			item.Name = "Imported event"
+		}
+		if item.UID == "" {
+			item.Action = "invalid"
+			item.Warnings = append(item.Warnings, "missing UID, the event cannot be imported idempotently")
+		}
+		if strings.EqualFold(component.text("STATUS"), "CANCELLED") {
+			item.Action = "invalid"
+			item.Warnings = append(item.Warnings, "event is cancelled in the source calendar")
+		}
+
+		if rule := component.get("RRULE"); rule != nil {
+			item.RRule = strings.TrimPrefix(strings.TrimSpace(rule.Value), "RRULE:")
+			if _, err := rrule.StrToROptionInLocation(item.RRule, loc); err != nil {
+				item.Action = "invalid"
+				item.Warnings = append(item.Warnings, "unsupported RRULE: "+err.Error())
+			}
+		}
+		for _, exdate := range component.all("EXDATE") {
+			exLoc, _ := resolveICalLocation(exdate.Params["TZID"], loc)
+			for _, value := range strings.Split(exdate.Value, ",") {
+				if t, _, err := parseICalTime(value, exdate.Params, exLoc); err == nil {
+					item.ExDates = append(item.ExDates, t)
+				} else {
+					item.Warnings = append(item.Warnings, fmt.Sprintf("ignored invalid EXDATE %q", value))
+				}
+			}
+		}
+		for _, rdate := range component.all("RDATE") {
+			if strings.EqualFold(rdate.Params["VALUE"], "PERIOD") {
+				item.Warnings = append(item.Warnings, "ignored RDATE periods, only date-time values are supported")
+				continue
+			}
+			rLoc, _ := resolveICalLocation(rdate.Params["TZID"], loc)
+			for _, value := range strings.Split(rdate.Value, ",") {
+				if t, _, err := parseICalTime(value, rdate.Params, rLoc); err == nil {
+					item.RDates = append(item.RDates, t)
+				} else {
+					item.Warnings = append(item.Warnings, fmt.Sprintf("ignored invalid RDATE %q", value))
+				}
+			}
+		}
+
+		items = append(items, item)
+		locations[item.UID] = loc
+	}
+
+	for _, item := range items {
+		if item.Action == "invalid" {
+			continue
+		}
+		expandImportItem(item, locations[item.UID], overrides[item.UID])
+	}
+	return items
+}
+
+// expandImportItem materializes the sessions of an import item, applying EXDATEs and occurrence overrides.
+func expandImportItem(item *domain.ICalImportItem, loc *time.Location, overrides []icalEventOverride) {
+	duration := item.EndTime.Sub(item.StartTime)
+	occurrences := []time.Time{item.StartTime}
+
+	if item.RRule != "" {
+		rOption, err := rrule.StrToROptionInLocation(item.RRule, loc)
+		if err != nil {
+			item.Action = "invalid"
+			item.Warnings = append(item.Warnings, "unsupported RRULE: "+err.Error())
+			return
+		}
+		// Expanding from a DTSTART in the event's own timezone keeps wall-clock times stable across DST changes.
+		rOption.Dtstart = item.StartTime.In(loc)
+		rule, err := rrule.NewRRule(*rOption)
+		if err != nil {
+			item.Action = "invalid"
+			item.Warnings = append(item.Warnings, "unsupported RRULE: "+err.Error())
+			return
+		}
+
+		set := &rrule.Set{}
+		set.RRule(rule)
+		for _, exdate := range item.ExDates {
+			set.ExDate(exdate)
+		}
+		for _, rdate := range item.RDates {
+			set.RDate(rdate)
+		}
+
+		if rOption.Count == 0 && rOption.Until.IsZero() {
+			windowEnd := time.Now().Add(importGenerationWindow)
+			if item.StartTime.After(windowEnd) {
+				windowEnd = item.StartTime.Add(importGenerationWindow)
+			}
+			occurrences = set.Between(item.StartTime, windowEnd, true)
+			item.Warnings = append(item.Warnings, "open-ended recurrence: sessions beyond the next 30 days are generated automatically")
+		} else {
+			occurrences = set.All()
+		}
+		if len(occurrences) > maxImportedSessions {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("recurrence truncated to the first %d sessions", maxImportedSessions))
+			occurrences = occurrences[:maxImportedSessions]
+		}
+	}
+
+	overridesByStart := make(map[int64]icalEventOverride)
+	for _, override := range overrides {
+		overridesByStart[override.RecurrenceID.Unix()] = override
+	}
+
+	item.Sessions = make([]domain.EventSession, 0, len(occurrences))
+	for i, occ := range occurrences {
+		session := domain.EventSession{
+			SessionNumber: i + 1,
+			StartTime:     occ,
+			EndTime:       occ.Add(duration),
+			Timezone:      item.Timezone,
+		}
+		if override, ok := overridesByStart[occ.Unix()]; ok {
+			session.OriginalStartTime = sql.NullTime{Time: occ, Valid: true}
+			session.IsOverride = true
+			session.StartTime = override.StartTime
+			session.EndTime = override.EndTime
+			if override.Summary != "" && override.Summary != item.Name {
+				session.Name = sql.NullString{String: override.Summary, Valid: true}
+			}
+			if override.Location != "" && override.Location != item.Location {
+				session.LocationOverride = sql.NullString{String: override.Location, Valid: true}
+			}
+			if override.Cancelled {
+				session.IsCancelled = true
+				session.CancellationReason = sql.NullString{String: "Cancelled in the source calendar", Valid: true}
+			}
+		}
+		item.Sessions = append(item.Sessions, session)
+	}
+	if len(item.Sessions) == 0 {
+		item.Action = "invalid"
+		item.Warnings = append(item.Warnings, "recurrence produces no sessions")
+	}
+}
+
+// ImportEventsFromICal parses an .ics file for a community. Without confirm it only returns a preview;
+// with confirm it creates every new event. Events whose UID was already imported into the community are skipped.
+func (s *Service) ImportEventsFromICal(ctx context.Context, communityID, hostID string, data []byte, confirm bool) (*domain.ICalImportResult, error) {
+	isAdmin, err := s.permService.IsCommunityAdmin(ctx, communityID, hostID)
+	if err != nil {
+		return nil, fmt.Errorf("could not verify community admin status: %w", err)
+	}
+	if !isAdmin {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+
+	calendar, err := parseICalendar(data)
+	if err != nil {
+		return nil, err
+	}
+	items := buildImportItems(calendar)
+
+	var uids []string
+	for _, item := range items {
+		if item.UID != "" {
+			uids = append(uids, item.UID)
+		}
+	}
+	imported, err := s.repo.GetImportedEventIDs(ctx, communityID, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ICalImportResult{Confirmed: confirm, Items: items}
+	seen := make(map[string]bool)
+	for _, item := range items {
+		if item.Action == "create" {
+			if eventID, ok := imported[item.UID]; ok {
+				item.Action = "skip"
+				item.ExistingEventID = eventID
+			} else if seen[item.UID] {
+				item.Action = "skip"
+				item.Warnings = append(item.Warnings, "duplicate UID in file")
+			}
+		}
+		seen[item.UID] = true
+
+		switch item.Action {
+		case "skip":
+			result.Skipped++
+			continue
+		case "invalid":
+			result.Invalid++
+			continue
+		}
+		if !confirm {
+			continue
+		}
+
+		event, err := s.createImportedEvent(ctx, communityID, hostID, item)
+		if errors.Is(err, domain.ErrAlreadyImported) {
+			// Another import of the same file created the event first.
+			item.Action = "skip"
+			item.Warnings = append(item.Warnings, "imported concurrently")
+			if imported, err := s.repo.GetImportedEventIDs(ctx, communityID, []string{item.UID}); err == nil {
+				item.ExistingEventID = imported[item.UID]
+			}
+			result.Skipped++
+			continue
+		}
+		if err != nil {
+			log.Printf("Error importing event %s into community %s: %v", item.UID, communityID, err)
+			item.Action = "invalid"
+			item.Warnings = append(item.Warnings, "could not create event: "+err.Error())
+			result.Invalid++
+			continue
+		}
+		item.CreatedEventID = event.ID
+		result.Created++
+	}
+
+	return result, nil
+}
+
+func (s *Service) createImportedEvent(ctx context.Context, communityID, hostID string, item *domain.ICalImportItem) (*domain.Event, error) {
+	event := &domain.Event{
+		ID:                   uuid.New().String(),
+		CommunityID:          communityID,
+		Name:                 item.Name,
+		Description:          sql.NullString{String: item.Description, Valid: item.Description != ""},
+		LocationType:         "physical",
+		LocationAddress:      sql.NullString{String: item.Location, Valid: item.Location != ""},
+		Timezone:             item.Timezone,
+		StartTime:            sql.NullTime{Time: item.StartTime, Valid: true},
+		EndTime:              sql.NullTime{Time: item.EndTime, Valid: true},
+		RegistrationRequired: true,
+		QRCodeEnabled:        true,
+		FallbackCodeEnabled:  true,
+		ManualCheckinAllowed: true,
+		Currency:             "VND",
+		Status:               domain.EventStatusDraft,
+		ReminderSchedule:     defaultImportReminderSchedule,
+		ImportUID:            item.UID,
+	}
+	event.Slug = slug.Make(fmt.Sprintf("%s-%s", event.Name, event.ID[:8]))
+	if item.Location == "" && item.URL != "" {
+		event.LocationType = "online"
+		event.OnlineMeetingURL = sql.NullString{String: item.URL, Valid: true}
+	}
+
+	if item.RRule != "" {
+		recurrence, err := (&domain.Recurrence{RRule: item.RRule, ExDates: item.ExDates, RDates: item.RDates}).Marshal()
+		if err != nil {
+			return nil, err
+		}
+		event.IsRecurring = true
+		event.RecurrenceRule = recurrence
+	}
+
+	sessions := make([]domain.EventSession, len(item.Sessions))
+	copy(sessions, item.Sessions)
+	for i := range sessions {
+		sessions[i].ID = uuid.New().String()
+		sessions[i].EventID = event.ID
+	}
+
+	return s.persistEvent(ctx, event, sessions, hostID, nil)
+}
@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// icalDocument wraps content lines into a VCALENDAR with CRLF line endings.
+func icalDocument(lines ...string) []byte {
+	all := append([]string{"BEGIN:VCALENDAR", "VERSION:2.0"}, lines...)
+	all = append(all, "END:VCALENDAR")
+	return []byte(strings.Join(all, "\r\n") + "\r\n")
+}
+
+func TestParseICalendar(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "valid", data: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"},
+		{name: "bare newlines", data: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:1\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{name: "missing calendar", data: "BEGIN:VEVENT\r\nUID:1\r\nEND:VEVENT\r\n", wantErr: true},
+		{name: "empty", data: "", wantErr: true},
+		{name: "unterminated component", data: "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:1\r\nEND:VCALENDAR\r\n", wantErr: true},
+		{name: "unexpected end", data: "BEGIN:VCALENDAR\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n", wantErr: true},
+		{name: "property outside of a component", data: "UID:1\r\nBEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", wantErr: true},
+		{name: "malformed line", data: "BEGIN:VCALENDAR\r\nno separator\r\nEND:VCALENDAR\r\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar, err := parseICalendar([]byte(tt.data))
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidICalendar) {
+					t.Fatalf("want ErrInvalidICalendar, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if len(calendar.Children) != 1 || calendar.Children[0].text("UID") != "1" {
+				t.Fatalf("got children %+v, want one VEVENT with UID 1", calendar.Children)
+			}
+		})
+	}
+}
+
+func TestParseICalendarUnfoldsAndUnescapes(t *testing.T) {
+	calendar, err := parseICalendar([]byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Board game\r\n  night\\, with snacks\r\nDESCRIPTION:Line one\\nLine two\r\nLOCATION;ALTREP=\"http://example.com/a:b\":Hall\\; room 2\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	event := calendar.Children[0]
+	tests := []struct{ name, want string }{
+		{name: "SUMMARY", want: "Board game night, with snacks"},
+		{name: "DESCRIPTION", want: "Line one\nLine two"},
+		{name: "LOCATION", want: "Hall; room 2"},
+	}
+	for _, tt := range tests {
+		if got := event.text(tt.name); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := event.get("LOCATION").Params["ALTREP"]; got != "http://example.com/a:b" {
+		t.Errorf("quoted parameter: got %q", got)
+	}
+}
+
+func TestBuildImportItems(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	at := func(month time.Month, day, hour int) time.Time { return time.Date(2026, month, day, hour, 0, 0, 0, ny) }
+	utc := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name         string
+		event        []string
+		extra        [][]string // Further VEVENTs of the calendar, e.g. occurrence overrides
+		wantUID      string
+		wantAction   string
+		wantTimezone string
+		wantStarts   []time.Time
+		wantEnd      time.Time // End of the first session, checked if set
+		wantWarning  string
+	}{
+		{
+			name:         "single event in UTC",
+			event:        []string{"UID:single@example.com", "SUMMARY:Kickoff", "DTSTART:20260601T170000Z", "DTEND:20260601T183000Z"},
+			wantUID:      "single@example.com",
+			wantAction:   "create",
+			wantTimezone: "UTC",
+			wantStarts:   []time.Time{utc(time.June, 1, 17)},
+			wantEnd:      time.Date(2026, time.June, 1, 18, 30, 0, 0, time.UTC),
+		},
+		{
+			name:         "TZID sets the timezone",
+			event:        []string{"UID:tz@example.com", "DTSTART;TZID=America/New_York:20260601T100000", "DURATION:PT2H"},
+			wantUID:      "tz@example.com",
+			wantAction:   "create",
+			wantTimezone: "America/New_York",
+			wantStarts:   []time.Time{at(time.June, 1, 10)},
+			wantEnd:      at(time.June, 1, 12),
+		},
+		{
+			name:         "unknown TZID falls back to UTC",
+			event:        []string{"UID:unknown-tz@example.com", "DTSTART;TZID=Mars/Olympus:20260601T100000"},
+			wantUID:      "unknown-tz@example.com",
+			wantAction:   "create",
+			wantTimezone: "UTC",
+			wantStarts:   []time.Time{utc(time.June, 1, 10)},
+			wantWarning:  "unknown timezone",
+		},
+		{
+			name:         "RRULE keeps the wall clock across daylight saving",
+			event:        []string{"UID:weekly@example.com", "DTSTART;TZID=America/New_York:20260301T100000", "RRULE:FREQ=WEEKLY;COUNT=3"},
+			wantUID:      "weekly@example.com",
+			wantAction:   "create",
+			wantTimezone: "America/New_York",
+			wantStarts:   []time.Time{at(time.March, 1, 10), at(time.March, 8, 10), at(time.March, 15, 10)},
+		},
+		{
+			name: "EXDATE removes occurrences",
+			event: []string{
+				"UID:exdate@example.com", "DTSTART;TZID=America/New_York:20260601T100000", "RRULE:FREQ=DAILY;COUNT=4",
+				"EXDATE;TZID=America/New_York:20260602T100000,20260603T100000",
+			},
+			wantUID:      "exdate@example.com",
+			wantAction:   "create",
+			wantTimezone: "America/New_York",
+			wantStarts:   []time.Time{at(time.June, 1, 10), at(time.June, 4, 10)},
+		},
+		{
+			name:       "RECURRENCE-ID moves an occurrence",
+			event:      []string{"UID:moved@example.com", "DTSTART:20260601T100000Z", "RRULE:FREQ=DAILY;COUNT=3"},
+			extra:      [][]string{{"UID:moved@example.com", "RECURRENCE-ID:20260602T100000Z", "DTSTART:20260602T150000Z", "DTEND:20260602T160000Z"}},
+			wantUID:    "moved@example.com",
+			wantAction: "create",
+			wantStarts: []time.Time{utc(time.June, 1, 10), utc(time.June, 2, 15), utc(time.June, 3, 10)},
+		},
+		{
+			name:        "missing UID",
+			event:       []string{"DTSTART:20260601T100000Z"},
+			wantAction:  "invalid",
+			wantWarning: "missing UID",
+		},
+		{
+			name:        "missing DTSTART",
+			event:       []string{"UID:no-start@example.com", "SUMMARY:Someday"},
+			wantUID:     "no-start@example.com",
+			wantAction:  "invalid",
+			wantWarning: "missing DTSTART",
+		},
+		{
+			name:        "invalid DTSTART",
+			event:       []string{"UID:bad-start@example.com", "DTSTART:tomorrow"},
+			wantUID:     "bad-start@example.com",
+			wantAction:  "invalid",
+			wantWarning: "invalid DTSTART",
+		},
+		{
+			name:        "unsupported RRULE",
+			event:       []string{"UID:bad-rule@example.com", "DTSTART:20260601T100000Z", "RRULE:FREQ=FORTNIGHTLY"},
+			wantUID:     "bad-rule@example.com",
+			wantAction:  "invalid",
+			wantWarning: "unsupported RRULE",
+		},
+		{
+			name:        "cancelled in the source calendar",
+			event:       []string{"UID:cancelled@example.com", "DTSTART:20260601T100000Z", "STATUS:CANCELLED"},
+			wantUID:     "cancelled@example.com",
+			wantAction:  "invalid",
+			wantWarning: "cancelled",
+		},
+		{
+			name:         "end before start gets the default duration",
+			event:        []string{"UID:backwards@example.com", "DTSTART:20260601T100000Z", "DTEND:20260601T090000Z"},
+			wantUID:      "backwards@example.com",
+			wantAction:   "create",
+			wantTimezone: "UTC",
+			wantStarts:   []time.Time{utc(time.June, 1, 10)},
+			wantEnd:      utc(time.June, 1, 11),
+			wantWarning:  "end is not after start",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := append(append([]string{"BEGIN:VEVENT"}, tt.event...), "END:VEVENT")
+			for _, extra := range tt.extra {
+				lines = append(append(append(lines, "BEGIN:VEVENT"), extra...), "END:VEVENT")
+			}
+			calendar, err := parseICalendar(icalDocument(lines...))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+
+			items := buildImportItems(calendar)
+			if len(items) != 1 {
+				t.Fatalf("got %d items, want 1", len(items))
+			}
+			item := items[0]
+			if item.UID != tt.wantUID || item.Action != tt.wantAction {
+				t.Fatalf("got UID %q action %q, want %q %q (warnings %v)", item.UID, item.Action, tt.wantUID, tt.wantAction, item.Warnings)
+			}
+			if tt.wantWarning != "" && !strings.Contains(strings.Join(item.Warnings, "; "), tt.wantWarning) {
+				t.Errorf("warnings %v do not mention %q", item.Warnings, tt.wantWarning)
+			}
+			if tt.wantAction == "invalid" {
+				return
+			}
+			if item.Timezone != tt.wantTimezone && tt.wantTimezone != "" {
+				t.Errorf("got timezone %s, want %s", item.Timezone, tt.wantTimezone)
+			}
+			if len(item.Sessions) != len(tt.wantStarts) {
+				t.Fatalf("got %d sessions, want %d", len(item.Sessions), len(tt.wantStarts))
+			}
+			for i, want := range tt.wantStarts {
+				if got := item.Sessions[i].StartTime; !got.Equal(want) {
+					t.Errorf("session %d: got start %s, want %s", i+1, got, want)
+				}
+				if item.Sessions[i].SessionNumber != i+1 {
+					t.Errorf("session %d: got number %d", i+1, item.Sessions[i].SessionNumber)
+				}
+			}
+			if !tt.wantEnd.IsZero() && !item.Sessions[0].EndTime.Equal(tt.wantEnd) {
+				t.Errorf("got end %s, want %s", item.Sessions[0].EndTime, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestBuildImportItemsOverrides(t *testing.T) {
+	calendar, err := parseICalendar(icalDocument(
+		"BEGIN:VEVENT", "UID:series@example.com", "SUMMARY:Standup", "LOCATION:Room 1",
+		"DTSTART:20260601T090000Z", "DTEND:20260601T091500Z", "RRULE:FREQ=DAILY;COUNT=3", "END:VEVENT",
+		"BEGIN:VEVENT", "UID:series@example.com", "RECURRENCE-ID:20260602T090000Z", "SUMMARY:Standup (remote)",
+		"LOCATION:Online", "DTSTART:20260602T100000Z", "DTEND:20260602T101500Z", "END:VEVENT",
+		"BEGIN:VEVENT", "UID:series@example.com", "RECURRENCE-ID:20260603T090000Z", "STATUS:CANCELLED",
+		"DTSTART:20260603T090000Z", "END:VEVENT",
+	))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	items := buildImportItems(calendar)
+	if len(items) != 1 || len(items[0].Sessions) != 3 {
+		t.Fatalf("got %d items, want one with 3 sessions", len(items))
+	}
+	sessions := items[0].Sessions
+
+	if sessions[0].IsOverride {
+		t.Errorf("first session should not be an override")
+	}
+
+	moved := sessions[1]
+	if !moved.IsOverride || !moved.OriginalStartTime.Valid || !moved.OriginalStartTime.Time.Equal(time.Date(2026, time.June, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("moved session keeps its original start: got %+v", moved.OriginalStartTime)
+	}
+	if moved.Name.String != "Standup (remote)" || moved.LocationOverride.String != "Online" {
+		t.Errorf("moved session: got name %q location %q", moved.Name.String, moved.LocationOverride.String)
+	}
+
+	if cancelled := sessions[2]; !cancelled.IsCancelled || !cancelled.CancellationReason.Valid {
+		t.Errorf("third session should be cancelled, got %+v", cancelled)
+	}
+}
+
+func TestParseICalDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "PT1H30M", want: 90 * time.Minute},
+		{value: "P1D", want: 24 * time.Hour},
+		{value: "P1W", want: 7 * 24 * time.Hour},
+		{value: "P1DT2H", want: 26 * time.Hour},
+		{value: "-PT15M", want: -15 * time.Minute},
+		{value: "PT45S", want: 45 * time.Second},
+		{value: "1H", wantErr: true},
+		{value: "PT1X", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseICalDuration(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("want error, got %s", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got (%s, %v), want %s", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// exportedEvents renders the events and returns the VEVENTs of the parsed document.
+func exportedEvents(t *testing.T, events ...*domain.Event) []*icalComponent {
+	t.Helper()
+	data := buildICalendar("Test calendar", events)
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n") {
+		if len(line) > icalMaxLineLen {
+			t.Errorf("line longer than %d octets: %q", icalMaxLineLen, line)
+		}
+	}
+	calendar, err := parseICalendar(data)
+	if err != nil {
+		t.Fatalf("exported calendar does not parse: %v\n%s", err, data)
+	}
+	return calendar.Children
+}
+
+func TestBuildICalendarSeries(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	at := func(day, hour int) time.Time { return time.Date(2026, time.June, day, hour, 0, 0, 0, ny) }
+	rule, err := (&domain.Recurrence{RRule: "FREQ=WEEKLY", ExDates: []time.Time{at(15, 10).UTC(), at(22, 10).UTC()}}).Marshal()
+	if err != nil {
+		t.Fatalf("marshal recurrence: %v", err)
+	}
+	event := &domain.Event{
+		ID:                "00000000-0000-0000-0000-000000000001",
+		Name:              "Weekly meetup",
+		Timezone:          "America/New_York",
+		StartTime:         sql.NullTime{Time: at(1, 10), Valid: true},
+		EndTime:           sql.NullTime{Time: at(1, 11), Valid: true},
+		IsRecurring:       true,
+		RecurrenceRule:    rule,
+		RecurrenceEndDate: sql.NullTime{Time: at(30, 0), Valid: true},
+		Sessions: []domain.EventSession{
+			{ID: "s1", StartTime: at(1, 10), EndTime: at(1, 11)},
+			{ID: "s2", StartTime: at(9, 14), EndTime: at(9, 15), OriginalStartTime: sql.NullTime{Time: at(8, 10), Valid: true}, IsOverride: true},
+			{ID: "s3", StartTime: at(15, 10), EndTime: at(15, 11), OriginalStartTime: sql.NullTime{Time: at(15, 10), Valid: true}, IsCancelled: true, CancellationReason: sql.NullString{String: "Holiday", Valid: true}},
+		},
+	}
+
+	components := exportedEvents(t, event)
+	if len(components) != 4 {
+		t.Fatalf("got %d VEVENTs, want the series and 3 sessions", len(components))
+	}
+
+	master := components[0]
+	if master.get("RECURRENCE-ID") != nil {
+		t.Fatalf("first VEVENT should be the series")
+	}
+	if got := master.text("RRULE"); got != "FREQ=WEEKLY;UNTIL=20260630T040000Z" {
+		t.Errorf("got RRULE %q, want the series bounded by its end date", got)
+	}
+	if dtstart := master.get("DTSTART"); dtstart.Params["TZID"] != "America/New_York" || dtstart.Value != "20260601T100000" {
+		t.Errorf("got DTSTART %+v", dtstart)
+	}
+	// June 15 still has a session and is published as a cancelled override instead.
+	exdates := master.all("EXDATE")
+	if len(exdates) != 1 || exdates[0].Value != "20260622T100000" {
+		t.Errorf("got EXDATEs %+v, want only June 22", exdates)
+	}
+
+	tests := []struct {
+		name             string
+		component        *icalComponent
+		wantRecurrenceID string
+		wantStart        string
+		wantStatus       string
+	}{
+		{name: "unchanged occurrence", component: components[1], wantRecurrenceID: "20260601T100000", wantStart: "20260601T100000", wantStatus: "CONFIRMED"},
+		{name: "moved occurrence keeps its original start", component: components[2], wantRecurrenceID: "20260608T100000", wantStart: "20260609T140000", wantStatus: "CONFIRMED"},
+		{name: "cancelled occurrence", component: components[3], wantRecurrenceID: "20260615T100000", wantStart: "20260615T100000", wantStatus: "CANCELLED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.component.text("UID"); got != master.text("UID") {
+				t.Errorf("got UID %q, want the series UID %q", got, master.text("UID"))
+			}
+			recurrenceID := tt.component.get("RECURRENCE-ID")
+			if recurrenceID == nil || recurrenceID.Value != tt.wantRecurrenceID || recurrenceID.Params["TZID"] != "America/New_York" {
+				t.Errorf("got RECURRENCE-ID %+v, want %s", recurrenceID, tt.wantRecurrenceID)
+			}
+			if got := tt.component.get("DTSTART").Value; got != tt.wantStart {
+				t.Errorf("got DTSTART %s, want %s", got, tt.wantStart)
+			}
+			if got := tt.component.text("STATUS"); got != tt.wantStatus {
+				t.Errorf("got STATUS %s, want %s", got, tt.wantStatus)
+			}
+		})
+	}
+	if got := components[3].text("DESCRIPTION"); !strings.HasPrefix(got, "Cancelled: Holiday") {
+		t.Errorf("cancelled session description %q does not give the reason", got)
+	}
+}
+
+func TestBuildICalendarStandaloneEvents(t *testing.T) {
+	start := time.Date(2026, time.June, 1, 17, 0, 0, 0, time.UTC)
+	long := strings.Repeat("Ünïcødé agenda, ", 10)
+
+	tests := []struct {
+		name      string
+		event     *domain.Event
+		wantUIDs  []string
+		wantTZID  string
+		wantTitle string
+	}{
+		{
+			name: "event without sessions",
+			event: &domain.Event{
+				ID: "e1", Name: "Launch; party, v2", StartTime: sql.NullTime{Time: start, Valid: true},
+				EndTime: sql.NullTime{Time: start.Add(time.Hour), Valid: true},
+			},
+			wantUIDs:  []string{"e1@attendwise"},
+			wantTitle: "Launch; party, v2",
+		},
+		{
+			name: "sessions of a non-recurring event",
+			event: &domain.Event{
+				ID: "e2", Name: "Conference", Timezone: "Europe/Paris", Description: sql.NullString{String: long, Valid: true},
+				Sessions: []domain.EventSession{
+					{ID: "a", StartTime: start, EndTime: start.Add(time.Hour), Name: sql.NullString{String: "Day 1", Valid: true}},
+					{ID: "b", StartTime: start.AddDate(0, 0, 1), EndTime: start.AddDate(0, 0, 1).Add(time.Hour)},
+				},
+			},
+			wantUIDs:  []string{"a@attendwise", "b@attendwise"},
+			wantTZID:  "Europe/Paris",
+			wantTitle: "Conference - Day 1",
+		},
+		{
+			name:  "event without times is left out",
+			event: &domain.Event{ID: "e3", Name: "Someday"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components := exportedEvents(t, tt.event)
+			if len(components) != len(tt.wantUIDs) {
+				t.Fatalf("got %d VEVENTs, want %d", len(components), len(tt.wantUIDs))
+			}
+			for i, component := range components {
+				if got := component.text("UID"); got != tt.wantUIDs[i] {
+					t.Errorf("got UID %q, want %q", got, tt.wantUIDs[i])
+				}
+				if component.get("RECURRENCE-ID") != nil {
+					t.Errorf("standalone VEVENT %q has a RECURRENCE-ID", tt.wantUIDs[i])
+				}
+				if got := component.get("DTSTART").Params["TZID"]; got != tt.wantTZID {
+					t.Errorf("got TZID %q, want %q", got, tt.wantTZID)
+				}
+			}
+			if len(components) > 0 {
+				if got := components[0].text("SUMMARY"); got != tt.wantTitle {
+					t.Errorf("got SUMMARY %q, want %q", got, tt.wantTitle)
+				}
+				if got := components[0].text("DESCRIPTION"); got != tt.event.Description.String {
+					t.Errorf("folded DESCRIPTION does not round-trip: got %q", got)
+				}
+			}
+		})
+	}
+}
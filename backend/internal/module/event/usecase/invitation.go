@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// InviteToEvent invites users and email addresses to an event, with an optional personal message, and notifies
+// them. People already invited are skipped. Invitees of a whitelist-only event are added to its whitelist so
+// that they can register. It returns the invitations created.
+func (s *Service) InviteToEvent(ctx context.Context, eventID, userID string, inviteeUserIDs, emails []string, message string) ([]*domain.EventInvitation, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if !domain.AcceptsRegistrations(event.Status) {
+		return nil, domain.ErrInvitationsClosed
+	}
+	if len(inviteeUserIDs) == 0 && len(emails) == 0 {
+		return nil, fmt.Errorf("%w: user_ids or emails is required", domain.ErrInvalidInvitation)
+	}
+	if len(inviteeUserIDs)+len(emails) > domain.MaxInvitationsPerRequest {
+		return nil, fmt.Errorf("%w: at most %d people can be invited at once", domain.ErrInvalidInvitation, domain.MaxInvitationsPerRequest)
+	}
+
+	message = strings.TrimSpace(message)
+	inviterID := sql.NullString{String: userID, Valid: true}
+	var invitations []*domain.EventInvitation
+	var whitelist []*domain.WhitelistEntry
+	seen := map[string]bool{}
+	for _, inviteeUserID := range inviteeUserIDs {
+		if inviteeUserID == "" || seen[inviteeUserID] {
+			continue
+		}
+		seen[inviteeUserID] = true
+		invitations = append(invitations, &domain.EventInvitation{
+			EventID:       eventID,
+			InviteeUserID: sql.NullString{String: inviteeUserID, Valid: true},
+			InviterID:     inviterID,
+			Message:       sql.NullString{String: message, Valid: message != ""},
+		})
+		whitelist = append(whitelist, &domain.WhitelistEntry{
+			EventID: eventID,
+			UserID:  sql.NullString{String: inviteeUserID, Valid: true},
+			AddedBy: userID,
+		})
+	}
+	for _, raw := range emails {
+		email, err := domain.NormalizeWhitelistEmail(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInvitation, err)
+		}
+		if email == "" || seen[email] {
+			continue
+		}
+		seen[email] = true
+		invitations = append(invitations, &domain.EventInvitation{
+			EventID:      eventID,
+			InviteeEmail: sql.NullString{String: email, Valid: true},
+			InviterID:    inviterID,
+			Message:      sql.NullString{String: message, Valid: message != ""},
+		})
+		whitelist = append(whitelist, &domain.WhitelistEntry{
+			EventID: eventID,
+			Email:   sql.NullString{String: email, Valid: true},
+			AddedBy: userID,
+		})
+	}
+
+	if event.WhitelistOnly && len(whitelist) > 0 {
+		if _, err := s.repo.AddWhitelistEntries(ctx, whitelist); err != nil {
+			return nil, err
+		}
+		if _, err := s.repo.LinkEventWhitelistEntries(ctx, eventID); err != nil {
+			log.Printf("Error linking the whitelist entries of event %s to accounts: %v", eventID, err)
+		}
+	}
+
+	created, err := s.repo.CreateEventInvitations(ctx, invitations)
+	if err != nil {
+		return nil, err
+	}
+	for _, invitation := range created {
+		invitation.EventName = event.Name
+		s.publishInvitation(invitation, false)
+	}
+	return created, nil
+}
+
+// GetInvitationDashboard returns the invitations of an event, optionally only those with an RSVP, with
+// counts by response, to the staff who can view its attendees.
+func (s *Service) GetInvitationDashboard(ctx context.Context, eventID, userID, rsvp string) (*domain.InvitationDashboard, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionViewAttendees); err != nil {
+		return nil, err
+	}
+	if rsvp != "" && rsvp != domain.RSVPPending && !domain.IsValidRSVP(rsvp) {
+		return nil, domain.ErrInvalidRSVP
+	}
+
+	invitations, err := s.repo.ListEventInvitations(ctx, eventID, rsvp)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewInvitationDashboard(eventID, invitations), nil
+}
+
+// RemindPendingInvitations reminds the invitees of an event who have not responded yet. Each invitation is
+// reminded at most once a day and MaxInvitationReminders times in total. It returns the number of
+// reminders sent.
+func (s *Service) RemindPendingInvitations(ctx context.Context, eventID, userID string) (int, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return 0, err
+	}
+
+	invitations, err := s.repo.ClaimInvitationReminders(ctx, eventID, domain.ManualInvitationReminderInterval, domain.MaxInvitationReminders, domain.MaxInvitationsPerRequest)
+	if err != nil {
+		return 0, err
+	}
+	for _, invitation := range invitations {
+		s.publishInvitation(invitation, true)
+	}
+	return len(invitations), nil
+}
+
+// RevokeInvitation withdraws an invitation. A registration the invitee already made is kept.
+func (s *Service) RevokeInvitation(ctx context.Context, invitationID, userID string) error {
+	invitation, err := s.repo.GetEventInvitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	event, err := s.repo.GetEventByID(ctx, invitation.EventID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteEventInvitation(ctx, invitationID)
+}
+
+// ListMyInvitations lists the invitations sent to the user, by account or by verified email, optionally only
+// those with an RSVP.
+func (s *Service) ListMyInvitations(ctx context.Context, userID, rsvp string) ([]*domain.EventInvitation, error) {
+	if rsvp != "" && rsvp != domain.RSVPPending && !domain.IsValidRSVP(rsvp) {
+		return nil, domain.ErrInvalidRSVP
+	}
+	return s.repo.ListUserInvitations(ctx, userID, rsvp)
+}
+
+// RespondToInvitation records the user's RSVP. "going" registers the user for the event under its usual
+// approval, capacity and eligibility rules; if the registration is refused the RSVP is still recorded and the
+// response says why. "declined" cancels a registration the user made.
+func (s *Service) RespondToInvitation(ctx context.Context, invitationID, userID, rsvp string) (*domain.InvitationResponse, error) {
+	if !domain.IsValidRSVP(rsvp) {
+		return nil, domain.ErrInvalidRSVP
+	}
+	invitation, err := s.repo.GetUserInvitation(ctx, invitationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.InvitationResponse{}
+	switch rsvp {
+	case domain.RSVPGoing:
+		_, err := s.RegisterForEvent(ctx, invitation.EventID, userID, nil, false)
+		switch {
+		case err == nil, errors.Is(err, domain.ErrAlreadyRegistered):
+		case errors.Is(err, domain.ErrRegistrationClosed), errors.Is(err, domain.ErrWhitelistOnly), errors.Is(err, domain.ErrEventFull):
+			response.RegistrationError = err.Error()
+		case errors.Is(err, permission_domain.ErrPermissionDenied):
+			response.RegistrationError = "only members of the event's community can register"
+		default:
+			return nil, err
+		}
+	case domain.RSVPDeclined:
+		attendee, err := s.repo.GetEventAttendee(ctx, invitation.EventID, userID)
+		if err != nil && !errors.Is(err, domain.ErrAttendeeNotFound) {
+			return nil, err
+		}
+		if attendee != nil && attendee.Role != "host" && attendee.Status != "cancelled" {
+			if err := s.CancelRegistration(ctx, attendee.ID, userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.repo.RespondToInvitation(ctx, invitation.ID, userID, rsvp); err != nil {
+		return nil, err
+	}
+	response.Invitation, err = s.repo.GetUserInvitation(ctx, invitation.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if attendee, err := s.repo.GetEventAttendee(ctx, invitation.EventID, userID); err == nil {
+		response.RegistrationStatus = attendee.Status
+	}
+	return response, nil
+}
+
+// SendInvitationReminders reminds the invitees who have not responded InvitationReminderInterval after the
+// invitation or the last reminder, up to MaxAutomaticInvitationReminders times. It is run by a worker.
+func (s *Service) SendInvitationReminders(ctx context.Context) error {
+	for {
+		invitations, err := s.repo.ClaimInvitationReminders(ctx, "", domain.InvitationReminderInterval, domain.MaxAutomaticInvitationReminders, domain.MaxInvitationsPerRequest)
+		if err != nil {
+			return err
+		}
+		for _, invitation := range invitations {
+			s.publishInvitation(invitation, true)
+		}
+		if len(invitations) < domain.MaxInvitationsPerRequest {
+			return nil
+		}
+	}
+}
+
+// publishInvitation publishes an EventInvitationSentEvent for an invitation or a reminder of it.
+func (s *Service) publishInvitation(invitation *domain.EventInvitation, reminder bool) {
+	if s.publisher == nil {
+		return
+	}
+	payload, err := json.Marshal(domain.EventInvitationSentEvent{
+		InvitationID: invitation.ID,
+		EventID:      invitation.EventID,
+		EventName:    invitation.EventName,
+		UserID:       invitation.InviteeUserID.String,
+		Email:        invitation.InviteeEmail.String,
+		Message:      invitation.Message.String,
+		Reminder:     reminder,
+	})
+	if err != nil {
+		log.Printf("Error marshalling event invitation: %v", err)
+		return
+	}
+	if err := s.publisher.Publish(domain.EventInvitationSubject, payload); err != nil {
+		log.Printf("Error publishing event invitation message: %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// ChangeEventStatus moves an event to another status of its lifecycle on behalf of its staff, e.g. to
+// publish it, close registration early, cancel or archive it. Publishing an event immediately brings it
+// to the status its schedule calls for.
+func (s *Service) ChangeEventStatus(ctx context.Context, eventID, status, reason, userID string) (*domain.Event, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.transitionEventStatus(ctx, event, status, userID, reason); err != nil {
+		return nil, err
+	}
+	s.advanceEventLifecycle(ctx, event)
+
+	return s.repo.GetEventByID(ctx, eventID, userID)
+}
+
+// GetEventStatusHistory lists the status transitions of an event, oldest first.
+func (s *Service) GetEventStatusHistory(ctx context.Context, eventID, userID string) ([]*domain.EventStatusTransition, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	canView, err := s.permService.CanViewCommunityContent(ctx, event.CommunityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+	return s.repo.ListEventStatusTransitions(ctx, eventID)
+}
+
+// AdvanceEventLifecycles moves every published event on to the status its sessions and registration
+// window call for. It is run periodically by the lifecycle worker.
+func (s *Service) AdvanceEventLifecycles(ctx context.Context) error {
+	lifecycles, err := s.repo.ListEventLifecycles(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, lifecycle := range lifecycles {
+		s.applyScheduledStatus(ctx, lifecycle, now)
+	}
+	return nil
+}
+
+// advanceEventLifecycle brings a single event to the status its schedule calls for, e.g. right after it
+// was published or rescheduled, and updates event.Status accordingly. Errors are logged, the worker
+// retries on its next run.
+func (s *Service) advanceEventLifecycle(ctx context.Context, event *domain.Event) {
+	lifecycles, err := s.repo.ListEventLifecycles(ctx, event.ID)
+	if err != nil {
+		log.Printf("Error loading the lifecycle of event %s: %v", event.ID, err)
+		return
+	}
+	for _, lifecycle := range lifecycles {
+		if s.applyScheduledStatus(ctx, lifecycle, time.Now()) {
+			event.Status = lifecycle.Event.Status
+		}
+	}
+}
+
+// applyScheduledStatus makes the automatic transition the lifecycle calls for at the given time, if any,
+// and reports whether it did.
+func (s *Service) applyScheduledStatus(ctx context.Context, lifecycle *domain.EventLifecycle, now time.Time) bool {
+	next, ok := lifecycle.NextStatus(now)
+	if !ok {
+		return false
+	}
+	if err := s.transitionEventStatus(ctx, lifecycle.Event, next, "", ""); err != nil {
+		log.Printf("Error moving event %s from %s to %s: %v", lifecycle.Event.ID, lifecycle.Event.Status, next, err)
+		return false
+	}
+	return true
+}
+
+// transitionEventStatus validates and saves a status transition, then publishes it as an
+// EventStatusChangedEvent. An empty userID marks an automatic transition. On success event.Status is
+// updated to the new status.
+func (s *Service) transitionEventStatus(ctx context.Context, event *domain.Event, status, userID, reason string) error {
+	if err := domain.ValidateStatusTransition(event.Status, status); err != nil {
+		return err
+	}
+
+	transition := &domain.EventStatusTransition{
+		EventID:     event.ID,
+		FromStatus:  event.Status,
+		ToStatus:    status,
+		TriggeredBy: sql.NullString{String: userID, Valid: userID != ""},
+		Reason:      sql.NullString{String: reason, Valid: reason != ""},
+	}
+	if err := s.repo.TransitionEventStatus(ctx, transition); err != nil {
+		return err
+	}
+	event.Status = status
+	s.syncReminders(ctx, event.ID)
+	if status == domain.EventStatusCompleted {
+		s.issueCertificatesOnCompletion(ctx, event)
+	}
+
+	s.repo.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+	if userID != "" && userID != event.CreatedBy {
+		s.repo.InvalidateEventCache(ctx, event.ID, userID)
+	}
+
+	if s.publisher != nil {
+		payload, err := json.Marshal(domain.EventStatusChangedEvent{
+			EventID:     event.ID,
+			CommunityID: event.CommunityID,
+			EventName:   event.Name,
+			CreatedBy:   event.CreatedBy,
+			FromStatus:  transition.FromStatus,
+			ToStatus:    transition.ToStatus,
+			TriggeredBy: userID,
+			Reason:      reason,
+			OccurredAt:  transition.CreatedAt,
+		})
+		if err != nil {
+			log.Printf("Error marshalling event status change: %v", err)
+			return nil
+		}
+		if err := s.publisher.Publish(domain.EventStatusChangedSubjectPrefix+status, payload); err != nil {
+			log.Printf("Error publishing event status change message: %v", err)
+		}
+	}
+	return nil
+}
+
+// authorizeEventEdit allows the event's editors and the community's admins to manage it.
+func (s *Service) authorizeEventEdit(ctx context.Context, event *domain.Event, userID string) error {
+	return s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionEdit)
+}
+
+// authorizeEventPermission allows the event staff holding the permission and the community's admins.
+func (s *Service) authorizeEventPermission(ctx context.Context, event *domain.Event, userID string, permission permission_domain.EventPermission) error {
+	allowed, err := s.permService.HasEventPermission(ctx, event.ID, userID, permission)
+	if err != nil {
+		return err
+	}
+	if allowed {
+		return nil
+	}
+	isAdmin, err := s.permService.IsCommunityAdmin(ctx, event.CommunityID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return permission_domain.ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/google/uuid"
+)
+
+// GetJoinLinkToken returns the token of the user's personal join link for an online or hybrid event,
+// issuing one on first use. Only approved registrants have a join link.
+func (s *Service) GetJoinLinkToken(ctx context.Context, eventID, userID string) (string, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !event.HasMeetingLink() {
+		return "", domain.ErrNoOnlineMeeting
+	}
+	attendee, err := s.repo.GetEventAttendee(ctx, eventID, userID)
+	if errors.Is(err, domain.ErrAttendeeNotFound) {
+		return "", domain.ErrNotRegistered
+	}
+	if err != nil {
+		return "", err
+	}
+	if !isApprovedRegistrant(attendee) {
+		return "", domain.ErrNotRegistered
+	}
+	return s.repo.IssueJoinToken(ctx, attendee.ID, uuid.New().String())
+}
+
+// JoinOnlineSession resolves a join link to the meeting of the session it opens now and checks the
+// attendee in to that session. On events with per-session registration only the sessions the attendee is
+// registered for can be joined.
+func (s *Service) JoinOnlineSession(ctx context.Context, token string) (string, error) {
+	attendee, err := s.repo.GetAttendeeByJoinToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	if !isApprovedRegistrant(attendee) {
+		return "", domain.ErrNotRegistered
+	}
+	event, err := s.repo.GetEventByID(ctx, attendee.EventID, attendee.UserID)
+	if err != nil {
+		return "", err
+	}
+	if event.Status == domain.EventStatusCancelled {
+		return "", domain.ErrSessionNotJoinable
+	}
+
+	var allowed map[string]bool
+	if event.PerSessionRegistration {
+		registrations, err := s.repo.ListUserSessionRegistrations(ctx, event.ID, attendee.UserID)
+		if err != nil {
+			return "", err
+		}
+		allowed = make(map[string]bool, len(registrations))
+		for _, registration := range registrations {
+			if registration.Status == domain.SessionRegistrationRegistered {
+				allowed[registration.SessionID] = true
+			}
+		}
+	}
+	session := event.JoinableSession(time.Now(), allowed)
+	if session == nil {
+		return "", domain.ErrSessionNotJoinable
+	}
+	meetingURL := event.SessionMeetingURL(session)
+	if meetingURL == "" {
+		return "", domain.ErrNoOnlineMeeting
+	}
+
+	if err := s.repo.RecordVirtualCheckin(ctx, session.ID, attendee.UserID, attendee.ID); err != nil {
+		return "", err
+	}
+	s.repo.InvalidateEventCache(ctx, event.ID, attendee.UserID)
+	s.publishVirtualCheckin(session.ID, attendee)
+	return meetingURL, nil
+}
+
+// gateMeetingLinks withholds from the user the meeting links of an event they may not see. The event's
+// staff and the community's admins see every link; approved registrants see those within the reveal
+// window; anyone else sees none.
+func (s *Service) gateMeetingLinks(ctx context.Context, event *domain.Event, userID string) error {
+	if !event.HasMeetingLink() {
+		return nil
+	}
+	role, err := s.permService.GetEventRole(ctx, event.ID, userID)
+	if err != nil {
+		return err
+	}
+	if role != "" {
+		return nil
+	}
+	isAdmin, err := s.permService.IsCommunityAdmin(ctx, event.CommunityID, userID)
+	if err != nil {
+		return err
+	}
+	if isAdmin {
+		return nil
+	}
+
+	attendee, err := s.repo.GetEventAttendee(ctx, event.ID, userID)
+	if err != nil && !errors.Is(err, domain.ErrAttendeeNotFound) {
+		return err
+	}
+	event.HideMeetingLinks(attendee != nil && isApprovedRegistrant(attendee), time.Now())
+	return nil
+}
+
+// isApprovedRegistrant reports whether the attendee's registration went through, as opposed to pending
+// approval, waitlisted or cancelled.
+func isApprovedRegistrant(attendee *domain.EventAttendee) bool {
+	return attendee.Status == "registered" || attendee.Status == "attended"
+}
+
+// publishVirtualCheckin announces a virtual check-in on the subject the check-in dashboards listen on.
+func (s *Service) publishVirtualCheckin(sessionID string, attendee *domain.EventAttendee) {
+	if s.publisher == nil {
+		return
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"session_id":   sessionID,
+		"user_id":      attendee.UserID,
+		"user_name":    attendee.UserName,
+		"profile_url":  attendee.UserProfilePictureURL.String,
+		"success":      true,
+		"message":      "Joined online",
+		"method":       domain.CheckinMethodVirtual,
+		"checkin_time": time.Now(),
+	})
+	if err != nil {
+		log.Printf("Error marshalling virtual check-in event: %v", err)
+		return
+	}
+	if err := s.publisher.Publish(fmt.Sprintf("checkin.updates.%s", sessionID), payload); err != nil {
+		log.Printf("Error publishing virtual check-in event: %v", err)
+	}
+}
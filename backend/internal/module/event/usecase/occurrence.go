@@ -0,0 +1,451 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+	"github.com/google/uuid"
+	"github.com/gosimple/slug"
+	"github.com/teambition/rrule-go"
+)
+
+// maxPreviewYears bounds how far ahead a recurrence preview looks for occurrences.
+const maxPreviewYears = 10
+
+// UpdateEventOccurrence edits the occurrence represented by a session. Depending on the scope the change
+// applies to that session only, to it and every later occurrence (splitting the series in two), or to
+// the whole series. It returns the event the edited occurrence belongs to afterwards.
+func (s *Service) UpdateEventOccurrence(ctx context.Context, sessionID, userID string, edit *domain.OccurrenceEdit) (*domain.Event, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return nil, err
+	}
+
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsCancelled {
+		return nil, fmt.Errorf("%w: cancelled sessions cannot be edited", domain.ErrInvalidOccurrence)
+	}
+
+	start, end := session.StartTime, session.EndTime
+	if edit.StartTime.Valid {
+		start = edit.StartTime.Time
+		if !edit.EndTime.Valid {
+			end = start.Add(session.EndTime.Sub(session.StartTime))
+		}
+	}
+	if edit.EndTime.Valid {
+		end = edit.EndTime.Time
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: end time must be after start time", domain.ErrInvalidOccurrence)
+	}
+
+	switch edit.Scope {
+	case "", domain.OccurrenceScopeThis:
+		return s.updateSingleOccurrence(ctx, event, session, start, end, edit, userID)
+	case domain.OccurrenceScopeFollowing, domain.OccurrenceScopeAll:
+	default:
+		return nil, fmt.Errorf("%w: unknown scope %q", domain.ErrInvalidOccurrence, edit.Scope)
+	}
+
+	if !event.IsRecurring {
+		return nil, domain.ErrEventNotRecurring
+	}
+	recurrence, err := domain.ParseRecurrence(event.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	if recurrence == nil || !event.StartTime.Valid || !event.EndTime.Valid {
+		return nil, domain.ErrEventNotRecurring
+	}
+	option, err := recurrence.Option()
+	if err != nil {
+		return nil, err
+	}
+
+	// The series is moved by how far the edited occurrence moves from its rule-generated start time, on the
+	// event's wall clock, so occurrences on the other side of a daylight-saving change keep their local time.
+	key := sessionRecurrenceID(session)
+	loc := event.Location()
+	var shift time.Duration
+	if edit.StartTime.Valid {
+		shift = domain.LocalShift(key, edit.StartTime.Time, loc)
+	}
+	duration := event.EndTime.Time.Sub(event.StartTime.Time)
+	if edit.StartTime.Valid || edit.EndTime.Valid {
+		duration = end.Sub(start)
+	}
+	if err := validateSeriesShift(option, key, shift, loc); err != nil {
+		return nil, err
+	}
+
+	if edit.Scope == domain.OccurrenceScopeAll || !hasOccurrencesBefore(recurrence, event.StartTime.Time, key) {
+		if err := s.shiftSeries(ctx, event, recurrence, shift, duration, edit); err != nil {
+			return nil, err
+		}
+		return s.repo.GetEventByID(ctx, event.ID, userID)
+	}
+
+	series, err := s.splitSeries(ctx, event, recurrence, key)
+	if err != nil {
+		return nil, err
+	}
+	seriesRecurrence, err := domain.ParseRecurrence(series.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.shiftSeries(ctx, series, seriesRecurrence, shift, duration, edit); err != nil {
+		return nil, err
+	}
+	return s.repo.GetEventByID(ctx, series.ID, userID)
+}
+
+// AddEventOccurrence adds an extra occurrence (RDATE) to a recurring event and creates its session.
+func (s *Service) AddEventOccurrence(ctx context.Context, eventID, userID string, startTime, endTime time.Time) (*domain.EventSession, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if !endTime.After(startTime) {
+		return nil, fmt.Errorf("%w: end time must be after start time", domain.ErrInvalidOccurrence)
+	}
+
+	recurrence, err := domain.ParseRecurrence(event.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsRecurring || recurrence == nil {
+		return nil, domain.ErrEventNotRecurring
+	}
+
+	startTime = startTime.Truncate(time.Second)
+	existing, err := s.repo.GetSessionStartTimes(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing[startTime.UTC()] {
+		return nil, fmt.Errorf("%w: an occurrence already exists at this time", domain.ErrInvalidOccurrence)
+	}
+
+	conflicts, err := s.checkScheduleConflicts(ctx, event, event.CreatedBy, []domain.EventSession{{StartTime: startTime, EndTime: endTime}})
+	if err != nil {
+		return nil, err
+	}
+
+	recurrence.AddRDate(startTime)
+	if event.RecurrenceRule, err = recurrence.Marshal(); err != nil {
+		return nil, err
+	}
+	session := domain.EventSession{
+		ID:                uuid.New().String(),
+		EventID:           event.ID,
+		StartTime:         startTime,
+		EndTime:           endTime,
+		Timezone:          event.Timezone,
+		OriginalStartTime: sql.NullTime{Time: startTime, Valid: true},
+	}
+	if err := s.repo.AddEventOccurrence(ctx, event, &session); err != nil {
+		return nil, err
+	}
+	s.syncReminders(ctx, event.ID)
+
+	s.repo.InvalidateEventCache(ctx, event.ID, userID)
+	s.repo.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+
+	created, err := s.repo.GetEventSessionByID(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	created.ScheduleConflicts = conflicts
+	return created, nil
+}
+
+// PreviewEventOccurrences is a dry run of an event's recurrence: it returns the next occurrences the event
+// would have from now on (or from its start if it lies ahead), without saving anything.
+func (s *Service) PreviewEventOccurrences(ctx context.Context, event *domain.Event, count int) ([]domain.OccurrencePreview, error) {
+	if !event.StartTime.Valid || !event.EndTime.Valid || !event.EndTime.Time.After(event.StartTime.Time) {
+		return nil, fmt.Errorf("%w: a start time before the end time is required", domain.ErrInvalidRecurrence)
+	}
+	if err := event.ValidateGenerationHorizon(); err != nil {
+		return nil, err
+	}
+	recurrence, err := domain.ParseRecurrence(event.RecurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+	if recurrence == nil {
+		return nil, fmt.Errorf("%w: recurrence_rule is required", domain.ErrInvalidRecurrence)
+	}
+	if _, err := recurrence.Option(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from := now
+	if event.StartTime.Time.After(from) {
+		from = event.StartTime.Time
+	}
+	// The upper bound only guards against rules that never match; the count limits the result.
+	occurrences, err := recurrence.Occurrences(event, from, from.AddDate(maxPreviewYears, 0, 0), count)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := event.EndTime.Time.Sub(event.StartTime.Time)
+	horizon := now.Add(event.GenerationHorizon())
+	previews := make([]domain.OccurrencePreview, len(occurrences))
+	for i, occurrence := range occurrences {
+		previews[i] = domain.OccurrencePreview{
+			StartTime:     occurrence,
+			EndTime:       occurrence.Add(duration),
+			WithinHorizon: !occurrence.After(horizon),
+		}
+	}
+	return previews, nil
+}
+
+// authorizeSessionManagement allows the event's host and co-hosts and the admins of its community to manage sessions.
+func (s *Service) authorizeSessionManagement(ctx context.Context, event *domain.Event, userID string) error {
+	allowed, err := s.permService.HasEventPermission(ctx, event.ID, userID, permission_domain.EventPermissionEdit)
+	if err != nil {
+		return err
+	}
+	if allowed {
+		return nil
+	}
+	isAdmin, err := s.permService.IsCommunityAdmin(ctx, event.CommunityID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return permission_domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+// updateSingleOccurrence applies the edit to one session and marks it as an override of the series.
+func (s *Service) updateSingleOccurrence(ctx context.Context, event *domain.Event, session *domain.EventSession, start, end time.Time, edit *domain.OccurrenceEdit, userID string) (*domain.Event, error) {
+	previousStart := session.StartTime
+	if !session.OriginalStartTime.Valid {
+		session.OriginalStartTime = sql.NullTime{Time: session.StartTime, Valid: true}
+	}
+	session.StartTime = start
+	session.EndTime = end
+	if edit.Name.Valid {
+		session.Name = edit.Name
+	}
+	if edit.LocationOverride.Valid {
+		session.LocationOverride = edit.LocationOverride
+	}
+	if edit.OnlineMeetingURLOverride.Valid {
+		session.OnlineMeetingURLOverride = edit.OnlineMeetingURLOverride
+	}
+	if edit.MaxAttendeesOverride.Valid {
+		session.MaxAttendeesOverride = edit.MaxAttendeesOverride
+	}
+	session.IsOverride = event.IsRecurring
+
+	conflicts, err := s.checkScheduleConflicts(ctx, event, event.CreatedBy, []domain.EventSession{*session})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateEventSession(ctx, session); err != nil {
+		return nil, err
+	}
+	s.shiftAgendaItems(ctx, session.ID, previousStart, start)
+	s.promoteSessionWaitlists(ctx, event.ID)
+	s.syncReminders(ctx, event.ID)
+
+	s.repo.InvalidateEventCache(ctx, event.ID, userID)
+	s.repo.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+
+	if s.publisher != nil {
+		payload := fmt.Sprintf(`{"session_id": "%s", "event_id": "%s"}`, session.ID, event.ID)
+		if err := s.publisher.Publish("events.session.updated", []byte(payload)); err != nil {
+			log.Printf("Error publishing event session update message: %v", err)
+		}
+	}
+
+	updated, err := s.repo.GetEventByID(ctx, event.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	updated.ScheduleConflicts = conflicts
+	return updated, nil
+}
+
+// shiftSeries moves a series by the given shift on the event's wall clock, including its exception and extra
+// dates, and gives its upcoming sessions the new duration and the changes of the edit.
+func (s *Service) shiftSeries(ctx context.Context, event *domain.Event, recurrence *domain.Recurrence, shift, duration time.Duration, edit *domain.OccurrenceEdit) error {
+	loc := event.Location()
+	if err := recurrence.Shift(shift, loc); err != nil {
+		return err
+	}
+	rule, err := recurrence.Marshal()
+	if err != nil {
+		return err
+	}
+
+	event.RecurrenceRule = rule
+	event.StartTime = sql.NullTime{Time: domain.ShiftLocal(event.StartTime.Time, shift, loc), Valid: true}
+	event.EndTime = sql.NullTime{Time: event.StartTime.Time.Add(duration), Valid: true}
+	if err := s.repo.ShiftEventSeries(ctx, event, shift, duration, edit); err != nil {
+		return err
+	}
+	s.promoteSessionWaitlists(ctx, event.ID)
+	s.syncReminders(ctx, event.ID)
+	return nil
+}
+
+// splitSeries ends the event's series right before the occurrence at 'from' and continues it as a new
+// event starting with that occurrence. COUNT-bounded rules are divided between the two series.
+func (s *Service) splitSeries(ctx context.Context, event *domain.Event, recurrence *domain.Recurrence, from time.Time) (*domain.Event, error) {
+	series, err := planSeriesSplit(event, recurrence, from)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SplitEventSeries(ctx, event, series, from); err != nil {
+		return nil, err
+	}
+	s.syncReminders(ctx, event.ID)
+	s.syncReminders(ctx, series.ID)
+
+	if err := s.neo4jRepo.CreateEventNode(ctx, series); err != nil {
+		log.Printf("Warning: could not create event node in Neo4j: %v", err)
+	}
+
+	if s.publisher != nil {
+		payload := fmt.Sprintf(`{"event_id": "%s", "new_event_id": "%s"}`, event.ID, series.ID)
+		if err := s.publisher.Publish("events.series.split", []byte(payload)); err != nil {
+			log.Printf("Error publishing event series split message: %v", err)
+		}
+	}
+
+	return series, nil
+}
+
+// planSeriesSplit computes a split of the event's series at 'from' without saving it: the event's rule is
+// ended right before 'from', and the returned continuation starts with that occurrence. Exception and extra
+// dates go to the series they fall in; COUNT and max occurrences are divided between the two.
+func planSeriesSplit(event *domain.Event, recurrence *domain.Recurrence, from time.Time) (*domain.Event, error) {
+	option, err := recurrence.Option()
+	if err != nil {
+		return nil, err
+	}
+	option.Dtstart = event.StartTime.Time.In(event.Location())
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidRecurrence, err)
+	}
+	generatedBefore := 0
+	for _, occurrence := range rule.Between(event.StartTime.Time, from, true) {
+		if occurrence.Before(from) {
+			generatedBefore++
+		}
+	}
+
+	head := &domain.Recurrence{}
+	tail := &domain.Recurrence{}
+	for _, exdate := range recurrence.ExDates {
+		if exdate.Before(from) {
+			head.ExDates = append(head.ExDates, exdate)
+		} else {
+			tail.ExDates = append(tail.ExDates, exdate)
+		}
+	}
+	for _, rdate := range recurrence.RDates {
+		if rdate.Before(from) {
+			head.RDates = append(head.RDates, rdate)
+		} else {
+			tail.RDates = append(tail.RDates, rdate)
+		}
+	}
+
+	tailOption := *option
+	tailOption.Dtstart = time.Time{}
+	if option.Count > 0 {
+		tailOption.Count = option.Count - generatedBefore
+		if tailOption.Count < 1 {
+			tailOption.Count = 1
+		}
+	}
+	tail.RRule = tailOption.RRuleString()
+
+	headOption := *option
+	headOption.Dtstart = time.Time{}
+	headOption.Count = 0
+	headOption.Until = from.Add(-time.Second)
+	head.RRule = headOption.RRuleString()
+
+	series := *event
+	series.ID = uuid.New().String()
+	series.Slug = slug.Make(fmt.Sprintf("%s-%s", event.Name, series.ID[:8]))
+	series.StartTime = sql.NullTime{Time: from, Valid: true}
+	series.EndTime = sql.NullTime{Time: from.Add(event.EndTime.Time.Sub(event.StartTime.Time)), Valid: true}
+	series.Sessions = nil
+	if event.MaxOccurrences.Valid && event.MaxOccurrences.Int32 > 0 {
+		remaining := event.MaxOccurrences.Int32 - int32(generatedBefore)
+		if remaining < 1 {
+			remaining = 1
+		}
+		series.MaxOccurrences = sql.NullInt32{Int32: remaining, Valid: true}
+	}
+	if series.RecurrenceRule, err = tail.Marshal(); err != nil {
+		return nil, err
+	}
+
+	if event.RecurrenceRule, err = head.Marshal(); err != nil {
+		return nil, err
+	}
+	event.RecurrenceEndDate = sql.NullTime{Time: headOption.Until, Valid: true}
+	return &series, nil
+}
+
+// hasOccurrencesBefore reports whether the series has any occurrence before the given one.
+func hasOccurrencesBefore(recurrence *domain.Recurrence, dtstart, occurrence time.Time) bool {
+	if dtstart.Before(occurrence) {
+		return true
+	}
+	for _, rdate := range recurrence.RDates {
+		if rdate.Before(occurrence) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSeriesShift rejects moves that cannot be expressed by moving the series start: rules that pin
+// the time of day, or that pin the day while the move changes it, have to be edited instead.
+func validateSeriesShift(option *rrule.ROption, occurrence time.Time, shift time.Duration, loc *time.Location) error {
+	if shift == 0 {
+		return nil
+	}
+	if len(option.Byhour) > 0 || len(option.Byminute) > 0 || len(option.Bysecond) > 0 {
+		return fmt.Errorf("%w: the recurrence rule fixes the time of day, edit the rule instead", domain.ErrInvalidOccurrence)
+	}
+
+	before := occurrence.In(loc)
+	after := domain.ShiftLocal(occurrence, shift, loc)
+	sameDay := before.Year() == after.Year() && before.YearDay() == after.YearDay()
+	pinsDay := len(option.Byweekday) > 0 || len(option.Bymonthday) > 0 || len(option.Byyearday) > 0 ||
+		len(option.Byweekno) > 0 || len(option.Bymonth) > 0 || len(option.Bysetpos) > 0
+	if !sameDay && pinsDay {
+		return fmt.Errorf("%w: the recurrence rule fixes the days of the series, edit the rule instead", domain.ErrInvalidOccurrence)
+	}
+	return nil
+}
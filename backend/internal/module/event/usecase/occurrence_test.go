@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %s: %v", name, err)
+	}
+	return loc
+}
+
+func seriesOccurrences(t *testing.T, event *domain.Event) []time.Time {
+	t.Helper()
+	recurrence, err := domain.ParseRecurrence(event.RecurrenceRule)
+	if err != nil {
+		t.Fatalf("parse recurrence: %v", err)
+	}
+	occurrences, err := recurrence.Occurrences(event, time.Time{}, event.StartTime.Time.AddDate(1, 0, 0), 1000)
+	if err != nil {
+		t.Fatalf("expand recurrence: %v", err)
+	}
+	return occurrences
+}
+
+func TestPlanSeriesSplit(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	at := func(month time.Month, day int) time.Time { return time.Date(2026, month, day, 10, 0, 0, 0, ny) }
+
+	tests := []struct {
+		name      string
+		rrule     string
+		start     time.Time
+		exDates   []time.Time
+		rDates    []time.Time
+		maxOcc    sql.NullInt32
+		from      time.Time
+		wantHead  int
+		wantTail  int
+		wantCount int   // COUNT of the continuation's rule, 0 if it has none
+		wantMax   int32 // Max occurrences of the continuation, 0 if unset
+	}{
+		{
+			name:      "count is divided",
+			rrule:     "FREQ=WEEKLY;COUNT=6",
+			start:     at(time.June, 1),
+			from:      at(time.June, 15),
+			wantHead:  2,
+			wantTail:  4,
+			wantCount: 4,
+		},
+		{
+			name:      "exception and extra dates go to their side",
+			rrule:     "FREQ=WEEKLY;COUNT=6",
+			start:     at(time.June, 1),
+			exDates:   []time.Time{at(time.June, 8).UTC(), at(time.June, 22).UTC()},
+			rDates:    []time.Time{at(time.June, 3).UTC(), at(time.June, 24).UTC()},
+			from:      at(time.June, 15),
+			wantHead:  2, // June 1 and 3
+			wantTail:  4, // June 15, 24, 29 and July 6
+			wantCount: 4,
+		},
+		{
+			name:     "until is kept by the continuation",
+			rrule:    "FREQ=DAILY;UNTIL=20260610T140000Z",
+			start:    at(time.June, 1),
+			from:     at(time.June, 4),
+			wantHead: 3,
+			wantTail: 7,
+		},
+		{
+			name:     "max occurrences are divided",
+			rrule:    "FREQ=DAILY",
+			start:    at(time.June, 1),
+			maxOcc:   sql.NullInt32{Int32: 5, Valid: true},
+			from:     at(time.June, 2),
+			wantHead: 1,
+			wantTail: 4,
+			wantMax:  4,
+		},
+		{
+			name:      "split after a daylight-saving change",
+			rrule:     "FREQ=WEEKLY;COUNT=5",
+			start:     at(time.March, 1),
+			from:      at(time.March, 15),
+			wantHead:  2,
+			wantTail:  3,
+			wantCount: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurrence := &domain.Recurrence{RRule: tt.rrule, ExDates: tt.exDates, RDates: tt.rDates}
+			rule, err := recurrence.Marshal()
+			if err != nil {
+				t.Fatalf("marshal recurrence: %v", err)
+			}
+			event := &domain.Event{
+				ID:             "00000000-0000-0000-0000-000000000001",
+				Name:           "Weekly meetup",
+				Timezone:       "America/New_York",
+				StartTime:      sql.NullTime{Time: tt.start, Valid: true},
+				EndTime:        sql.NullTime{Time: tt.start.Add(time.Hour), Valid: true},
+				IsRecurring:    true,
+				RecurrenceRule: rule,
+				MaxOccurrences: tt.maxOcc,
+			}
+			original := *event
+			want := seriesOccurrences(t, &original)
+
+			series, err := planSeriesSplit(event, recurrence, tt.from)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			head := seriesOccurrences(t, event)
+			tail := seriesOccurrences(t, series)
+
+			if len(head) != tt.wantHead || len(tail) != tt.wantTail {
+				t.Fatalf("got %d + %d occurrences (%v | %v), want %d + %d", len(head), len(tail), head, tail, tt.wantHead, tt.wantTail)
+			}
+			for _, occurrence := range head {
+				if !occurrence.Before(tt.from) {
+					t.Errorf("head occurrence %v is not before the split", occurrence.In(ny))
+				}
+			}
+			for _, occurrence := range tail {
+				if occurrence.Before(tt.from) {
+					t.Errorf("tail occurrence %v is before the split", occurrence.In(ny))
+				}
+			}
+			got := append(head, tail...)
+			if len(got) != len(want) {
+				t.Fatalf("split series have %d occurrences, the original %d", len(got), len(want))
+			}
+			for i := range want {
+				if !got[i].Equal(want[i]) {
+					t.Errorf("occurrence %d: got %v, want %v", i, got[i].In(ny), want[i].In(ny))
+				}
+			}
+
+			if series.ID == event.ID || series.Slug == "" {
+				t.Errorf("continuation needs its own ID and slug, got %q and %q", series.ID, series.Slug)
+			}
+			if !series.StartTime.Time.Equal(tt.from) || series.EndTime.Time.Sub(series.StartTime.Time) != time.Hour {
+				t.Errorf("continuation runs %v to %v, want to start at %v for an hour", series.StartTime.Time, series.EndTime.Time, tt.from)
+			}
+			if !event.RecurrenceEndDate.Valid || !event.RecurrenceEndDate.Time.Before(tt.from) {
+				t.Errorf("original series must end before the split, got %v", event.RecurrenceEndDate)
+			}
+			tailRecurrence, err := domain.ParseRecurrence(series.RecurrenceRule)
+			if err != nil {
+				t.Fatalf("parse continuation: %v", err)
+			}
+			tailOption, err := tailRecurrence.Option()
+			if err != nil {
+				t.Fatalf("parse continuation rule: %v", err)
+			}
+			if tailOption.Count != tt.wantCount {
+				t.Errorf("continuation COUNT: got %d, want %d", tailOption.Count, tt.wantCount)
+			}
+			if series.MaxOccurrences.Int32 != tt.wantMax {
+				t.Errorf("continuation max occurrences: got %d, want %d", series.MaxOccurrences.Int32, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestValidateSeriesShift(t *testing.T) {
+	ny := mustLocation(t, "America/New_York")
+	monday := time.Date(2026, time.June, 1, 10, 0, 0, 0, ny)
+
+	tests := []struct {
+		name    string
+		rrule   string
+		shift   time.Duration
+		wantErr bool
+	}{
+		{name: "no shift", rrule: "FREQ=WEEKLY;BYDAY=MO;BYHOUR=10", shift: 0},
+		{name: "later the same day", rrule: "FREQ=WEEKLY;BYDAY=MO", shift: 2 * time.Hour},
+		{name: "to another day without pinned days", rrule: "FREQ=WEEKLY", shift: 24 * time.Hour},
+		{name: "to another day with pinned weekdays", rrule: "FREQ=WEEKLY;BYDAY=MO", shift: 24 * time.Hour, wantErr: true},
+		{name: "to another day with pinned month days", rrule: "FREQ=MONTHLY;BYMONTHDAY=1", shift: 24 * time.Hour, wantErr: true},
+		{name: "pinned time of day", rrule: "FREQ=DAILY;BYHOUR=10", shift: time.Hour, wantErr: true},
+		{name: "past midnight with pinned weekdays", rrule: "FREQ=WEEKLY;BYDAY=MO", shift: 14 * time.Hour, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			option, err := (&domain.Recurrence{RRule: tt.rrule}).Option()
+			if err != nil {
+				t.Fatalf("parse rule: %v", err)
+			}
+			err = validateSeriesShift(option, monday, tt.shift, ny)
+			if tt.wantErr != errors.Is(err, domain.ErrInvalidOccurrence) || (!tt.wantErr && err != nil) {
+				t.Fatalf("got error %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHasOccurrencesBefore(t *testing.T) {
+	start := time.Date(2026, time.June, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		rDates     []time.Time
+		dtstart    time.Time
+		occurrence time.Time
+		want       bool
+	}{
+		{name: "first occurrence", dtstart: start, occurrence: start, want: false},
+		{name: "later occurrence", dtstart: start, occurrence: start.AddDate(0, 0, 7), want: true},
+		{name: "extra date before the first", rDates: []time.Time{start.AddDate(0, 0, -1)}, dtstart: start, occurrence: start, want: true},
+		{name: "extra date after", rDates: []time.Time{start.AddDate(0, 0, 3)}, dtstart: start, occurrence: start, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurrence := &domain.Recurrence{RRule: "FREQ=WEEKLY", RDates: tt.rDates}
+			if got := hasOccurrencesBefore(recurrence, tt.dtstart, tt.occurrence); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// CreateSessionPoll prepares a draft poll for a session. It opens when staff open it, or at OpensAt if set.
+func (s *Service) CreateSessionPoll(ctx context.Context, sessionID, userID string, poll *domain.SessionPoll) (*domain.SessionPoll, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionRunPolls); err != nil {
+		return nil, err
+	}
+	if err := s.checkSessionNotCancelled(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	if err := poll.Validate(); err != nil {
+		return nil, err
+	}
+	poll.SessionID = sessionID
+	poll.EventID = event.ID
+	poll.CreatedBy = sql.NullString{String: userID, Valid: true}
+	if err := s.repo.CreateSessionPoll(ctx, poll); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSessionPoll(ctx, poll.ID, userID)
+}
+
+// UpdateSessionPoll changes the question, options and timing of a poll that was not opened yet.
+func (s *Service) UpdateSessionPoll(ctx context.Context, pollID, userID string, poll *domain.SessionPoll) (*domain.SessionPoll, error) {
+	existing, err := s.authorizeSessionPoll(ctx, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Status != domain.PollStatusDraft {
+		return nil, domain.ErrPollNotDraft
+	}
+	if err := poll.Validate(); err != nil {
+		return nil, err
+	}
+	poll.ID = pollID
+	if err := s.repo.UpdateSessionPoll(ctx, poll); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSessionPoll(ctx, pollID, userID)
+}
+
+// DeleteSessionPoll removes a poll that was not opened yet. Polls that ran are kept for the session report.
+func (s *Service) DeleteSessionPoll(ctx context.Context, pollID, userID string) error {
+	if _, err := s.authorizeSessionPoll(ctx, pollID, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteSessionPoll(ctx, pollID)
+}
+
+// ListSessionPolls returns the polls of a session with their results. Drafts are only listed to the staff
+// who run polls.
+func (s *Service) ListSessionPolls(ctx context.Context, sessionID, userID string) ([]*domain.SessionPoll, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventParticipation(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	canRunPolls := true
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionRunPolls); err != nil {
+		if !errors.Is(err, permission_domain.ErrPermissionDenied) {
+			return nil, err
+		}
+		canRunPolls = false
+	}
+	return s.repo.ListSessionPolls(ctx, sessionID, userID, canRunPolls)
+}
+
+// OpenSessionPoll opens a draft poll now and streams it to the session's live clients.
+func (s *Service) OpenSessionPoll(ctx context.Context, pollID, userID string) (*domain.SessionPoll, error) {
+	poll, err := s.authorizeSessionPoll(ctx, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSessionNotCancelled(ctx, poll.SessionID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.OpenSessionPoll(ctx, pollID); err != nil {
+		return nil, err
+	}
+	opened, err := s.repo.GetSessionPoll(ctx, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishSessionPoll(domain.SessionLivePollOpened, opened)
+	return opened, nil
+}
+
+// CloseSessionPoll closes an open poll now and streams its final results to the session's live clients.
+func (s *Service) CloseSessionPoll(ctx context.Context, pollID, userID string) (*domain.SessionPoll, error) {
+	if _, err := s.authorizeSessionPoll(ctx, pollID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CloseSessionPoll(ctx, pollID); err != nil {
+		return nil, err
+	}
+	closed, err := s.repo.GetSessionPoll(ctx, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishSessionPoll(domain.SessionLivePollClosed, closed)
+	return closed, nil
+}
+
+// VoteSessionPoll records the vote of an attendee checked in to the session for an option of an open poll,
+// replacing their earlier vote, and streams the new results to the session's live clients.
+func (s *Service) VoteSessionPoll(ctx context.Context, pollID, optionID, userID string) (*domain.SessionPoll, error) {
+	poll, err := s.repo.GetSessionPoll(ctx, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.Status == domain.PollStatusDraft {
+		return nil, domain.ErrPollNotFound
+	}
+	checkedIn, err := s.repo.IsCheckedInToSession(ctx, poll.SessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !checkedIn {
+		return nil, domain.ErrNotCheckedIn
+	}
+	if !poll.IsAcceptingVotes() {
+		return nil, domain.ErrPollNotOpen
+	}
+	if !poll.HasOption(optionID) {
+		return nil, domain.ErrInvalidPollVote
+	}
+	if err := s.repo.VoteSessionPoll(ctx, pollID, optionID, userID); err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.GetSessionPoll(ctx, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishSessionPoll(domain.SessionLivePollResults, updated)
+	return updated, nil
+}
+
+// AdvanceSessionPolls opens the scheduled polls whose time has come and closes the timed polls whose time
+// has run out, streaming each change to the session's live clients.
+func (s *Service) AdvanceSessionPolls(ctx context.Context) error {
+	opened, err := s.repo.OpenScheduledSessionPolls(ctx)
+	if err != nil {
+		return err
+	}
+	s.publishSessionPollsByID(ctx, domain.SessionLivePollOpened, opened)
+
+	closed, err := s.repo.CloseExpiredSessionPolls(ctx)
+	if err != nil {
+		return err
+	}
+	s.publishSessionPollsByID(ctx, domain.SessionLivePollClosed, closed)
+	return nil
+}
+
+// authorizeSessionPoll returns a poll if the user can run the polls of its event.
+func (s *Service) authorizeSessionPoll(ctx context.Context, pollID, userID string) (*domain.SessionPoll, error) {
+	poll, err := s.repo.GetSessionPoll(ctx, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.repo.GetEventByID(ctx, poll.EventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionRunPolls); err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+// checkSessionNotCancelled fails if the session was cancelled, as its polls cannot run anymore.
+func (s *Service) checkSessionNotCancelled(ctx context.Context, sessionID string) error {
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.IsCancelled {
+		return fmt.Errorf("%w: the session is cancelled", domain.ErrInvalidPoll)
+	}
+	return nil
+}
+
+func (s *Service) publishSessionPollsByID(ctx context.Context, eventType string, pollIDs []string) {
+	for _, pollID := range pollIDs {
+		poll, err := s.repo.GetSessionPoll(ctx, pollID, "")
+		if err != nil {
+			log.Printf("Error getting poll %s to publish: %v", pollID, err)
+			continue
+		}
+		s.publishSessionPoll(eventType, poll)
+	}
+}
+
+// publishSessionPoll streams a poll change with the poll's current results to the live clients of its
+// session, attendees and dashboards alike. The poll is sent without the fields specific to the user who
+// made the change.
+func (s *Service) publishSessionPoll(eventType string, poll *domain.SessionPoll) {
+	if s.publisher == nil {
+		return
+	}
+	public := *poll
+	public.UserOptionID = sql.NullString{}
+	public.CreatedBy = sql.NullString{}
+	liveEvent := domain.SessionLiveEvent{Type: eventType, SessionID: poll.SessionID, PollID: poll.ID, Poll: &public}
+	payload, err := json.Marshal(liveEvent)
+	if err != nil {
+		log.Printf("Error marshalling session live event: %v", err)
+		return
+	}
+	if err := s.publisher.Publish(domain.SessionLiveSubject(poll.SessionID), payload); err != nil {
+		log.Printf("Error publishing session live event for session %s: %v", poll.SessionID, err)
+	}
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// GetPublicEventBySlug returns the public landing page of an event to anyone, signed in or not. Only events
+// of public communities that are not drafts or archived have one; others are reported as not found.
+func (s *Service) GetPublicEventBySlug(ctx context.Context, slug string) (*domain.PublicEvent, error) {
+	eventID, communityName, err := s.repo.GetEventIDBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.repo.GetEventByID(ctx, eventID, "")
+	if err != nil {
+		return nil, err
+	}
+	if event.CommunityType != "public" || !domain.IsPubliclyListed(event.Status) {
+		return nil, domain.ErrEventNotFound
+	}
+
+	speakers, err := s.repo.ListEventStaff(ctx, event.ID, permission_domain.EventRoleSpeaker)
+	if err != nil {
+		log.Printf("Error loading speakers of event %s: %v", event.ID, err)
+	} else {
+		event.Speakers = speakers
+	}
+	if event.VenueID.Valid {
+		venue, err := s.repo.GetVenue(ctx, event.VenueID.String)
+		if err != nil {
+			log.Printf("Error loading the venue of event %s: %v", event.ID, err)
+		} else {
+			event.Venue = venue
+		}
+	}
+	return domain.NewPublicEvent(event, communityName, time.Now()), nil
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// AuthorizeEventDiscussion returns an event if the user can take part in its discussion: its approved
+// registrants, its staff and the community's admins.
+func (s *Service) AuthorizeEventDiscussion(ctx context.Context, eventID, userID string) (*domain.Event, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventParticipation(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// AuthorizeSessionLive checks that the user can follow the live activity of a session.
+func (s *Service) AuthorizeSessionLive(ctx context.Context, sessionID, userID string) error {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	return s.authorizeEventParticipation(ctx, event, userID)
+}
+
+// SetSessionQAEnabled opens or closes the Q&A of a session. Questions asked stay visible once it is closed.
+func (s *Service) SetSessionQAEnabled(ctx context.Context, sessionID, userID string, enabled bool) error {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionModerateQA); err != nil {
+		return err
+	}
+	return s.repo.SetSessionQAEnabled(ctx, sessionID, enabled)
+}
+
+// GetSessionQA returns the Q&A of a session, sorted by votes or by recency. Moderators also see hidden
+// questions and the authors of anonymous ones.
+func (s *Service) GetSessionQA(ctx context.Context, sessionID, userID, sort string) (*domain.SessionQA, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventParticipation(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	canModerate, err := s.canModerateQA(ctx, event, userID)
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := s.repo.IsSessionQAEnabled(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.repo.ListSessionQuestions(ctx, sessionID, userID, sort, canModerate)
+	if err != nil {
+		return nil, err
+	}
+	if !canModerate {
+		for _, question := range questions {
+			if !question.IsOwn {
+				question.Anonymize()
+			}
+		}
+	}
+	return &domain.SessionQA{SessionID: sessionID, Enabled: enabled, CanModerate: canModerate, Questions: questions}, nil
+}
+
+// AskSessionQuestion adds a question to the Q&A of a session, which must be open, and streams it to the
+// session's live clients.
+func (s *Service) AskSessionQuestion(ctx context.Context, sessionID, userID string, question *domain.SessionQuestion) (*domain.SessionQuestion, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventParticipation(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkSessionQAOpen(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	if err := question.Validate(); err != nil {
+		return nil, err
+	}
+	question.SessionID = sessionID
+	question.EventID = event.ID
+	question.AuthorID = userID
+	if err := s.repo.CreateSessionQuestion(ctx, question); err != nil {
+		return nil, err
+	}
+	created, err := s.repo.GetSessionQuestion(ctx, question.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishSessionQuestion(domain.SessionLiveQuestionCreated, created)
+	return created, nil
+}
+
+// VoteSessionQuestion upvotes an open question on behalf of the user.
+func (s *Service) VoteSessionQuestion(ctx context.Context, questionID, userID string) (*domain.SessionQuestion, error) {
+	return s.voteSessionQuestion(ctx, questionID, userID, true)
+}
+
+// UnvoteSessionQuestion withdraws the user's upvote of an open question.
+func (s *Service) UnvoteSessionQuestion(ctx context.Context, questionID, userID string) (*domain.SessionQuestion, error) {
+	return s.voteSessionQuestion(ctx, questionID, userID, false)
+}
+
+func (s *Service) voteSessionQuestion(ctx context.Context, questionID, userID string, vote bool) (*domain.SessionQuestion, error) {
+	question, err := s.repo.GetSessionQuestion(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.repo.GetEventByID(ctx, question.EventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventParticipation(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if question.Status == domain.QuestionStatusHidden {
+		return nil, domain.ErrQuestionNotFound
+	}
+	if question.Status != domain.QuestionStatusOpen {
+		return nil, fmt.Errorf("%w: only open questions can be voted on", domain.ErrInvalidQuestion)
+	}
+	if err := s.checkSessionQAOpen(ctx, question.SessionID); err != nil {
+		return nil, err
+	}
+	if vote {
+		err = s.repo.VoteSessionQuestion(ctx, questionID, userID)
+	} else {
+		err = s.repo.UnvoteSessionQuestion(ctx, questionID, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.GetSessionQuestion(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishSessionQuestion(domain.SessionLiveQuestionVoted, updated)
+	if !updated.IsOwn {
+		updated.Anonymize()
+	}
+	return updated, nil
+}
+
+// ModerateSessionQuestion marks a question answered, hides it or reopens it, and streams the change to the
+// session's live clients.
+func (s *Service) ModerateSessionQuestion(ctx context.Context, questionID, userID, status string) (*domain.SessionQuestion, error) {
+	if !domain.IsValidQuestionStatus(status) {
+		return nil, fmt.Errorf("%w: status must be open, answered or hidden", domain.ErrInvalidQuestion)
+	}
+	question, err := s.repo.GetSessionQuestion(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.repo.GetEventByID(ctx, question.EventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionModerateQA); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateSessionQuestionStatus(ctx, questionID, status, userID); err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.GetSessionQuestion(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if status == domain.QuestionStatusHidden {
+		s.publishSessionQuestion(domain.SessionLiveQuestionHidden, updated)
+	} else {
+		s.publishSessionQuestion(domain.SessionLiveQuestionUpdated, updated)
+	}
+	return updated, nil
+}
+
+// checkSessionQAOpen fails unless the Q&A of the session is on and the session is not cancelled.
+func (s *Service) checkSessionQAOpen(ctx context.Context, sessionID string) error {
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	enabled, err := s.repo.IsSessionQAEnabled(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if !enabled || session.IsCancelled {
+		return domain.ErrSessionQAClosed
+	}
+	return nil
+}
+
+// canModerateQA reports whether the user can moderate the Q&A of the event's sessions.
+func (s *Service) canModerateQA(ctx context.Context, event *domain.Event, userID string) (bool, error) {
+	err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionModerateQA)
+	if errors.Is(err, permission_domain.ErrPermissionDenied) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// authorizeEventParticipation allows the event's approved registrants, its staff and the community's
+// admins to take part in the event's discussion and live activity.
+func (s *Service) authorizeEventParticipation(ctx context.Context, event *domain.Event, userID string) error {
+	role, err := s.permService.GetEventRole(ctx, event.ID, userID)
+	if err != nil {
+		return err
+	}
+	if role != "" {
+		return nil
+	}
+	isAdmin, err := s.permService.IsCommunityAdmin(ctx, event.CommunityID, userID)
+	if err != nil {
+		return err
+	}
+	if isAdmin {
+		return nil
+	}
+	attendee, err := s.repo.GetEventAttendee(ctx, event.ID, userID)
+	if err != nil && !errors.Is(err, domain.ErrAttendeeNotFound) {
+		return err
+	}
+	if attendee == nil || !isApprovedRegistrant(attendee) {
+		return domain.ErrNotEventParticipant
+	}
+	return nil
+}
+
+// publishSessionQuestion streams a question change to the live clients of its session. The question is
+// sent anonymized and without the fields specific to the user who made the change; hidden questions are
+// only referred to by their ID.
+func (s *Service) publishSessionQuestion(eventType string, question *domain.SessionQuestion) {
+	if s.publisher == nil {
+		return
+	}
+	liveEvent := domain.SessionLiveEvent{Type: eventType, SessionID: question.SessionID, QuestionID: question.ID}
+	if eventType != domain.SessionLiveQuestionHidden {
+		public := *question
+		public.UserHasVoted, public.IsOwn = false, false
+		public.ModeratedBy = sql.NullString{}
+		public.Anonymize()
+		liveEvent.Question = &public
+	}
+	payload, err := json.Marshal(liveEvent)
+	if err != nil {
+		log.Printf("Error marshalling session live event: %v", err)
+		return
+	}
+	if err := s.publisher.Publish(domain.SessionLiveSubject(question.SessionID), payload); err != nil {
+		log.Printf("Error publishing session live event for session %s: %v", question.SessionID, err)
+	}
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// RescheduleEventSession moves a session to another time or place. Registrations are kept, while tickets
+// already issued for the session are revoked so that attendees get new ones for the new time. Attendees are
+// told about the change and, if input.RequireReconfirmation is set, those holding a seat are asked to
+// confirm they still attend.
+func (s *Service) RescheduleEventSession(ctx context.Context, sessionID, userID string, input *domain.SessionRescheduleInput) (*domain.SessionReschedule, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsCancelled {
+		return nil, fmt.Errorf("%w: cancelled sessions cannot be rescheduled", domain.ErrInvalidReschedule)
+	}
+	if session.EndTime.Before(time.Now()) {
+		return nil, fmt.Errorf("%w: sessions that already ended cannot be rescheduled", domain.ErrInvalidReschedule)
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	reschedule := &domain.SessionReschedule{
+		SessionID:                session.ID,
+		EventID:                  event.ID,
+		PreviousStartTime:        session.StartTime,
+		PreviousEndTime:          session.EndTime,
+		PreviousLocation:         session.LocationOverride,
+		PreviousOnlineMeetingURL: session.OnlineMeetingURLOverride,
+		NewStartTime:             session.StartTime,
+		NewEndTime:               session.EndTime,
+		NewLocation:              session.LocationOverride,
+		NewOnlineMeetingURL:      session.OnlineMeetingURLOverride,
+		Reason:                   sql.NullString{String: reason, Valid: reason != ""},
+		RequiresReconfirmation:   input.RequireReconfirmation,
+		RescheduledBy:            sql.NullString{String: userID, Valid: true},
+	}
+	if input.StartTime.Valid {
+		reschedule.NewStartTime = input.StartTime.Time
+		if !input.EndTime.Valid {
+			reschedule.NewEndTime = input.StartTime.Time.Add(session.EndTime.Sub(session.StartTime))
+		}
+	}
+	if input.EndTime.Valid {
+		reschedule.NewEndTime = input.EndTime.Time
+	}
+	if input.LocationOverride.Valid {
+		reschedule.NewLocation = input.LocationOverride
+	}
+	if input.OnlineMeetingURLOverride.Valid {
+		reschedule.NewOnlineMeetingURL = input.OnlineMeetingURLOverride
+	}
+	if !reschedule.NewEndTime.After(reschedule.NewStartTime) {
+		return nil, fmt.Errorf("%w: end time must be after start time", domain.ErrInvalidReschedule)
+	}
+	if reschedule.NewStartTime.Equal(reschedule.PreviousStartTime) && reschedule.NewEndTime.Equal(reschedule.PreviousEndTime) &&
+		reschedule.NewLocation == reschedule.PreviousLocation && reschedule.NewOnlineMeetingURL == reschedule.PreviousOnlineMeetingURL {
+		return nil, fmt.Errorf("%w: the new time or place is required", domain.ErrInvalidReschedule)
+	}
+
+	moved := *session
+	moved.StartTime, moved.EndTime = reschedule.NewStartTime, reschedule.NewEndTime
+	conflicts, err := s.checkScheduleConflicts(ctx, event, event.CreatedBy, []domain.EventSession{moved})
+	if err != nil {
+		return nil, err
+	}
+	reschedule.ScheduleConflicts = conflicts
+
+	attendees, err := s.repo.ListSessionAttendees(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	var userIDs, reconfirmUserIDs []string
+	for _, attendee := range attendees {
+		if attendee.UserID == userID {
+			continue
+		}
+		userIDs = append(userIDs, attendee.UserID)
+		if input.RequireReconfirmation && attendee.HoldsSeat {
+			reconfirmUserIDs = append(reconfirmUserIDs, attendee.UserID)
+		}
+	}
+
+	if !session.OriginalStartTime.Valid {
+		session.OriginalStartTime = sql.NullTime{Time: session.StartTime, Valid: true}
+	}
+	session.StartTime = reschedule.NewStartTime
+	session.EndTime = reschedule.NewEndTime
+	session.LocationOverride = reschedule.NewLocation
+	session.OnlineMeetingURLOverride = reschedule.NewOnlineMeetingURL
+	session.IsOverride = event.IsRecurring
+	if err := s.repo.RescheduleSession(ctx, session, reschedule, reconfirmUserIDs); err != nil {
+		return nil, err
+	}
+	s.shiftAgendaItems(ctx, sessionID, reschedule.PreviousStartTime, reschedule.NewStartTime)
+	s.syncReminders(ctx, event.ID)
+
+	s.repo.InvalidateEventCache(ctx, event.ID, userID)
+	s.repo.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+
+	if s.publisher != nil {
+		payload, err := json.Marshal(domain.SessionRescheduledEvent{
+			RescheduleID:      reschedule.ID,
+			SessionID:         session.ID,
+			EventID:           event.ID,
+			EventName:         event.Name,
+			SessionName:       session.Name.String,
+			Timezone:          event.Timezone,
+			PreviousStartTime: reschedule.PreviousStartTime,
+			PreviousLocation:  sessionLocation(event, reschedule.PreviousLocation, reschedule.PreviousOnlineMeetingURL),
+			NewStartTime:      reschedule.NewStartTime,
+			NewLocation:       sessionLocation(event, reschedule.NewLocation, reschedule.NewOnlineMeetingURL),
+			MeetingURLChanged: meetingURLChanged(event, reschedule),
+			Reason:            reschedule.Reason.String,
+			RescheduledBy:     userID,
+			UserIDs:           userIDs,
+			ReconfirmUserIDs:  reconfirmUserIDs,
+		})
+		if err != nil {
+			log.Printf("Error marshalling session rescheduled event: %v", err)
+		} else if err := s.publisher.Publish(domain.SessionRescheduledSubject, payload); err != nil {
+			log.Printf("Error publishing session rescheduled message: %v", err)
+		}
+	}
+
+	return reschedule, nil
+}
+
+// ListSessionReschedules returns the changes of a session with how attendees answered the re-confirmation
+// requests, to the staff who can view its attendees.
+func (s *Service) ListSessionReschedules(ctx context.Context, sessionID, userID string) ([]*domain.SessionReschedule, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionViewAttendees); err != nil {
+		return nil, err
+	}
+	return s.repo.ListSessionReschedules(ctx, sessionID)
+}
+
+// ListMyReconfirmations lists the user's re-confirmation requests, optionally only those with a status.
+func (s *Service) ListMyReconfirmations(ctx context.Context, userID, status string) ([]*domain.SessionReconfirmation, error) {
+	switch status {
+	case "", domain.ReconfirmationPending, domain.ReconfirmationConfirmed, domain.ReconfirmationDeclined:
+	default:
+		return nil, domain.ErrInvalidReconfirmation
+	}
+	return s.repo.ListUserReconfirmations(ctx, userID, status)
+}
+
+// AnswerReconfirmation records whether the user still attends a rescheduled session. Declining releases the
+// user's seat: their registration for the session on events with per-session registration, and their
+// registration for the event otherwise.
+func (s *Service) AnswerReconfirmation(ctx context.Context, reconfirmationID, userID, status string) (*domain.SessionReconfirmation, error) {
+	if status != domain.ReconfirmationConfirmed && status != domain.ReconfirmationDeclined {
+		return nil, domain.ErrInvalidReconfirmation
+	}
+	reconfirmation, err := s.repo.GetUserReconfirmation(ctx, reconfirmationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if reconfirmation.Status != domain.ReconfirmationPending {
+		return nil, domain.ErrReconfirmationAlreadyAnswered
+	}
+
+	if status == domain.ReconfirmationDeclined {
+		if err := s.releaseSessionSeat(ctx, reconfirmation, userID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.AnswerReconfirmation(ctx, reconfirmationID, status); err != nil {
+		return nil, err
+	}
+	return s.repo.GetUserReconfirmation(ctx, reconfirmationID, userID)
+}
+
+// releaseSessionSeat gives up the seat of a user who declined to attend a rescheduled session.
+func (s *Service) releaseSessionSeat(ctx context.Context, reconfirmation *domain.SessionReconfirmation, userID string) error {
+	event, err := s.repo.GetEventBySessionID(ctx, reconfirmation.SessionID)
+	if err != nil {
+		return err
+	}
+	if event.PerSessionRegistration {
+		return s.DropSessionRegistration(ctx, reconfirmation.SessionID, userID)
+	}
+
+	attendee, err := s.repo.GetEventAttendee(ctx, event.ID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAttendeeNotFound) {
+			return nil
+		}
+		return err
+	}
+	if attendee.Role == "host" || attendee.Status == "cancelled" {
+		return nil
+	}
+	return s.CancelRegistration(ctx, attendee.ID, userID)
+}
+
+// sessionLocation describes where a session takes place: its own location, or the event's, followed by
+// "online" for online sessions. The meeting URL itself is left out, as the notified attendees include pending
+// and waitlisted registrants who may not see it.
+func sessionLocation(event *domain.Event, location, meetingURL sql.NullString) string {
+	if !location.Valid {
+		location = event.LocationAddress
+	}
+	if !meetingURL.Valid {
+		meetingURL = event.OnlineMeetingURL
+	}
+	var parts []string
+	if location.Valid && location.String != "" {
+		parts = append(parts, location.String)
+	}
+	if meetingURL.Valid && meetingURL.String != "" {
+		parts = append(parts, "online")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// meetingURLChanged reports whether the reschedule gives the session another meeting URL.
+func meetingURLChanged(event *domain.Event, reschedule *domain.SessionReschedule) bool {
+	previous, next := reschedule.PreviousOnlineMeetingURL, reschedule.NewOnlineMeetingURL
+	if !previous.Valid {
+		previous = event.OnlineMeetingURL
+	}
+	if !next.Valid {
+		next = event.OnlineMeetingURL
+	}
+	return next.String != "" && previous.String != next.String
+}
@@ -0,0 +1,391 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// seatKey identifies a seat of a layout.
+type seatKey struct {
+	unitID string
+	number int
+}
+
+// SaveSeatingLayout sets the seating layout of a session, replacing its current one as long as no seat of
+// it is assigned.
+func (s *Service) SaveSeatingLayout(ctx context.Context, sessionID, userID string, layout *domain.SeatingLayout) (*domain.SeatingLayout, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkSeatingLayoutUnused(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	layout.SessionID = sessionID
+	layout.EventID = event.ID
+	layout.CreatedBy = sql.NullString{String: userID, Valid: true}
+	if err := s.repo.SaveSeatingLayout(ctx, layout); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSeatingLayout(ctx, sessionID)
+}
+
+// GetSeatingLayout returns the seating layout of a session to its registrants and staff.
+func (s *Service) GetSeatingLayout(ctx context.Context, sessionID, userID string) (*domain.SeatingLayout, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventParticipation(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSeatingLayout(ctx, sessionID)
+}
+
+// DeleteSeatingLayout removes the seating layout of a session, as long as no seat of it is assigned.
+func (s *Service) DeleteSeatingLayout(ctx context.Context, sessionID, userID string) error {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return err
+	}
+	if err := s.checkSeatingLayoutUnused(ctx, sessionID); err != nil {
+		return err
+	}
+	return s.repo.DeleteSeatingLayout(ctx, sessionID)
+}
+
+// ListSeatAssignments lists the seats assigned for a session to the staff who can view its attendees.
+func (s *Service) ListSeatAssignments(ctx context.Context, sessionID, userID string) ([]*domain.SeatAssignment, error) {
+	if _, err := s.authorizeSessionAttendees(ctx, sessionID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListSeatAssignments(ctx, sessionID)
+}
+
+// AutoAssignSeats gives a seat to every registrant of the session who has none, in registration order and
+// in layout order. Seats of users who are no longer registered are freed first. Registrants left over
+// when the layout is full are reported as unseated.
+func (s *Service) AutoAssignSeats(ctx context.Context, sessionID, userID string) (*domain.SeatAssignmentResult, error) {
+	event, layout, err := s.authorizeSeating(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	registrants, err := s.repo.ListSeatableRegistrants(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.repo.ListSeatAssignments(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	registered := make(map[string]*domain.SeatBadge, len(registrants))
+	for _, registrant := range registrants {
+		registered[registrant.UserID] = registrant
+	}
+	seated := make(map[string]bool, len(assignments))
+	occupied := make(map[seatKey]bool, len(assignments))
+	var stale []string
+	for _, assignment := range assignments {
+		if registered[assignment.UserID] == nil {
+			stale = append(stale, assignment.UserID)
+			continue
+		}
+		seated[assignment.UserID] = true
+		occupied[seatKey{assignment.UnitID, assignment.SeatNumber}] = true
+	}
+
+	result := &domain.SeatAssignmentResult{Assigned: []*domain.SeatAssignment{}}
+	if len(stale) > 0 {
+		if result.Released, err = s.repo.DeleteSeatAssignments(ctx, sessionID, stale, domain.SeatAssignmentAuto, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var pending []*domain.SeatBadge
+	for _, registrant := range registrants {
+		if !seated[registrant.UserID] {
+			pending = append(pending, registrant)
+		}
+	}
+	for _, section := range layout.Sections {
+		for _, unit := range section.Units {
+			for number := 1; number <= unit.Capacity && len(pending) > 0; number++ {
+				if occupied[seatKey{unit.ID, number}] {
+					continue
+				}
+				result.Assigned = append(result.Assigned, newSeatAssignment(event, sessionID, pending[0], section, unit, number, domain.SeatAssignmentAuto))
+				pending = pending[1:]
+			}
+		}
+	}
+	for _, registrant := range pending {
+		result.Unseated = append(result.Unseated, registrant.UserID)
+	}
+
+	if err := s.repo.SaveSeatAssignments(ctx, result.Assigned, userID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AssignSeatGroup seats a group of registrants together: at the same table, or on consecutive seats of a
+// row. The first row or table of the layout, or of the given section, with enough free seats is used;
+// members who already had a seat are moved.
+func (s *Service) AssignSeatGroup(ctx context.Context, sessionID, userID string, memberIDs []string, sectionID string) (*domain.SeatAssignmentResult, error) {
+	if len(memberIDs) == 0 || len(memberIDs) > domain.MaxSeatGroupSize {
+		return nil, fmt.Errorf("%w: a group has between 1 and %d members", domain.ErrInvalidSeatGroup, domain.MaxSeatGroupSize)
+	}
+	event, layout, err := s.authorizeSeating(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.seatableRegistrants(ctx, sessionID, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.repo.ListSeatAssignments(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	inGroup := make(map[string]bool, len(members))
+	for _, member := range members {
+		inGroup[member.UserID] = true
+	}
+	// Seats of the members count as free, as the members leave them.
+	occupied := make(map[seatKey]bool, len(assignments))
+	for _, assignment := range assignments {
+		if !inGroup[assignment.UserID] {
+			occupied[seatKey{assignment.UnitID, assignment.SeatNumber}] = true
+		}
+	}
+
+	for _, section := range layout.Sections {
+		if sectionID != "" && section.ID != sectionID {
+			continue
+		}
+		for _, unit := range section.Units {
+			numbers := findGroupSeats(unit, occupied, len(members))
+			if numbers == nil {
+				continue
+			}
+			result := &domain.SeatAssignmentResult{Assigned: make([]*domain.SeatAssignment, 0, len(members))}
+			for i, member := range members {
+				result.Assigned = append(result.Assigned, newSeatAssignment(event, sessionID, member, section, unit, numbers[i], domain.SeatAssignmentGroup))
+			}
+			if err := s.repo.SaveSeatAssignments(ctx, result.Assigned, userID); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+	}
+	if sectionID != "" {
+		if findSection(layout, sectionID) == nil {
+			return nil, fmt.Errorf("%w: the layout has no such section", domain.ErrInvalidSeatGroup)
+		}
+	}
+	return nil, fmt.Errorf("%w: no row or table has %d free seats together", domain.ErrNotEnoughSeats, len(members))
+}
+
+// AssignSeat gives a registrant a specific seat, moving them if they already had one.
+func (s *Service) AssignSeat(ctx context.Context, sessionID, userID, attendeeID, unitID string, seatNumber int) (*domain.SeatAssignment, error) {
+	event, layout, err := s.authorizeSeating(ctx, sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	section, unit := layout.FindUnit(unitID)
+	if unit == nil || seatNumber < 1 || seatNumber > unit.Capacity {
+		return nil, domain.ErrSeatNotFound
+	}
+	members, err := s.seatableRegistrants(ctx, sessionID, []string{attendeeID})
+	if err != nil {
+		return nil, err
+	}
+	assignment := newSeatAssignment(event, sessionID, members[0], section, unit, seatNumber, domain.SeatAssignmentManual)
+	if err := s.repo.SaveSeatAssignments(ctx, []*domain.SeatAssignment{assignment}, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetSeatAssignment(ctx, sessionID, attendeeID)
+}
+
+// UnassignSeat frees the seat of a user for a session.
+func (s *Service) UnassignSeat(ctx context.Context, sessionID, userID, attendeeID string) error {
+	if _, _, err := s.authorizeSeating(ctx, sessionID, userID); err != nil {
+		return err
+	}
+	released, err := s.repo.DeleteSeatAssignments(ctx, sessionID, []string{attendeeID}, domain.SeatAssignmentManual, userID)
+	if err != nil {
+		return err
+	}
+	if released == 0 {
+		return domain.ErrSeatAssignmentNotFound
+	}
+	return nil
+}
+
+// ClearSeatAssignments frees every seat of a session and returns how many were freed.
+func (s *Service) ClearSeatAssignments(ctx context.Context, sessionID, userID string) (int, error) {
+	if _, _, err := s.authorizeSeating(ctx, sessionID, userID); err != nil {
+		return 0, err
+	}
+	return s.repo.DeleteSeatAssignments(ctx, sessionID, nil, domain.SeatAssignmentManual, userID)
+}
+
+// ListSeatAssignmentAudit lists the seat changes of a session, newest first, to the staff who can view its
+// attendees.
+func (s *Service) ListSeatAssignmentAudit(ctx context.Context, sessionID, userID string, limit, offset int) ([]*domain.SeatAssignmentAudit, int, error) {
+	if _, err := s.authorizeSessionAttendees(ctx, sessionID, userID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.ListSeatAssignmentAudit(ctx, sessionID, limit, offset)
+}
+
+// ListSessionBadges returns what to print on the badges of the registrants of a session, with their seats.
+func (s *Service) ListSessionBadges(ctx context.Context, sessionID, userID string) ([]*domain.SeatBadge, error) {
+	if _, err := s.authorizeSessionAttendees(ctx, sessionID, userID); err != nil {
+		return nil, err
+	}
+	badges, err := s.repo.ListSeatableRegistrants(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.repo.ListSeatAssignments(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	seats := make(map[string]*domain.SeatAssignment, len(assignments))
+	for _, assignment := range assignments {
+		seats[assignment.UserID] = assignment
+	}
+	for _, badge := range badges {
+		badge.Seat = seats[badge.UserID]
+	}
+	return badges, nil
+}
+
+// authorizeSeating returns the event and the seating layout of a session if the user can manage the
+// session's seating.
+func (s *Service) authorizeSeating(ctx context.Context, sessionID, userID string) (*domain.Event, *domain.SeatingLayout, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return nil, nil, err
+	}
+	layout, err := s.repo.GetSeatingLayout(ctx, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return event, layout, nil
+}
+
+// authorizeSessionAttendees returns the event of a session if the user can view its attendees.
+func (s *Service) authorizeSessionAttendees(ctx context.Context, sessionID, userID string) (*domain.Event, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionViewAttendees); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// checkSeatingLayoutUnused fails if seats of the session's layout are assigned.
+func (s *Service) checkSeatingLayoutUnused(ctx context.Context, sessionID string) error {
+	current, err := s.repo.GetSeatingLayout(ctx, sessionID)
+	if errors.Is(err, domain.ErrSeatingLayoutNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if current.AssignedCount > 0 {
+		return domain.ErrSeatingLayoutInUse
+	}
+	return nil
+}
+
+// seatableRegistrants returns the registrants of the session with the given user IDs, in that order, and
+// fails if one of them is not a registrant or appears twice.
+func (s *Service) seatableRegistrants(ctx context.Context, sessionID string, userIDs []string) ([]*domain.SeatBadge, error) {
+	registrants, err := s.repo.ListSeatableRegistrants(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.SeatBadge, len(registrants))
+	for _, registrant := range registrants {
+		byID[registrant.UserID] = registrant
+	}
+	members := make([]*domain.SeatBadge, 0, len(userIDs))
+	seen := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: user %s appears twice", domain.ErrInvalidSeatGroup, id)
+		}
+		seen[id] = true
+		registrant, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: user %s", domain.ErrNotSeatable, id)
+		}
+		members = append(members, registrant)
+	}
+	return members, nil
+}
+
+// findGroupSeats returns the numbers of size free seats of a unit that a group can take together: any
+// free seats of a table, or consecutive free seats of a row. It returns nil if the unit has no room.
+func findGroupSeats(unit *domain.SeatingUnit, occupied map[seatKey]bool, size int) []int {
+	var numbers []int
+	for number := 1; number <= unit.Capacity; number++ {
+		if occupied[seatKey{unit.ID, number}] {
+			if unit.Kind == domain.SeatingUnitRow {
+				numbers = numbers[:0]
+			}
+			continue
+		}
+		numbers = append(numbers, number)
+		if len(numbers) == size {
+			return numbers
+		}
+	}
+	return nil
+}
+
+func findSection(layout *domain.SeatingLayout, sectionID string) *domain.SeatingSection {
+	for _, section := range layout.Sections {
+		if section.ID == sectionID {
+			return section
+		}
+	}
+	return nil
+}
+
+func newSeatAssignment(event *domain.Event, sessionID string, registrant *domain.SeatBadge, section *domain.SeatingSection, unit *domain.SeatingUnit, seatNumber int, method string) *domain.SeatAssignment {
+	assignment := &domain.SeatAssignment{
+		SessionID: sessionID,
+		EventID:   event.ID,
+		UserID:    registrant.UserID,
+		UserName:  registrant.UserName,
+		UserEmail: registrant.UserEmail,
+		Method:    method,
+	}
+	assignment.SetSeat(section, unit, seatNumber)
+	return assignment
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+func TestFindGroupSeats(t *testing.T) {
+	row := &domain.SeatingUnit{ID: "row", Kind: domain.SeatingUnitRow, Capacity: 6}
+	table := &domain.SeatingUnit{ID: "table", Kind: domain.SeatingUnitTable, Capacity: 6}
+
+	tests := []struct {
+		name     string
+		unit     *domain.SeatingUnit
+		occupied []int
+		size     int
+		want     []int
+	}{
+		{name: "empty row", unit: row, size: 3, want: []int{1, 2, 3}},
+		{name: "row fills from the first gap that fits", unit: row, occupied: []int{2, 5}, size: 2, want: []int{3, 4}},
+		{name: "row without consecutive seats", unit: row, occupied: []int{2, 4, 6}, size: 2, want: nil},
+		{name: "seats at the end of the row", unit: row, occupied: []int{1, 2, 3}, size: 3, want: []int{4, 5, 6}},
+		{name: "whole row", unit: row, size: 6, want: []int{1, 2, 3, 4, 5, 6}},
+		{name: "group larger than the row", unit: row, size: 7, want: nil},
+		{name: "table seats need not be consecutive", unit: table, occupied: []int{2, 4, 6}, size: 3, want: []int{1, 3, 5}},
+		{name: "full table", unit: table, occupied: []int{1, 2, 3, 4, 5}, size: 2, want: nil},
+		{name: "last seat at a table", unit: table, occupied: []int{1, 2, 3, 4, 5}, size: 1, want: []int{6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			occupied := map[seatKey]bool{{"elsewhere", 1}: true}
+			for _, number := range tt.occupied {
+				occupied[seatKey{tt.unit.ID, number}] = true
+			}
+			if got := findGroupSeats(tt.unit, occupied, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got seats %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
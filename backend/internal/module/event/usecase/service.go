@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"time"
 
 	community_domain "github.com/attendwise/backend/internal/module/community/domain"
@@ -15,31 +16,195 @@ import (
 	"github.com/attendwise/backend/internal/platform/pubsub"
 	"github.com/google/uuid"
 	"github.com/gosimple/slug"
-	"github.com/teambition/rrule-go"
 )
 
 // EventService defines the interface for event-related business logic.
 type EventService interface {
 	CreateEvent(ctx context.Context, event *domain.Event, hostID string, whitelistUserIDs []string) (*domain.Event, error)
-	RegisterForEvent(ctx context.Context, eventID, userID string, formData json.RawMessage) error
+	RegisterForEvent(ctx context.Context, eventID, userID string, formData json.RawMessage, strict bool) ([]*domain.ScheduleConflict, error)
 	GetEvent(ctx context.Context, id string, userID string) (*domain.Event, error)
 	ListEventItemsByCommunity(ctx context.Context, communityID string, userID string, statusFilter string, page, limit int) ([]*domain.EventItem, error)
 	ListMyAccessibleEventItems(ctx context.Context, userID string, statusFilter string, page, limit int) ([]*domain.EventItem, error)
 	AddUsersToWhitelist(ctx context.Context, eventID string, userIDs []string, performingUserID string) error
 	IsUserInWhitelist(ctx context.Context, eventID, userID string) (bool, error)
-	GetEventAttendanceSummary(ctx context.Context, eventID string) (*domain.AttendanceSummary, error)
+	GetEventAttendanceSummary(ctx context.Context, eventID, userID string) (*domain.AttendanceSummary, error)
 	GetEventAttendees(ctx context.Context, eventID, sessionID, status, userID string) ([]*domain.EventAttendee, error)
 	ListPendingRegistrations(ctx context.Context, eventID, userID string) ([]*domain.EventAttendee, error)
 	ApproveRegistration(ctx context.Context, eventID, registrationID, userID string) error
+	RejectRegistration(ctx context.Context, eventID, registrationID, userID, reason string) error
+	DecideRegistrations(ctx context.Context, eventID, userID string, input *domain.RegistrationDecisionInput) (*domain.RegistrationDecisionResult, error)
+	CreateRegistrationApprovalRule(ctx context.Context, eventID, userID string, rule *domain.RegistrationApprovalRule) (*domain.RegistrationApprovalRule, int, error)
+	ListRegistrationApprovalRules(ctx context.Context, eventID, userID string) ([]*domain.RegistrationApprovalRule, error)
+	DeleteRegistrationApprovalRule(ctx context.Context, ruleID, userID string) error
 	CancelRegistration(ctx context.Context, registrationID, userID string) error
 	ListMyRegistrations(ctx context.Context, userID string, status string) ([]*domain.RegistrationWithEvent, error)
-	GetEventSessions(ctx context.Context, eventID string) ([]domain.EventSession, error)
-	GetEventSessionByID(ctx context.Context, sessionID string) (*domain.EventSession, error)
+	GetMyAgenda(ctx context.Context, userID string, from, to time.Time) ([]*domain.AgendaItem, error)
+	GetEventSessions(ctx context.Context, eventID, userID string) ([]domain.EventSession, error)
+	GetEventSessionByID(ctx context.Context, sessionID, userID string) (*domain.EventSession, error)
 	UpdateEvent(ctx context.Context, event *domain.Event, fieldMask []string, userID string) (*domain.Event, error)
-	GetUpcomingSessionsWithAttendees(ctx context.Context, from, to time.Time) ([]*domain.UpcomingSessionInfo, error)
 	DeleteEvent(ctx context.Context, eventID string, userID string) error
 	HardDeleteEvent(ctx context.Context, eventID string, userID string) error
 	CancelEventSession(ctx context.Context, sessionID string, userID string, reason string) error
+
+	// Per-session registration
+	RegisterForSession(ctx context.Context, sessionID, userID string) (*domain.SessionRegistration, error)
+	DropSessionRegistration(ctx context.Context, sessionID, userID string) error
+	ListMySessionRegistrations(ctx context.Context, eventID, userID string) ([]*domain.SessionRegistration, error)
+	ListSessionRegistrations(ctx context.Context, sessionID, status, userID string) ([]*domain.SessionRegistration, error)
+
+	// Event staff
+	ListEventStaff(ctx context.Context, eventID, userID, role string) ([]*domain.EventStaffMember, error)
+	AssignEventStaff(ctx context.Context, eventID, staffUserID, role, performingUserID string) (*domain.EventStaffMember, error)
+	RemoveEventStaff(ctx context.Context, eventID, staffUserID, performingUserID string) error
+
+	// Calendar export
+	ExportEventICal(ctx context.Context, eventID, userID string) ([]byte, error)
+	GetCalendarFeedToken(ctx context.Context, userID string) (string, error)
+	RotateCalendarFeedToken(ctx context.Context, userID string) (string, error)
+	GetUserCalendarFeed(ctx context.Context, token string) ([]byte, error)
+	GetCommunityCalendarFeed(ctx context.Context, communityID string) ([]byte, error)
+
+	// Recurrence exceptions
+	UpdateEventOccurrence(ctx context.Context, sessionID, userID string, edit *domain.OccurrenceEdit) (*domain.Event, error)
+	AddEventOccurrence(ctx context.Context, eventID, userID string, startTime, endTime time.Time) (*domain.EventSession, error)
+	PreviewEventOccurrences(ctx context.Context, event *domain.Event, count int) ([]domain.OccurrencePreview, error)
+
+	// Calendar import
+	ImportEventsFromICal(ctx context.Context, communityID, hostID string, data []byte, confirm bool) (*domain.ICalImportResult, error)
+
+	// Event lifecycle
+	ChangeEventStatus(ctx context.Context, eventID, status, reason, userID string) (*domain.Event, error)
+	GetEventStatusHistory(ctx context.Context, eventID, userID string) ([]*domain.EventStatusTransition, error)
+	AdvanceEventLifecycles(ctx context.Context) error
+
+	// Feedback surveys
+	CreateFeedbackSurvey(ctx context.Context, eventID, userID string, survey *domain.FeedbackSurvey) (*domain.FeedbackSurvey, error)
+	UpdateFeedbackSurvey(ctx context.Context, survey *domain.FeedbackSurvey, userID string) (*domain.FeedbackSurvey, error)
+	DeleteFeedbackSurvey(ctx context.Context, surveyID, userID string) error
+	GetFeedbackSurvey(ctx context.Context, surveyID, userID string) (*domain.FeedbackSurvey, error)
+	ListFeedbackSurveys(ctx context.Context, eventID, userID string) ([]*domain.FeedbackSurvey, error)
+	SubmitFeedback(ctx context.Context, surveyID, sessionID, userID string, answers []domain.FeedbackAnswer) (*domain.FeedbackResponse, error)
+	ListPendingFeedback(ctx context.Context, userID string) ([]*domain.FeedbackRequest, error)
+
+	// Certificates
+	SaveCertificateTemplate(ctx context.Context, eventID, userID string, template *domain.CertificateTemplate) (*domain.CertificateTemplate, error)
+	GetCertificateTemplate(ctx context.Context, eventID, userID string) (*domain.CertificateTemplate, error)
+	DeleteCertificateTemplate(ctx context.Context, eventID, userID string) error
+	IssueEventCertificates(ctx context.Context, eventID, userID string) ([]*domain.Certificate, error)
+	ListEventCertificates(ctx context.Context, eventID, userID string) ([]*domain.Certificate, error)
+	ListMyCertificates(ctx context.Context, userID string) ([]*domain.Certificate, error)
+	GetCertificatePDF(ctx context.Context, certificateID, userID, verificationBaseURL string) ([]byte, *domain.Certificate, error)
+	RevokeCertificate(ctx context.Context, certificateID, userID, reason string) error
+	VerifyCertificate(ctx context.Context, code string) (*domain.CertificateVerification, error)
+
+	// Whitelist
+	ListWhitelistEntries(ctx context.Context, eventID, userID string) ([]*domain.WhitelistEntry, error)
+	AddWhitelistContacts(ctx context.Context, eventID, userID string, rows []*domain.WhitelistImportRow, confirm bool) (*domain.WhitelistImportResult, error)
+	ImportWhitelistCSV(ctx context.Context, eventID, userID string, data []byte, confirm bool) (*domain.WhitelistImportResult, error)
+	RemoveWhitelistEntry(ctx context.Context, eventID, entryID, userID string) error
+	ConfirmWhitelistLink(ctx context.Context, eventID, entryID, accountID, userID string) error
+
+	// Event invitations
+	InviteToEvent(ctx context.Context, eventID, userID string, inviteeUserIDs, emails []string, message string) ([]*domain.EventInvitation, error)
+	GetInvitationDashboard(ctx context.Context, eventID, userID, rsvp string) (*domain.InvitationDashboard, error)
+	RemindPendingInvitations(ctx context.Context, eventID, userID string) (int, error)
+	RevokeInvitation(ctx context.Context, invitationID, userID string) error
+	ListMyInvitations(ctx context.Context, userID, rsvp string) ([]*domain.EventInvitation, error)
+	RespondToInvitation(ctx context.Context, invitationID, userID, rsvp string) (*domain.InvitationResponse, error)
+	SendInvitationReminders(ctx context.Context) error
+
+	// Event announcements
+	CreateAnnouncement(ctx context.Context, eventID, userID string, announcement *domain.EventAnnouncement) (*domain.EventAnnouncement, error)
+	ListAnnouncements(ctx context.Context, eventID, userID string) ([]*domain.EventAnnouncement, error)
+	CancelAnnouncement(ctx context.Context, announcementID, userID string) error
+	SendDueAnnouncements(ctx context.Context) error
+
+	// Session reschedules
+	RescheduleEventSession(ctx context.Context, sessionID, userID string, input *domain.SessionRescheduleInput) (*domain.SessionReschedule, error)
+	ListSessionReschedules(ctx context.Context, sessionID, userID string) ([]*domain.SessionReschedule, error)
+	ListMyReconfirmations(ctx context.Context, userID, status string) ([]*domain.SessionReconfirmation, error)
+	AnswerReconfirmation(ctx context.Context, reconfirmationID, userID, status string) (*domain.SessionReconfirmation, error)
+
+	// Event templates
+	SaveEventAsTemplate(ctx context.Context, eventID, userID string, template *domain.EventTemplate) (*domain.EventTemplate, error)
+	ListEventTemplates(ctx context.Context, communityID, userID string) ([]*domain.EventTemplate, error)
+	GetEventTemplate(ctx context.Context, templateID, userID string) (*domain.EventTemplate, error)
+	UpdateEventTemplate(ctx context.Context, template *domain.EventTemplate, userID string) (*domain.EventTemplate, error)
+	DeleteEventTemplate(ctx context.Context, templateID, userID string) error
+	CreateEventFromTemplate(ctx context.Context, templateID, userID, name string, start time.Time) (*domain.Event, error)
+	DuplicateEvent(ctx context.Context, eventID, userID, name string, start time.Time) (*domain.Event, error)
+
+	// Venues
+	CreateVenue(ctx context.Context, communityID, userID string, venue *domain.Venue) (*domain.Venue, error)
+	ListVenues(ctx context.Context, communityID, userID string) ([]*domain.Venue, error)
+	GetVenue(ctx context.Context, venueID, userID string) (*domain.Venue, error)
+	UpdateVenue(ctx context.Context, venue *domain.Venue, userID string) (*domain.Venue, error)
+	DeleteVenue(ctx context.Context, venueID, userID string) error
+	SetSessionVenue(ctx context.Context, sessionID, userID, venueID string) (*domain.EventSession, error)
+
+	// Agenda items and tracks
+	CreateEventTrack(ctx context.Context, eventID, userID string, track *domain.EventTrack) (*domain.EventTrack, error)
+	ListEventTracks(ctx context.Context, eventID, userID string) ([]*domain.EventTrack, error)
+	UpdateEventTrack(ctx context.Context, track *domain.EventTrack, userID string) (*domain.EventTrack, error)
+	DeleteEventTrack(ctx context.Context, trackID, userID string) error
+	CreateAgendaItem(ctx context.Context, sessionID, userID string, item *domain.SessionAgendaItem, speakerIDs []string) (*domain.SessionAgendaItem, error)
+	ListSessionAgendaItems(ctx context.Context, sessionID, userID string) ([]*domain.SessionAgendaItem, error)
+	UpdateAgendaItem(ctx context.Context, item *domain.SessionAgendaItem, speakerIDs []string, userID string) (*domain.SessionAgendaItem, error)
+	DeleteAgendaItem(ctx context.Context, itemID, userID string) error
+	StarAgendaItem(ctx context.Context, itemID, userID string) (*domain.SessionAgendaItem, error)
+	UnstarAgendaItem(ctx context.Context, itemID, userID string) error
+	GetEventSchedule(ctx context.Context, eventID, userID, timezone string, starredOnly bool) (*domain.EventSchedule, error)
+	ExportEventScheduleICal(ctx context.Context, eventID, userID string, starredOnly bool) ([]byte, error)
+
+	// Event discussion and session Q&A
+	AuthorizeEventDiscussion(ctx context.Context, eventID, userID string) (*domain.Event, error)
+	AuthorizeSessionLive(ctx context.Context, sessionID, userID string) error
+	SetSessionQAEnabled(ctx context.Context, sessionID, userID string, enabled bool) error
+	GetSessionQA(ctx context.Context, sessionID, userID, sort string) (*domain.SessionQA, error)
+	AskSessionQuestion(ctx context.Context, sessionID, userID string, question *domain.SessionQuestion) (*domain.SessionQuestion, error)
+	VoteSessionQuestion(ctx context.Context, questionID, userID string) (*domain.SessionQuestion, error)
+	UnvoteSessionQuestion(ctx context.Context, questionID, userID string) (*domain.SessionQuestion, error)
+	ModerateSessionQuestion(ctx context.Context, questionID, userID, status string) (*domain.SessionQuestion, error)
+
+	// Session polls
+	CreateSessionPoll(ctx context.Context, sessionID, userID string, poll *domain.SessionPoll) (*domain.SessionPoll, error)
+	UpdateSessionPoll(ctx context.Context, pollID, userID string, poll *domain.SessionPoll) (*domain.SessionPoll, error)
+	DeleteSessionPoll(ctx context.Context, pollID, userID string) error
+	ListSessionPolls(ctx context.Context, sessionID, userID string) ([]*domain.SessionPoll, error)
+	OpenSessionPoll(ctx context.Context, pollID, userID string) (*domain.SessionPoll, error)
+	CloseSessionPoll(ctx context.Context, pollID, userID string) (*domain.SessionPoll, error)
+	VoteSessionPoll(ctx context.Context, pollID, optionID, userID string) (*domain.SessionPoll, error)
+	AdvanceSessionPolls(ctx context.Context) error
+
+	// Seating
+	SaveSeatingLayout(ctx context.Context, sessionID, userID string, layout *domain.SeatingLayout) (*domain.SeatingLayout, error)
+	GetSeatingLayout(ctx context.Context, sessionID, userID string) (*domain.SeatingLayout, error)
+	DeleteSeatingLayout(ctx context.Context, sessionID, userID string) error
+	ListSeatAssignments(ctx context.Context, sessionID, userID string) ([]*domain.SeatAssignment, error)
+	AutoAssignSeats(ctx context.Context, sessionID, userID string) (*domain.SeatAssignmentResult, error)
+	AssignSeatGroup(ctx context.Context, sessionID, userID string, memberIDs []string, sectionID string) (*domain.SeatAssignmentResult, error)
+	AssignSeat(ctx context.Context, sessionID, userID, attendeeID, unitID string, seatNumber int) (*domain.SeatAssignment, error)
+	UnassignSeat(ctx context.Context, sessionID, userID, attendeeID string) error
+	ClearSeatAssignments(ctx context.Context, sessionID, userID string) (int, error)
+	ListSeatAssignmentAudit(ctx context.Context, sessionID, userID string, limit, offset int) ([]*domain.SeatAssignmentAudit, int, error)
+	ListSessionBadges(ctx context.Context, sessionID, userID string) ([]*domain.SeatBadge, error)
+
+	// Online meeting join links
+	GetJoinLinkToken(ctx context.Context, eventID, userID string) (string, error)
+	JoinOnlineSession(ctx context.Context, token string) (string, error)
+
+	// Registration transfers
+	RequestRegistrationTransfer(ctx context.Context, eventID, userID, toUserID, toEmail, message string) (*domain.RegistrationTransfer, error)
+	ListEventRegistrationTransfers(ctx context.Context, eventID, userID, status string) ([]*domain.RegistrationTransfer, error)
+	ListMyRegistrationTransfers(ctx context.Context, userID, status string) ([]*domain.RegistrationTransfer, error)
+	AcceptRegistrationTransfer(ctx context.Context, transferID, userID string) (*domain.RegistrationTransfer, error)
+	DeclineRegistrationTransfer(ctx context.Context, transferID, userID string) error
+	CancelRegistrationTransfer(ctx context.Context, transferID, userID string) error
+	ApproveRegistrationTransfer(ctx context.Context, transferID, userID string) (*domain.RegistrationTransfer, error)
+	RejectRegistrationTransfer(ctx context.Context, transferID, userID string) error
+
+	// Public landing pages
+	GetPublicEventBySlug(ctx context.Context, slug string) (*domain.PublicEvent, error)
 }
 
 // Service is the implementation of the EventService interface.
@@ -62,6 +227,12 @@ func NewService(repo domain.EventRepository, neo4jRepo community_domain.Neo4jCom
 
 // CreateEvent handles the business logic for creating a new event.
 func (s *Service) CreateEvent(ctx context.Context, event *domain.Event, hostID string, whitelistUserIDs []string) (*domain.Event, error) {
+	return s.createEvent(ctx, event, hostID, whitelistUserIDs, nil)
+}
+
+// createEvent creates an event. A non-recurring event is given the copied sessions, when there are any, instead
+// of a single session spanning the event; recurring events always get the sessions of their rule.
+func (s *Service) createEvent(ctx context.Context, event *domain.Event, hostID string, whitelistUserIDs []string, copies []domain.EventSession) (*domain.Event, error) {
 	// Authorization: Check if the user is a member of the community they are creating an event in.
 	isAdmin, err := s.permService.IsCommunityAdmin(ctx, event.CommunityID, hostID)
 	if err != nil {
@@ -75,7 +246,10 @@ func (s *Service) CreateEvent(ctx context.Context, event *domain.Event, hostID s
 	event.ID = uuid.New().String()
 	event.Slug = slug.Make(fmt.Sprintf("%s-%s", event.Name, event.ID[:8]))
 	if event.Status == "" {
-		event.Status = "draft"
+		event.Status = domain.EventStatusDraft
+	}
+	if event.Status != domain.EventStatusDraft && event.Status != domain.EventStatusPublished {
+		return nil, fmt.Errorf("%w: new events start as draft or published", domain.ErrInvalidStatusTransition)
 	}
 	if event.Timezone == "" {
 		event.Timezone = "UTC" // Default to UTC if not provided
@@ -84,6 +258,18 @@ func (s *Service) CreateEvent(ctx context.Context, event *domain.Event, hostID s
 	if event.IsRecurring && (event.RecurrenceRule == nil || len(event.RecurrenceRule) == 0) {
 		return nil, errors.New("recurrence_rule is required for recurring events")
 	}
+	if err := domain.ValidateReminderSchedule(event.ReminderSchedule); err != nil {
+		return nil, err
+	}
+	if err := event.ValidateMeetingLinkReveal(); err != nil {
+		return nil, err
+	}
+	if event.TransferPolicy == "" {
+		event.TransferPolicy = domain.TransferPolicyDisabled
+	}
+	if err := event.ValidateTransferPolicy(); err != nil {
+		return nil, err
+	}
 
 	// Ensure nullable fields are correctly set
 	event.Description = sql.NullString{String: event.Description.String, Valid: event.Description.String != ""}
@@ -91,47 +277,64 @@ func (s *Service) CreateEvent(ctx context.Context, event *domain.Event, hostID s
 	event.LocationAddress = sql.NullString{String: event.LocationAddress.String, Valid: event.LocationAddress.String != ""}
 		event.OnlineMeetingURL = sql.NullString{String: event.OnlineMeetingURL.String, Valid: event.OnlineMeetingURL.String != ""}
 	event.RecurrencePattern = sql.NullString{String: event.RecurrencePattern.String, Valid: event.RecurrencePattern.String != ""}
+	if event.VenueID.Valid {
+		venue, err := s.communityVenue(ctx, event.CommunityID, event.VenueID.String)
+		if err != nil {
+			return nil, err
+		}
+		if !event.LocationAddress.Valid {
+			event.LocationAddress = sql.NullString{String: venue.Location(), Valid: true}
+		}
+	}
 
 	// 2. Generate sessions based on recurrence rule
 	var sessionsToCreate []domain.EventSession
 	duration := event.EndTime.Time.Sub(event.StartTime.Time)
 
 	if event.IsRecurring && len(event.RecurrenceRule) > 0 && string(event.RecurrenceRule) != "null" {
-		var rrulePayload struct {
-			RRule string `json:"rrule"`
-		}
-		if err := json.Unmarshal(event.RecurrenceRule, &rrulePayload); err != nil {
+		recurrence, err := domain.ParseRecurrence(event.RecurrenceRule)
+		if err != nil {
 			log.Printf("ERROR: Could not unmarshal rrule json for event %s: %v", event.ID, err)
 			return nil, fmt.Errorf("invalid recurrence_rule json: %w", err)
 		}
-		ruleString := rrulePayload.RRule
-		log.Printf("Attempting to parse recurrence rule: %s", ruleString)
 
-		if ruleString != "" {
-			rOption, err := rrule.StrToROption(ruleString)
-			if err != nil {
-				return nil, fmt.Errorf("invalid rrule string format: %w", err)
+		if recurrence != nil {
+			log.Printf("Attempting to parse recurrence rule: %s", recurrence.RRule)
+			if err := event.ValidateGenerationHorizon(); err != nil {
+				return nil, err
 			}
-			rOption.Dtstart = event.StartTime.Time
-
-			rule, err := rrule.NewRRule(*rOption)
+			// Bounded series are generated up front; open-ended ones only up to the event's generation
+			// horizon, the recurring event worker generates the rest as time goes by.
+			var until time.Time
+			if !recurrence.IsBounded(event) {
+				until = time.Now()
+				if event.StartTime.Time.After(until) {
+					until = event.StartTime.Time
+				}
+				until = until.Add(event.GenerationHorizon())
+			}
+			occurrences, err := recurrence.Occurrences(event, event.StartTime.Time, until, domain.MaxGeneratedSessions)
 			if err != nil {
-				return nil, fmt.Errorf("failed to create new rrule: %w", err)
+				return nil, fmt.Errorf("invalid rrule string format: %w", err)
 			}
 
-			occurrences := rule.All()
-
 			for i, occ := range occurrences {
 				sessionsToCreate = append(sessionsToCreate, domain.EventSession{
-					ID:            uuid.New().String(),
-					EventID:       event.ID,
-					SessionNumber: i + 1,
-					StartTime:     occ,
-					EndTime:       occ.Add(duration),
-					Timezone:      event.Timezone,
+					ID:                uuid.New().String(),
+					EventID:           event.ID,
+					SessionNumber:     i + 1,
+					StartTime:         occ,
+					EndTime:           occ.Add(duration),
+					Timezone:          event.Timezone,
+					OriginalStartTime: sql.NullTime{Time: occ, Valid: true},
 				})
 			}
 		}
+	} else if len(copies) > 0 {
+		for i := range copies {
+			copies[i].EventID = event.ID
+		}
+		sessionsToCreate = copies
 	} else {
 		// If not recurring, create just one session
 		sessionsToCreate = append(sessionsToCreate, domain.EventSession{
@@ -144,11 +347,26 @@ func (s *Service) CreateEvent(ctx context.Context, event *domain.Event, hostID s
 		})
 	}
 
+	return s.persistEvent(ctx, event, sessionsToCreate, hostID, whitelistUserIDs)
+}
+
+// persistEvent saves a fully prepared event with its sessions and runs the post-creation side effects. Venues
+// its sessions would double-book are refused, and double-booked staff are returned with the event.
+func (s *Service) persistEvent(ctx context.Context, event *domain.Event, sessionsToCreate []domain.EventSession, hostID string, whitelistUserIDs []string) (*domain.Event, error) {
+	conflicts, err := s.checkScheduleConflicts(ctx, event, hostID, sessionsToCreate)
+	if err != nil {
+		return nil, err
+	}
+
 	// 3. Call repository to save everything in a transaction
 	createdEvent, err := s.repo.CreateEvent(ctx, event, sessionsToCreate, hostID, whitelistUserIDs)
 	if err != nil {
 		return nil, fmt.Errorf("service could not create event: %w", err)
 	}
+	if createdEvent.Status == domain.EventStatusPublished {
+		s.advanceEventLifecycle(ctx, createdEvent)
+	}
+	s.syncReminders(ctx, createdEvent.ID)
 
 	// 4. Create node in Neo4j Graph
 	if err := s.neo4jRepo.CreateEventNode(ctx, createdEvent); err != nil {
@@ -163,30 +381,52 @@ func (s *Service) CreateEvent(ctx context.Context, event *domain.Event, hostID s
 		}
 	}
 
+	createdEvent.ScheduleConflicts = conflicts
 	return createdEvent, nil
 }
 
-// RegisterForEvent handles the logic for a user to register for an event.
-func (s *Service) RegisterForEvent(ctx context.Context, eventID, userID string, formData json.RawMessage) error {
+// RegisterForEvent handles the logic for a user to register for an event. It returns the user's other
+// registrations whose sessions overlap the event's; in strict mode these make the registration fail with
+// ErrScheduleConflict instead.
+func (s *Service) RegisterForEvent(ctx context.Context, eventID, userID string, formData json.RawMessage, strict bool) ([]*domain.ScheduleConflict, error) {
 	if err := s.repo.CheckRegistrationEligibility(ctx, eventID, userID); err != nil {
-		return err
+		return nil, err
 	}
 
 	status := "registered"
 	event, err := s.repo.GetEventByID(ctx, eventID, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if event.RequireApproval {
 		status = "pending"
 	}
 
+	conflicts, err := s.findAttendeeConflicts(ctx, event, userID)
+	if err != nil {
+		return nil, err
+	}
+	if strict && len(conflicts) > 0 {
+		return conflicts, fmt.Errorf("%w: %s", domain.ErrScheduleConflict, conflicts[0].Describe())
+	}
+
 	if err := s.repo.RegisterForEvent(ctx, eventID, userID, status, formData); err != nil {
-		return err // Return the error directly, repo handles ErrAlreadyRegistered
+		return nil, err // Return the error directly, repo handles ErrAlreadyRegistered
+	}
+	if status == "pending" {
+		// Approval rules may approve the registration right away
+		if approved, err := s.applyApprovalRules(ctx, event, userID); err != nil {
+			log.Printf("Error applying the approval rules of event %s: %v", event.ID, err)
+		} else if approved > 0 {
+			status = "registered"
+		}
 	}
 
 	s.repo.InvalidateEventCache(ctx, eventID, userID)
 	s.repo.InvalidateEventCache(ctx, eventID, event.CreatedBy)
+	if status == "registered" {
+		s.syncReminders(ctx, eventID)
+	}
 
 	if s.publisher != nil {
 		payload := fmt.Sprintf(`{"event_id": "%s", "user_id": "%s", "status": "%s"}`, event.ID, userID, status)
@@ -195,7 +435,7 @@ func (s *Service) RegisterForEvent(ctx context.Context, eventID, userID string,
 		}
 	}
 
-	return nil
+	return conflicts, nil
 }
 
 // GetEvent retrieves a single event by its ID.
@@ -213,6 +453,24 @@ func (s *Service) GetEvent(ctx context.Context, id string, userID string) (*doma
 		return nil, permission_domain.ErrPermissionDenied
 	}
 
+	speakers, err := s.repo.ListEventStaff(ctx, event.ID, permission_domain.EventRoleSpeaker)
+	if err != nil {
+		log.Printf("Error loading speakers of event %s: %v", event.ID, err)
+	} else {
+		event.Speakers = speakers
+	}
+	if event.VenueID.Valid {
+		venue, err := s.repo.GetVenue(ctx, event.VenueID.String)
+		if err != nil {
+			log.Printf("Error loading the venue of event %s: %v", event.ID, err)
+		} else {
+			event.Venue = venue
+		}
+	}
+	if err := s.gateMeetingLinks(ctx, event, userID); err != nil {
+		return nil, err
+	}
+
 	return event, nil
 }
 
@@ -236,28 +494,35 @@ func (s *Service) ListMyAccessibleEventItems(ctx context.Context, userID string,
 
 // AddUsersToWhitelist adds a list of users to an event's whitelist.
 func (s *Service) AddUsersToWhitelist(ctx context.Context, eventID string, userIDs []string, performingUserID string) error {
-	isHost, err := s.permService.IsEventHost(ctx, eventID, performingUserID)
+	allowed, err := s.permService.HasEventPermission(ctx, eventID, performingUserID, permission_domain.EventPermissionEdit)
 	if err != nil {
 		return err
 	}
-	if !isHost {
+	if !allowed {
 		return permission_domain.ErrPermissionDenied
 	}
 	return s.repo.AddUsersToWhitelist(ctx, eventID, userIDs, performingUserID)
 }
 
 // GetEventAttendanceSummary retrieves an attendance summary for an event.
-func (s *Service) GetEventAttendanceSummary(ctx context.Context, eventID string) (*domain.AttendanceSummary, error) {
+func (s *Service) GetEventAttendanceSummary(ctx context.Context, eventID, userID string) (*domain.AttendanceSummary, error) {
+	allowed, err := s.permService.HasEventPermission(ctx, eventID, userID, permission_domain.EventPermissionViewAttendees)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, permission_domain.ErrPermissionDenied
+	}
 	return s.repo.GetEventAttendanceSummary(ctx, eventID)
 }
 
 // GetEventAttendees retrieves a list of attendees for an event.
 func (s *Service) GetEventAttendees(ctx context.Context, eventID, sessionID, status, userID string) ([]*domain.EventAttendee, error) {
-	isHost, err := s.permService.IsEventHost(ctx, eventID, userID)
+	allowed, err := s.permService.HasEventPermission(ctx, eventID, userID, permission_domain.EventPermissionViewAttendees)
 	if err != nil {
 		return nil, err
 	}
-	if !isHost {
+	if !allowed {
 		return nil, permission_domain.ErrPermissionDenied
 	}
 	return s.repo.GetEventAttendees(ctx, eventID, sessionID, status)
@@ -267,51 +532,158 @@ func (s *Service) IsUserInWhitelist(ctx context.Context, eventID, userID string)
 	return s.repo.IsUserInWhitelist(ctx, eventID, userID)
 }
 
-func (s *Service) GetEventSessions(ctx context.Context, eventID string) ([]domain.EventSession, error) {
-	return s.repo.GetEventSessions(ctx, eventID)
-}
-
-func (s *Service) GetEventSessionByID(ctx context.Context, sessionID string) (*domain.EventSession, error) {
-	return s.repo.GetEventSessionByID(ctx, sessionID)
+// GetEventSessions lists the sessions of an event, with the meeting links the user may see.
+func (s *Service) GetEventSessions(ctx context.Context, eventID, userID string) ([]domain.EventSession, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.gateMeetingLinks(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	return event.Sessions, nil
 }
 
-func (s *Service) GetUpcomingSessionsWithAttendees(ctx context.Context, from, to time.Time) ([]*domain.UpcomingSessionInfo, error) {
-	return s.repo.GetUpcomingSessionsWithAttendees(ctx, from, to)
+// GetEventSessionByID returns a session, with its meeting link if the user may see it.
+func (s *Service) GetEventSessionByID(ctx context.Context, sessionID, userID string) (*domain.EventSession, error) {
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	event.Sessions = []domain.EventSession{*session}
+	if err := s.gateMeetingLinks(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	return &event.Sessions[0], nil
 }
 
 func (s *Service) UpdateEvent(ctx context.Context, event *domain.Event, fieldMask []string, userID string) (*domain.Event, error) {
-	isHost, err := s.permService.IsEventHost(ctx, event.ID, userID)
+	allowed, err := s.permService.HasEventPermission(ctx, event.ID, userID, permission_domain.EventPermissionEdit)
 	if err != nil {
 		return nil, err
 	}
-	if !isHost {
+	if !allowed {
 		return nil, permission_domain.ErrPermissionDenied
 	}
 
-	return s.repo.UpdateEvent(ctx, event, fieldMask)
-}
+	// Status changes go through the lifecycle rather than being written like the other fields.
+	var newStatus string
+	fields := make([]string, 0, len(fieldMask))
+	for _, field := range fieldMask {
+		switch field {
+		case "status":
+			newStatus = event.Status
+			continue
+		case "recurrence_rule":
+			if err := s.keepRecurrenceExceptions(ctx, event, userID); err != nil {
+				return nil, err
+			}
+		case "generation_horizon_days":
+			if err := event.ValidateGenerationHorizon(); err != nil {
+				return nil, err
+			}
+		case "reminder_schedule":
+			if err := domain.ValidateReminderSchedule(event.ReminderSchedule); err != nil {
+				return nil, err
+			}
+		case "meeting_link_reveal_minutes":
+			if err := event.ValidateMeetingLinkReveal(); err != nil {
+				return nil, err
+			}
+		case "transfer_policy", "transfer_deadline_minutes":
+			if err := event.ValidateTransferPolicy(); err != nil {
+				return nil, err
+			}
+		}
+		fields = append(fields, field)
+	}
 
-func (s *Service) ListPendingRegistrations(ctx context.Context, eventID, userID string) ([]*domain.EventAttendee, error) {
-	isHost, err := s.permService.IsEventHost(ctx, eventID, userID)
+	current, err := s.repo.GetEventByID(ctx, event.ID, userID)
 	if err != nil {
 		return nil, err
 	}
-	if !isHost {
-		return nil, permission_domain.ErrPermissionDenied
+	if slices.Contains(fields, "venue_id") && event.VenueID.Valid {
+		venue, err := s.communityVenue(ctx, current.CommunityID, event.VenueID.String)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(fields, "location_address") {
+			event.LocationAddress = sql.NullString{String: venue.Location(), Valid: true}
+			fields = append(fields, "location_address")
+		}
+		moved := *current
+		moved.VenueID = event.VenueID
+		if err := s.checkVenueConflicts(ctx, &moved, current.Sessions); err != nil {
+			return nil, err
+		}
 	}
-	return s.repo.GetPendingRegistrations(ctx, eventID)
+	if newStatus == current.Status {
+		newStatus = ""
+	}
+	if newStatus != "" {
+		if err := domain.ValidateStatusTransition(current.Status, newStatus); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(fields) > 0 {
+		if _, err := s.repo.UpdateEvent(ctx, event, fields); err != nil {
+			return nil, err
+		}
+	}
+	if newStatus != "" {
+		if err := s.transitionEventStatus(ctx, current, newStatus, userID, ""); err != nil {
+			return nil, err
+		}
+	}
+	// Moved sessions or registration windows may call for another status right away.
+	s.advanceEventLifecycle(ctx, current)
+	s.syncReminders(ctx, event.ID)
+
+	s.repo.InvalidateEventCache(ctx, event.ID, userID)
+	s.repo.InvalidateEventCache(ctx, event.ID, current.CreatedBy)
+	return s.repo.GetEventByID(ctx, event.ID, userID)
 }
 
-func (s *Service) ApproveRegistration(ctx context.Context, eventID, registrationID, userID string) error {
-	isHost, err := s.permService.IsEventHost(ctx, eventID, userID)
+// keepRecurrenceExceptions carries the exception and extra dates of the current rule over to a new
+// recurrence rule that does not specify its own, so cancelled or added occurrences survive rule edits.
+func (s *Service) keepRecurrenceExceptions(ctx context.Context, event *domain.Event, userID string) error {
+	recurrence, err := domain.ParseRecurrence(event.RecurrenceRule)
+	if err != nil || recurrence == nil {
+		return err
+	}
+	current, err := s.repo.GetEventByID(ctx, event.ID, userID)
 	if err != nil {
 		return err
 	}
-	if !isHost {
-		return permission_domain.ErrPermissionDenied
+	previous, err := domain.ParseRecurrence(current.RecurrenceRule)
+	if err != nil || previous == nil {
+		return nil
 	}
 
-	return s.repo.UpdateRegistrationStatus(ctx, registrationID, "registered", sql.NullString{String: userID, Valid: true})
+	if recurrence.ExDates == nil {
+		recurrence.ExDates = previous.ExDates
+	}
+	if recurrence.RDates == nil {
+		recurrence.RDates = previous.RDates
+	}
+	event.RecurrenceRule, err = recurrence.Marshal()
+	return err
+}
+
+func (s *Service) ListPendingRegistrations(ctx context.Context, eventID, userID string) ([]*domain.EventAttendee, error) {
+	allowed, err := s.permService.HasEventPermission(ctx, eventID, userID, permission_domain.EventPermissionApproveRegistrations)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+	return s.repo.GetPendingRegistrations(ctx, eventID)
 }
 
 func (s *Service) CancelRegistration(ctx context.Context, registrationID, userID string) error {
@@ -323,6 +695,14 @@ func (s *Service) CancelRegistration(ctx context.Context, registrationID, userID
 	if err := s.repo.CancelRegistration(ctx, registrationID, userID); err != nil {
 		return fmt.Errorf("could not cancel registration: %w", err)
 	}
+	// Free the seats the user held in individual sessions
+	promoted, err := s.repo.CancelSessionRegistrations(ctx, eventID, userID, "")
+	if err != nil {
+		log.Printf("Error cancelling session registrations of user %s for event %s: %v", userID, eventID, err)
+	} else {
+		s.notifyPromotedSessionRegistrations(promoted)
+	}
+	s.syncReminders(ctx, eventID)
 
 	// Invalidate caches
 	s.repo.InvalidateEventCache(ctx, eventID, userID)
@@ -359,9 +739,17 @@ func (s *Service) DeleteEvent(ctx context.Context, eventID string, userID string
 		}
 	}
 
+	// Record the cancellation in the event's lifecycle before it is removed from view.
+	if domain.ValidateStatusTransition(event.Status, domain.EventStatusCancelled) == nil {
+		if err := s.transitionEventStatus(ctx, event, domain.EventStatusCancelled, userID, "event deleted"); err != nil {
+			log.Printf("Error recording the cancellation of event %s: %v", eventID, err)
+		}
+	}
+
 	if err := s.repo.DeleteEvent(ctx, eventID); err != nil {
 		return err
 	}
+	s.syncReminders(ctx, eventID)
 
 	// Invalidate cache
 	s.repo.InvalidateEventCache(ctx, eventID, userID)
@@ -383,12 +771,12 @@ func (s *Service) CancelEventSession(ctx context.Context, sessionID string, user
 		return err
 	}
 
-	isHost, err := s.permService.IsEventHost(ctx, event.ID, userID)
+	allowed, err := s.permService.HasEventPermission(ctx, event.ID, userID, permission_domain.EventPermissionEdit)
 	if err != nil {
 		return err
 	}
 
-	if !isHost {
+	if !allowed {
 		isAdmin, err := s.permService.IsCommunityAdmin(ctx, event.CommunityID, userID)
 		if err != nil {
 			return err
@@ -398,17 +786,53 @@ func (s *Service) CancelEventSession(ctx context.Context, sessionID string, user
 		}
 	}
 
+	// The attendees are read before the cancellation so they can be told about it.
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	attendees, err := s.repo.ListSessionAttendees(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
 	if err := s.repo.CancelEventSession(ctx, sessionID, sql.NullString{String: reason, Valid: reason != ""}); err != nil {
 		return err
 	}
+	s.syncReminders(ctx, event.ID)
+
+	// Record the cancellation as an exception so the occurrence stays removed if the series is regenerated.
+	if event.IsRecurring {
+		if err := s.excludeOccurrence(ctx, event, sessionID); err != nil {
+			log.Printf("Error excluding cancelled occurrence %s from event %s: %v", sessionID, event.ID, err)
+		}
+	}
 
 	// Invalidate cache
 	s.repo.InvalidateEventCache(ctx, event.ID, userID)
 	s.repo.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
 
 	if s.publisher != nil {
-		payload := fmt.Sprintf(`{"session_id": "%s", "event_id": "%s"}`, sessionID, event.ID)
-		if err := s.publisher.Publish("events.session.cancelled", []byte(payload)); err != nil {
+		var userIDs []string
+		for _, attendee := range attendees {
+			if attendee.UserID != userID {
+				userIDs = append(userIDs, attendee.UserID)
+			}
+		}
+		payload, err := json.Marshal(domain.SessionCancelledEvent{
+			SessionID:   sessionID,
+			EventID:     event.ID,
+			EventName:   event.Name,
+			SessionName: session.Name.String,
+			Timezone:    event.Timezone,
+			StartTime:   session.StartTime,
+			Reason:      reason,
+			TriggeredBy: userID,
+			UserIDs:     userIDs,
+		})
+		if err != nil {
+			log.Printf("Error marshalling session cancelled event: %v", err)
+		} else if err := s.publisher.Publish(domain.SessionCancelledSubject, payload); err != nil {
 			log.Printf("Error publishing event session cancellation message: %v", err)
 		}
 	}
@@ -416,6 +840,33 @@ func (s *Service) CancelEventSession(ctx context.Context, sessionID string, user
 	return nil
 }
 
+// excludeOccurrence adds the session's original start time to the exception dates of its series.
+func (s *Service) excludeOccurrence(ctx context.Context, event *domain.Event, sessionID string) error {
+	recurrence, err := domain.ParseRecurrence(event.RecurrenceRule)
+	if err != nil || recurrence == nil {
+		return err
+	}
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	recurrence.AddExDate(sessionRecurrenceID(session))
+	if event.RecurrenceRule, err = recurrence.Marshal(); err != nil {
+		return err
+	}
+	_, err = s.repo.UpdateEvent(ctx, event, []string{"recurrence_rule"})
+	return err
+}
+
+// syncReminders reschedules the session reminders of an event after its sessions, registrations or reminder
+// schedule changed. Failures are only logged: the notification worker also reconciles all reminders periodically.
+func (s *Service) syncReminders(ctx context.Context, eventID string) {
+	if err := s.repo.SyncEventReminders(ctx, eventID); err != nil {
+		log.Printf("Error scheduling reminders for event %s: %v", eventID, err)
+	}
+}
+
 func (s *Service) HardDeleteEvent(ctx context.Context, eventID string, userID string) error {
 	isHost, err := s.permService.IsEventHost(ctx, eventID, userID)
 	if err != nil {
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// RegisterForSession signs a registered attendee up for one session of an event that uses per-session
+// registration. Sessions that overlap one the user already signed up for are refused; once the session is
+// full the user is put on its waitlist.
+func (s *Service) RegisterForSession(ctx context.Context, sessionID, userID string) (*domain.SessionRegistration, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.PerSessionRegistration {
+		return nil, domain.ErrSessionRegistrationDisabled
+	}
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsCancelled || !session.StartTime.After(time.Now()) {
+		return nil, domain.ErrSessionUnavailable
+	}
+
+	attendee, err := s.repo.GetEventAttendee(ctx, event.ID, userID)
+	if err != nil || attendee.Status != "registered" {
+		return nil, fmt.Errorf("%w: register for the event first", domain.ErrNotRegisteredForSession)
+	}
+
+	registration := &domain.SessionRegistration{
+		SessionID:  sessionID,
+		EventID:    event.ID,
+		AttendeeID: attendee.ID,
+		UserID:     userID,
+	}
+	if err := s.repo.RegisterForSession(ctx, registration); err != nil {
+		return nil, err
+	}
+	if registration.Status == domain.SessionRegistrationRegistered {
+		s.syncReminders(ctx, event.ID)
+	}
+
+	s.repo.InvalidateEventCache(ctx, event.ID, userID)
+	s.repo.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+
+	if s.publisher != nil {
+		payload := fmt.Sprintf(`{"event_id": "%s", "session_id": "%s", "user_id": "%s", "status": "%s"}`, event.ID, sessionID, userID, registration.Status)
+		if err := s.publisher.Publish("events.session.registered", []byte(payload)); err != nil {
+			log.Printf("Error publishing session registration message: %v", err)
+		}
+	}
+
+	return s.repo.GetSessionRegistration(ctx, sessionID, userID)
+}
+
+// DropSessionRegistration cancels the user's registration (or waitlist spot) for a session and gives the
+// freed seat to the next user on the waitlist.
+func (s *Service) DropSessionRegistration(ctx context.Context, sessionID, userID string) error {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	promoted, err := s.repo.CancelSessionRegistrations(ctx, event.ID, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	s.notifyPromotedSessionRegistrations(promoted)
+	s.syncReminders(ctx, event.ID)
+
+	s.repo.InvalidateEventCache(ctx, event.ID, userID)
+	s.repo.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+
+	if s.publisher != nil {
+		payload := fmt.Sprintf(`{"event_id": "%s", "session_id": "%s", "user_id": "%s"}`, event.ID, sessionID, userID)
+		if err := s.publisher.Publish("events.session.registration_cancelled", []byte(payload)); err != nil {
+			log.Printf("Error publishing session registration cancellation message: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// ListMySessionRegistrations lists the sessions of an event the user is registered or waitlisted for.
+func (s *Service) ListMySessionRegistrations(ctx context.Context, eventID, userID string) ([]*domain.SessionRegistration, error) {
+	return s.repo.ListUserSessionRegistrations(ctx, eventID, userID)
+}
+
+// ListSessionRegistrations lists the registrations and the waitlist of a session for the event's staff.
+func (s *Service) ListSessionRegistrations(ctx context.Context, sessionID, status, userID string) ([]*domain.SessionRegistration, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := s.permService.HasEventPermission(ctx, event.ID, userID, permission_domain.EventPermissionViewAttendees)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+	return s.repo.ListSessionRegistrations(ctx, sessionID, status)
+}
+
+// promoteSessionWaitlists fills the free seats of the event's sessions from their waitlists. Errors are
+// logged, since the change that freed the seats has already been saved.
+func (s *Service) promoteSessionWaitlists(ctx context.Context, eventID string) {
+	promoted, err := s.repo.PromoteSessionWaitlists(ctx, eventID)
+	if err != nil {
+		log.Printf("Error promoting session waitlists of event %s: %v", eventID, err)
+		return
+	}
+	s.notifyPromotedSessionRegistrations(promoted)
+}
+
+func (s *Service) notifyPromotedSessionRegistrations(promoted []*domain.SessionRegistration) {
+	if s.publisher == nil {
+		return
+	}
+	for _, registration := range promoted {
+		payload := fmt.Sprintf(`{"event_id": "%s", "session_id": "%s", "user_id": "%s"}`, registration.EventID, registration.SessionID, registration.UserID)
+		if err := s.publisher.Publish("events.session.waitlist_promoted", []byte(payload)); err != nil {
+			log.Printf("Error publishing session waitlist promotion message: %v", err)
+		}
+	}
+}
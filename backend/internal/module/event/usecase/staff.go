@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// ListEventStaff lists the staff of an event, optionally only those holding the given role.
+// Anyone who can view the event can see its staff.
+func (s *Service) ListEventStaff(ctx context.Context, eventID, userID, role string) ([]*domain.EventStaffMember, error) {
+	if _, err := s.GetEvent(ctx, eventID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListEventStaff(ctx, eventID, role)
+}
+
+// AssignEventStaff gives a user a role within the event, replacing any role the user had.
+// Only the event host and the admins of its community can assign roles.
+func (s *Service) AssignEventStaff(ctx context.Context, eventID, staffUserID, role, performingUserID string) (*domain.EventStaffMember, error) {
+	if !permission_domain.IsAssignableEventRole(role) {
+		return nil, domain.ErrInvalidEventRole
+	}
+	event, err := s.authorizeStaffManagement(ctx, eventID, performingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if staffUserID == event.CreatedBy {
+		return nil, fmt.Errorf("%w: the event host cannot be given another role", domain.ErrInvalidEventRole)
+	}
+
+	member := &domain.EventStaffMember{
+		EventID:    eventID,
+		UserID:     staffUserID,
+		Role:       role,
+		AssignedBy: sql.NullString{String: performingUserID, Valid: true},
+	}
+	if err := s.repo.UpsertEventStaff(ctx, member); err != nil {
+		return nil, err
+	}
+
+	s.repo.InvalidateEventCache(ctx, eventID, staffUserID)
+	s.repo.InvalidateEventCache(ctx, eventID, event.CreatedBy)
+
+	if s.publisher != nil {
+		payload := fmt.Sprintf(`{"event_id": "%s", "user_id": "%s", "role": "%s"}`, eventID, staffUserID, role)
+		if err := s.publisher.Publish("events.staff.assigned", []byte(payload)); err != nil {
+			log.Printf("Error publishing event staff assignment message: %v", err)
+		}
+	}
+
+	return member, nil
+}
+
+// RemoveEventStaff removes a user's role within the event.
+func (s *Service) RemoveEventStaff(ctx context.Context, eventID, staffUserID, performingUserID string) error {
+	event, err := s.authorizeStaffManagement(ctx, eventID, performingUserID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.RemoveEventStaff(ctx, eventID, staffUserID); err != nil {
+		return err
+	}
+
+	s.repo.InvalidateEventCache(ctx, eventID, staffUserID)
+	s.repo.InvalidateEventCache(ctx, eventID, event.CreatedBy)
+
+	if s.publisher != nil {
+		payload := fmt.Sprintf(`{"event_id": "%s", "user_id": "%s"}`, eventID, staffUserID)
+		if err := s.publisher.Publish("events.staff.removed", []byte(payload)); err != nil {
+			log.Printf("Error publishing event staff removal message: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// authorizeStaffManagement allows the event host and the admins of its community to manage the event's staff.
+func (s *Service) authorizeStaffManagement(ctx context.Context, eventID, userID string) (*domain.Event, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := s.permService.HasEventPermission(ctx, eventID, userID, permission_domain.EventPermissionManageStaff)
+	if err != nil {
+		return nil, err
+	}
+	if allowed {
+		return event, nil
+	}
+	isAdmin, err := s.permService.IsCommunityAdmin(ctx, event.CommunityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+	return event, nil
+}
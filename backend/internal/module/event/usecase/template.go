@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+	"github.com/google/uuid"
+)
+
+// SaveEventAsTemplate saves the settings of an event, with its staff roles, event-wide feedback surveys and
+// certificate template, as a template of the event's community. Only the event's editors can save it.
+func (s *Service) SaveEventAsTemplate(ctx context.Context, eventID, userID string, template *domain.EventTemplate) (*domain.EventTemplate, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	settings, err := s.captureEventSettings(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	template.CommunityID = event.CommunityID
+	template.CreatedBy = userID
+	template.SourceEventID = sql.NullString{String: event.ID, Valid: true}
+	template.Settings = settings
+	if err := s.repo.CreateEventTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return s.repo.GetEventTemplate(ctx, template.ID)
+}
+
+// ListEventTemplates lists the templates of a community the user created and, to the community's admins,
+// those shared with the community.
+func (s *Service) ListEventTemplates(ctx context.Context, communityID, userID string) ([]*domain.EventTemplate, error) {
+	isAdmin, err := s.permService.IsCommunityAdmin(ctx, communityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListEventTemplates(ctx, communityID, userID, isAdmin)
+}
+
+// GetEventTemplate returns a template to its creator and, once shared, to the community's admins.
+func (s *Service) GetEventTemplate(ctx context.Context, templateID, userID string) (*domain.EventTemplate, error) {
+	return s.authorizeEventTemplate(ctx, templateID, userID)
+}
+
+// UpdateEventTemplate renames a template, changes its description or whether it is shared with the community.
+func (s *Service) UpdateEventTemplate(ctx context.Context, template *domain.EventTemplate, userID string) (*domain.EventTemplate, error) {
+	existing, err := s.authorizeEventTemplate(ctx, template.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	if template.IsShared != existing.IsShared && existing.CreatedBy != userID {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+	if err := s.repo.UpdateEventTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return s.repo.GetEventTemplate(ctx, template.ID)
+}
+
+// DeleteEventTemplate removes a template. Events created from it are kept.
+func (s *Service) DeleteEventTemplate(ctx context.Context, templateID, userID string) error {
+	if _, err := s.authorizeEventTemplate(ctx, templateID, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteEventTemplate(ctx, templateID)
+}
+
+// CreateEventFromTemplate creates a draft event in the template's community starting at the given time.
+// The event is named after the template unless a name is given.
+func (s *Service) CreateEventFromTemplate(ctx context.Context, templateID, userID, name string, start time.Time) (*domain.Event, error) {
+	template, err := s.authorizeEventTemplate(ctx, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		name = template.Name
+	}
+	return s.createEventFromSettings(ctx, &template.Settings, template.CommunityID, userID, name, start, nil)
+}
+
+// DuplicateEvent creates a draft copy of an event starting at the given time. Registration windows and the
+// end of a series move along with the start. The sessions of a non-recurring event are copied with their
+// agenda and session surveys, moved by as much as the start; attendees, check-ins and edits of single
+// occurrences are not copied. The copy keeps the event's name unless a name is given.
+func (s *Service) DuplicateEvent(ctx context.Context, eventID, userID, name string, start time.Time) (*domain.Event, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.captureEventSettings(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		name = event.Name
+	}
+	var copies []domain.EventSession
+	if !event.IsRecurring && !start.IsZero() {
+		copies = copySessions(event.Sessions, start.Sub(event.StartTime.Time))
+	}
+	return s.createEventFromSettings(ctx, &settings, event.CommunityID, userID, name, start, copies)
+}
+
+// copySessions returns new sessions copying those of an event, moved by the offset and numbered in order.
+// Cancelled sessions are left out.
+func copySessions(sessions []domain.EventSession, offset time.Duration) []domain.EventSession {
+	shift := func(t sql.NullTime) sql.NullTime {
+		if t.Valid {
+			t.Time = t.Time.Add(offset)
+		}
+		return t
+	}
+
+	var copies []domain.EventSession
+	for _, session := range sessions {
+		if session.IsCancelled {
+			continue
+		}
+		copies = append(copies, domain.EventSession{
+			ID:                               uuid.New().String(),
+			SessionNumber:                    len(copies) + 1,
+			Name:                             session.Name,
+			StartTime:                        session.StartTime.Add(offset),
+			EndTime:                          session.EndTime.Add(offset),
+			Timezone:                         session.Timezone,
+			LocationOverride:                 session.LocationOverride,
+			OnlineMeetingURLOverride:         session.OnlineMeetingURLOverride,
+			CheckinOpensAt:                   shift(session.CheckinOpensAt),
+			CheckinClosesAt:                  shift(session.CheckinClosesAt),
+			MaxAttendeesOverride:             session.MaxAttendeesOverride,
+			FaceVerificationRequiredOverride: session.FaceVerificationRequiredOverride,
+			VenueID:                          session.VenueID,
+			CopiedFromSessionID:              session.ID,
+		})
+	}
+	return copies
+}
+
+// captureEventSettings snapshots the settings of an event with its staff roles, event-wide feedback surveys
+// and certificate template.
+func (s *Service) captureEventSettings(ctx context.Context, event *domain.Event) (domain.EventTemplateSettings, error) {
+	settings, err := domain.NewEventTemplateSettings(event)
+	if err != nil {
+		return settings, err
+	}
+
+	staff, err := s.repo.ListEventStaff(ctx, event.ID, "")
+	if err != nil {
+		return settings, err
+	}
+	for _, member := range staff {
+		settings.Staff = append(settings.Staff, domain.EventTemplateStaff{UserID: member.UserID, Role: member.Role})
+	}
+
+	surveys, err := s.repo.ListFeedbackSurveys(ctx, event.ID)
+	if err != nil {
+		return settings, err
+	}
+	for _, survey := range surveys {
+		if survey.SessionID.Valid {
+			continue
+		}
+		settings.FeedbackSurveys = append(settings.FeedbackSurveys, domain.EventTemplateSurvey{
+			Title:            survey.Title,
+			Description:      survey.Description.String,
+			Questions:        survey.Questions,
+			PerSession:       survey.PerSession,
+			SendDelayMinutes: survey.SendDelayMinutes,
+			IsActive:         survey.IsActive,
+		})
+	}
+
+	certificate, err := s.repo.GetCertificateTemplate(ctx, event.ID)
+	if err != nil && !errors.Is(err, domain.ErrCertificateTemplateNotFound) {
+		return settings, err
+	}
+	if certificate != nil {
+		settings.CertificateTemplate = &domain.EventTemplateCertificate{
+			Title:                certificate.Title,
+			Body:                 certificate.Body,
+			SignerName:           certificate.SignerName.String,
+			SignerTitle:          certificate.SignerTitle.String,
+			MinAttendancePercent: certificate.MinAttendancePercent,
+			IsActive:             certificate.IsActive,
+		}
+	}
+	return settings, nil
+}
+
+// createEventFromSettings creates a draft event from captured settings, with the copied sessions if any, then
+// gives it their staff roles, feedback surveys and certificate template. Only the community's admins can
+// create events.
+func (s *Service) createEventFromSettings(ctx context.Context, settings *domain.EventTemplateSettings, communityID, userID, name string, start time.Time, copies []domain.EventSession) (*domain.Event, error) {
+	if start.IsZero() {
+		return nil, fmt.Errorf("%w: start_time is required", domain.ErrInvalidEventTemplate)
+	}
+	event, err := settings.NewEvent(communityID, name, start)
+	if err != nil {
+		return nil, err
+	}
+	if event.VenueID.Valid {
+		// The venue may have been removed since; the event keeps its location text.
+		if _, err := s.repo.GetVenue(ctx, event.VenueID.String); errors.Is(err, domain.ErrVenueNotFound) {
+			event.VenueID = sql.NullString{}
+		}
+	}
+	created, err := s.createEvent(ctx, event, userID, nil, copies)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, staff := range settings.Staff {
+		if staff.UserID == created.CreatedBy || !permission_domain.IsAssignableEventRole(staff.Role) {
+			continue
+		}
+		member := &domain.EventStaffMember{
+			EventID:    created.ID,
+			UserID:     staff.UserID,
+			Role:       staff.Role,
+			AssignedBy: sql.NullString{String: userID, Valid: true},
+		}
+		if err := s.repo.UpsertEventStaff(ctx, member); err != nil {
+			log.Printf("Warning: could not give user %s the %s role in event %s: %v", staff.UserID, staff.Role, created.ID, err)
+		}
+	}
+	for _, template := range settings.FeedbackSurveys {
+		survey := &domain.FeedbackSurvey{
+			EventID:          created.ID,
+			Title:            template.Title,
+			Description:      sql.NullString{String: template.Description, Valid: template.Description != ""},
+			Questions:        template.Questions,
+			PerSession:       template.PerSession,
+			SendDelayMinutes: template.SendDelayMinutes,
+			IsActive:         template.IsActive,
+			CreatedBy:        userID,
+		}
+		if err := s.repo.CreateFeedbackSurvey(ctx, survey); err != nil {
+			log.Printf("Warning: could not copy feedback survey %q to event %s: %v", template.Title, created.ID, err)
+		}
+	}
+	if certificate := settings.CertificateTemplate; certificate != nil {
+		if err := s.repo.SaveCertificateTemplate(ctx, &domain.CertificateTemplate{
+			EventID:              created.ID,
+			Title:                certificate.Title,
+			Body:                 certificate.Body,
+			SignerName:           sql.NullString{String: certificate.SignerName, Valid: certificate.SignerName != ""},
+			SignerTitle:          sql.NullString{String: certificate.SignerTitle, Valid: certificate.SignerTitle != ""},
+			MinAttendancePercent: certificate.MinAttendancePercent,
+			IsActive:             certificate.IsActive,
+			CreatedBy:            userID,
+		}); err != nil {
+			log.Printf("Warning: could not copy the certificate template to event %s: %v", created.ID, err)
+		}
+	}
+
+	s.repo.InvalidateEventCache(ctx, created.ID, userID)
+	return s.repo.GetEventByID(ctx, created.ID, userID)
+}
+
+// authorizeEventTemplate allows the creator of a template and, once it is shared, the admins of its community.
+func (s *Service) authorizeEventTemplate(ctx context.Context, templateID, userID string) (*domain.EventTemplate, error) {
+	template, err := s.repo.GetEventTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.CreatedBy == userID {
+		return template, nil
+	}
+	if !template.IsShared {
+		return nil, domain.ErrEventTemplateNotFound
+	}
+	isAdmin, err := s.permService.IsCommunityAdmin(ctx, template.CommunityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+	return template, nil
+}
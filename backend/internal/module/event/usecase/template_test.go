@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+func TestCopySessions(t *testing.T) {
+	start := time.Date(2026, time.June, 1, 9, 0, 0, 0, time.UTC)
+	opens := sql.NullTime{Time: start.Add(-30 * time.Minute), Valid: true}
+	sessions := []domain.EventSession{
+		{ID: "s1", SessionNumber: 1, Name: sql.NullString{String: "Day 1", Valid: true}, StartTime: start, EndTime: start.Add(8 * time.Hour), CheckinOpensAt: opens},
+		{ID: "s2", SessionNumber: 2, StartTime: start.AddDate(0, 0, 1), EndTime: start.AddDate(0, 0, 1).Add(8 * time.Hour), IsCancelled: true},
+		{ID: "s3", SessionNumber: 3, StartTime: start.AddDate(0, 0, 2), EndTime: start.AddDate(0, 0, 2).Add(4 * time.Hour)},
+	}
+	offset := 7 * 24 * time.Hour
+
+	copies := copySessions(sessions, offset)
+	if len(copies) != 2 {
+		t.Fatalf("got %d sessions, want 2 without the cancelled one", len(copies))
+	}
+
+	tests := []struct {
+		copy   domain.EventSession
+		source domain.EventSession
+		number int
+	}{
+		{copy: copies[0], source: sessions[0], number: 1},
+		{copy: copies[1], source: sessions[2], number: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source.ID, func(t *testing.T) {
+			if tt.copy.ID == "" || tt.copy.ID == tt.source.ID {
+				t.Errorf("got ID %q, want a new one", tt.copy.ID)
+			}
+			if tt.copy.CopiedFromSessionID != tt.source.ID {
+				t.Errorf("got copied from %q, want %q", tt.copy.CopiedFromSessionID, tt.source.ID)
+			}
+			if tt.copy.SessionNumber != tt.number {
+				t.Errorf("got session number %d, want %d", tt.copy.SessionNumber, tt.number)
+			}
+			if !tt.copy.StartTime.Equal(tt.source.StartTime.Add(offset)) || !tt.copy.EndTime.Equal(tt.source.EndTime.Add(offset)) {
+				t.Errorf("got %s - %s, want the source moved by a week", tt.copy.StartTime, tt.copy.EndTime)
+			}
+			if tt.copy.Name != tt.source.Name {
+				t.Errorf("got name %v, want %v", tt.copy.Name, tt.source.Name)
+			}
+			if tt.copy.CheckinOpensAt.Valid != tt.source.CheckinOpensAt.Valid ||
+				(tt.copy.CheckinOpensAt.Valid && !tt.copy.CheckinOpensAt.Time.Equal(tt.source.CheckinOpensAt.Time.Add(offset))) {
+				t.Errorf("got check-in opening %v, want the source's moved by a week", tt.copy.CheckinOpensAt)
+			}
+			if tt.copy.CheckinClosesAt.Valid {
+				t.Errorf("unset check-in closing became %v", tt.copy.CheckinClosesAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// RequestRegistrationTransfer offers the user's registration for an event to another user or to an email
+// address, with an optional message, and notifies the recipient. The event's transfer policy must allow it
+// and its transfer deadline must not have passed.
+func (s *Service) RequestRegistrationTransfer(ctx context.Context, eventID, userID, toUserID, toEmail, message string) (*domain.RegistrationTransfer, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTransfersOpen(event); err != nil {
+		return nil, err
+	}
+	attendee, err := s.repo.GetEventAttendee(ctx, eventID, userID)
+	if errors.Is(err, domain.ErrAttendeeNotFound) {
+		return nil, domain.ErrNotRegistered
+	}
+	if err != nil {
+		return nil, err
+	}
+	if attendee.Role != "attendee" || attendee.Status != "registered" {
+		return nil, domain.ErrNotRegistered
+	}
+
+	transfer := &domain.RegistrationTransfer{
+		EventID:          eventID,
+		AttendeeID:       attendee.ID,
+		FromUserID:       userID,
+		RequiresApproval: event.TransferPolicy == domain.TransferPolicyApproval,
+	}
+	switch {
+	case toUserID != "" && toEmail != "":
+		return nil, fmt.Errorf("%w: give either to_user_id or email, not both", domain.ErrInvalidTransfer)
+	case toUserID != "":
+		if toUserID == userID {
+			return nil, fmt.Errorf("%w: a registration cannot be transferred to its holder", domain.ErrInvalidTransfer)
+		}
+		if err := s.checkTransferRecipient(ctx, eventID, toUserID); err != nil {
+			return nil, err
+		}
+		transfer.ToUserID = sql.NullString{String: toUserID, Valid: true}
+	case toEmail != "":
+		email, err := domain.NormalizeWhitelistEmail(toEmail)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidTransfer, err)
+		}
+		if strings.EqualFold(email, attendee.UserEmail) {
+			return nil, fmt.Errorf("%w: a registration cannot be transferred to its holder", domain.ErrInvalidTransfer)
+		}
+		transfer.ToEmail = sql.NullString{String: email, Valid: true}
+	default:
+		return nil, fmt.Errorf("%w: to_user_id or email is required", domain.ErrInvalidTransfer)
+	}
+	message = strings.TrimSpace(message)
+	transfer.Message = sql.NullString{String: message, Valid: message != ""}
+
+	if err := s.repo.CreateRegistrationTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+	created, err := s.repo.GetRegistrationTransfer(ctx, transfer.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishRegistrationTransfer(created, domain.TransferKindOffered)
+	return created, nil
+}
+
+// ListEventRegistrationTransfers lists the transfers of an event, optionally only those with a status, to the
+// staff who can approve its registrations.
+func (s *Service) ListEventRegistrationTransfers(ctx context.Context, eventID, userID, status string) ([]*domain.RegistrationTransfer, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionApproveRegistrations); err != nil {
+		return nil, err
+	}
+	return s.repo.ListEventRegistrationTransfers(ctx, eventID, status)
+}
+
+// ListMyRegistrationTransfers lists the transfers the user requested or was offered, by account or by
+// verified email, optionally only those with a status.
+func (s *Service) ListMyRegistrationTransfers(ctx context.Context, userID, status string) ([]*domain.RegistrationTransfer, error) {
+	return s.repo.ListUserRegistrationTransfers(ctx, userID, status)
+}
+
+// AcceptRegistrationTransfer records the recipient's acceptance of a transfer. The registration changes hands
+// right away unless the event's policy requires a host's approval that was not given yet. The recipient
+// must be a member of the event's community and not registered already.
+func (s *Service) AcceptRegistrationTransfer(ctx context.Context, transferID, userID string) (*domain.RegistrationTransfer, error) {
+	transfer, err := s.repo.GetRecipientRegistrationTransfer(ctx, transferID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != domain.TransferPending {
+		return nil, domain.ErrTransferNotPending
+	}
+	event, err := s.repo.GetEventByID(ctx, transfer.EventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTransfersOpen(event); err != nil {
+		return nil, err
+	}
+	isMember, err := s.permService.IsCommunityMember(ctx, event.CommunityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+	if err := s.checkTransferRecipient(ctx, event.ID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AcceptRegistrationTransfer(ctx, transferID, userID); err != nil {
+		return nil, err
+	}
+	return s.completeRegistrationTransfer(ctx, event, transferID)
+}
+
+// DeclineRegistrationTransfer turns down a transfer offered to the user. The sender keeps the registration
+// and is notified.
+func (s *Service) DeclineRegistrationTransfer(ctx context.Context, transferID, userID string) error {
+	transfer, err := s.repo.GetRecipientRegistrationTransfer(ctx, transferID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.CloseRegistrationTransfer(ctx, transferID, domain.TransferDeclined); err != nil {
+		return err
+	}
+	s.publishRegistrationTransfer(transfer, domain.TransferKindDeclined)
+	return nil
+}
+
+// CancelRegistrationTransfer withdraws a pending transfer the user requested.
+func (s *Service) CancelRegistrationTransfer(ctx context.Context, transferID, userID string) error {
+	transfer, err := s.repo.GetRegistrationTransfer(ctx, transferID)
+	if err != nil {
+		return err
+	}
+	if transfer.FromUserID != userID {
+		return domain.ErrTransferNotFound
+	}
+	return s.repo.CloseRegistrationTransfer(ctx, transferID, domain.TransferCancelled)
+}
+
+// ApproveRegistrationTransfer approves a transfer on an event whose policy requires approval. The
+// registration changes hands once the recipient has accepted too.
+func (s *Service) ApproveRegistrationTransfer(ctx context.Context, transferID, userID string) (*domain.RegistrationTransfer, error) {
+	transfer, event, err := s.authorizeTransferApproval(ctx, transferID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !transfer.RequiresApproval {
+		return nil, fmt.Errorf("%w: the transfer does not need approval", domain.ErrInvalidTransfer)
+	}
+	if err := s.repo.ApproveRegistrationTransfer(ctx, transferID, userID); err != nil {
+		return nil, err
+	}
+	return s.completeRegistrationTransfer(ctx, event, transferID)
+}
+
+// RejectRegistrationTransfer turns down a transfer on an event whose policy requires approval. Both parties
+// are notified and the sender keeps the registration.
+func (s *Service) RejectRegistrationTransfer(ctx context.Context, transferID, userID string) error {
+	transfer, _, err := s.authorizeTransferApproval(ctx, transferID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.CloseRegistrationTransfer(ctx, transferID, domain.TransferRejected); err != nil {
+		return err
+	}
+	s.publishRegistrationTransfer(transfer, domain.TransferKindRejected)
+	return nil
+}
+
+// completeRegistrationTransfer hands the registration over if the transfer is ready to complete, and notifies
+// both parties. It returns the transfer as it stands.
+func (s *Service) completeRegistrationTransfer(ctx context.Context, event *domain.Event, transferID string) (*domain.RegistrationTransfer, error) {
+	transfer, err := s.repo.GetRegistrationTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if !transfer.ReadyToComplete() {
+		return transfer, nil
+	}
+	if err := s.repo.CompleteRegistrationTransfer(ctx, transferID); err != nil {
+		return nil, err
+	}
+	s.syncReminders(ctx, event.ID)
+	s.repo.InvalidateEventCache(ctx, event.ID, transfer.FromUserID)
+	s.repo.InvalidateEventCache(ctx, event.ID, transfer.ToUserID.String)
+	s.repo.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+
+	completed, err := s.repo.GetRegistrationTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishRegistrationTransfer(completed, domain.TransferKindCompleted)
+	return completed, nil
+}
+
+// authorizeTransferApproval allows the staff who can approve the registrations of the transfer's event to
+// decide on a pending transfer.
+func (s *Service) authorizeTransferApproval(ctx context.Context, transferID, userID string) (*domain.RegistrationTransfer, *domain.Event, error) {
+	transfer, err := s.repo.GetRegistrationTransfer(ctx, transferID)
+	if err != nil {
+		return nil, nil, err
+	}
+	event, err := s.repo.GetEventByID(ctx, transfer.EventID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.authorizeEventPermission(ctx, event, userID, permission_domain.EventPermissionApproveRegistrations); err != nil {
+		return nil, nil, err
+	}
+	if transfer.Status != domain.TransferPending {
+		return nil, nil, domain.ErrTransferNotPending
+	}
+	return transfer, event, nil
+}
+
+// checkTransferRecipient refuses recipients already holding a registration for the event that was not
+// cancelled.
+func (s *Service) checkTransferRecipient(ctx context.Context, eventID, userID string) error {
+	attendee, err := s.repo.GetEventAttendee(ctx, eventID, userID)
+	if errors.Is(err, domain.ErrAttendeeNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if attendee.Status != "cancelled" {
+		return domain.ErrTransferRecipientActive
+	}
+	return nil
+}
+
+// checkTransfersOpen checks that the event's policy allows transfers and that they have not closed.
+func checkTransfersOpen(event *domain.Event) error {
+	if event.TransferPolicy == domain.TransferPolicyDisabled || event.TransferPolicy == "" {
+		return domain.ErrTransfersDisabled
+	}
+	if event.Status == domain.EventStatusCancelled || !event.TransfersOpen(time.Now()) {
+		return domain.ErrTransfersClosed
+	}
+	return nil
+}
+
+// publishRegistrationTransfer publishes a RegistrationTransferEvent of the given kind for a transfer.
+func (s *Service) publishRegistrationTransfer(transfer *domain.RegistrationTransfer, kind string) {
+	if s.publisher == nil {
+		return
+	}
+	payload, err := json.Marshal(domain.RegistrationTransferEvent{
+		TransferID: transfer.ID,
+		EventID:    transfer.EventID,
+		EventName:  transfer.EventName,
+		Kind:       kind,
+		FromUserID: transfer.FromUserID,
+		ToUserID:   transfer.ToUserID.String,
+		ToEmail:    transfer.ToEmail.String,
+		Message:    transfer.Message.String,
+	})
+	if err != nil {
+		log.Printf("Error marshalling registration transfer event: %v", err)
+		return
+	}
+	if err := s.publisher.Publish(domain.RegistrationTransferSubject, payload); err != nil {
+		log.Printf("Error publishing registration transfer message: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+)
+
+// CreateVenue adds a venue to a community. Only the community's admins manage its venues.
+func (s *Service) CreateVenue(ctx context.Context, communityID, userID string, venue *domain.Venue) (*domain.Venue, error) {
+	if err := s.authorizeVenueManagement(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+	if err := venue.Validate(); err != nil {
+		return nil, err
+	}
+	venue.CommunityID = communityID
+	venue.CreatedBy = sql.NullString{String: userID, Valid: true}
+	if err := s.repo.CreateVenue(ctx, venue); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+// ListVenues lists the venues of a community to those who can view its content.
+func (s *Service) ListVenues(ctx context.Context, communityID, userID string) ([]*domain.Venue, error) {
+	canView, err := s.permService.CanViewCommunityContent(ctx, communityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+	return s.repo.ListVenues(ctx, communityID)
+}
+
+// GetVenue returns a venue to those who can view its community's content.
+func (s *Service) GetVenue(ctx context.Context, venueID, userID string) (*domain.Venue, error) {
+	venue, err := s.repo.GetVenue(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	canView, err := s.permService.CanViewCommunityContent(ctx, venue.CommunityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, permission_domain.ErrPermissionDenied
+	}
+	return venue, nil
+}
+
+// UpdateVenue saves the details of a venue. Events already held there keep the location text they were
+// given.
+func (s *Service) UpdateVenue(ctx context.Context, venue *domain.Venue, userID string) (*domain.Venue, error) {
+	existing, err := s.repo.GetVenue(ctx, venue.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeVenueManagement(ctx, existing.CommunityID, userID); err != nil {
+		return nil, err
+	}
+	if err := venue.Validate(); err != nil {
+		return nil, err
+	}
+	venue.CommunityID = existing.CommunityID
+	venue.CreatedBy = existing.CreatedBy
+	venue.CreatedAt = existing.CreatedAt
+	if err := s.repo.UpdateVenue(ctx, venue); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+// DeleteVenue removes a venue. Events and sessions held there no longer reference it but keep their
+// location text.
+func (s *Service) DeleteVenue(ctx context.Context, venueID, userID string) error {
+	venue, err := s.repo.GetVenue(ctx, venueID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeVenueManagement(ctx, venue.CommunityID, userID); err != nil {
+		return err
+	}
+	return s.repo.DeleteVenue(ctx, venueID)
+}
+
+// SetSessionVenue holds a session at another venue of the event's community than the event, or at the
+// event's again if venueID is empty. The session's location follows the venue.
+func (s *Service) SetSessionVenue(ctx context.Context, sessionID, userID, venueID string) (*domain.EventSession, error) {
+	event, err := s.repo.GetEventBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeSessionManagement(ctx, event, userID); err != nil {
+		return nil, err
+	}
+
+	var venueRef, location sql.NullString
+	if venueID != "" {
+		venue, err := s.communityVenue(ctx, event.CommunityID, venueID)
+		if err != nil {
+			return nil, err
+		}
+		venueRef = sql.NullString{String: venue.ID, Valid: true}
+		location = sql.NullString{String: venue.Location(), Valid: true}
+	}
+	session, err := s.repo.GetEventSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	session.VenueID = venueRef
+	if err := s.checkVenueConflicts(ctx, event, []domain.EventSession{*session}); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetSessionVenue(ctx, sessionID, venueRef, location); err != nil {
+		return nil, err
+	}
+
+	s.repo.InvalidateEventCache(ctx, event.ID, userID)
+	s.repo.InvalidateEventCache(ctx, event.ID, event.CreatedBy)
+	return s.repo.GetEventSessionByID(ctx, sessionID)
+}
+
+// communityVenue returns a venue if it belongs to the community.
+func (s *Service) communityVenue(ctx context.Context, communityID, venueID string) (*domain.Venue, error) {
+	venue, err := s.repo.GetVenue(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if venue.CommunityID != communityID {
+		return nil, fmt.Errorf("%w: the venue belongs to another community", domain.ErrInvalidVenue)
+	}
+	return venue, nil
+}
+
+// authorizeVenueManagement allows the admins of the community to manage its venues.
+func (s *Service) authorizeVenueManagement(ctx context.Context, communityID, userID string) error {
+	isAdmin, err := s.permService.IsCommunityAdmin(ctx, communityID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return permission_domain.ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// ListWhitelistEntries lists the whitelist of an event to its editors.
+func (s *Service) ListWhitelistEntries(ctx context.Context, eventID, userID string) ([]*domain.WhitelistEntry, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListWhitelistEntries(ctx, eventID)
+}
+
+// AddWhitelistContacts whitelists people by email and/or phone, whether or not they have an account yet.
+// Without confirm the rows are only validated; the returned report tells which would be added, skipped or
+// rejected and why.
+func (s *Service) AddWhitelistContacts(ctx context.Context, eventID, userID string, rows []*domain.WhitelistImportRow, confirm bool) (*domain.WhitelistImportResult, error) {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListWhitelistEntries(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	entries := domain.PlanWhitelistImport(eventID, userID, rows, existing)
+
+	result := &domain.WhitelistImportResult{Confirmed: confirm, Rows: rows}
+	for _, row := range rows {
+		switch row.Action {
+		case domain.WhitelistImportActionSkip:
+			result.Skipped++
+		case domain.WhitelistImportActionInvalid:
+			result.Invalid++
+		}
+	}
+	if !confirm {
+		result.Added = len(entries)
+		return result, nil
+	}
+
+	added, err := s.repo.AddWhitelistEntries(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+	result.Added = added
+	result.Skipped += len(entries) - added
+
+	if _, err := s.repo.LinkEventWhitelistEntries(ctx, eventID); err != nil {
+		log.Printf("Error linking the whitelist entries of event %s to accounts: %v", eventID, err)
+	}
+	return result, nil
+}
+
+// ImportWhitelistCSV whitelists the people listed in a CSV file with "email", "phone" and "notes" columns.
+// Without confirm the file is only validated.
+func (s *Service) ImportWhitelistCSV(ctx context.Context, eventID, userID string, data []byte, confirm bool) (*domain.WhitelistImportResult, error) {
+	rows, err := domain.ParseWhitelistCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddWhitelistContacts(ctx, eventID, userID, rows, confirm)
+}
+
+// RemoveWhitelistEntry removes an entry from the whitelist of an event. Registrations already made stay.
+func (s *Service) RemoveWhitelistEntry(ctx context.Context, eventID, entryID, userID string) error {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return err
+	}
+	return s.repo.RemoveWhitelistEntry(ctx, eventID, entryID)
+}
+
+// ConfirmWhitelistLink links a whitelist entry to the account of the person it was added for, when that account
+// has the entry's email or phone but has not verified it. Entries are only linked on their own by a verified
+// email, so a host confirms the others.
+func (s *Service) ConfirmWhitelistLink(ctx context.Context, eventID, entryID, accountID, userID string) error {
+	event, err := s.repo.GetEventByID(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeEventEdit(ctx, event, userID); err != nil {
+		return err
+	}
+	return s.repo.ConfirmWhitelistLink(ctx, eventID, entryID, accountID)
+}
@@ -127,6 +127,12 @@ func (r *NotificationRepository) GetNotificationPreferencesWithDefaults(ctx cont
 							domain.RegistrationApprovedNotification: true,
 							domain.RegistrationPendingNotification:  true,
 							domain.EventCancelledNotification:       true,
+							domain.FeedbackRequestNotification:      true,
+							domain.CertificateIssuedNotification:    true,
+							domain.EventInvitationNotification:      true,
+							domain.EventAnnouncementNotification:    true,
+							domain.SessionRescheduledNotification:   true,
+							domain.RegistrationTransferNotification: true,
 						},
 					},
 					Push: domain.NotificationChannelPreferences{
@@ -143,6 +149,12 @@ func (r *NotificationRepository) GetNotificationPreferencesWithDefaults(ctx cont
 							domain.RegistrationApprovedNotification: true,
 							domain.RegistrationPendingNotification:  true,
 							domain.EventCancelledNotification:       true,
+							domain.FeedbackRequestNotification:      true,
+							domain.CertificateIssuedNotification:    true,
+							domain.EventInvitationNotification:      true,
+							domain.EventAnnouncementNotification:    true,
+							domain.SessionRescheduledNotification:   true,
+							domain.RegistrationTransferNotification: true,
 						},
 					},
 					InApp: domain.NotificationChannelPreferences{
@@ -159,6 +171,12 @@ func (r *NotificationRepository) GetNotificationPreferencesWithDefaults(ctx cont
 							domain.RegistrationApprovedNotification: true,
 							domain.RegistrationPendingNotification:  true,
 							domain.EventCancelledNotification:       true,
+							domain.FeedbackRequestNotification:      true,
+							domain.CertificateIssuedNotification:    true,
+							domain.EventInvitationNotification:      true,
+							domain.EventAnnouncementNotification:    true,
+							domain.SessionRescheduledNotification:   true,
+							domain.RegistrationTransferNotification: true,
 						},
 					},
 				},
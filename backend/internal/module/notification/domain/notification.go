@@ -19,6 +19,12 @@ const (
 	RegistrationApprovedNotification NotificationType = "registration_approved"
 	RegistrationPendingNotification  NotificationType = "registration_pending"
 	EventCancelledNotification       NotificationType = "event_cancelled"
+	FeedbackRequestNotification      NotificationType = "feedback_request"
+	CertificateIssuedNotification    NotificationType = "certificate_issued"
+	EventInvitationNotification      NotificationType = "event_invitation"
+	EventAnnouncementNotification    NotificationType = "event_announcement"
+	SessionRescheduledNotification   NotificationType = "session_rescheduled"
+	RegistrationTransferNotification NotificationType = "registration_transfer"
 )
 
 type Notification struct {
@@ -44,6 +50,13 @@ type NotificationChannelPreferences struct {
 	Types   map[NotificationType]bool `json:"types"`
 }
 
+// Allows reports whether the channel is enabled for notifications of type t. Types missing from the
+// preferences, e.g. added after they were saved, are allowed.
+func (p NotificationChannelPreferences) Allows(t NotificationType) bool {
+	enabled, ok := p.Types[t]
+	return p.Enabled && (enabled || !ok)
+}
+
 type NotificationChannels struct {
 	Email NotificationChannelPreferences `json:"email"`
 	Push  NotificationChannelPreferences `json:"push"`
@@ -51,6 +51,24 @@ func (r *permissionRepository) IsEventHost(ctx context.Context, eventID, userID
 	return exists, nil
 }
 
+// GetEventRole retrieves the role of a user within a specific event.
+func (r *permissionRepository) GetEventRole(ctx context.Context, eventID, userID string) (string, error) {
+	var role string
+	query := `
+		SELECT CASE WHEN e.created_by = $2 THEN 'host' ELSE COALESCE(s.role, '') END
+		FROM events e
+		LEFT JOIN event_staff s ON s.event_id = e.id AND s.user_id = $2
+		WHERE e.id = $1`
+	err := r.db.QueryRow(ctx, query, eventID, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("database error when getting event role: %w", err)
+	}
+	return role, nil
+}
+
 // GetCommunityType retrieves the type of a community (e.g., "public", "private").
 func (r *permissionRepository) GetCommunityType(ctx context.Context, communityID string) (string, error) {
 	var communityType string
@@ -16,6 +16,59 @@ type CommunitySettings struct {
 	AutoApprovePosts bool
 }
 
+// Event-scoped roles. The creator of an event is its host; the other roles are assigned per event.
+const (
+	EventRoleHost         = "host"
+	EventRoleCoHost       = "co_host"
+	EventRoleCheckinStaff = "checkin_staff"
+	EventRoleSpeaker      = "speaker"
+	EventRoleVolunteer    = "volunteer"
+)
+
+// EventPermission is an action on an event that is granted through an event role.
+type EventPermission string
+
+const (
+	EventPermissionEdit                 EventPermission = "edit_event"            // Update the event and its sessions
+	EventPermissionApproveRegistrations EventPermission = "approve_registrations" // Review pending registrations
+	EventPermissionViewAttendees        EventPermission = "view_attendees"        // List attendees and their check-in status
+	EventPermissionCheckIn              EventPermission = "check_in"              // Check attendees in
+	EventPermissionManageStaff          EventPermission = "manage_staff"          // Assign and remove event roles
+	EventPermissionModerateQA           EventPermission = "moderate_qa"           // Run the Q&A of sessions and moderate questions
+	EventPermissionRunPolls             EventPermission = "run_polls"             // Prepare, open and close the live polls of sessions
+)
+
+// eventRolePermissions is the permission set of each event role.
+var eventRolePermissions = map[string][]EventPermission{
+	EventRoleHost: {
+		EventPermissionEdit, EventPermissionApproveRegistrations, EventPermissionViewAttendees,
+		EventPermissionCheckIn, EventPermissionManageStaff, EventPermissionModerateQA, EventPermissionRunPolls,
+	},
+	EventRoleCoHost: {
+		EventPermissionEdit, EventPermissionApproveRegistrations, EventPermissionViewAttendees, EventPermissionCheckIn,
+		EventPermissionModerateQA, EventPermissionRunPolls,
+	},
+	EventRoleCheckinStaff: {EventPermissionViewAttendees, EventPermissionCheckIn},
+	EventRoleVolunteer:    {EventPermissionViewAttendees},
+	EventRoleSpeaker:      {EventPermissionModerateQA, EventPermissionRunPolls},
+}
+
+// EventRoleHasPermission reports whether the event role grants the permission.
+func EventRoleHasPermission(role string, permission EventPermission) bool {
+	for _, granted := range eventRolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAssignableEventRole reports whether the role can be assigned to a user for an event.
+func IsAssignableEventRole(role string) bool {
+	_, ok := eventRolePermissions[role]
+	return ok && role != EventRoleHost
+}
+
 // PermissionRepository defines the interface for accessing permission data from the database.
 
 type PermissionRepository interface {
@@ -26,6 +79,10 @@ type PermissionRepository interface {
 	// IsEventHost checks if a user is the creator (host) of a specific event.
 	IsEventHost(ctx context.Context, eventID, userID string) (bool, error)
 
+	// GetEventRole retrieves the role of a user within a specific event: "host" for its creator,
+	// the assigned staff role otherwise, or an empty string if the user has no role.
+	GetEventRole(ctx context.Context, eventID, userID string) (string, error)
+
 	// GetCommunityType retrieves the type of a community (e.g., "public", "private").
 	GetCommunityType(ctx context.Context, communityID string) (string, error)
 
@@ -48,6 +105,12 @@ type PermissionService interface {
 	// IsEventHost checks if a user is the host of the event.
 	IsEventHost(ctx context.Context, eventID, userID string) (bool, error)
 
+	// GetEventRole retrieves the role of a user within the event, or an empty string if the user has none.
+	GetEventRole(ctx context.Context, eventID, userID string) (string, error)
+
+	// HasEventPermission checks if a user's event role grants the permission on the event.
+	HasEventPermission(ctx context.Context, eventID, userID string, permission EventPermission) (bool, error)
+
 	// CanViewCommunityContent checks if a user can view content within a community.
 	// This encapsulates the logic for public vs. private/secret communities.
 	CanViewCommunityContent(ctx context.Context, communityID, userID string) (bool, error)
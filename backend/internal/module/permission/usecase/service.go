@@ -56,6 +56,20 @@ func (s *Service) IsEventHost(ctx context.Context, eventID, userID string) (bool
 	return s.permRepo.IsEventHost(ctx, eventID, userID)
 }
 
+// GetEventRole retrieves the role of a user within the event.
+func (s *Service) GetEventRole(ctx context.Context, eventID, userID string) (string, error) {
+	return s.permRepo.GetEventRole(ctx, eventID, userID)
+}
+
+// HasEventPermission checks if a user's event role grants the permission on the event.
+func (s *Service) HasEventPermission(ctx context.Context, eventID, userID string, permission domain.EventPermission) (bool, error) {
+	role, err := s.permRepo.GetEventRole(ctx, eventID, userID)
+	if err != nil {
+		return false, err
+	}
+	return domain.EventRoleHasPermission(role, permission), nil
+}
+
 // CanViewCommunityContent checks if a user can view content within a community.
 func (s *Service) CanViewCommunityContent(ctx context.Context, communityID, userID string) (bool, error) {
 	communityType, err := s.permRepo.GetCommunityType(ctx, communityID)
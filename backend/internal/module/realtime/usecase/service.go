@@ -26,6 +26,13 @@ type DashboardClient struct {
 	Conn      chan []byte
 }
 
+// SessionClient represents a single WebSocket client following the live activity of a SESSION, such as its Q&A.
+type SessionClient struct {
+	SessionID string
+	UserID    string
+	Conn      chan []byte
+}
+
 // Hub maintains the set of active clients and broadcasts messages to them.
 type Hub struct {
 	// For Chat
@@ -43,6 +50,12 @@ type Hub struct {
 	registerDashboard   chan *DashboardClient
 	unregisterDashboard chan *DashboardClient
 
+	// For Session Live Activity, followed by session clients and dashboards alike
+	sessionClients    map[*SessionClient]bool
+	liveSubs          map[string]*nats.Subscription // One per session, shared by all its followers
+	registerSession   chan *SessionClient
+	unregisterSession chan *SessionClient
+
 	// Common
 	broadcast     chan *nats.Msg
 	mu            sync.RWMutex
@@ -72,6 +85,11 @@ func NewHub(nc *nats.Conn, messagingRepo messaging_domain.MessagingRepository) *
 		unregisterDashboard: make(chan *DashboardClient),
 		dashboardClients:    make(map[*DashboardClient]bool),
 		dashboardSubs:       make(map[*DashboardClient]*nats.Subscription),
+		// Session live activity
+		registerSession:   make(chan *SessionClient),
+		unregisterSession: make(chan *SessionClient),
+		sessionClients:    make(map[*SessionClient]bool),
+		liveSubs:          make(map[string]*nats.Subscription),
 		// Common
 		nc:            nc,
 		messagingRepo: messagingRepo,
@@ -109,6 +127,9 @@ func (h *Hub) RegisterChatClient(client *Client) {
 func (h *Hub) RegisterDashboardClient(client *DashboardClient) {
 	h.registerDashboard <- client
 }
+func (h *Hub) RegisterSessionClient(client *SessionClient) {
+	h.registerSession <- client
+}
 
 func (h *Hub) SubmitTypingEvent(event TypingEvent) {
 	h.typingEvents <- event
@@ -121,6 +142,9 @@ func (h *Hub) UnregisterChatClient(client *Client) {
 func (h *Hub) UnregisterDashboardClient(client *DashboardClient) {
 	h.unregisterDashboard <- client
 }
+func (h *Hub) UnregisterSessionClient(client *SessionClient) {
+	h.unregisterSession <- client
+}
 
 func (h *Hub) SubscribeToConversations() {
 	// Subscribe to a wildcard subject for all CHAT conversations
@@ -164,6 +188,81 @@ func (h *Hub) SubscribeToConversations() {
 	log.Println("Subscribed to NATS subject 'user.status.*'")
 }
 
+// followSessionLive subscribes the hub to the live activity of a session unless it already follows it.
+// It must be called with h.mu locked.
+func (h *Hub) followSessionLive(sessionID string) error {
+	if _, ok := h.liveSubs[sessionID]; ok {
+		return nil
+	}
+	subject := fmt.Sprintf("session.live.%s", sessionID)
+	sub, err := h.nc.Subscribe(subject, func(msg *nats.Msg) {
+		h.forwardSessionLive(sessionID, msg.Data)
+	})
+	if err != nil {
+		return err
+	}
+	h.liveSubs[sessionID] = sub
+	log.Printf("Subscribed to NATS subject '%s'", subject)
+	return nil
+}
+
+// unfollowSessionLive drops the subscription to the live activity of a session once no session client or
+// dashboard follows it anymore. It must be called with h.mu locked.
+func (h *Hub) unfollowSessionLive(sessionID string) {
+	for client := range h.sessionClients {
+		if client.SessionID == sessionID {
+			return
+		}
+	}
+	for client := range h.dashboardClients {
+		if client.SessionID == sessionID {
+			return
+		}
+	}
+	if sub, ok := h.liveSubs[sessionID]; ok {
+		sub.Unsubscribe()
+		delete(h.liveSubs, sessionID)
+	}
+}
+
+// forwardSessionLive sends a live activity update of a session to its session clients and dashboards.
+// Clients whose channel is full are unregistered.
+func (h *Hub) forwardSessionLive(sessionID string, data []byte) {
+	var blockedSessions []*SessionClient
+	var blockedDashboards []*DashboardClient
+	h.mu.RLock()
+	for client := range h.sessionClients {
+		if client.SessionID != sessionID {
+			continue
+		}
+		select {
+		case client.Conn <- data:
+		default:
+			blockedSessions = append(blockedSessions, client)
+		}
+	}
+	for client := range h.dashboardClients {
+		if client.SessionID != sessionID {
+			continue
+		}
+		select {
+		case client.Conn <- data:
+		default:
+			blockedDashboards = append(blockedDashboards, client)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, client := range blockedSessions {
+		log.Printf("[WARN] Hub session client channel for user %s in session %s blocked, unregistering.", client.UserID, client.SessionID)
+		h.unregisterSession <- client
+	}
+	for _, client := range blockedDashboards {
+		log.Printf("[WARN] Hub client channel for session %s blocked, unregistering.", client.SessionID)
+		h.unregisterDashboard <- client
+	}
+}
+
 func (h *Hub) Run() {
 	for {
 		select {
@@ -250,17 +349,21 @@ func (h *Hub) Run() {
 			h.dashboardClients[client] = true
 			subject := fmt.Sprintf("checkin.updates.%s", client.SessionID)
 			sub, err := h.nc.Subscribe(subject, func(msg *nats.Msg) {
-				log.Printf("[DEBUG] Hub received NATS checkin update for session %s: %s", client.SessionID, string(msg.Data))
 				select {
 				case client.Conn <- msg.Data:
-					log.Printf("[DEBUG] Hub successfully sent checkin update to client for session %s", client.SessionID)
 				default:
 					log.Printf("[WARN] Hub client channel for session %s blocked, unregistering.", client.SessionID)
 					h.unregisterDashboard <- client
 				}
 			})
+			if err == nil {
+				// Dashboards also follow the live activity of the session, such as poll results.
+				if err = h.followSessionLive(client.SessionID); err != nil {
+					sub.Unsubscribe()
+				}
+			}
 			if err != nil {
-				log.Printf("Failed to subscribe to dashboard subject %s: %v", subject, err)
+				log.Printf("Failed to subscribe dashboard client for session %s: %v", client.SessionID, err)
 				delete(h.dashboardClients, client)
 				close(client.Conn)
 			} else {
@@ -278,10 +381,34 @@ func (h *Hub) Run() {
 			if _, ok := h.dashboardClients[client]; ok {
 				delete(h.dashboardClients, client)
 				close(client.Conn)
+				h.unfollowSessionLive(client.SessionID)
 			}
 			h.mu.Unlock()
 			log.Printf("Dashboard client for session %s unregistered", client.SessionID)
 
+		// --- Session Live Client Logic ---
+		case client := <-h.registerSession:
+			h.mu.Lock()
+			h.sessionClients[client] = true
+			if err := h.followSessionLive(client.SessionID); err != nil {
+				log.Printf("Failed to subscribe session client for session %s: %v", client.SessionID, err)
+				delete(h.sessionClients, client)
+				close(client.Conn)
+			} else {
+				log.Printf("Session client for user %s follows session %s", client.UserID, client.SessionID)
+			}
+			h.mu.Unlock()
+
+		case client := <-h.unregisterSession:
+			h.mu.Lock()
+			if _, ok := h.sessionClients[client]; ok {
+				delete(h.sessionClients, client)
+				close(client.Conn)
+				h.unfollowSessionLive(client.SessionID)
+			}
+			h.mu.Unlock()
+			log.Printf("Session client for user %s in session %s unregistered", client.UserID, client.SessionID)
+
 		// --- Message Broadcasting for Chat and Read Events ---
 		case msg := <-h.broadcast:
 			log.Printf("[DEBUG] Hub received NATS message on subject: %s", msg.Subject)
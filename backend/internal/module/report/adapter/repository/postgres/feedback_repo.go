@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	event_domain "github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/attendwise/backend/internal/module/report/domain"
+)
+
+// GetEventFeedbackSurveys lists the feedback surveys of an event, event surveys first.
+func (r *reportRepository) GetEventFeedbackSurveys(ctx context.Context, eventID string) ([]*event_domain.FeedbackSurvey, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT s.id, s.event_id, s.session_id, s.title, s.questions, s.per_session, s.is_active
+		FROM event_feedback_surveys s
+		LEFT JOIN event_sessions es ON es.id = s.session_id
+		WHERE s.event_id = $1
+		ORDER BY es.start_time NULLS FIRST, s.created_at
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feedback surveys: %w", err)
+	}
+	defer rows.Close()
+
+	var surveys []*event_domain.FeedbackSurvey
+	for rows.Next() {
+		var survey event_domain.FeedbackSurvey
+		var questions []byte
+		if err := rows.Scan(&survey.ID, &survey.EventID, &survey.SessionID, &survey.Title, &questions, &survey.PerSession, &survey.IsActive); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback survey: %w", err)
+		}
+		if err := json.Unmarshal(questions, &survey.Questions); err != nil {
+			return nil, fmt.Errorf("failed to decode survey questions: %w", err)
+		}
+		surveys = append(surveys, &survey)
+	}
+	return surveys, rows.Err()
+}
+
+// CountFeedbackRequests counts the feedback requests sent for each survey of an event, by survey ID.
+func (r *reportRepository) CountFeedbackRequests(ctx context.Context, eventID string) (map[string]int, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT survey_id, COUNT(*)
+		FROM event_feedback_requests
+		WHERE event_id = $1
+		GROUP BY survey_id
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count feedback requests: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var surveyID string
+		var count int
+		if err := rows.Scan(&surveyID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback request count: %w", err)
+		}
+		counts[surveyID] = count
+	}
+	return counts, rows.Err()
+}
+
+// GetEventFeedbackResponses lists the responses to the feedback surveys of an event with their respondents
+// and sessions, ordered by session and submission time.
+func (r *reportRepository) GetEventFeedbackResponses(ctx context.Context, eventID string) ([]*domain.FeedbackResponseDetail, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT fr.id, fr.survey_id, fr.session_id, es.name, es.start_time,
+		       u.id, u.name, u.email, fr.answers, fr.submitted_at
+		FROM event_feedback_responses fr
+		JOIN users u ON u.id = fr.user_id
+		LEFT JOIN event_sessions es ON es.id = fr.session_id
+		WHERE fr.event_id = $1
+		ORDER BY es.start_time NULLS FIRST, fr.submitted_at
+	`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feedback responses: %w", err)
+	}
+	defer rows.Close()
+
+	var responses []*domain.FeedbackResponseDetail
+	for rows.Next() {
+		var response domain.FeedbackResponseDetail
+		var answers []byte
+		if err := rows.Scan(
+			&response.ResponseID, &response.SurveyID, &response.SessionID, &response.SessionName, &response.SessionStartTime,
+			&response.UserID, &response.UserName, &response.UserEmail, &answers, &response.SubmittedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback response: %w", err)
+		}
+		if err := json.Unmarshal(answers, &response.Answers); err != nil {
+			return nil, fmt.Errorf("failed to decode feedback answers: %w", err)
+		}
+		responses = append(responses, &response)
+	}
+	return responses, rows.Err()
+}
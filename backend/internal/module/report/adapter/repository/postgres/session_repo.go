@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	event_domain "github.com/attendwise/backend/internal/module/event/domain"
+	"github.com/attendwise/backend/internal/module/report/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetSessionReportSummary retrieves a session with its attendance and Q&A counts.
+func (r *reportRepository) GetSessionReportSummary(ctx context.Context, sessionID string) (*domain.SessionReport, error) {
+	var report domain.SessionReport
+	if err := r.db.QueryRow(ctx, `
+		SELECT es.id, es.event_id, es.name, es.start_time, es.end_time,
+		       (SELECT COUNT(*) FROM event_attendees ea WHERE ea.event_id = es.event_id AND ea.status IN ('registered', 'attended')),
+		       (SELECT COUNT(*) FROM event_session_checkins esc WHERE esc.session_id = es.id AND esc.status = 'success'),
+		       (SELECT COUNT(*) FROM session_questions q WHERE q.session_id = es.id AND q.status <> 'hidden'),
+		       (SELECT COUNT(*) FROM session_questions q WHERE q.session_id = es.id AND q.status = 'answered')
+		FROM event_sessions es
+		WHERE es.id = $1
+	`, sessionID).Scan(
+		&report.SessionID, &report.EventID, &report.SessionName, &report.StartTime, &report.EndTime,
+		&report.Registered, &report.CheckedIn, &report.QuestionsAsked, &report.QuestionsAnswered,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, event_domain.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session report summary: %w", err)
+	}
+	return &report, nil
+}
+
+// GetSessionPollResults lists the polls that were opened during a session with the votes of each option,
+// in the order they were opened.
+func (r *reportRepository) GetSessionPollResults(ctx context.Context, sessionID string) ([]*domain.SessionPollResult, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT p.id, p.question, p.status, p.opened_at, p.closed_at, o.id, o.label, o.vote_count
+		FROM session_polls p
+		JOIN session_poll_options o ON o.poll_id = p.id
+		WHERE p.session_id = $1 AND p.status <> 'draft'
+		ORDER BY p.opened_at, p.id, o.position
+	`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query session poll results: %w", err)
+	}
+	defer rows.Close()
+
+	polls := []*domain.SessionPollResult{}
+	var current *domain.SessionPollResult
+	for rows.Next() {
+		var poll domain.SessionPollResult
+		var option domain.SessionPollOptionResult
+		if err := rows.Scan(
+			&poll.PollID, &poll.Question, &poll.Status, &poll.OpenedAt, &poll.ClosedAt,
+			&option.OptionID, &option.Label, &option.VoteCount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan session poll result: %w", err)
+		}
+		if current == nil || current.PollID != poll.PollID {
+			current = &poll
+			polls = append(polls, current)
+		}
+		current.Options = append(current.Options, &option)
+		current.TotalVotes += option.VoteCount
+	}
+	return polls, rows.Err()
+}
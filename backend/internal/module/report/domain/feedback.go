@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	event_domain "github.com/attendwise/backend/internal/module/event/domain"
+)
+
+// EventFeedbackReport aggregates the responses to the feedback surveys of an event.
+type EventFeedbackReport struct {
+	EventID     string                   `json:"event_id"`
+	GeneratedAt time.Time                `json:"generated_at"`
+	Surveys     []*FeedbackSurveySummary `json:"surveys"`
+}
+
+// FeedbackSurveySummary aggregates the responses to one survey, over the whole event and per session.
+type FeedbackSurveySummary struct {
+	SurveyID      string                     `json:"survey_id"`
+	Title         string                     `json:"title"`
+	SessionID     sql.NullString             `json:"session_id,omitempty"`
+	PerSession    bool                       `json:"per_session"`
+	RequestsSent  int                        `json:"requests_sent"`
+	ResponseCount int                        `json:"response_count"`
+	ResponseRate  float64                    `json:"response_rate"`
+	Questions     []*FeedbackQuestionSummary `json:"questions"`
+	Sessions      []*SessionFeedbackSummary  `json:"sessions"`
+}
+
+// SessionFeedbackSummary aggregates the responses given for one session.
+type SessionFeedbackSummary struct {
+	SessionID     string                     `json:"session_id"`
+	SessionName   sql.NullString             `json:"session_name,omitempty"`
+	StartTime     sql.NullTime               `json:"start_time,omitempty"`
+	ResponseCount int                        `json:"response_count"`
+	Questions     []*FeedbackQuestionSummary `json:"questions"`
+}
+
+// FeedbackQuestionSummary aggregates the answers to one question. Rating and NPS questions get an average
+// and the number of answers per score, NPS questions also their Net Promoter Score (the percentage of
+// promoters, 9-10, minus that of detractors, 0-6). Text questions list their answers.
+type FeedbackQuestionSummary struct {
+	QuestionID   string      `json:"question_id"`
+	Type         string      `json:"type"`
+	Label        string      `json:"label"`
+	AnswerCount  int         `json:"answer_count"`
+	AverageScore float64     `json:"average_score,omitempty"`
+	Distribution map[int]int `json:"distribution,omitempty"`
+	NPS          *float64    `json:"nps,omitempty"`
+	Promoters    int         `json:"promoters,omitempty"`
+	Passives     int         `json:"passives,omitempty"`
+	Detractors   int         `json:"detractors,omitempty"`
+	TextAnswers  []string    `json:"text_answers,omitempty"`
+}
+
+// FeedbackResponseDetail is a single response together with its respondent and session, as exported.
+type FeedbackResponseDetail struct {
+	ResponseID       string                        `json:"response_id"`
+	SurveyID         string                        `json:"survey_id"`
+	SessionID        sql.NullString                `json:"session_id,omitempty"`
+	SessionName      sql.NullString                `json:"session_name,omitempty"`
+	SessionStartTime sql.NullTime                  `json:"session_start_time,omitempty"`
+	UserID           string                        `json:"user_id"`
+	UserName         string                        `json:"user_name"`
+	UserEmail        string                        `json:"user_email"`
+	Answers          []event_domain.FeedbackAnswer `json:"answers"`
+	SubmittedAt      time.Time                     `json:"submitted_at"`
+}
@@ -3,6 +3,8 @@ package domain
 import (
 	"context"
 	"database/sql"
+
+	event_domain "github.com/attendwise/backend/internal/module/event/domain"
 )
 
 // ReportRepository defines the interface for the report data access layer.
@@ -14,6 +16,11 @@ type ReportRepository interface {
 	ExportEventAttendanceReportPDF(ctx context.Context, eventID string) ([]byte, error)
 	GetMonthlySummary(ctx context.Context) (*MonthlySummary, error)
 	GetCommunityEngagementReport(ctx context.Context, communityID string) (*CommunityEngagementReport, error)
+	GetEventFeedbackSurveys(ctx context.Context, eventID string) ([]*event_domain.FeedbackSurvey, error)
+	CountFeedbackRequests(ctx context.Context, eventID string) (map[string]int, error)
+	GetEventFeedbackResponses(ctx context.Context, eventID string) ([]*FeedbackResponseDetail, error)
+	GetSessionReportSummary(ctx context.Context, sessionID string) (*SessionReport, error)
+	GetSessionPollResults(ctx context.Context, sessionID string) ([]*SessionPollResult, error)
 }
 
 // ReportService defines the interface for the report business logic.
@@ -24,6 +31,9 @@ type ReportService interface {
 	ExportEventAttendanceReportPDF(ctx context.Context, eventID string) ([]byte, error)
 	GetMonthlySummary(ctx context.Context) (*MonthlySummary, error)
 	GetCommunityEngagementReport(ctx context.Context, userID, communityID string) (*CommunityEngagementReport, error)
+	GetEventFeedbackReport(ctx context.Context, userID, eventID string) (*EventFeedbackReport, error)
+	ExportEventFeedbackCSV(ctx context.Context, userID, eventID string) ([]byte, error)
+	GetSessionReport(ctx context.Context, userID, sessionID string) (*SessionReport, error)
 }
 
 // SessionAttendeeDetail represents the detailed check-in information for a single attendee in a session.
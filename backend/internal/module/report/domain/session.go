@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SessionReport summarizes what happened during a session: its attendance, its Q&A and the results of its
+// live polls.
+type SessionReport struct {
+	SessionID         string               `json:"session_id"`
+	EventID           string               `json:"event_id"`
+	SessionName       sql.NullString       `json:"session_name,omitempty"`
+	StartTime         time.Time            `json:"start_time"`
+	EndTime           time.Time            `json:"end_time"`
+	GeneratedAt       time.Time            `json:"generated_at"`
+	Registered        int                  `json:"registered"` // Approved registrants of the event
+	CheckedIn         int                  `json:"checked_in"`
+	AttendanceRate    float64              `json:"attendance_rate"` // Percentage of registrants who checked in
+	QuestionsAsked    int                  `json:"questions_asked"` // Hidden questions excluded
+	QuestionsAnswered int                  `json:"questions_answered"`
+	Polls             []*SessionPollResult `json:"polls"`
+}
+
+// SessionPollResult is the outcome of a poll that was run during a session.
+type SessionPollResult struct {
+	PollID            string                     `json:"poll_id"`
+	Question          string                     `json:"question"`
+	Status            string                     `json:"status"`
+	OpenedAt          sql.NullTime               `json:"opened_at,omitempty"`
+	ClosedAt          sql.NullTime               `json:"closed_at,omitempty"`
+	TotalVotes        int                        `json:"total_votes"`
+	ParticipationRate float64                    `json:"participation_rate"` // Percentage of checked-in attendees who voted
+	Options           []*SessionPollOptionResult `json:"options"`
+}
+
+// SessionPollOptionResult is the number and share of votes an option of a poll received.
+type SessionPollOptionResult struct {
+	OptionID   string  `json:"option_id"`
+	Label      string  `json:"label"`
+	VoteCount  int     `json:"vote_count"`
+	Percentage float64 `json:"percentage"`
+}
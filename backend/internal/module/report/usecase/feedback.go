@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	event_domain "github.com/attendwise/backend/internal/module/event/domain"
+	permission_domain "github.com/attendwise/backend/internal/module/permission/domain"
+	"github.com/attendwise/backend/internal/module/report/domain"
+)
+
+// GetEventFeedbackReport aggregates the responses to each feedback survey of an event, over the whole
+// event and per session. It is available to the event staff who can view its attendees.
+func (s *reportService) GetEventFeedbackReport(ctx context.Context, userID, eventID string) (*domain.EventFeedbackReport, error) {
+	if err := s.authorizeEventReport(ctx, userID, eventID); err != nil {
+		return nil, err
+	}
+
+	surveys, err := s.repo.GetEventFeedbackSurveys(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	requestCounts, err := s.repo.CountFeedbackRequests(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := s.repo.GetEventFeedbackResponses(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.EventFeedbackReport{
+		EventID:     eventID,
+		GeneratedAt: time.Now(),
+		Surveys:     []*domain.FeedbackSurveySummary{},
+	}
+	for _, survey := range surveys {
+		var surveyResponses []*domain.FeedbackResponseDetail
+		for _, response := range responses {
+			if response.SurveyID == survey.ID {
+				surveyResponses = append(surveyResponses, response)
+			}
+		}
+
+		summary := &domain.FeedbackSurveySummary{
+			SurveyID:      survey.ID,
+			Title:         survey.Title,
+			SessionID:     survey.SessionID,
+			PerSession:    survey.PerSession,
+			RequestsSent:  requestCounts[survey.ID],
+			ResponseCount: len(surveyResponses),
+			Questions:     summarizeFeedback(survey.Questions, surveyResponses),
+			Sessions:      []*domain.SessionFeedbackSummary{},
+		}
+		if summary.RequestsSent > 0 {
+			summary.ResponseRate = float64(summary.ResponseCount) / float64(summary.RequestsSent) * 100
+		}
+
+		// Responses come ordered by session start, so each session's responses are contiguous.
+		for start := 0; start < len(surveyResponses); {
+			sessionID := surveyResponses[start].SessionID
+			end := start
+			for end < len(surveyResponses) && surveyResponses[end].SessionID == sessionID {
+				end++
+			}
+			if sessionID.Valid {
+				summary.Sessions = append(summary.Sessions, &domain.SessionFeedbackSummary{
+					SessionID:     sessionID.String,
+					SessionName:   surveyResponses[start].SessionName,
+					StartTime:     surveyResponses[start].SessionStartTime,
+					ResponseCount: end - start,
+					Questions:     summarizeFeedback(survey.Questions, surveyResponses[start:end]),
+				})
+			}
+			start = end
+		}
+
+		report.Surveys = append(report.Surveys, summary)
+	}
+	return report, nil
+}
+
+// ExportEventFeedbackCSV exports every answer to the feedback surveys of an event as a CSV, one row per
+// answered question.
+func (s *reportService) ExportEventFeedbackCSV(ctx context.Context, userID, eventID string) ([]byte, error) {
+	if err := s.authorizeEventReport(ctx, userID, eventID); err != nil {
+		return nil, err
+	}
+
+	surveys, err := s.repo.GetEventFeedbackSurveys(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback surveys for CSV export: %w", err)
+	}
+	responses, err := s.repo.GetEventFeedbackResponses(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback responses for CSV export: %w", err)
+	}
+
+	surveyTitles := make(map[string]string, len(surveys))
+	questions := make(map[string]event_domain.FeedbackQuestion)
+	for _, survey := range surveys {
+		surveyTitles[survey.ID] = survey.Title
+		for _, question := range survey.Questions {
+			questions[survey.ID+"/"+question.ID] = question
+		}
+	}
+
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+
+	header := []string{
+		"Survey ID", "Survey Title", "Session ID", "Session Name", "Session Start",
+		"User ID", "User Name", "User Email", "Submitted At",
+		"Question ID", "Question", "Question Type", "Score", "Text",
+	}
+	if err := w.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, response := range responses {
+		sessionStart := ""
+		if response.SessionStartTime.Valid {
+			sessionStart = response.SessionStartTime.Time.Format(time.RFC3339)
+		}
+		for _, answer := range response.Answers {
+			question := questions[response.SurveyID+"/"+answer.QuestionID]
+			score := ""
+			if answer.Score != nil {
+				score = strconv.Itoa(*answer.Score)
+			}
+			record := []string{
+				response.SurveyID,
+				surveyTitles[response.SurveyID],
+				response.SessionID.String,
+				response.SessionName.String,
+				sessionStart,
+				response.UserID,
+				response.UserName,
+				response.UserEmail,
+				response.SubmittedAt.Format(time.RFC3339),
+				answer.QuestionID,
+				question.Label,
+				question.Type,
+				score,
+				answer.Text,
+			}
+			if err := w.Write(record); err != nil {
+				return nil, fmt.Errorf("failed to write CSV record: %w", err)
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("error flushing CSV writer: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// authorizeEventReport allows the event staff who can view the event's attendees.
+func (s *reportService) authorizeEventReport(ctx context.Context, userID, eventID string) error {
+	allowed, err := s.permissionService.HasEventPermission(ctx, eventID, userID, permission_domain.EventPermissionViewAttendees)
+	if err != nil {
+		return fmt.Errorf("failed to check permissions: %w", err)
+	}
+	if !allowed {
+		return permission_domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+// summarizeFeedback aggregates the answers of the given responses to each of the questions.
+func summarizeFeedback(questions []event_domain.FeedbackQuestion, responses []*domain.FeedbackResponseDetail) []*domain.FeedbackQuestionSummary {
+	summaries := make([]*domain.FeedbackQuestionSummary, 0, len(questions))
+	for _, question := range questions {
+		summary := &domain.FeedbackQuestionSummary{
+			QuestionID: question.ID,
+			Type:       question.Type,
+			Label:      question.Label,
+		}
+		if question.Type != event_domain.FeedbackQuestionText {
+			summary.Distribution = make(map[int]int)
+		}
+
+		scoreTotal := 0
+		for _, response := range responses {
+			for _, answer := range response.Answers {
+				if answer.QuestionID != question.ID {
+					continue
+				}
+				if question.Type == event_domain.FeedbackQuestionText {
+					if text := strings.TrimSpace(answer.Text); text != "" {
+						summary.TextAnswers = append(summary.TextAnswers, text)
+						summary.AnswerCount++
+					}
+					continue
+				}
+				if answer.Score == nil {
+					continue
+				}
+				score := *answer.Score
+				summary.AnswerCount++
+				summary.Distribution[score]++
+				scoreTotal += score
+				if question.Type == event_domain.FeedbackQuestionNPS {
+					switch {
+					case score >= 9:
+						summary.Promoters++
+					case score >= 7:
+						summary.Passives++
+					default:
+						summary.Detractors++
+					}
+				}
+			}
+		}
+
+		if summary.AnswerCount > 0 && question.Type != event_domain.FeedbackQuestionText {
+			summary.AverageScore = float64(scoreTotal) / float64(summary.AnswerCount)
+			if question.Type == event_domain.FeedbackQuestionNPS {
+				nps := float64(summary.Promoters-summary.Detractors) / float64(summary.AnswerCount) * 100
+				summary.NPS = &nps
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
@@ -20,6 +20,9 @@ type ReportService interface {
 	ExportEventAttendanceReportPDF(ctx context.Context, eventID string) ([]byte, error)
 	GetMonthlySummary(ctx context.Context) (*domain.MonthlySummary, error)
 	GetCommunityEngagementReport(ctx context.Context, userID, communityID string) (*domain.CommunityEngagementReport, error)
+	GetEventFeedbackReport(ctx context.Context, userID, eventID string) (*domain.EventFeedbackReport, error)
+	ExportEventFeedbackCSV(ctx context.Context, userID, eventID string) ([]byte, error)
+	GetSessionReport(ctx context.Context, userID, sessionID string) (*domain.SessionReport, error)
 }
 
 // reportService is the implementation of the ReportService interface.
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/attendwise/backend/internal/module/report/domain"
+)
+
+// GetSessionReport summarizes a session: its attendance, its Q&A and the results of the polls run during
+// it. It is available to the event staff who can view its attendees.
+func (s *reportService) GetSessionReport(ctx context.Context, userID, sessionID string) (*domain.SessionReport, error) {
+	report, err := s.repo.GetSessionReportSummary(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeEventReport(ctx, userID, report.EventID); err != nil {
+		return nil, err
+	}
+
+	polls, err := s.repo.GetSessionPollResults(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, poll := range polls {
+		if report.CheckedIn > 0 {
+			poll.ParticipationRate = float64(poll.TotalVotes) / float64(report.CheckedIn) * 100
+		}
+		for _, option := range poll.Options {
+			if poll.TotalVotes > 0 {
+				option.Percentage = float64(option.VoteCount) / float64(poll.TotalVotes) * 100
+			}
+		}
+	}
+
+	report.Polls = polls
+	report.GeneratedAt = time.Now()
+	if report.Registered > 0 {
+		report.AttendanceRate = float64(report.CheckedIn) / float64(report.Registered) * 100
+	}
+	return report, nil
+}
@@ -259,6 +259,59 @@ func (r *searchRepository) SearchEvents(ctx context.Context, userID, query strin
 	return results, nil
 }
 
+// SearchEventsNearby finds the upcoming events with a session held at a venue within radiusKm of a point,
+// using the great-circle distance. Sessions without their own venue are held at the event's. Each event is
+// returned once with its closest venue and the next session there. Access follows SearchEvents.
+func (r *searchRepository) SearchEventsNearby(ctx context.Context, userID string, latitude, longitude, radiusKm float64, limit, offset int) ([]*domain.NearbyEventResult, error) {
+	sqlQuery := `
+		WITH candidates AS (
+			SELECT e.id AS event_id, es.id AS session_id, es.start_time, v.id AS venue_id, v.name AS venue_name, v.address AS venue_address,
+			       6371 * 2 * ASIN(LEAST(1, SQRT(
+			           POWER(SIN(RADIANS(v.latitude - $1::float8) / 2), 2) +
+			           COS(RADIANS($1::float8)) * COS(RADIANS(v.latitude)) * POWER(SIN(RADIANS(v.longitude - $2::float8) / 2), 2)
+			       ))) AS distance_km
+			FROM events e
+			JOIN event_sessions es ON es.event_id = e.id
+			JOIN venues v ON v.id = COALESCE(es.venue_id, e.venue_id)
+			LEFT JOIN communities c ON e.community_id = c.id
+			LEFT JOIN community_members cm ON e.community_id = cm.community_id AND cm.user_id = $4
+			WHERE (c.type = 'public' OR (cm.user_id IS NOT NULL AND cm.status = 'active'))
+			  AND e.deleted_at IS NULL
+			  AND e.status IN ('published', 'registration_open', 'registration_closed', 'ongoing')
+			  AND NOT es.is_cancelled AND es.end_time > NOW()
+			  AND v.latitude BETWEEN $1::float8 - $3::float8 / 111.045 AND $1::float8 + $3::float8 / 111.045
+		),
+		nearest AS (
+			SELECT DISTINCT ON (event_id) *
+			FROM candidates
+			WHERE distance_km <= $3::float8
+			ORDER BY event_id, distance_km, start_time
+		)
+		SELECT 'event' AS type, n.distance_km, json_build_object('id', e.id, 'name', e.name, 'slug', e.slug, 'description', e.description, 'cover_image_url', e.cover_image_url, 'start_time', e.start_time, 'end_time', e.end_time, 'community_id', e.community_id, 'community_name', c.name, 'session_id', n.session_id, 'session_start_time', n.start_time, 'venue', json_build_object('id', n.venue_id, 'name', n.venue_name, 'address', n.venue_address)) AS result
+		FROM nearest n
+		JOIN events e ON e.id = n.event_id
+		LEFT JOIN communities c ON e.community_id = c.id
+		ORDER BY n.distance_km, n.start_time
+		LIMIT $5 OFFSET $6
+	`
+	rows, err := r.db.Query(ctx, sqlQuery, latitude, longitude, radiusKm, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute nearby events query: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*domain.NearbyEventResult
+	for rows.Next() {
+		var res domain.NearbyEventResult
+		if err := rows.Scan(&res.Type, &res.DistanceKm, &res.Result); err != nil {
+			return nil, fmt.Errorf("failed to scan nearby event: %w", err)
+		}
+		results = append(results, &res)
+	}
+
+	return results, rows.Err()
+}
+
 func buildPrefixTsQuery(input string) string {
 	terms := strings.FieldsFunc(input, func(r rune) bool {
 		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
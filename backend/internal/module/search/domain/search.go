@@ -3,8 +3,15 @@ package domain
 import (
 	"context"
 	"encoding/json"
+	"errors"
 )
 
+// ErrInvalidGeoQuery is returned for a nearby search around an invalid point or radius.
+var ErrInvalidGeoQuery = errors.New("invalid location search")
+
+// MaxSearchRadiusKm is the largest radius of a nearby event search.
+const MaxSearchRadiusKm = 500
+
 // SearchResult represents a single item in the search results list.
 type SearchResult struct {
 	Type   string          `json:"type"`
@@ -12,6 +19,14 @@ type SearchResult struct {
 	Result json.RawMessage `json:"result"`
 }
 
+// NearbyEventResult is an upcoming event held within the radius of a searched point. DistanceKm is the
+// distance to the event's closest venue that has an upcoming session.
+type NearbyEventResult struct {
+	Type       string          `json:"type"`
+	DistanceKm float64         `json:"distance_km"`
+	Result     json.RawMessage `json:"result"`
+}
+
 // SearchRepository defines the interface for the search data access layer.
 type SearchRepository interface {
 	// Search và SearchUsers không có userID
@@ -23,6 +38,7 @@ type SearchRepository interface {
 
 	// SearchEvents vẫn cần userID
 	SearchEvents(ctx context.Context, userID, query string, limit, offset int) ([]*SearchResult, error)
+	SearchEventsNearby(ctx context.Context, userID string, latitude, longitude, radiusKm float64, limit, offset int) ([]*NearbyEventResult, error)
 }
 
 // SearchService defines the interface for the search business logic.
@@ -36,4 +52,5 @@ type SearchService interface {
 
 	// SearchEvents vẫn cần userID
 	SearchEvents(ctx context.Context, userID, query string, limit, offset int) ([]*SearchResult, error)
+	SearchEventsNearby(ctx context.Context, userID string, latitude, longitude, radiusKm float64, limit, offset int) ([]*NearbyEventResult, error)
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/attendwise/backend/internal/module/search/domain"
@@ -43,6 +44,18 @@ func (s *searchService) SearchEvents(ctx context.Context, userID, query string,
 	return s.repo.SearchEvents(ctx, userID, cleanQuery, limit, offset)
 }
 
+// SearchEventsNearby finds the upcoming events held within radiusKm of a point, closest first, among those the
+// user can see like SearchEvents.
+func (s *searchService) SearchEventsNearby(ctx context.Context, userID string, latitude, longitude, radiusKm float64, limit, offset int) ([]*domain.NearbyEventResult, error) {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil, fmt.Errorf("%w: latitude must be between -90 and 90 and longitude between -180 and 180", domain.ErrInvalidGeoQuery)
+	}
+	if radiusKm <= 0 || radiusKm > domain.MaxSearchRadiusKm {
+		return nil, fmt.Errorf("%w: radius_km must be between 0 and %d", domain.ErrInvalidGeoQuery, domain.MaxSearchRadiusKm)
+	}
+	return s.repo.SearchEventsNearby(ctx, userID, latitude, longitude, radiusKm, limit, offset)
+}
+
 // Hàm SearchUsers không có userID - đã đúng
 func (s *searchService) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*domain.SearchResult, error) {
 	cleanQuery := strings.TrimSpace(query)
@@ -33,12 +33,12 @@ func NewUserRepository(db *pgxpool.Pool, redis *redis.Client) domain.UserReposit
 
 func (r *userRepository) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
 	query := `
-        INSERT INTO users (name, email, phone, password_hash, bio, company, position, location, profile_picture_url, google_id)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        INSERT INTO users (name, email, phone, password_hash, bio, company, position, location, profile_picture_url, google_id, is_verified)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
         RETURNING id, created_at, updated_at, is_active, is_verified, face_id_enrolled
     `
 	err := r.db.QueryRow(ctx, query,
-		user.Name, user.Email, user.Phone, user.PasswordHash, user.Bio, user.Company, user.Position, user.Location, user.ProfilePictureURL, user.GoogleID,
+		user.Name, user.Email, user.Phone, user.PasswordHash, user.Bio, user.Company, user.Position, user.Location, user.ProfilePictureURL, user.GoogleID, user.IsVerified,
 	).Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt, &user.IsActive, &user.IsVerified, &user.FaceIDEnrolled)
 
 	if err != nil {
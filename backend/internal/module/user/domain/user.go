@@ -198,6 +198,11 @@ type UserGraphRepository interface {
 	CheckGDS(ctx context.Context) (bool, error)
 }
 
+// WhitelistLinker links the event whitelist entries added by email to the account that verified that email.
+type WhitelistLinker interface {
+	LinkUserWhitelistEntries(ctx context.Context, userID string) (int64, error)
+}
+
 // UserService defines the interface for user-related business logic.
 type UserService interface {
 	Register(ctx context.Context, name, email, password, phone, company, position, profilePictureURL, bio, location string) (*User, error)
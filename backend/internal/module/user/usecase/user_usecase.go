@@ -23,16 +23,18 @@ type userService struct {
 	userGraphRepo domain.UserGraphRepository
 	neo4jRepo     community_domain.Neo4jCommunityRepository
 	aiClient      *platform.AIClient
+	whitelists    domain.WhitelistLinker
 	jwtSecret     string
 }
 
 // NewUserService creates a new userService instance.
-func NewUserService(userRepo domain.UserRepository, userGraphRepo domain.UserGraphRepository, neo4jRepo community_domain.Neo4jCommunityRepository, aiClient *platform.AIClient, jwtSecret string) domain.UserService {
+func NewUserService(userRepo domain.UserRepository, userGraphRepo domain.UserGraphRepository, neo4jRepo community_domain.Neo4jCommunityRepository, aiClient *platform.AIClient, whitelists domain.WhitelistLinker, jwtSecret string) domain.UserService {
 	return &userService{
 		userRepo:      userRepo,
 		userGraphRepo: userGraphRepo,
 		neo4jRepo:     neo4jRepo,
 		aiClient:      aiClient,
+		whitelists:    whitelists,
 		jwtSecret:     jwtSecret,
 	}
 }
@@ -257,6 +259,7 @@ func (s *userService) GoogleLoginOrRegister(ctx context.Context, googleUser *goo
 				return nil, "", "", fmt.Errorf("failed to link Google ID to existing user: %w", err)
 			}
 			user.GoogleID = sql.NullString{String: googleUser.ID, Valid: true} // Update in memory
+			go s.linkWhitelistEntries(user.ID)
 		} else {
 			// 3. No existing user found, create a new one
 			log.Printf("Creating new user for Google ID %s (%s).", googleUser.ID, googleUser.Email)
@@ -279,6 +282,7 @@ func (s *userService) GoogleLoginOrRegister(ctx context.Context, googleUser *goo
 					log.Printf("ERROR: Failed to create user node in graph database for Google user ID %s: %v", user.ID, err)
 				}
 			}()
+			go s.linkWhitelistEntries(user.ID)
 		}
 	}
 
@@ -443,3 +447,14 @@ func (s *userService) Unfriend(ctx context.Context, userID1, userID2 string) err
 func (s *userService) ListFriends(ctx context.Context, userID string) ([]domain.User, error) {
 	return s.userRepo.GetFriends(ctx, userID)
 }
+
+// linkWhitelistEntries links the event whitelist entries added with the user's email to the account once
+// the email is verified, so that events the user was whitelisted for before signing up recognize them.
+func (s *userService) linkWhitelistEntries(userID string) {
+	if s.whitelists == nil {
+		return
+	}
+	if _, err := s.whitelists.LinkUserWhitelistEntries(context.Background(), userID); err != nil {
+		log.Printf("ERROR: Failed to link event whitelist entries to user ID %s: %v", userID, err)
+	}
+}
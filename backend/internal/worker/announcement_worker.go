@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	event_usecase "github.com/attendwise/backend/internal/module/event/usecase"
+)
+
+// AnnouncementWorker sends the event announcements hosts scheduled for later.
+type AnnouncementWorker struct {
+	eventService event_usecase.EventService
+}
+
+// NewAnnouncementWorker creates a new instance of AnnouncementWorker.
+func NewAnnouncementWorker(eventService event_usecase.EventService) *AnnouncementWorker {
+	return &AnnouncementWorker{
+		eventService: eventService,
+	}
+}
+
+// Start sends the due announcements once on startup and then every minute.
+func (w *AnnouncementWorker) Start() {
+	log.Println("Starting Announcement Worker...")
+	w.sendAnnouncements()
+
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		w.sendAnnouncements()
+	}
+}
+
+func (w *AnnouncementWorker) sendAnnouncements() {
+	if err := w.eventService.SendDueAnnouncements(context.Background()); err != nil {
+		log.Printf("ERROR: AnnouncementWorker could not send scheduled announcements: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	event_usecase "github.com/attendwise/backend/internal/module/event/usecase"
+)
+
+// EventLifecycleWorker moves events through their lifecycle as time goes by: it opens and closes
+// registration according to the registration window and marks events ongoing and completed according
+// to their sessions.
+type EventLifecycleWorker struct {
+	eventService event_usecase.EventService
+}
+
+// NewEventLifecycleWorker creates a new instance of EventLifecycleWorker.
+func NewEventLifecycleWorker(eventService event_usecase.EventService) *EventLifecycleWorker {
+	return &EventLifecycleWorker{
+		eventService: eventService,
+	}
+}
+
+// Start advances event lifecycles once on startup and then every minute.
+func (w *EventLifecycleWorker) Start() {
+	log.Println("Starting Event Lifecycle Worker...")
+	w.advanceLifecycles()
+
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		w.advanceLifecycles()
+	}
+}
+
+func (w *EventLifecycleWorker) advanceLifecycles() {
+	if err := w.eventService.AdvanceEventLifecycles(context.Background()); err != nil {
+		log.Printf("ERROR: EventLifecycleWorker could not advance event lifecycles: %v", err)
+	}
+}
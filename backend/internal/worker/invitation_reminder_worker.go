@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	event_usecase "github.com/attendwise/backend/internal/module/event/usecase"
+)
+
+// InvitationReminderWorker reminds the invitees of upcoming events who have not responded to their invitation.
+type InvitationReminderWorker struct {
+	eventService event_usecase.EventService
+}
+
+// NewInvitationReminderWorker creates a new instance of InvitationReminderWorker.
+func NewInvitationReminderWorker(eventService event_usecase.EventService) *InvitationReminderWorker {
+	return &InvitationReminderWorker{
+		eventService: eventService,
+	}
+}
+
+// Start sends the due invitation reminders once on startup and then every hour.
+func (w *InvitationReminderWorker) Start() {
+	log.Println("Starting Invitation Reminder Worker...")
+	w.sendReminders()
+
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		w.sendReminders()
+	}
+}
+
+func (w *InvitationReminderWorker) sendReminders() {
+	if err := w.eventService.SendInvitationReminders(context.Background()); err != nil {
+		log.Printf("ERROR: InvitationReminderWorker could not send invitation reminders: %v", err)
+	}
+}
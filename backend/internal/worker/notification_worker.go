@@ -18,6 +18,12 @@ import (
 	"github.com/nats-io/nats.go"
 )
 
+// reminderBatchSize is the number of due reminders claimed at a time.
+const reminderBatchSize = 100
+
+// feedbackRequestBatchSize is the number of due feedback requests claimed at a time.
+const feedbackRequestBatchSize = 100
+
 // NotificationWorker handles background jobs related to sending notifications.
 type NotificationWorker struct {
 	eventRepo           event_domain.EventRepository
@@ -51,11 +57,22 @@ func (w *NotificationWorker) Start() {
 }
 
 func (w *NotificationWorker) startEventReminderScanner() {
-	// Run once on start, then every minute.
-	go w.scanAndNotify()
-	ticker := time.NewTicker(1 * time.Minute)
-	for range ticker.C {
-		go w.scanAndNotify()
+	// Reconcile all reminders on start and then every hour; dispatch due reminders and feedback requests every minute.
+	w.syncAllReminders()
+	w.dispatchDueReminders()
+	w.dispatchFeedbackRequests()
+	dispatchTicker := time.NewTicker(1 * time.Minute)
+	defer dispatchTicker.Stop()
+	syncTicker := time.NewTicker(1 * time.Hour)
+	defer syncTicker.Stop()
+	for {
+		select {
+		case <-dispatchTicker.C:
+			w.dispatchDueReminders()
+			w.dispatchFeedbackRequests()
+		case <-syncTicker.C:
+			w.syncAllReminders()
+		}
 	}
 }
 
@@ -95,7 +112,440 @@ func (w *NotificationWorker) subscribeToActionEvents() {
 		log.Printf("Error subscribing to 'chat.*': %v", err)
 	}
 
-	log.Println("Subscribed to NATS subjects: comment.created, chat.*, community.post.created, community.comment.created, community.reaction.created")
+	// Subscription for cancelled events
+	cancelledSubject := event_domain.EventStatusChangedSubjectPrefix + event_domain.EventStatusCancelled
+	_, err = w.nc.Subscribe(cancelledSubject, w.handleEventCancelled)
+	if err != nil {
+		log.Printf("Error subscribing to '%s': %v", cancelledSubject, err)
+	}
+
+	// Subscription for issued certificates
+	_, err = w.nc.Subscribe(event_domain.CertificateIssuedSubject, w.handleCertificateIssued)
+	if err != nil {
+		log.Printf("Error subscribing to '%s': %v", event_domain.CertificateIssuedSubject, err)
+	}
+
+	_, err = w.nc.Subscribe(event_domain.EventInvitationSubject, w.handleEventInvitation)
+	if err != nil {
+		log.Printf("Error subscribing to '%s': %v", event_domain.EventInvitationSubject, err)
+	}
+
+	_, err = w.nc.Subscribe(event_domain.EventAnnouncementSubject, w.handleEventAnnouncement)
+	if err != nil {
+		log.Printf("Error subscribing to '%s': %v", event_domain.EventAnnouncementSubject, err)
+	}
+
+	// Subscriptions for changed sessions
+	_, err = w.nc.Subscribe(event_domain.SessionRescheduledSubject, w.handleSessionRescheduled)
+	if err != nil {
+		log.Printf("Error subscribing to '%s': %v", event_domain.SessionRescheduledSubject, err)
+	}
+
+	_, err = w.nc.Subscribe(event_domain.SessionCancelledSubject, w.handleSessionCancelled)
+	if err != nil {
+		log.Printf("Error subscribing to '%s': %v", event_domain.SessionCancelledSubject, err)
+	}
+
+	// Subscription for registration transfers
+	_, err = w.nc.Subscribe(event_domain.RegistrationTransferSubject, w.handleRegistrationTransfer)
+	if err != nil {
+		log.Printf("Error subscribing to '%s': %v", event_domain.RegistrationTransferSubject, err)
+	}
+
+	// Subscription for approved and rejected registrations
+	_, err = w.nc.Subscribe(event_domain.RegistrationDecisionSubject, w.handleRegistrationDecision)
+	if err != nil {
+		log.Printf("Error subscribing to '%s': %v", event_domain.RegistrationDecisionSubject, err)
+	}
+
+	log.Println("Subscribed to NATS subjects: comment.created, chat.*, community.post.created, community.comment.created, community.reaction.created, events.status.cancelled, events.certificate.issued, events.invitation.sent, events.announcement.sent, events.session.rescheduled, events.session.cancelled, events.registration.transfer, events.registration.decided")
+}
+
+// handleRegistrationDecision tells registrants that their pending registration was approved or rejected,
+// with the reason given.
+func (w *NotificationWorker) handleRegistrationDecision(m *nats.Msg) {
+	var event event_domain.RegistrationDecisionEvent
+	if err := json.Unmarshal(m.Data, &event); err != nil {
+		log.Printf("[ERROR] Error unmarshalling RegistrationDecisionEvent payload: %v", err)
+		return
+	}
+
+	var title, message string
+	switch event.Decision {
+	case event_domain.RegistrationDecisionApprove:
+		title = fmt.Sprintf("You're registered for %s", event.EventName)
+		message = fmt.Sprintf("Your registration for '%s' was approved. Get your ticket from the event page.", event.EventName)
+	case event_domain.RegistrationDecisionReject:
+		title = fmt.Sprintf("Your registration for %s was declined", event.EventName)
+		message = fmt.Sprintf("The host did not approve your registration for '%s'.", event.EventName)
+	default:
+		log.Printf("[WARN] Unknown registration decision %q", event.Decision)
+		return
+	}
+	if event.Reason != "" && event.Decision == event_domain.RegistrationDecisionReject {
+		message = fmt.Sprintf("%s Reason: %s", message, event.Reason)
+	} else if event.Reason != "" {
+		message = fmt.Sprintf("%s %s", message, event.Reason)
+	}
+
+	ctx := context.Background()
+	link := fmt.Sprintf("/events/%s", event.EventID)
+	for _, userID := range event.UserIDs {
+		preferences, err := w.notificationService.GetPreferences(ctx, userID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to get notification preferences of user %s: %v", userID, err)
+			continue
+		}
+		channels := preferences.Channels
+		if channels.InApp.Allows(notification_domain.RegistrationApprovedNotification) {
+			_, err := w.notificationService.CreateNotification(ctx, userID, notification_domain.RegistrationApprovedNotification, title, message, link, sql.NullString{String: event.DecidedBy, Valid: event.DecidedBy != ""}, sql.NullString{}, sql.NullString{}, sql.NullString{String: event.EventID, Valid: true}, sql.NullString{})
+			if err != nil {
+				log.Printf("[ERROR] Failed to create registration decision notification for user %s: %v", userID, err)
+			}
+		}
+		if channels.Email.Allows(notification_domain.RegistrationApprovedNotification) {
+			// Placeholder for sending registration decision email
+			log.Printf("Registration %s email would be sent to user %s for event %s", event.Decision, userID, event.EventName)
+		}
+	}
+}
+
+// handleRegistrationTransfer tells the parties of a registration transfer about it: the recipient when it is
+// offered, both when it completes or is rejected, and the sender when it is declined.
+func (w *NotificationWorker) handleRegistrationTransfer(m *nats.Msg) {
+	var event event_domain.RegistrationTransferEvent
+	if err := json.Unmarshal(m.Data, &event); err != nil {
+		log.Printf("[ERROR] Error unmarshalling RegistrationTransferEvent payload: %v", err)
+		return
+	}
+
+	var senderTitle, senderMessage, recipientTitle, recipientMessage string
+	switch event.Kind {
+	case event_domain.TransferKindOffered:
+		recipientTitle = fmt.Sprintf("A registration for %s is offered to you", event.EventName)
+		recipientMessage = fmt.Sprintf("Someone wants to transfer their registration for '%s' to you. Accept it to take their place.", event.EventName)
+		if event.Message != "" {
+			recipientMessage = fmt.Sprintf("%s Their message: %s", recipientMessage, event.Message)
+		}
+	case event_domain.TransferKindCompleted:
+		senderTitle = fmt.Sprintf("Your registration for %s was transferred", event.EventName)
+		senderMessage = fmt.Sprintf("Your registration for '%s' now belongs to its recipient. Your tickets no longer work.", event.EventName)
+		recipientTitle = fmt.Sprintf("You're registered for %s", event.EventName)
+		recipientMessage = fmt.Sprintf("The registration for '%s' transferred to you is yours. Get your ticket from the event page.", event.EventName)
+	case event_domain.TransferKindDeclined:
+		senderTitle = fmt.Sprintf("Your transfer for %s was declined", event.EventName)
+		senderMessage = fmt.Sprintf("The recipient declined your registration for '%s'. You are still registered.", event.EventName)
+	case event_domain.TransferKindRejected:
+		senderTitle = fmt.Sprintf("Your transfer for %s was not approved", event.EventName)
+		senderMessage = fmt.Sprintf("The host did not approve transferring your registration for '%s'. You are still registered.", event.EventName)
+		recipientTitle = fmt.Sprintf("The transfer for %s was not approved", event.EventName)
+		recipientMessage = fmt.Sprintf("The host did not approve the registration for '%s' transferred to you.", event.EventName)
+	default:
+		log.Printf("[WARN] Unknown registration transfer kind %q", event.Kind)
+		return
+	}
+
+	ctx := context.Background()
+	link := fmt.Sprintf("/events/%s", event.EventID)
+	eventRef := sql.NullString{String: event.EventID, Valid: true}
+	notify := func(userID, title, message string, actorID sql.NullString) {
+		preferences, err := w.notificationService.GetPreferences(ctx, userID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to get notification preferences of user %s: %v", userID, err)
+			return
+		}
+		channels := preferences.Channels
+		if channels.InApp.Allows(notification_domain.RegistrationTransferNotification) {
+			_, err := w.notificationService.CreateNotification(ctx, userID, notification_domain.RegistrationTransferNotification, title, message, link, actorID, sql.NullString{}, sql.NullString{}, eventRef, sql.NullString{})
+			if err != nil {
+				log.Printf("[ERROR] Failed to create registration transfer notification for user %s: %v", userID, err)
+			}
+		}
+		if channels.Email.Allows(notification_domain.RegistrationTransferNotification) {
+			// Placeholder for sending transfer email
+			log.Printf("Registration transfer email would be sent to user %s for event %s (%s)", userID, event.EventName, event.Kind)
+		}
+	}
+
+	if senderTitle != "" {
+		notify(event.FromUserID, senderTitle, senderMessage, sql.NullString{})
+	}
+	if recipientTitle == "" {
+		return
+	}
+	if event.ToUserID == "" {
+		// Placeholder for sending transfer email
+		log.Printf("Registration transfer email would be sent to %s for event %s (%s)", event.ToEmail, event.EventName, event.Kind)
+		return
+	}
+	notify(event.ToUserID, recipientTitle, recipientMessage, sql.NullString{String: event.FromUserID, Valid: true})
+}
+
+// handleSessionRescheduled tells the attendees of a rescheduled session about its new time and place, and
+// asks those who must re-confirm whether they still attend.
+func (w *NotificationWorker) handleSessionRescheduled(m *nats.Msg) {
+	var event event_domain.SessionRescheduledEvent
+	if err := json.Unmarshal(m.Data, &event); err != nil {
+		log.Printf("[ERROR] Error unmarshalling SessionRescheduledEvent payload: %v", err)
+		return
+	}
+
+	subject := sessionSubject(event.EventName, event.SessionName)
+	loc := timezoneLocation(event.Timezone)
+	title := fmt.Sprintf("%s has been rescheduled", subject)
+	message := fmt.Sprintf("'%s' moved from %s to %s.", subject, formatSessionTime(event.PreviousStartTime, loc), formatSessionTime(event.NewStartTime, loc))
+	if event.NewLocation != event.PreviousLocation && event.NewLocation != "" {
+		message = fmt.Sprintf("%s It now takes place at %s instead of %s.", message, event.NewLocation, event.PreviousLocation)
+	}
+	if event.MeetingURLChanged {
+		message = fmt.Sprintf("%s The online meeting link changed; get the new one from the event page.", message)
+	}
+	if event.Reason != "" {
+		message = fmt.Sprintf("%s Reason: %s", message, event.Reason)
+	}
+	reconfirm := make(map[string]bool, len(event.ReconfirmUserIDs))
+	for _, userID := range event.ReconfirmUserIDs {
+		reconfirm[userID] = true
+	}
+	link := fmt.Sprintf("/events/%s", event.EventID)
+
+	ctx := context.Background()
+	for _, userID := range event.UserIDs {
+		preferences, err := w.notificationService.GetPreferences(ctx, userID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to get notification preferences of user %s: %v", userID, err)
+			continue
+		}
+		userMessage := message
+		if reconfirm[userID] {
+			userMessage = fmt.Sprintf("%s Please confirm whether you still attend; declining frees your seat.", message)
+		}
+		channels := preferences.Channels
+		if channels.InApp.Allows(notification_domain.SessionRescheduledNotification) {
+			_, err := w.notificationService.CreateNotification(ctx, userID, notification_domain.SessionRescheduledNotification, title, userMessage, link, sql.NullString{String: event.RescheduledBy, Valid: event.RescheduledBy != ""}, sql.NullString{}, sql.NullString{}, sql.NullString{String: event.EventID, Valid: true}, sql.NullString{})
+			if err != nil {
+				log.Printf("[ERROR] Failed to create session rescheduled notification for user %s: %v", userID, err)
+			}
+		}
+		if channels.Email.Allows(notification_domain.SessionRescheduledNotification) {
+			// Placeholder for sending session rescheduled email
+			log.Printf("Session rescheduled email would be sent to user %s for session %s", userID, event.SessionID)
+		}
+	}
+}
+
+// handleSessionCancelled tells the attendees of a cancelled session that it will not take place.
+func (w *NotificationWorker) handleSessionCancelled(m *nats.Msg) {
+	var event event_domain.SessionCancelledEvent
+	if err := json.Unmarshal(m.Data, &event); err != nil {
+		log.Printf("[ERROR] Error unmarshalling SessionCancelledEvent payload: %v", err)
+		return
+	}
+
+	subject := sessionSubject(event.EventName, event.SessionName)
+	title := fmt.Sprintf("%s has been cancelled", subject)
+	message := fmt.Sprintf("'%s' on %s has been cancelled.", subject, formatSessionTime(event.StartTime, timezoneLocation(event.Timezone)))
+	if event.Reason != "" {
+		message = fmt.Sprintf("%s Reason: %s", message, event.Reason)
+	}
+	link := fmt.Sprintf("/events/%s", event.EventID)
+
+	ctx := context.Background()
+	for _, userID := range event.UserIDs {
+		preferences, err := w.notificationService.GetPreferences(ctx, userID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to get notification preferences of user %s: %v", userID, err)
+			continue
+		}
+		channels := preferences.Channels
+		if channels.InApp.Allows(notification_domain.EventCancelledNotification) {
+			_, err := w.notificationService.CreateNotification(ctx, userID, notification_domain.EventCancelledNotification, title, message, link, sql.NullString{String: event.TriggeredBy, Valid: event.TriggeredBy != ""}, sql.NullString{}, sql.NullString{}, sql.NullString{String: event.EventID, Valid: true}, sql.NullString{})
+			if err != nil {
+				log.Printf("[ERROR] Failed to create session cancelled notification for user %s: %v", userID, err)
+			}
+		}
+		if channels.Email.Allows(notification_domain.EventCancelledNotification) {
+			// Placeholder for sending session cancelled email
+			log.Printf("Session cancelled email would be sent to user %s for session %s", userID, event.SessionID)
+		}
+	}
+}
+
+// sessionSubject names a session in notifications: the event's name, followed by the session's if it has one.
+func sessionSubject(eventName, sessionName string) string {
+	if sessionName == "" {
+		return eventName
+	}
+	return fmt.Sprintf("%s: %s", eventName, sessionName)
+}
+
+// timezoneLocation loads an IANA timezone, falling back to UTC.
+func timezoneLocation(timezone string) *time.Location {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		return time.UTC
+	}
+	return loc
+}
+
+// formatSessionTime formats a session's start time for notifications, e.g. "Mon, Mar 2 at 15:04 CET".
+func formatSessionTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format("Mon, Jan 2 at 15:04 MST")
+}
+
+// handleEventAnnouncement delivers a host's announcement to its recipients through each channel they enabled
+// for announcements.
+func (w *NotificationWorker) handleEventAnnouncement(m *nats.Msg) {
+	var event event_domain.EventAnnouncementSentEvent
+	if err := json.Unmarshal(m.Data, &event); err != nil {
+		log.Printf("[ERROR] Error unmarshalling EventAnnouncementSentEvent payload: %v", err)
+		return
+	}
+
+	ctx := context.Background()
+	title := fmt.Sprintf("%s: %s", sessionSubject(event.EventName, event.SessionName), event.Title)
+	link := fmt.Sprintf("/events/%s", event.EventID)
+
+	for _, userID := range event.UserIDs {
+		preferences, err := w.notificationService.GetPreferences(ctx, userID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to get notification preferences of user %s: %v", userID, err)
+			continue
+		}
+		channels := preferences.Channels
+		if channels.InApp.Allows(notification_domain.EventAnnouncementNotification) {
+			_, err := w.notificationService.CreateNotification(ctx, userID, notification_domain.EventAnnouncementNotification, title, event.Message, link, sql.NullString{String: event.AuthorID, Valid: event.AuthorID != ""}, sql.NullString{}, sql.NullString{}, sql.NullString{String: event.EventID, Valid: true}, sql.NullString{})
+			if err != nil {
+				log.Printf("[ERROR] Failed to create event announcement notification for user %s: %v", userID, err)
+			}
+		}
+		if channels.Email.Allows(notification_domain.EventAnnouncementNotification) {
+			// Placeholder for sending announcement email
+			log.Printf("Announcement email would be sent to user %s for event %s: %s", userID, event.EventName, event.Title)
+		}
+		if channels.Push.Allows(notification_domain.EventAnnouncementNotification) {
+			// Placeholder for sending push notification
+			log.Printf("Announcement push notification would be sent to user %s for event %s: %s", userID, event.EventName, event.Title)
+		}
+	}
+}
+
+// handleEventInvitation tells an invitee about an event invitation, or reminds them to respond to it.
+func (w *NotificationWorker) handleEventInvitation(m *nats.Msg) {
+	var event event_domain.EventInvitationSentEvent
+	if err := json.Unmarshal(m.Data, &event); err != nil {
+		log.Printf("[ERROR] Error unmarshalling EventInvitationSentEvent payload: %v", err)
+		return
+	}
+
+	if event.UserID == "" {
+		// Placeholder for sending invitation email
+		log.Printf("Invitation email would be sent to %s for event %s (reminder: %t)", event.Email, event.EventName, event.Reminder)
+		return
+	}
+
+	title := fmt.Sprintf("You're invited to %s", event.EventName)
+	message := fmt.Sprintf("You have been invited to '%s'. Let the host know whether you're going.", event.EventName)
+	if event.Message != "" {
+		message = fmt.Sprintf("You have been invited to '%s': %s", event.EventName, event.Message)
+	}
+	if event.Reminder {
+		title = fmt.Sprintf("Are you going to %s?", event.EventName)
+		message = fmt.Sprintf("You haven't answered your invitation to '%s' yet.", event.EventName)
+	}
+	link := fmt.Sprintf("/events/%s", event.EventID)
+	ctx := context.Background()
+	preferences, err := w.notificationService.GetPreferences(ctx, event.UserID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get notification preferences of user %s: %v", event.UserID, err)
+		return
+	}
+	channels := preferences.Channels
+	if channels.InApp.Allows(notification_domain.EventInvitationNotification) {
+		_, err := w.notificationService.CreateNotification(ctx, event.UserID, notification_domain.EventInvitationNotification, title, message, link, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{String: event.EventID, Valid: true}, sql.NullString{})
+		if err != nil {
+			log.Printf("[ERROR] Failed to create event invitation notification for user %s: %v", event.UserID, err)
+		}
+	}
+	if channels.Email.Allows(notification_domain.EventInvitationNotification) {
+		// Placeholder for sending invitation email
+		log.Printf("Invitation email would be sent to user %s for event %s (reminder: %t)", event.UserID, event.EventName, event.Reminder)
+	}
+}
+
+// handleCertificateIssued tells an attendee that their attendance certificate is ready.
+func (w *NotificationWorker) handleCertificateIssued(m *nats.Msg) {
+	var event event_domain.CertificateIssuedEvent
+	if err := json.Unmarshal(m.Data, &event); err != nil {
+		log.Printf("[ERROR] Error unmarshalling CertificateIssuedEvent payload: %v", err)
+		return
+	}
+
+	title := "Your certificate is ready"
+	message := fmt.Sprintf("Your certificate of attendance for '%s' has been issued.", event.EventName)
+	link := fmt.Sprintf("/events/%s", event.EventID)
+	ctx := context.Background()
+	preferences, err := w.notificationService.GetPreferences(ctx, event.UserID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get notification preferences of user %s: %v", event.UserID, err)
+		return
+	}
+	channels := preferences.Channels
+	if channels.InApp.Allows(notification_domain.CertificateIssuedNotification) {
+		_, err := w.notificationService.CreateNotification(ctx, event.UserID, notification_domain.CertificateIssuedNotification, title, message, link, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{String: event.EventID, Valid: true}, sql.NullString{})
+		if err != nil {
+			log.Printf("[ERROR] Failed to create certificate issued notification for user %s: %v", event.UserID, err)
+		}
+	}
+	if channels.Email.Allows(notification_domain.CertificateIssuedNotification) {
+		// Placeholder for sending certificate email
+		log.Printf("Certificate email would be sent to user %s for event %s", event.UserID, event.EventName)
+	}
+}
+
+// handleEventCancelled tells the attendees of a cancelled event that it will not take place.
+func (w *NotificationWorker) handleEventCancelled(m *nats.Msg) {
+	var event event_domain.EventStatusChangedEvent
+	if err := json.Unmarshal(m.Data, &event); err != nil {
+		log.Printf("[ERROR] Error unmarshalling EventStatusChangedEvent payload: %v", err)
+		return
+	}
+
+	ctx := context.Background()
+	attendees, err := w.eventRepo.GetEventAttendees(ctx, event.EventID, "", "")
+	if err != nil {
+		log.Printf("[ERROR] Failed to get attendees of cancelled event %s: %v", event.EventID, err)
+		return
+	}
+
+	title := fmt.Sprintf("%s has been cancelled", event.EventName)
+	message := fmt.Sprintf("The event '%s' you registered for has been cancelled.", event.EventName)
+	if event.Reason != "" {
+		message = fmt.Sprintf("%s Reason: %s", message, event.Reason)
+	}
+	link := fmt.Sprintf("/events/%s", event.EventID)
+
+	for _, attendee := range attendees {
+		if attendee.Status == "cancelled" || attendee.UserID == event.TriggeredBy {
+			continue
+		}
+		preferences, err := w.notificationService.GetPreferences(ctx, attendee.UserID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to get notification preferences of user %s: %v", attendee.UserID, err)
+			continue
+		}
+		channels := preferences.Channels
+		if channels.InApp.Allows(notification_domain.EventCancelledNotification) {
+			_, err := w.notificationService.CreateNotification(ctx, attendee.UserID, notification_domain.EventCancelledNotification, title, message, link, sql.NullString{String: event.TriggeredBy, Valid: event.TriggeredBy != ""}, sql.NullString{}, sql.NullString{}, sql.NullString{String: event.EventID, Valid: true}, sql.NullString{})
+			if err != nil {
+				log.Printf("[ERROR] Failed to create event cancelled notification for user %s: %v", attendee.UserID, err)
+			}
+		}
+		if channels.Email.Allows(notification_domain.EventCancelledNotification) {
+			// Placeholder for sending event cancelled email
+			log.Printf("Event cancelled email would be sent to user %s for event %s", attendee.UserID, event.EventID)
+		}
+	}
 }
 
 func (w *NotificationWorker) handleMessageCreated(m *nats.Msg) {
@@ -358,34 +808,120 @@ func (w *NotificationWorker) handleReactionCreatedEvent(m *nats.Msg) {
 	log.Printf("[DEBUG] Worker finished processing ReactionCreatedEvent for target: %s", event.TargetID)
 }
 
-func (w *NotificationWorker) scanAndNotify() {
-	log.Println("Scanning for upcoming event sessions to send reminders...")
+// dispatchDueReminders sends the session reminders that are due. Reminders are claimed before they are sent,
+// so each one goes out once even if several dispatchers run, and are marked sent or failed afterwards.
+func (w *NotificationWorker) dispatchDueReminders() {
 	ctx := context.Background()
 
-	// Define reminder windows (e.g., 15 minutes and 24 hours)
-	windows := []time.Duration{15 * time.Minute, 24 * time.Hour}
+	for {
+		reminders, err := w.eventRepo.ClaimDueReminders(ctx, reminderBatchSize)
+		if err != nil {
+			log.Printf("Error claiming due reminders: %v", err)
+			return
+		}
 
-	for _, window := range windows {
-		from := time.Now().Add(window)
-		to := from.Add(1 * time.Minute) // Check in a 1-minute slice to avoid duplicate notifications
+		for _, reminder := range reminders {
+			if err := w.sendReminder(ctx, reminder); err != nil {
+				log.Printf("Error sending reminder %s to user %s for session %s: %v", reminder.ID, reminder.UserID, reminder.SessionID, err)
+				if err := w.eventRepo.MarkReminderFailed(ctx, reminder.ID, err.Error()); err != nil {
+					log.Printf("Error marking reminder %s as failed: %v", reminder.ID, err)
+				}
+				continue
+			}
+			if err := w.eventRepo.MarkReminderSent(ctx, reminder.ID); err != nil {
+				log.Printf("Error marking reminder %s as sent: %v", reminder.ID, err)
+			}
+		}
 
-		sessions, err := w.eventRepo.GetUpcomingSessionsWithAttendees(ctx, from, to)
-		if err != nil {
-			log.Printf("Error getting upcoming sessions: %v", err)
-			continue
+		if len(reminders) < reminderBatchSize {
+			return
 		}
+	}
+}
 
-		for _, session := range sessions {
-			title := fmt.Sprintf("Reminder: %s is starting soon!", session.EventName)
-			message := fmt.Sprintf("Your session for '%s' is scheduled to start at %s.", session.EventName, session.StartTime.Format(time.Kitchen))
-			link := fmt.Sprintf("/events/%s", session.EventID)
+// sendReminder delivers a reminder on each of its channels that the user enabled for event reminders.
+func (w *NotificationWorker) sendReminder(ctx context.Context, reminder *event_domain.ScheduledReminder) error {
+	preferences, err := w.notificationService.GetPreferences(ctx, reminder.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	channels := preferences.Channels
+	for _, channel := range reminder.Channels {
+		switch channel {
+		case "in_app":
+			if !channels.InApp.Allows(notification_domain.EventReminderNotification) {
+				continue
+			}
+			link := fmt.Sprintf("/events/%s", reminder.EventID)
+			_, err := w.notificationService.CreateNotification(ctx, reminder.UserID, notification_domain.EventReminderNotification, reminder.Title, reminder.Message, link, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{String: reminder.EventID, Valid: true}, sql.NullString{})
+			if err != nil {
+				return err
+			}
+		case "email":
+			if channels.Email.Allows(notification_domain.EventReminderNotification) {
+				// Placeholder for sending reminder email
+				log.Printf("Reminder email would be sent to user %s for session %s: %s", reminder.UserID, reminder.SessionID, reminder.Title)
+			}
+		case "push":
+			if channels.Push.Allows(notification_domain.EventReminderNotification) {
+				// Placeholder for sending push notification
+				log.Printf("Reminder push notification would be sent to user %s for session %s: %s", reminder.UserID, reminder.SessionID, reminder.Title)
+			}
+		case "sms":
+			// Placeholder for sending SMS; users have no SMS preferences, so the schedule alone decides
+			log.Printf("Reminder SMS would be sent to user %s for session %s: %s", reminder.UserID, reminder.SessionID, reminder.Title)
+		}
+	}
+	return nil
+}
 
-			for _, attendeeID := range session.AttendeeIDs {
-				_, err := w.notificationService.CreateNotification(ctx, attendeeID, notification_domain.EventReminderNotification, title, message, link, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{String: session.EventID, Valid: true}, sql.NullString{})
+// dispatchFeedbackRequests asks attendees who checked in to fill in the feedback surveys that are due.
+// Requests are recorded when they are claimed, so each attendee is asked once per survey and session.
+func (w *NotificationWorker) dispatchFeedbackRequests() {
+	ctx := context.Background()
+
+	for {
+		requests, err := w.eventRepo.ClaimDueFeedbackRequests(ctx, feedbackRequestBatchSize)
+		if err != nil {
+			log.Printf("Error claiming due feedback requests: %v", err)
+			return
+		}
+
+		for _, request := range requests {
+			subject := request.EventName
+			if request.SessionName.Valid {
+				subject = fmt.Sprintf("%s: %s", request.EventName, request.SessionName.String)
+			}
+			title := fmt.Sprintf("How was %s?", subject)
+			message := fmt.Sprintf("Thanks for attending! Please take a moment to answer '%s'.", request.SurveyTitle)
+			link := fmt.Sprintf("/events/%s", request.EventID)
+			preferences, err := w.notificationService.GetPreferences(ctx, request.UserID)
+			if err != nil {
+				log.Printf("Error getting notification preferences of user %s: %v", request.UserID, err)
+				continue
+			}
+			channels := preferences.Channels
+			if channels.InApp.Allows(notification_domain.FeedbackRequestNotification) {
+				_, err := w.notificationService.CreateNotification(ctx, request.UserID, notification_domain.FeedbackRequestNotification, title, message, link, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{String: request.EventID, Valid: true}, sql.NullString{})
 				if err != nil {
-					log.Printf("Error creating notification for user %s for session %s: %v", attendeeID, session.ID, err)
+					log.Printf("Error sending feedback request %s to user %s: %v", request.ID, request.UserID, err)
 				}
 			}
+			if channels.Email.Allows(notification_domain.FeedbackRequestNotification) {
+				// Placeholder for sending feedback request email
+				log.Printf("Feedback request email would be sent to user %s for survey %s", request.UserID, request.SurveyTitle)
+			}
+		}
+
+		if len(requests) < feedbackRequestBatchSize {
+			return
 		}
 	}
 }
+
+// syncAllReminders reconciles the reminders of every event, picking up changes made outside the event service.
+func (w *NotificationWorker) syncAllReminders() {
+	if err := w.eventRepo.SyncEventReminders(context.Background(), ""); err != nil {
+		log.Printf("Error synchronizing event reminders: %v", err)
+	}
+}
@@ -2,15 +2,12 @@ package worker
 
 import (
 	"context"
+	"database/sql"
 	"log"
-	"strings"
 	"time"
 
-	"encoding/json"
-
 	event_domain "github.com/attendwise/backend/internal/module/event/domain"
 	"github.com/google/uuid"
-	"github.com/teambition/rrule-go"
 )
 
 // RecurringEventWorker is responsible for processing recurring events and creating future sessions.
@@ -59,73 +56,70 @@ func (w *RecurringEventWorker) ProcessRecurringEvents() {
 		log.Printf("Processing recurring event: %s (%s)", event.Name, event.ID)
 
 		// 3. Parse the recurrence rule
-		if event.RecurrenceRule == nil || len(event.RecurrenceRule) == 0 || string(event.RecurrenceRule) == "null" {
-			log.Printf("INFO: Skipping event %s with empty recurrence rule", event.ID)
+		recurrence, err := event_domain.ParseRecurrence(event.RecurrenceRule)
+		if err != nil {
+			log.Printf("ERROR: Could not unmarshal rrule json for event %s: %v", event.ID, err)
 			continue
 		}
-		var rrulePayload struct { RRule string `json:"rrule"` }
-		if err := json.Unmarshal(event.RecurrenceRule, &rrulePayload); err != nil {
-			log.Printf("ERROR: Could not unmarshal rrule json for event %s: %v", event.ID, err)
+		if recurrence == nil {
+			log.Printf("INFO: Skipping event %s with empty recurrence rule", event.ID)
 			continue
 		}
-		ruleString := rrulePayload.RRule
-		// The library expects the rule properties without the "RRULE:" prefix.
-		cleanedRuleString := strings.TrimPrefix(ruleString, "RRULE:")
-
-		rOption, err := rrule.StrToROption(cleanedRuleString)
+		// 4. Get existing session start times to avoid duplicates. Sessions are keyed by their original
+		// start time, so moved or cancelled occurrences are kept as they are rather than regenerated.
+		existingStartTimes, err := w.eventRepo.GetSessionStartTimes(ctx, event.ID)
 		if err != nil {
-			log.Printf("ERROR: Could not parse rrule for event %s: %v", event.ID, err)
+			log.Printf("ERROR: Could not get existing session times for event %s: %v", event.ID, err)
 			continue
 		}
-		rOption.Dtstart = event.StartTime.Time
-		rule, err := rrule.NewRRule(*rOption)
+
+		// 5. Calculate future occurrences within the event's generation horizon. The rule is expanded in the
+		// event's timezone and stops at its recurrence end date or max occurrences. Excluded dates (EXDATE)
+		// are never generated and extra dates (RDATE) are added to the series.
+		now := time.Now()
+		futureOccurrences, err := recurrence.Occurrences(event, now, now.Add(event.GenerationHorizon()), event_domain.MaxGeneratedSessions)
 		if err != nil {
-			log.Printf("ERROR: Could not create rrule instance for event %s: %v", event.ID, err)
+			log.Printf("ERROR: Could not expand rrule for event %s: %v", event.ID, err)
 			continue
 		}
+		duration := event.EndTime.Time.Sub(event.StartTime.Time)
 
-		// 4. Get existing session start times to avoid duplicates
-		existingStartTimes, err := w.eventRepo.GetSessionStartTimes(ctx, event.ID)
+		var sessionsToCreate []event_domain.EventSession
+
+		// Get the current max session number to ensure uniqueness
+		currentMaxSessionNumber, err := w.eventRepo.GetMaxSessionNumber(ctx, event.ID)
 		if err != nil {
-			log.Printf("ERROR: Could not get existing session times for event %s: %v", event.ID, err)
+			log.Printf("ERROR: Could not get max session number for event %s: %v", event.ID, err)
 			continue
 		}
 
-		        // 5. Calculate future occurrences within a generation window (e.g., next 30 days)
-				generationWindowEnd := time.Now().Add(30 * 24 * time.Hour)
-				futureOccurrences := rule.Between(time.Now(), generationWindowEnd, true)
-				duration := event.EndTime.Time.Sub(event.StartTime.Time)
-		
-				var sessionsToCreate []event_domain.EventSession
-		
-				// Get the current max session number to ensure uniqueness
-				currentMaxSessionNumber, err := w.eventRepo.GetMaxSessionNumber(ctx, event.ID)
-				if err != nil {
-					log.Printf("ERROR: Could not get max session number for event %s: %v", event.ID, err)
-					continue
-				}
-		
-				for _, occurrence := range futureOccurrences {
-					// Round to the nearest second to avoid timezone/millisecond mismatches with DB
-					occurrence = occurrence.Truncate(time.Second)
-					if !existingStartTimes[occurrence] {
-						currentMaxSessionNumber++ // Increment for each new session
-						newSession := event_domain.EventSession{
-							ID:            uuid.New().String(),
-							EventID:       event.ID,
-							SessionNumber: currentMaxSessionNumber,
-							StartTime:     occurrence,
-							EndTime:       occurrence.Add(duration),
-							Timezone:      event.Timezone,
-						}
-						sessionsToCreate = append(sessionsToCreate, newSession)
-					}
+		for _, occurrence := range futureOccurrences {
+			// Round to the nearest second to avoid timezone/millisecond mismatches with DB
+			occurrence = occurrence.Truncate(time.Second)
+			if !existingStartTimes[occurrence.UTC()] {
+				currentMaxSessionNumber++ // Increment for each new session
+				newSession := event_domain.EventSession{
+					ID:                uuid.New().String(),
+					EventID:           event.ID,
+					SessionNumber:     currentMaxSessionNumber,
+					StartTime:         occurrence,
+					EndTime:           occurrence.Add(duration),
+					Timezone:          event.Timezone,
+					OriginalStartTime: sql.NullTime{Time: occurrence, Valid: true},
 				}
+				sessionsToCreate = append(sessionsToCreate, newSession)
+			}
+		}
 		// 6. Create the missing sessions in the database
 		if len(sessionsToCreate) > 0 {
 			log.Printf("Creating %d new sessions for event %s", len(sessionsToCreate), event.Name)
 			if err := w.eventRepo.CreateSessions(ctx, sessionsToCreate); err != nil {
 				log.Printf("ERROR: Failed to create new sessions for event %s: %v", event.ID, err)
+				continue
+			}
+			// 7. Schedule the reminders of the new sessions
+			if err := w.eventRepo.SyncEventReminders(ctx, event.ID); err != nil {
+				log.Printf("ERROR: Failed to schedule reminders for event %s: %v", event.ID, err)
 			}
 		} else {
 			log.Printf("No new sessions needed for event %s at this time.", event.Name)
@@ -133,4 +127,4 @@ func (w *RecurringEventWorker) ProcessRecurringEvents() {
 	}
 
 	log.Println("Recurring Event Worker: processing finished.")
-}
\ No newline at end of file
+}
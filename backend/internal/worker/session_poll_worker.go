@@ -0,0 +1,40 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	event_usecase "github.com/attendwise/backend/internal/module/event/usecase"
+)
+
+// SessionPollWorker opens the session polls scheduled to open and closes the timed polls whose time ran out.
+type SessionPollWorker struct {
+	eventService event_usecase.EventService
+}
+
+// NewSessionPollWorker creates a new instance of SessionPollWorker.
+func NewSessionPollWorker(eventService event_usecase.EventService) *SessionPollWorker {
+	return &SessionPollWorker{
+		eventService: eventService,
+	}
+}
+
+// Start advances session polls once on startup and then every five seconds, as live polls are often short.
+func (w *SessionPollWorker) Start() {
+	log.Println("Starting Session Poll Worker...")
+	w.advancePolls()
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		w.advancePolls()
+	}
+}
+
+func (w *SessionPollWorker) advancePolls() {
+	if err := w.eventService.AdvanceSessionPolls(context.Background()); err != nil {
+		log.Printf("ERROR: SessionPollWorker could not advance session polls: %v", err)
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS event_import_sources;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE event_import_sources (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    external_uid VARCHAR(512) NOT NULL,
+    imported_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(community_id, external_uid)
+);
+
+CREATE INDEX idx_event_import_sources_event_id ON event_import_sources(event_id);
\ No newline at end of file
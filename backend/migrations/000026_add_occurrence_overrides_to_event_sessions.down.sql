@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_event_sessions_event_original_start;
+
+ALTER TABLE event_sessions
+    DROP COLUMN IF EXISTS is_override,
+    DROP COLUMN IF EXISTS original_start_time;
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE event_sessions
+    ADD COLUMN original_start_time TIMESTAMPTZ,
+    ADD COLUMN is_override BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The original start time identifies an occurrence within its series, even after it has been moved.
+UPDATE event_sessions SET original_start_time = start_time;
+
+CREATE INDEX idx_event_sessions_event_original_start ON event_sessions(event_id, original_start_time);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE events DROP COLUMN IF EXISTS generation_horizon_days;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Number of days ahead the recurring event worker generates sessions for. NULL uses the default of 30 days.
+ALTER TABLE events ADD COLUMN generation_horizon_days INT CHECK (generation_horizon_days BETWEEN 1 AND 366);
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_scheduled_notif_event;
+DROP INDEX IF EXISTS idx_scheduled_notif_session_reminder;
+ALTER TABLE scheduled_notifications
+    DROP COLUMN IF EXISTS claimed_at,
+    DROP COLUMN IF EXISTS offset_minutes;
\ No newline at end of file
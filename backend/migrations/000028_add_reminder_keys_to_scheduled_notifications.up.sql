@@ -0,0 +1,7 @@
+-- Each reminder row is one offset of an event's reminder_schedule for one attendee of one session.
+ALTER TABLE scheduled_notifications
+    ADD COLUMN offset_minutes INT,
+    ADD COLUMN claimed_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX idx_scheduled_notif_session_reminder ON scheduled_notifications(session_id, user_id, offset_minutes);
+CREATE INDEX idx_scheduled_notif_event ON scheduled_notifications(event_id) WHERE status = 'pending';
\ No newline at end of file
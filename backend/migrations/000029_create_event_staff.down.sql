@@ -0,0 +1 @@
+DROP TABLE IF EXISTS event_staff;
\ No newline at end of file
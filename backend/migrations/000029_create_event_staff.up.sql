@@ -0,0 +1,12 @@
+-- Event-scoped staff roles. The event's creator is its host and is not listed here.
+CREATE TABLE IF NOT EXISTS event_staff (
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('co_host', 'checkin_staff', 'speaker', 'volunteer')),
+    assigned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, user_id)
+);
+
+CREATE INDEX idx_event_staff_user ON event_staff(user_id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS event_session_registrations;
+
+ALTER TABLE events DROP COLUMN IF EXISTS per_session_registration;
\ No newline at end of file
@@ -0,0 +1,17 @@
+ALTER TABLE events ADD COLUMN IF NOT EXISTS per_session_registration BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Sessions an attendee signed up for, on events with per_session_registration enabled.
+CREATE TABLE IF NOT EXISTS event_session_registrations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES event_sessions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    attendee_id UUID NOT NULL REFERENCES event_attendees(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'registered' CHECK (status IN ('registered', 'waitlisted', 'cancelled')),
+    registered_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    cancelled_at TIMESTAMPTZ,
+    UNIQUE (session_id, user_id)
+);
+
+CREATE INDEX idx_session_registrations_session ON event_session_registrations(session_id, status, registered_at);
+CREATE INDEX idx_session_registrations_user ON event_session_registrations(event_id, user_id);
\ No newline at end of file
@@ -0,0 +1,39 @@
+DROP VIEW IF EXISTS v_active_events;
+DROP TABLE IF EXISTS event_status_transitions;
+
+-- Postgres cannot drop enum values, so the type is recreated without the lifecycle states. The partial
+-- index compares status with enum literals and must be rebuilt against the new type.
+DROP INDEX IF EXISTS idx_events_upcoming;
+ALTER TABLE events ALTER COLUMN status DROP DEFAULT;
+ALTER TYPE event_status RENAME TO event_status_old;
+CREATE TYPE event_status AS ENUM ('draft', 'published', 'ongoing', 'completed', 'cancelled');
+ALTER TABLE events ALTER COLUMN status TYPE event_status USING (
+    CASE status::text
+        WHEN 'registration_open' THEN 'published'
+        WHEN 'registration_closed' THEN 'published'
+        WHEN 'archived' THEN 'completed'
+        ELSE status::text
+    END
+)::event_status;
+ALTER TABLE events ALTER COLUMN status SET DEFAULT 'draft';
+DROP TYPE event_status_old;
+
+CREATE INDEX idx_events_upcoming ON events(community_id, start_time) WHERE status IN ('published', 'ongoing') AND deleted_at IS NULL;
+
+CREATE VIEW v_active_events AS
+SELECT 
+    e.*,
+    c.name as community_name,
+    c.slug as community_slug,
+    u.name as creator_name,
+    COUNT(DISTINCT es.id) as session_count,
+    COUNT(DISTINCT ea.user_id) FILTER (WHERE ea.status = 'registered') as registered_count,
+    MIN(es.start_time) as next_session_time
+FROM events e
+JOIN communities c ON e.community_id = c.id
+JOIN users u ON e.created_by = u.id
+LEFT JOIN event_sessions es ON e.id = es.event_id AND es.is_cancelled = FALSE
+LEFT JOIN event_attendees ea ON e.id = ea.event_id
+WHERE e.status IN ('published', 'ongoing')
+    AND e.deleted_at IS NULL
+GROUP BY e.id, c.name, c.slug, u.name;
\ No newline at end of file
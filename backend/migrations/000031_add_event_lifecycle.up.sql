@@ -0,0 +1,36 @@
+ALTER TYPE event_status ADD VALUE IF NOT EXISTS 'registration_open' AFTER 'published';
+ALTER TYPE event_status ADD VALUE IF NOT EXISTS 'registration_closed' AFTER 'registration_open';
+ALTER TYPE event_status ADD VALUE IF NOT EXISTS 'archived' AFTER 'cancelled';
+
+-- History of an event's lifecycle. triggered_by is NULL for transitions made by the lifecycle worker.
+CREATE TABLE IF NOT EXISTS event_status_transitions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    triggered_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_status_transitions_event ON event_status_transitions(event_id, created_at);
+
+-- The new values cannot be used as enum literals in the transaction that adds them, hence the text comparison.
+DROP VIEW IF EXISTS v_active_events;
+CREATE VIEW v_active_events AS
+SELECT 
+    e.*,
+    c.name as community_name,
+    c.slug as community_slug,
+    u.name as creator_name,
+    COUNT(DISTINCT es.id) as session_count,
+    COUNT(DISTINCT ea.user_id) FILTER (WHERE ea.status = 'registered') as registered_count,
+    MIN(es.start_time) as next_session_time
+FROM events e
+JOIN communities c ON e.community_id = c.id
+JOIN users u ON e.created_by = u.id
+LEFT JOIN event_sessions es ON e.id = es.event_id AND es.is_cancelled = FALSE
+LEFT JOIN event_attendees ea ON e.id = ea.event_id
+WHERE e.status::text IN ('published', 'registration_open', 'registration_closed', 'ongoing')
+    AND e.deleted_at IS NULL
+GROUP BY e.id, c.name, c.slug, u.name;
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS event_feedback_responses;
+DROP TABLE IF EXISTS event_feedback_requests;
+DROP TABLE IF EXISTS event_feedback_surveys;
+
+-- Enum values cannot be dropped; 'feedback_request' is left in notification_type.
\ No newline at end of file
@@ -0,0 +1,48 @@
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'feedback_request';
+
+-- Feedback surveys hosts attach to an event (session_id NULL) or to one of its sessions. Event surveys with
+-- per_session set are answered once per attended session instead of once per event.
+CREATE TABLE IF NOT EXISTS event_feedback_surveys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    session_id UUID REFERENCES event_sessions(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    questions JSONB NOT NULL DEFAULT '[]'::jsonb,
+    per_session BOOLEAN NOT NULL DEFAULT FALSE,
+    send_delay_minutes INT NOT NULL DEFAULT 0 CHECK (send_delay_minutes >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_feedback_surveys_event ON event_feedback_surveys(event_id);
+
+-- Feedback requests sent to attendees who checked in. session_id is NULL for surveys answered once per event.
+CREATE TABLE IF NOT EXISTS event_feedback_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    survey_id UUID NOT NULL REFERENCES event_feedback_surveys(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    session_id UUID REFERENCES event_sessions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_feedback_requests_session ON event_feedback_requests(survey_id, user_id, session_id) WHERE session_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_feedback_requests_event ON event_feedback_requests(survey_id, user_id) WHERE session_id IS NULL;
+CREATE INDEX idx_feedback_requests_user ON event_feedback_requests(user_id, sent_at);
+
+CREATE TABLE IF NOT EXISTS event_feedback_responses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    survey_id UUID NOT NULL REFERENCES event_feedback_surveys(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    session_id UUID REFERENCES event_sessions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    answers JSONB NOT NULL,
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_feedback_responses_session ON event_feedback_responses(survey_id, user_id, session_id) WHERE session_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_feedback_responses_event ON event_feedback_responses(survey_id, user_id) WHERE session_id IS NULL;
+CREATE INDEX idx_feedback_responses_event_id ON event_feedback_responses(event_id, submitted_at);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_certificates;
+DROP TABLE IF EXISTS event_certificate_templates;
\ No newline at end of file
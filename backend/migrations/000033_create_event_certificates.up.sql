@@ -0,0 +1,42 @@
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'certificate_issued';
+
+-- Certificate template of an event. Attendees whose share of attended sessions reaches
+-- min_attendance_percent are issued a certificate when the event completes.
+CREATE TABLE IF NOT EXISTS event_certificate_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL UNIQUE REFERENCES events(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    signer_name VARCHAR(255),
+    signer_title VARCHAR(255),
+    min_attendance_percent INT NOT NULL DEFAULT 80 CHECK (min_attendance_percent BETWEEN 1 AND 100),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Issued certificates. The rendered text is stored so that a certificate does not change when its template
+-- or event does; verification_code is what the public verification endpoint looks certificates up by.
+CREATE TABLE IF NOT EXISTS event_certificates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    template_id UUID REFERENCES event_certificate_templates(id) ON DELETE SET NULL,
+    verification_code VARCHAR(32) NOT NULL UNIQUE,
+    recipient_name VARCHAR(255) NOT NULL,
+    event_name VARCHAR(255) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    signer_name VARCHAR(255),
+    signer_title VARCHAR(255),
+    sessions_attended INT NOT NULL,
+    sessions_total INT NOT NULL,
+    attendance_percent NUMERIC(5,2) NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ,
+    revoked_reason TEXT,
+    UNIQUE (event_id, user_id)
+);
+
+CREATE INDEX idx_event_certificates_user ON event_certificates(user_id, issued_at);
\ No newline at end of file
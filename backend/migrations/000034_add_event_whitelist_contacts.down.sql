@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_event_whitelists_phone;
+DROP INDEX IF EXISTS idx_event_whitelists_event_phone;
+DROP INDEX IF EXISTS idx_event_whitelists_event_email;
+DROP INDEX IF EXISTS idx_event_whitelists_event_user;
+
+CREATE UNIQUE INDEX idx_event_whitelists_unique ON event_whitelists (event_id, COALESCE(user_id::text, email, phone));
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- Whitelist contacts are matched case-insensitively by email and by phone digits only.
+UPDATE event_whitelists SET email = LOWER(TRIM(email)) WHERE email IS NOT NULL;
+UPDATE event_whitelists SET phone = regexp_replace(phone, '[^0-9+]', '', 'g') WHERE phone IS NOT NULL;
+
+DELETE FROM event_whitelists a
+USING event_whitelists b
+WHERE a.event_id = b.event_id AND a.email = b.email AND (a.added_at, a.id) > (b.added_at, b.id);
+
+DELETE FROM event_whitelists a
+USING event_whitelists b
+WHERE a.event_id = b.event_id AND a.phone = b.phone AND (a.added_at, a.id) > (b.added_at, b.id);
+
+-- An entry keeps its email or phone once it is linked to an account, so each of them is unique per event.
+DROP INDEX IF EXISTS idx_event_whitelists_unique;
+CREATE UNIQUE INDEX idx_event_whitelists_event_user ON event_whitelists (event_id, user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_event_whitelists_event_email ON event_whitelists (event_id, email) WHERE email IS NOT NULL;
+CREATE UNIQUE INDEX idx_event_whitelists_event_phone ON event_whitelists (event_id, phone) WHERE phone IS NOT NULL;
+
+CREATE INDEX idx_event_whitelists_phone ON event_whitelists (phone) WHERE phone IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS event_invitations;
\ No newline at end of file
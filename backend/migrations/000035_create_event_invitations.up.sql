@@ -0,0 +1,25 @@
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'event_invitation';
+
+-- Invitations hosts send to users or to email addresses. Invitations by email are matched to the account
+-- with that email, and linked to it when it responds.
+CREATE TABLE IF NOT EXISTS event_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    invitee_user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    invitee_email VARCHAR(255),
+    inviter_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    message TEXT,
+    rsvp VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (rsvp IN ('pending', 'going', 'maybe', 'declined')),
+    responded_at TIMESTAMPTZ,
+    reminders_sent INT NOT NULL DEFAULT 0,
+    last_reminded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (invitee_user_id IS NOT NULL OR invitee_email IS NOT NULL)
+);
+
+CREATE UNIQUE INDEX idx_event_invitations_event_user ON event_invitations(event_id, invitee_user_id) WHERE invitee_user_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_event_invitations_event_email ON event_invitations(event_id, invitee_email) WHERE invitee_email IS NOT NULL;
+CREATE INDEX idx_event_invitations_user ON event_invitations(invitee_user_id) WHERE invitee_user_id IS NOT NULL;
+CREATE INDEX idx_event_invitations_email ON event_invitations(invitee_email) WHERE invitee_email IS NOT NULL;
+CREATE INDEX idx_event_invitations_pending ON event_invitations(created_at) WHERE rsvp = 'pending';
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS event_announcements;
\ No newline at end of file
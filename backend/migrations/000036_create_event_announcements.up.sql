@@ -0,0 +1,22 @@
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'event_announcement';
+
+-- Messages hosts broadcast to the attendees of an event, or of one of its sessions. Announcements without
+-- a schedule are sent right away; scheduled ones are sent by a worker once scheduled_at has passed.
+CREATE TABLE IF NOT EXISTS event_announcements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    session_id UUID REFERENCES event_sessions(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    message TEXT NOT NULL,
+    audience VARCHAR(20) NOT NULL DEFAULT 'all' CHECK (audience IN ('all', 'checked_in', 'no_show', 'waitlist')),
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'sent', 'cancelled')),
+    scheduled_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMPTZ,
+    recipient_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_announcements_event ON event_announcements(event_id, created_at DESC);
+CREATE INDEX idx_event_announcements_due ON event_announcements(scheduled_at) WHERE status = 'scheduled';
\ No newline at end of file
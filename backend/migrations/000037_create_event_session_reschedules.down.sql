@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_session_reconfirmations;
+DROP TABLE IF EXISTS event_session_reschedules;
\ No newline at end of file
@@ -0,0 +1,37 @@
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'session_rescheduled';
+
+-- Changes of the time or place of a session, with its previous and new details.
+CREATE TABLE IF NOT EXISTS event_session_reschedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES event_sessions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    previous_start_time TIMESTAMPTZ NOT NULL,
+    previous_end_time TIMESTAMPTZ NOT NULL,
+    previous_location TEXT,
+    previous_online_meeting_url TEXT,
+    new_start_time TIMESTAMPTZ NOT NULL,
+    new_end_time TIMESTAMPTZ NOT NULL,
+    new_location TEXT,
+    new_online_meeting_url TEXT,
+    reason TEXT,
+    requires_reconfirmation BOOLEAN NOT NULL DEFAULT FALSE,
+    rescheduled_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_session_reschedules_session ON event_session_reschedules(session_id, created_at DESC);
+
+-- Attendees asked to confirm they still attend a rescheduled session. Declining releases their seat.
+CREATE TABLE IF NOT EXISTS event_session_reconfirmations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reschedule_id UUID NOT NULL REFERENCES event_session_reschedules(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL REFERENCES event_sessions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'declined')),
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (reschedule_id, user_id)
+);
+
+CREATE INDEX idx_session_reconfirmations_user ON event_session_reconfirmations(user_id, status);
+CREATE INDEX idx_session_reconfirmations_session ON event_session_reconfirmations(session_id, status);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS event_templates;
\ No newline at end of file
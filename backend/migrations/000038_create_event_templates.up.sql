@@ -0,0 +1,17 @@
+-- Settings of events saved for reuse. A template belongs to a community and is visible to its creator, or to
+-- all the community's admins once shared. The settings document keeps times relative to the event start so
+-- that events created from it can start at any time.
+CREATE TABLE IF NOT EXISTS event_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source_event_id UUID REFERENCES events(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    is_shared BOOLEAN NOT NULL DEFAULT FALSE,
+    settings JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_templates_community ON event_templates(community_id, name);
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE event_sessions DROP COLUMN IF EXISTS venue_id;
+ALTER TABLE events DROP COLUMN IF EXISTS venue_id;
+
+DROP TABLE IF EXISTS venues;
\ No newline at end of file
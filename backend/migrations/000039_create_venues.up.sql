@@ -0,0 +1,25 @@
+-- Places a community holds events at, reused across events instead of retyping their address. Events and
+-- sessions reference a venue; a session without one takes place at the event's venue.
+CREATE TABLE IF NOT EXISTS venues (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    address TEXT NOT NULL,
+    latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    capacity INT CHECK (capacity > 0),
+    accessibility_notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+CREATE INDEX idx_venues_community ON venues(community_id, name);
+CREATE INDEX idx_venues_coordinates ON venues(latitude, longitude) WHERE latitude IS NOT NULL;
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS venue_id UUID REFERENCES venues(id) ON DELETE SET NULL;
+ALTER TABLE event_sessions ADD COLUMN IF NOT EXISTS venue_id UUID REFERENCES venues(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_events_venue ON events(venue_id) WHERE venue_id IS NOT NULL;
+CREATE INDEX idx_event_sessions_venue ON event_sessions(venue_id) WHERE venue_id IS NOT NULL;
\ No newline at end of file
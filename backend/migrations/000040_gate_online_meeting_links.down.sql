@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_event_attendees_join_token;
+ALTER TABLE event_attendees DROP COLUMN IF EXISTS join_token;
+ALTER TABLE events DROP COLUMN IF EXISTS meeting_link_reveal_minutes;
+
+-- Check-ins already recorded as 'virtual' keep that method, so the enum value stays.
\ No newline at end of file
@@ -0,0 +1,9 @@
+ALTER TYPE checkin_method ADD VALUE IF NOT EXISTS 'virtual';
+
+-- Minutes before a session starts its meeting link is revealed to approved registrants; NULL reveals it as
+-- soon as they are approved.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS meeting_link_reveal_minutes INT CHECK (meeting_link_reveal_minutes >= 0);
+
+-- Personal join link of an attendee, redirecting to the meeting of the session in progress.
+ALTER TABLE event_attendees ADD COLUMN IF NOT EXISTS join_token VARCHAR(64);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_attendees_join_token ON event_attendees(join_token) WHERE join_token IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS registration_transfers;
+ALTER TABLE events DROP COLUMN IF EXISTS transfer_deadline_minutes;
+ALTER TABLE events DROP COLUMN IF EXISTS transfer_policy;
\ No newline at end of file
@@ -0,0 +1,33 @@
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'registration_transfer';
+
+-- Whether attendees can hand their registration over to someone else: 'disabled', 'allowed', or 'approval'
+-- when a host approves each transfer. Transfers close transfer_deadline_minutes before the next session
+-- starts, or when it starts if NULL.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS transfer_policy VARCHAR(20) NOT NULL DEFAULT 'disabled' CHECK (transfer_policy IN ('disabled', 'allowed', 'approval'));
+ALTER TABLE events ADD COLUMN IF NOT EXISTS transfer_deadline_minutes INT CHECK (transfer_deadline_minutes >= 0);
+
+-- Requests to move a registration to another user, or to whoever signs in with an email address. The
+-- registration row itself changes hands, keeping its payment and form data.
+CREATE TABLE IF NOT EXISTS registration_transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    attendee_id UUID NOT NULL REFERENCES event_attendees(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    to_email VARCHAR(255),
+    message TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'declined', 'rejected', 'cancelled')),
+    requires_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    approved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    approved_at TIMESTAMPTZ,
+    accepted_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (to_user_id IS NOT NULL OR to_email IS NOT NULL)
+);
+
+CREATE UNIQUE INDEX idx_registration_transfers_pending ON registration_transfers(attendee_id) WHERE status = 'pending';
+CREATE INDEX idx_registration_transfers_event ON registration_transfers(event_id, created_at DESC);
+CREATE INDEX idx_registration_transfers_to_user ON registration_transfers(to_user_id) WHERE to_user_id IS NOT NULL;
+CREATE INDEX idx_registration_transfers_to_email ON registration_transfers(to_email) WHERE to_email IS NOT NULL;
\ No newline at end of file
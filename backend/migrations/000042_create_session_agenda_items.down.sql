@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS session_agenda_item_stars;
+DROP TABLE IF EXISTS session_agenda_item_speakers;
+DROP TABLE IF EXISTS session_agenda_items;
+DROP TABLE IF EXISTS event_tracks;
\ No newline at end of file
@@ -0,0 +1,51 @@
+-- Parallel tracks of an event's programme, such as a main stage and a workshop room. Agenda items are grouped
+-- into tracks and shown in the tracks' order.
+CREATE TABLE IF NOT EXISTS event_tracks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    color VARCHAR(7),
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, name)
+);
+
+-- Talks, workshops and breaks within a session, each in its own time slot and room.
+CREATE TABLE IF NOT EXISTS session_agenda_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES event_sessions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    track_id UUID REFERENCES event_tracks(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    abstract TEXT,
+    room VARCHAR(255),
+    start_time TIMESTAMPTZ NOT NULL,
+    end_time TIMESTAMPTZ NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_session_agenda_items_event ON session_agenda_items(event_id, start_time);
+CREATE INDEX idx_session_agenda_items_session ON session_agenda_items(session_id);
+
+-- Speakers of an agenda item, in the order they are presented.
+CREATE TABLE IF NOT EXISTS session_agenda_item_speakers (
+    agenda_item_id UUID NOT NULL REFERENCES session_agenda_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (agenda_item_id, user_id)
+);
+
+-- Agenda items users starred to build their personal schedule.
+CREATE TABLE IF NOT EXISTS session_agenda_item_stars (
+    agenda_item_id UUID NOT NULL REFERENCES session_agenda_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (agenda_item_id, user_id)
+);
+
+CREATE INDEX idx_session_agenda_item_stars_user ON session_agenda_item_stars(user_id);
\ No newline at end of file
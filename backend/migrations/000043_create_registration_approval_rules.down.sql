@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS registration_approval_rules;
+ALTER TABLE event_attendees DROP COLUMN IF EXISTS rejected_by;
+ALTER TABLE event_attendees DROP COLUMN IF EXISTS rejected_at;
+ALTER TABLE event_attendees DROP COLUMN IF EXISTS decision_reason;
+
+-- Pending and rejected registrations keep their status, so event_attendee_status keeps those values.
\ No newline at end of file
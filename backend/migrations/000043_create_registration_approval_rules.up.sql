@@ -0,0 +1,31 @@
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'registration_approved';
+ALTER TYPE event_attendee_status ADD VALUE IF NOT EXISTS 'pending';
+ALTER TYPE event_attendee_status ADD VALUE IF NOT EXISTS 'rejected';
+
+-- Why a pending registration was approved or rejected, as told to the registrant, and who rejected it.
+-- Approvals keep using approved_at and approved_by, which is NULL when an approval rule approved it.
+ALTER TABLE event_attendees ADD COLUMN IF NOT EXISTS decision_reason TEXT;
+ALTER TABLE event_attendees ADD COLUMN IF NOT EXISTS rejected_at TIMESTAMPTZ;
+ALTER TABLE event_attendees ADD COLUMN IF NOT EXISTS rejected_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+-- Rules approving the registrations of events that require approval automatically. A registration matching
+-- any rule of its event is approved: 'community_member' for active members of community_id,
+-- 'email_domain' for emails at one of email_domains, and 'past_attendance' for users who attended at least
+-- min_attended_events other events of the event's community.
+CREATE TABLE IF NOT EXISTS registration_approval_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('community_member', 'email_domain', 'past_attendance')),
+    community_id UUID REFERENCES communities(id) ON DELETE CASCADE,
+    email_domains TEXT[],
+    min_attended_events INT CHECK (min_attended_events >= 1),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (
+        (kind = 'community_member' AND community_id IS NOT NULL) OR
+        (kind = 'email_domain' AND cardinality(email_domains) > 0) OR
+        (kind = 'past_attendance' AND min_attended_events IS NOT NULL)
+    )
+);
+
+CREATE INDEX idx_registration_approval_rules_event ON registration_approval_rules(event_id, created_at);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS session_question_votes;
+DROP TABLE IF EXISTS session_questions;
+DROP TYPE IF EXISTS session_question_status;
+ALTER TABLE event_sessions DROP COLUMN IF EXISTS qa_enabled;
\ No newline at end of file
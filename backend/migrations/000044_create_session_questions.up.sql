@@ -0,0 +1,30 @@
+-- Live Q&A of sessions. Hosts turn it on per session; registrants and staff then ask questions, possibly
+-- anonymously, and upvote those of others. Moderators mark questions answered or hide them.
+ALTER TABLE event_sessions ADD COLUMN IF NOT EXISTS qa_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TYPE session_question_status AS ENUM ('open', 'answered', 'hidden');
+
+CREATE TABLE IF NOT EXISTS session_questions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES event_sessions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    is_anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    status session_question_status NOT NULL DEFAULT 'open',
+    vote_count INT NOT NULL DEFAULT 0,
+    answered_at TIMESTAMPTZ,
+    moderated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    moderated_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_session_questions_session ON session_questions(session_id, status, vote_count DESC, created_at);
+
+CREATE TABLE IF NOT EXISTS session_question_votes (
+    question_id UUID NOT NULL REFERENCES session_questions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (question_id, user_id)
+);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS session_poll_votes;
+DROP TABLE IF EXISTS session_poll_options;
+DROP TABLE IF EXISTS session_polls;
+DROP TYPE IF EXISTS session_poll_status;
\ No newline at end of file
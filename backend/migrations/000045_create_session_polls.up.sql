@@ -0,0 +1,43 @@
+-- Live polls of sessions. Staff prepare a poll, then open it by hand or at a set time, optionally for a
+-- limited duration; attendees checked in to the session vote for one option, which they can change while
+-- the poll is open. Results are kept once the poll is closed.
+CREATE TYPE session_poll_status AS ENUM ('draft', 'open', 'closed');
+
+CREATE TABLE IF NOT EXISTS session_polls (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES event_sessions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    status session_poll_status NOT NULL DEFAULT 'draft',
+    duration_seconds INT NOT NULL DEFAULT 0, -- 0 keeps the poll open until it is closed by hand
+    opens_at TIMESTAMPTZ, -- Set to open a draft poll automatically
+    closes_at TIMESTAMPTZ, -- Set when a timed poll is opened
+    opened_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_session_polls_session ON session_polls(session_id, created_at);
+CREATE INDEX idx_session_polls_scheduled ON session_polls(opens_at) WHERE status = 'draft' AND opens_at IS NOT NULL;
+CREATE INDEX idx_session_polls_timed ON session_polls(closes_at) WHERE status = 'open' AND closes_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS session_poll_options (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    poll_id UUID NOT NULL REFERENCES session_polls(id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    position INT NOT NULL,
+    vote_count INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_session_poll_options_poll ON session_poll_options(poll_id, position);
+
+CREATE TABLE IF NOT EXISTS session_poll_votes (
+    poll_id UUID NOT NULL REFERENCES session_polls(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    option_id UUID NOT NULL REFERENCES session_poll_options(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (poll_id, user_id)
+);
\ No newline at end of file
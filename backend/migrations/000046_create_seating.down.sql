@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS seat_assignment_audit;
+DROP TABLE IF EXISTS seat_assignments;
+DROP TABLE IF EXISTS seating_units;
+DROP TABLE IF EXISTS seating_sections;
+DROP TABLE IF EXISTS seating_layouts;
+DROP TYPE IF EXISTS seat_assignment_method;
+DROP TYPE IF EXISTS seating_unit_kind;
\ No newline at end of file
@@ -0,0 +1,66 @@
+-- Assigned seating of sessions, for gala dinners, exams and the like. A session's layout is made of
+-- sections, each holding rows or tables with a number of seats. Registrants get a seat automatically, as a
+-- group or by hand; every change of assignment is kept in an audit trail.
+CREATE TYPE seating_unit_kind AS ENUM ('row', 'table');
+CREATE TYPE seat_assignment_method AS ENUM ('auto', 'group', 'manual');
+
+CREATE TABLE IF NOT EXISTS seating_layouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL UNIQUE REFERENCES event_sessions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS seating_sections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    layout_id UUID NOT NULL REFERENCES seating_layouts(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    position INT NOT NULL
+);
+
+CREATE INDEX idx_seating_sections_layout ON seating_sections(layout_id, position);
+
+-- A row or a table of a section; its seats are numbered from 1 to its capacity.
+CREATE TABLE IF NOT EXISTS seating_units (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    section_id UUID NOT NULL REFERENCES seating_sections(id) ON DELETE CASCADE,
+    kind seating_unit_kind NOT NULL,
+    label TEXT NOT NULL,
+    capacity INT NOT NULL CHECK (capacity > 0),
+    position INT NOT NULL
+);
+
+CREATE INDEX idx_seating_units_section ON seating_units(section_id, position);
+
+CREATE TABLE IF NOT EXISTS seat_assignments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES event_sessions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    unit_id UUID NOT NULL REFERENCES seating_units(id) ON DELETE CASCADE,
+    seat_number INT NOT NULL CHECK (seat_number > 0),
+    method seat_assignment_method NOT NULL,
+    assigned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (session_id, user_id),
+    UNIQUE (unit_id, seat_number)
+);
+
+-- Seats are stored as labels so that the trail stays readable once a layout changes.
+CREATE TABLE IF NOT EXISTS seat_assignment_audit (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES event_sessions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action TEXT NOT NULL, -- assigned, moved or unassigned
+    from_seat TEXT,
+    to_seat TEXT,
+    method seat_assignment_method NOT NULL,
+    changed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_seat_assignment_audit_session ON seat_assignment_audit(session_id, created_at DESC);
\ No newline at end of file